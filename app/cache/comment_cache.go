@@ -5,6 +5,7 @@ import (
 	"context"
 	"GoHub-Service/app/models/comment"
 	"GoHub-Service/pkg/cache"
+	"GoHub-Service/pkg/paginator"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -99,10 +100,47 @@ func (cc *CommentCache) SetByTopicID(ctx context.Context, topicID string, commen
 	return nil
 }
 
-// InvalidateByTopicID 删除话题的评论列表缓存
+// InvalidateByTopicID 删除话题的评论列表缓存和评论树缓存
 func (cc *CommentCache) InvalidateByTopicID(ctx context.Context, topicID string) error {
 	key := cc.topicCacheKeyPrefix + topicID
 	cache.Forget(ctx, key)
+	for _, sort := range []string{comment.TreeSortOldest, comment.TreeSortNewest, comment.TreeSortLikes} {
+		cache.Forget(ctx, cc.treeKey(topicID, sort))
+	}
+	return nil
+}
+
+// commentTree 评论树缓存内容
+type commentTree struct {
+	Items  []comment.TreeItem `json:"items"`
+	Paging paginator.Paging   `json:"paging"`
+}
+
+// treeKey 评论树缓存键，只缓存默认分页参数下的第一页
+func (cc *CommentCache) treeKey(topicID, sort string) string {
+	return cc.topicCacheKeyPrefix + topicID + ":tree:" + sort
+}
+
+// GetTree 从缓存获取话题评论树的第一页，未命中时返回 nil
+func (cc *CommentCache) GetTree(ctx context.Context, topicID, sort string) ([]comment.TreeItem, *paginator.Paging) {
+	dataStr, ok := cache.Get(ctx, cc.treeKey(topicID, sort)).(string)
+	if !ok || dataStr == "" {
+		return nil, nil
+	}
+	var tree commentTree
+	if err := json.Unmarshal([]byte(dataStr), &tree); err != nil {
+		return nil, nil
+	}
+	return tree.Items, &tree.Paging
+}
+
+// SetTree 缓存话题评论树的第一页
+func (cc *CommentCache) SetTree(ctx context.Context, topicID, sort string, items []comment.TreeItem, paging *paginator.Paging) error {
+	data, err := json.Marshal(commentTree{Items: items, Paging: *paging})
+	if err != nil {
+		return err
+	}
+	cache.Set(ctx, cc.treeKey(topicID, sort), string(data), cc.cacheTime)
 	return nil
 }
 
@@ -2,9 +2,12 @@
 package cache
 
 import (
-	"context"
 	"GoHub-Service/app/models/topic"
 	"GoHub-Service/pkg/cache"
+	"GoHub-Service/pkg/paginator"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -30,85 +33,144 @@ func NewTopicCache() *TopicCache {
 // GetByID 从缓存获取话题
 func (tc *TopicCache) GetByID(ctx context.Context, id string) (*topic.Topic, error) {
 	key := tc.cacheKeyPrefix + id
-	
+
 	// 从缓存获取
 	data := cache.Get(ctx, key)
 	dataStr, ok := data.(string)
 	if !ok || dataStr == "" {
 		return nil, nil
 	}
-	
+
 	var topicModel topic.Topic
 	err := json.Unmarshal([]byte(dataStr), &topicModel)
 	if err != nil {
 		return nil, nil
 	}
-	
+
 	return &topicModel, nil
 }
 
 // Set 设置话题缓存
 func (tc *TopicCache) Set(ctx context.Context, topicModel *topic.Topic) error {
 	key := tc.cacheKeyPrefix + fmt.Sprintf("%d", topicModel.ID)
-	
+
 	data, err := json.Marshal(topicModel)
 	if err != nil {
 		return err
 	}
-	
+
 	cache.Set(ctx, key, string(data), tc.cacheTime)
 	return nil
 }
 
-// Delete 删除话题缓存
+// Delete 删除话题缓存，同时删除该话题的相关话题缓存
 func (tc *TopicCache) Delete(ctx context.Context, id string) error {
 	key := tc.cacheKeyPrefix + id
 	cache.Forget(ctx, key)
+	cache.Forget(ctx, tc.relatedKey(id))
 	return nil
 }
 
+// relatedKey 相关话题缓存key
+func (tc *TopicCache) relatedKey(id string) string {
+	return tc.cacheKeyPrefix + "related:" + id
+}
+
+// GetRelated 从缓存获取相关话题
+func (tc *TopicCache) GetRelated(ctx context.Context, id string) ([]topic.Topic, bool) {
+	data := cache.Get(ctx, tc.relatedKey(id))
+	dataStr, ok := data.(string)
+	if !ok || dataStr == "" {
+		return nil, false
+	}
+
+	var topics []topic.Topic
+	if err := json.Unmarshal([]byte(dataStr), &topics); err != nil {
+		return nil, false
+	}
+	return topics, true
+}
+
+// SetRelated 设置相关话题缓存
+func (tc *TopicCache) SetRelated(ctx context.Context, id string, topics []topic.Topic) error {
+	data, err := json.Marshal(topics)
+	if err != nil {
+		return err
+	}
+
+	cache.Set(ctx, tc.relatedKey(id), string(data), tc.cacheTime)
+	return nil
+}
+
+// TopicListPage 话题列表缓存内容，数据和分页信息一同缓存
+type TopicListPage struct {
+	Topics []topic.Topic           `json:"topics"`
+	Paging paginator.Paging        `json:"paging"`
+	Cursor *paginator.CursorPaging `json:"cursor,omitempty"` // 游标分页时使用
+}
+
+// listVersionKey 列表缓存版本号，列表失效时递增，旧版本的 key 随 TTL 自然过期
+func (tc *TopicCache) listVersionKey() string {
+	return tc.cacheKeyPrefix + "list:version"
+}
+
 // GetListCacheKey 获取列表缓存key
-func (tc *TopicCache) GetListCacheKey(c *gin.Context) string {
-	page := c.DefaultQuery("page", "1")
-	perPage := c.DefaultQuery("per_page", "10")
-	return tc.cacheKeyPrefix + "list:page:" + page + ":per_page:" + perPage
+// key 由规范化后的完整查询条件（筛选、排序、分页）生成，不同条件不会共用缓存
+func (tc *TopicCache) GetListCacheKey(ctx context.Context, c *gin.Context, filter topic.ListFilter) string {
+	values := filter.Values()
+	// 不同用户可见的分类不同，受限分类的话题不会经由缓存泄露
+	if hidden := filter.HiddenKey(); hidden != "" {
+		values.Set("hidden", hidden)
+	}
+	for _, name := range []string{"page", "per_page", "sort", "order", "cursor", "with_total"} {
+		if v := c.Query(name); v != "" {
+			values.Set(name, v)
+		}
+	}
+	// 游标分页的首页 cursor 为空，需与普通分页区分
+	if paginator.UseCursor(c) {
+		values.Set("paginate", "cursor")
+	}
+	sum := md5.Sum([]byte(values.Encode()))
+	version := cache.GetInt64(ctx, tc.listVersionKey())
+	return fmt.Sprintf("%slist:v%d:%s", tc.cacheKeyPrefix, version, hex.EncodeToString(sum[:]))
 }
 
 // GetList 从缓存获取话题列表
-func (tc *TopicCache) GetList(ctx context.Context, c *gin.Context) ([]topic.Topic, bool) {
-	key := tc.GetListCacheKey(c)
-	
+func (tc *TopicCache) GetList(ctx context.Context, c *gin.Context, filter topic.ListFilter) (*TopicListPage, bool) {
+	key := tc.GetListCacheKey(ctx, c, filter)
+
 	data := cache.Get(ctx, key)
 	dataStr, ok := data.(string)
 	if !ok || dataStr == "" {
 		return nil, false
 	}
-	
-	var topics []topic.Topic
-	err := json.Unmarshal([]byte(dataStr), &topics)
+
+	var page TopicListPage
+	err := json.Unmarshal([]byte(dataStr), &page)
 	if err != nil {
 		return nil, false
 	}
-	
-	return topics, true
+
+	return &page, true
 }
 
 // SetList 设置话题列表缓存
-func (tc *TopicCache) SetList(ctx context.Context, c *gin.Context, topics []topic.Topic) error {
-	key := tc.GetListCacheKey(c)
-	
-	data, err := json.Marshal(topics)
+func (tc *TopicCache) SetList(ctx context.Context, c *gin.Context, filter topic.ListFilter, page *TopicListPage) error {
+	key := tc.GetListCacheKey(ctx, c, filter)
+
+	data, err := json.Marshal(page)
 	if err != nil {
 		return err
 	}
-	
-	cache.Set(ctx, key, string(data), 10*time.Minute)
+
+	cache.Set(ctx, key, string(data), ListDataTier.TTL)
 	return nil
 }
 
 // ClearList 清除话题列表缓存
+// 递增版本号使所有列表 key 失效，不影响其他缓存
 func (tc *TopicCache) ClearList(ctx context.Context) error {
-	// 简单处理，清空所有缓存
-	cache.Flush(ctx)
+	cache.Increment(ctx, tc.listVersionKey())
 	return nil
 }
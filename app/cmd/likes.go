@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/console"
+
+	"github.com/spf13/cobra"
+)
+
+// CmdLikes 点赞数据维护
+var CmdLikes = &cobra.Command{
+	Use:   "likes",
+	Short: "Like counters maintenance",
+}
+
+// CmdLikesReconcile 按点赞记录重新计算评论点赞数
+var CmdLikesReconcile = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Recompute comment like counts from the likes table",
+	Run:   runLikesReconcile,
+}
+
+func init() {
+	CmdLikes.AddCommand(CmdLikesReconcile)
+}
+
+func runLikesReconcile(cmd *cobra.Command, args []string) {
+	fixed, err := services.NewCommentService().RecountLikes(context.Background())
+	if err != nil {
+		console.Exit(err.Error())
+	}
+	console.Success(fmt.Sprintf("Comment like counts reconciled, %d comments fixed.", fixed))
+}
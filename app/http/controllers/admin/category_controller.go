@@ -2,11 +2,16 @@ package admin
 
 import (
 	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/auth"
 	"GoHub-Service/pkg/database"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
 	"GoHub-Service/pkg/response"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
 )
 
 // CategoryController 分类管理控制器
@@ -50,11 +55,14 @@ func (ctrl *CategoryController) Show(c *gin.Context) {
 	})
 }
 
-// Store 创建分类
+// Store 创建分类，parent_id 不为空时作为子分类
 func (ctrl *CategoryController) Store(c *gin.Context) {
 	type CreateCategoryRequest struct {
 		Name        string `json:"name" binding:"required,min=2,max=50"`
 		Description string `json:"description"`
+		IsQA        bool   `json:"is_qa"`     // 问答分类
+		ParentID    uint64 `json:"parent_id"` // 上级分类
+		services.CategoryAccessDTO
 	}
 
 	var req CreateCategoryRequest
@@ -63,13 +71,16 @@ func (ctrl *CategoryController) Store(c *gin.Context) {
 		return
 	}
 
-	cat := category.Category{
+	cat, err := services.NewCategoryService().Create(services.CategoryCreateDTO{
 		Name:        req.Name,
 		Description: req.Description,
-	}
+		IsQA:        req.IsQA,
+		ParentID:    cast.ToString(req.ParentID),
 
-	if err := database.DB.Create(&cat).Error; err != nil {
-		response.Abort500(c, "创建失败")
+		CategoryAccessDTO: req.CategoryAccessDTO,
+	})
+	if err != nil {
+		abortCategoryError(c, err, "创建失败")
 		return
 	}
 
@@ -78,19 +89,14 @@ func (ctrl *CategoryController) Store(c *gin.Context) {
 	})
 }
 
-// Update 更新分类
+// Update 更新分类，修改 parent_id 时子分类随之移动
 func (ctrl *CategoryController) Update(c *gin.Context) {
-	categoryID := c.Param("id")
-
-	var cat category.Category
-	if err := database.DB.First(&cat, categoryID).Error; err != nil {
-		response.Abort404(c, "分类不存在")
-		return
-	}
-
 	type UpdateCategoryRequest struct {
-		Name        string `json:"name"`
-		Description string `json:"description"`
+		Name        string  `json:"name"`
+		Description string  `json:"description"`
+		IsQA        *bool   `json:"is_qa"`
+		ParentID    *uint64 `json:"parent_id"` // 0 表示移到顶级
+		services.CategoryAccessDTO
 	}
 
 	var req UpdateCategoryRequest
@@ -99,16 +105,21 @@ func (ctrl *CategoryController) Update(c *gin.Context) {
 		return
 	}
 
-	// 更新字段
+	dto := services.CategoryUpdateDTO{IsQA: req.IsQA, CategoryAccessDTO: req.CategoryAccessDTO}
 	if req.Name != "" {
-		cat.Name = req.Name
+		dto.Name = &req.Name
 	}
 	if req.Description != "" {
-		cat.Description = req.Description
+		dto.Description = &req.Description
+	}
+	if req.ParentID != nil {
+		parentID := cast.ToString(*req.ParentID)
+		dto.ParentID = &parentID
 	}
 
-	if err := database.DB.Save(&cat).Error; err != nil {
-		response.Abort500(c, "更新失败")
+	cat, err := services.NewCategoryService().Update(c.Param("id"), dto)
+	if err != nil {
+		abortCategoryError(c, err, "更新失败")
 		return
 	}
 
@@ -138,8 +149,8 @@ func (ctrl *CategoryController) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := database.DB.Delete(&cat).Error; err != nil {
-		response.Abort500(c, "删除失败")
+	if err := services.NewCategoryService().Delete(categoryID); err != nil {
+		abortCategoryError(c, err, "删除失败")
 		return
 	}
 
@@ -180,3 +191,74 @@ func (ctrl *CategoryController) Sort(c *gin.Context) {
 		"count":   len(req.Items),
 	})
 }
+
+// Moderators 分类版主列表
+func (ctrl *CategoryController) Moderators(c *gin.Context) {
+	moderators, err := services.NewCategoryService().Moderators(c.Param("id"))
+	if err != nil {
+		abortCategoryError(c, err, "获取分类版主失败")
+		return
+	}
+
+	response.Data(c, gin.H{
+		"moderators": moderators,
+	})
+}
+
+// AddModerator 指派分类版主，被指派后该版主只能管理其负责的分类及子分类
+func (ctrl *CategoryController) AddModerator(c *gin.Context) {
+	type AddModeratorRequest struct {
+		UserID uint64 `json:"user_id" binding:"required"`
+	}
+
+	var req AddModeratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, "参数错误")
+		return
+	}
+
+	userID := cast.ToString(req.UserID)
+	if err := services.NewCategoryService().AddModerator(c.Param("id"), userID, auth.CurrentUID(c)); err != nil {
+		abortCategoryError(c, err, "指派分类版主失败")
+		return
+	}
+
+	response.Created(c, gin.H{
+		"message":     "已指派分类版主",
+		"category_id": c.Param("id"),
+		"user_id":     userID,
+	})
+}
+
+// RemoveModerator 取消分类版主
+func (ctrl *CategoryController) RemoveModerator(c *gin.Context) {
+	if err := services.NewCategoryService().RemoveModerator(c.Param("id"), c.Param("user_id")); err != nil {
+		abortCategoryError(c, err, "取消分类版主失败")
+		return
+	}
+
+	response.Data(c, gin.H{
+		"message": "已取消分类版主",
+	})
+}
+
+// abortCategoryError 分类服务错误的响应
+func abortCategoryError(c *gin.Context, err error, message string) {
+	appErr, ok := apperrors.GetAppError(err)
+	if !ok {
+		logger.LogErrorWithContext(c, err, message)
+		response.Abort500(c, message)
+		return
+	}
+	switch appErr.Type {
+	case apperrors.ErrorTypeNotFound:
+		response.Abort404(c, appErr.Message)
+	case apperrors.ErrorTypeBusiness, apperrors.ErrorTypeValidation, apperrors.ErrorTypeConflict:
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
+			"message": appErr.Message,
+		})
+	default:
+		logger.LogErrorWithContext(c, err, message)
+		response.Abort500(c, message)
+	}
+}
@@ -2,7 +2,10 @@ package admin
 
 import (
 	"GoHub-Service/app/models/comment"
+	"GoHub-Service/app/repositories"
 	"GoHub-Service/app/requests"
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/auth"
 	"GoHub-Service/pkg/database"
 	"GoHub-Service/pkg/response"
 	"fmt"
@@ -156,16 +159,14 @@ func (ctrl *CommentController) Delete(c *gin.Context) {
 		return
 	}
 
-	// 检查评论是否存在
-	var comm comment.Comment
-	if err := database.DB.First(&comm, "id = ?", id).Error; err != nil {
-		response.Abort404(c, "评论不存在")
+	// 软删除，评论进入回收站
+	count, err := services.NewTrashService().Delete(repositories.TrashComment, []uint64{cast.ToUint64(id)}, auth.CurrentUID(c), c.Query("reason"))
+	if err != nil {
+		response.ApiError(c, http.StatusInternalServerError, response.CodeServerError, "删除评论失败")
 		return
 	}
-
-	// 删除评论
-	if err := database.DB.Delete(&comm).Error; err != nil {
-		response.ApiError(c, http.StatusInternalServerError, response.CodeServerError, "删除评论失败")
+	if count == 0 {
+		response.Abort404(c, "评论不存在")
 		return
 	}
 
@@ -192,8 +193,12 @@ func (ctrl *CommentController) BatchDelete(c *gin.Context) {
 		return
 	}
 
-	// 删除评论
-	if err := database.DB.Where("id IN ?", req.IDs).Delete(&comment.Comment{}).Error; err != nil {
+	// 软删除，评论进入回收站
+	ids := make([]uint64, 0, len(req.IDs))
+	for _, id := range req.IDs {
+		ids = append(ids, cast.ToUint64(id))
+	}
+	if _, err := services.NewTrashService().Delete(repositories.TrashComment, ids, auth.CurrentUID(c), c.Query("reason")); err != nil {
 		response.ApiError(c, http.StatusInternalServerError, response.CodeServerError, "批量删除评论失败")
 		return
 	}
@@ -51,7 +51,7 @@ func (ctrl *DashboardController) Overview(c *gin.Context) {
 	sevenDaysAgo := time.Now().AddDate(0, 0, -7)
 	db.Model(&user.User{}).
 		Joins("LEFT JOIN topics ON users.id = topics.user_id").
-		Where("topics.created_at >= ?", sevenDaysAgo).
+		Where("topics.created_at >= ? AND topics.deleted_at IS NULL", sevenDaysAgo).
 		Group("users.id").
 		Count(&stats.ActiveUsers)
 
@@ -3,7 +3,6 @@ package admin
 import (
 	"GoHub-Service/app/models/follow"
 	"GoHub-Service/app/repositories"
-	"GoHub-Service/pkg/database"
 	"GoHub-Service/pkg/response"
 	"fmt"
 	"net/http"
@@ -95,17 +94,21 @@ func (ctrl *FollowController) Delete(c *gin.Context) {
 	}
 
 	// 检查关注是否存在
-	var f follow.Follow
-	if err := database.DB.First(&f, "id = ?", id).Error; err != nil {
+	repo := repositories.NewFollowRepository()
+	f, err := repo.GetByID(id)
+	if err != nil {
 		response.Abort404(c, "关注不存在")
 		return
 	}
 
-	// 删除关注
-	if err := database.DB.Delete(&f).Error; err != nil {
+	// 删除关注，同时维护双方的关注数和粉丝数
+	if err := repo.DeleteByID(id); err != nil {
 		response.ApiError(c, http.StatusInternalServerError, response.CodeServerError, "删除关注失败")
 		return
 	}
+	userRepo := repositories.NewUserRepository()
+	_ = userRepo.DeleteCache(f.UserID)
+	_ = userRepo.DeleteCache(f.FollowID)
 
 	c.Status(http.StatusNoContent)
 }
@@ -119,13 +122,15 @@ func (ctrl *FollowController) Delete(c *gin.Context) {
 // @Success 200 {object} map[string]interface{}
 // @Router /api/v1/admin/follows/stats [get]
 func (ctrl *FollowController) Stats(c *gin.Context) {
-	var totalFollows int64
+	repo := repositories.NewFollowRepository()
 
-	// 获取总关注数
-	database.DB.Model(&follow.Follow{}).Count(&totalFollows)
+	// 获取总关注数和互相关注的用户对数
+	totalFollows, _ := repo.Count()
+	mutualFollows, _ := repo.CountMutual()
 
 	response.Data(c, gin.H{
-		"total_follows": totalFollows,
+		"total_follows":  totalFollows,
+		"mutual_follows": mutualFollows,
 	})
 }
 
@@ -135,13 +140,13 @@ func (ctrl *FollowController) Stats(c *gin.Context) {
 // @Tags Follow
 // @Accept json
 // @Produce json
-// @Param user_id path string true "用户ID"
+// @Param id path string true "用户ID"
 // @Param page query int false "页码" default(1)
 // @Param per_page query int false "每页数量" default(20)
 // @Success 200 {object} map[string]interface{}
-// @Router /api/v1/admin/users/{user_id}/followers [get]
+// @Router /api/v1/admin/users/{id}/followers [get]
 func (ctrl *FollowController) GetFollowers(c *gin.Context) {
-	userID := c.Param("user_id")
+	userID := c.Param("id")
 	if userID == "" {
 		response.ApiError(c, http.StatusBadRequest, response.CodeValidationError, "用户ID无效")
 		return
@@ -187,13 +192,13 @@ func (ctrl *FollowController) GetFollowers(c *gin.Context) {
 // @Tags Follow
 // @Accept json
 // @Produce json
-// @Param user_id path string true "用户ID"
+// @Param id path string true "用户ID"
 // @Param page query int false "页码" default(1)
 // @Param per_page query int false "每页数量" default(20)
 // @Success 200 {object} map[string]interface{}
-// @Router /api/v1/admin/users/{user_id}/following [get]
+// @Router /api/v1/admin/users/{id}/following [get]
 func (ctrl *FollowController) GetFollowing(c *gin.Context) {
-	userID := c.Param("user_id")
+	userID := c.Param("id")
 	if userID == "" {
 		response.ApiError(c, http.StatusBadRequest, response.CodeValidationError, "用户ID无效")
 		return
@@ -0,0 +1,230 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/auth"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LinkController 友情链接管理控制器
+type LinkController struct{}
+
+// Index 友情链接列表，可按 status、enabled、broken、keyword 筛选
+// @Summary 获取友情链接列表
+// @Tags Link
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/links [get]
+func (ctrl *LinkController) Index(c *gin.Context) {
+	perPage := 20
+	if pp := c.Query("per_page"); pp != "" {
+		if ppInt, err := strconv.Atoi(pp); err == nil {
+			perPage = ppInt
+		}
+	}
+
+	links, paging, err := services.NewLinkService().List(c, perPage)
+	if err != nil {
+		response.Abort500(c, err.Message)
+		return
+	}
+	response.Data(c, gin.H{
+		"links":  links,
+		"paging": paging,
+	})
+}
+
+// Store 添加友情链接
+// @Summary 添加友情链接
+// @Tags Link
+// @Accept json
+// @Produce json
+// @Success 201 {object} map[string]interface{}
+// @Router /api/v1/admin/links [post]
+func (ctrl *LinkController) Store(c *gin.Context) {
+	var req services.LinkSaveDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, "参数错误")
+		return
+	}
+	link, err := services.NewLinkService().Create(req)
+	if err != nil {
+		abortLinkError(c, err)
+		return
+	}
+	response.Created(c, gin.H{
+		"link": link,
+	})
+}
+
+// Update 修改友情链接
+// @Summary 修改友情链接
+// @Tags Link
+// @Accept json
+// @Produce json
+// @Param id path int true "链接ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/links/{id} [put]
+func (ctrl *LinkController) Update(c *gin.Context) {
+	var req services.LinkSaveDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, "参数错误")
+		return
+	}
+	link, err := services.NewLinkService().Update(c.Param("id"), req)
+	if err != nil {
+		abortLinkError(c, err)
+		return
+	}
+	response.Data(c, gin.H{
+		"link": link,
+	})
+}
+
+// Delete 删除友情链接
+// @Summary 删除友情链接
+// @Tags Link
+// @Produce json
+// @Param id path int true "链接ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/links/{id} [delete]
+func (ctrl *LinkController) Delete(c *gin.Context) {
+	if err := services.NewLinkService().Delete(c.Param("id")); err != nil {
+		abortLinkError(c, err)
+		return
+	}
+	response.Data(c, gin.H{
+		"message": "友情链接已删除",
+	})
+}
+
+// Sort 友情链接排序
+// @Summary 友情链接排序
+// @Tags Link
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/links/sort [post]
+func (ctrl *LinkController) Sort(c *gin.Context) {
+	type SortItem struct {
+		ID    uint64 `json:"id" binding:"required"`
+		Order int    `json:"order"`
+	}
+
+	type SortRequest struct {
+		Items []SortItem `json:"items" binding:"required"`
+	}
+
+	var req SortRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, "参数错误")
+		return
+	}
+
+	orders := make(map[uint64]int, len(req.Items))
+	for _, item := range req.Items {
+		orders[item.ID] = item.Order
+	}
+	if err := services.NewLinkService().Sort(orders); err != nil {
+		response.Abort500(c, "排序失败")
+		return
+	}
+
+	response.Data(c, gin.H{
+		"message": "排序成功",
+		"count":   len(orders),
+	})
+}
+
+// Approve 审核通过友情链接申请
+// @Summary 审核通过友情链接申请
+// @Tags Link
+// @Produce json
+// @Param id path int true "链接ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/links/{id}/approve [post]
+func (ctrl *LinkController) Approve(c *gin.Context) {
+	link, err := services.NewLinkService().Approve(c.Param("id"), auth.CurrentUID(c))
+	if err != nil {
+		abortLinkError(c, err)
+		return
+	}
+	response.Data(c, gin.H{
+		"link": link,
+	})
+}
+
+// Reject 拒绝友情链接申请
+// @Summary 拒绝友情链接申请
+// @Tags Link
+// @Accept json
+// @Produce json
+// @Param id path int true "链接ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/links/{id}/reject [post]
+func (ctrl *LinkController) Reject(c *gin.Context) {
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	_ = c.ShouldBindJSON(&req)
+
+	link, err := services.NewLinkService().Reject(c.Param("id"), auth.CurrentUID(c), req.Reason)
+	if err != nil {
+		abortLinkError(c, err)
+		return
+	}
+	response.Data(c, gin.H{
+		"link": link,
+	})
+}
+
+// Check 立即检测友情链接是否可以访问，不传 id 时检测全部已启用的链接
+// @Summary 检测友情链接
+// @Tags Link
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/links/check [post]
+// @Router /api/v1/admin/links/{id}/check [post]
+func (ctrl *LinkController) Check(c *gin.Context) {
+	svc := services.NewLinkService()
+	if id := c.Param("id"); id != "" {
+		result, err := svc.Check(c.Request.Context(), id)
+		if err != nil {
+			abortLinkError(c, err)
+			return
+		}
+		response.Data(c, gin.H{
+			"result": result,
+		})
+		return
+	}
+
+	summary, err := svc.CheckAll(c.Request.Context())
+	if err != nil {
+		response.Abort500(c, "检测失败")
+		return
+	}
+	response.Data(c, gin.H{
+		"summary": summary,
+	})
+}
+
+// abortLinkError 根据错误类型返回响应
+func abortLinkError(c *gin.Context, err *apperrors.AppError) {
+	switch {
+	case err.Type == apperrors.ErrorTypeNotFound:
+		response.Abort404(c, "友情链接不存在")
+	case err.Type == apperrors.ErrorTypeValidation:
+		response.ApiError(c, http.StatusBadRequest, response.CodeValidationError, err.Message)
+	case err.Code == apperrors.CodeConflict:
+		response.ApiError(c, http.StatusConflict, err.Code, err.Message)
+	default:
+		response.Abort500(c, err.Message)
+	}
+}
@@ -0,0 +1,126 @@
+package admin
+
+import (
+	"GoHub-Service/app/services"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReactionController 表情回应管理控制器
+type ReactionController struct{}
+
+// reactionTypeRequest 创建或修改表情请求，enabled 省略时为启用
+type reactionTypeRequest struct {
+	Emoji    string `json:"emoji" binding:"required,max=32"`
+	Name     string `json:"name" binding:"required,max=50"`
+	Position int    `json:"position"`
+	Enabled  *bool  `json:"enabled"`
+}
+
+// toDTO 转换为服务层参数
+func (req reactionTypeRequest) toDTO() services.ReactionTypeSaveDTO {
+	return services.ReactionTypeSaveDTO{
+		Emoji:    req.Emoji,
+		Name:     req.Name,
+		Position: req.Position,
+		Enabled:  req.Enabled == nil || *req.Enabled,
+	}
+}
+
+// Index 表情列表，包含已停用的表情
+// @Summary 获取表情列表
+// @Tags Reaction
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/reaction-types [get]
+func (ctrl *ReactionController) Index(c *gin.Context) {
+	types, err := services.NewReactionService().AllTypes()
+	if err != nil {
+		response.Abort500(c, err.Message)
+		return
+	}
+	response.Data(c, gin.H{
+		"reaction_types": types,
+	})
+}
+
+// Store 添加表情
+// @Summary 添加表情
+// @Tags Reaction
+// @Accept json
+// @Produce json
+// @Success 201 {object} map[string]interface{}
+// @Router /api/v1/admin/reaction-types [post]
+func (ctrl *ReactionController) Store(c *gin.Context) {
+	var req reactionTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, "参数错误")
+		return
+	}
+	model, err := services.NewReactionService().CreateType(req.toDTO())
+	if err != nil {
+		abortReactionError(c, err)
+		return
+	}
+	response.Created(c, gin.H{
+		"reaction_type": model,
+	})
+}
+
+// Update 修改表情名称、排序和启用状态
+// @Summary 修改表情
+// @Tags Reaction
+// @Accept json
+// @Produce json
+// @Param id path int true "表情ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/reaction-types/{id} [put]
+func (ctrl *ReactionController) Update(c *gin.Context) {
+	var req reactionTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, "参数错误")
+		return
+	}
+	model, err := services.NewReactionService().UpdateType(c.Param("id"), req.toDTO())
+	if err != nil {
+		abortReactionError(c, err)
+		return
+	}
+	response.Data(c, gin.H{
+		"reaction_type": model,
+	})
+}
+
+// Delete 删除表情及其全部回应
+// @Summary 删除表情
+// @Tags Reaction
+// @Produce json
+// @Param id path int true "表情ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/reaction-types/{id} [delete]
+func (ctrl *ReactionController) Delete(c *gin.Context) {
+	if err := services.NewReactionService().DeleteType(c.Param("id")); err != nil {
+		abortReactionError(c, err)
+		return
+	}
+	response.Data(c, gin.H{
+		"message": "表情已删除",
+	})
+}
+
+// abortReactionError 将表情管理的业务错误转换为响应
+func abortReactionError(c *gin.Context, err *apperrors.AppError) {
+	switch {
+	case err.Type == apperrors.ErrorTypeNotFound:
+		response.Abort404(c, "表情不存在")
+	case err.Type == apperrors.ErrorTypeValidation:
+		response.ApiError(c, http.StatusBadRequest, response.CodeValidationError, err.Message)
+	case err.Code == apperrors.CodeConflict:
+		response.ApiError(c, http.StatusConflict, err.Code, err.Message)
+	default:
+		response.Abort500(c, err.Message)
+	}
+}
@@ -0,0 +1,126 @@
+package admin
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/auth"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportController 举报处理控制器
+type ReportController struct{}
+
+// Index 举报队列，默认只看待处理的举报，status=all 查看全部
+// 支持按 target_type、target_id、reason 筛选
+func (ctrl *ReportController) Index(c *gin.Context) {
+	perPage := 20
+	if pp := c.Query("per_page"); pp != "" {
+		if ppInt, err := strconv.Atoi(pp); err == nil {
+			perPage = ppInt
+		}
+	}
+
+	reports, paging, err := services.NewReportService().Queue(c, auth.CurrentUID(c), perPage)
+	if err != nil {
+		logger.LogErrorWithContext(c, err, "获取举报队列失败")
+		response.Abort500(c, "获取举报队列失败")
+		return
+	}
+
+	response.Data(c, gin.H{
+		"reports": reports,
+		"paging":  paging,
+	})
+}
+
+// Show 举报详情
+func (ctrl *ReportController) Show(c *gin.Context) {
+	rp, err := services.NewReportService().Get(c.Param("id"))
+	if err != nil {
+		abortReportError(c, err, "获取举报失败")
+		return
+	}
+	response.Data(c, rp)
+}
+
+// Triage 分拣举报，标记为已查看
+func (ctrl *ReportController) Triage(c *gin.Context) {
+	rp, err := services.NewReportService().Triage(c.Param("id"), auth.CurrentUID(c))
+	if err != nil {
+		abortReportError(c, err, "分拣举报失败")
+		return
+	}
+	response.Data(c, rp)
+}
+
+// Action 处置举报：删除或隐藏内容、封禁作者，关闭该内容上所有待处理的举报
+func (ctrl *ReportController) Action(c *gin.Context) {
+	type ActionRequest struct {
+		Action string `json:"action" binding:"required,oneof=delete hide ban"`
+		Note   string `json:"note" binding:"max=255"`
+		Days   int    `json:"days" binding:"min=0,max=3650"`
+	}
+
+	var req ActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": "参数错误"})
+		return
+	}
+
+	rp, err := services.NewReportService().Act(c.Param("id"), auth.CurrentUID(c), services.ReportActionDTO{
+		Action: req.Action,
+		Note:   req.Note,
+		Days:   req.Days,
+	})
+	if err != nil {
+		abortReportError(c, err, "处置举报失败")
+		return
+	}
+	response.Data(c, rp)
+}
+
+// Dismiss 驳回举报，关闭该内容上所有待处理的举报
+func (ctrl *ReportController) Dismiss(c *gin.Context) {
+	type DismissRequest struct {
+		Note string `json:"note" binding:"max=255"`
+	}
+
+	// 说明可以不填，允许空请求体
+	var req DismissRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": "参数错误"})
+		return
+	}
+
+	rp, err := services.NewReportService().Dismiss(c.Param("id"), auth.CurrentUID(c), req.Note)
+	if err != nil {
+		abortReportError(c, err, "驳回举报失败")
+		return
+	}
+	response.Data(c, rp)
+}
+
+// abortReportError 举报处理的错误响应
+func abortReportError(c *gin.Context, err *apperrors.AppError, message string) {
+	switch err.Type {
+	case apperrors.ErrorTypeNotFound:
+		response.Abort404(c, err.Message)
+	case apperrors.ErrorTypeAuthorization:
+		response.Abort403(c, err.Message)
+	case apperrors.ErrorTypeBusiness, apperrors.ErrorTypeValidation:
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
+			"message": err.Message,
+		})
+	default:
+		logger.LogErrorWithContext(c, err, message)
+		response.Abort500(c, message)
+	}
+}
@@ -0,0 +1,231 @@
+package admin
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"GoHub-Service/app/services"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SensitiveWordController 敏感词词库管理控制器
+type SensitiveWordController struct{}
+
+// sensitiveWordCSVHeader 导入导出的 CSV 表头，变体列中多个变体用逗号分隔
+var sensitiveWordCSVHeader = []string{"word", "category", "severity", "pinyin", "note"}
+
+// Index 敏感词列表，可按 category、severity、keyword 筛选
+// @Summary 获取敏感词列表
+// @Tags SensitiveWord
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/sensitive-words [get]
+func (ctrl *SensitiveWordController) Index(c *gin.Context) {
+	perPage := 20
+	if pp := c.Query("per_page"); pp != "" {
+		if ppInt, err := strconv.Atoi(pp); err == nil {
+			perPage = ppInt
+		}
+	}
+
+	words, paging, err := services.NewSensitiveWordService().List(c, perPage)
+	if err != nil {
+		response.Abort500(c, err.Message)
+		return
+	}
+	response.Data(c, gin.H{
+		"words":  words,
+		"paging": paging,
+	})
+}
+
+// Store 添加敏感词
+// @Summary 添加敏感词
+// @Tags SensitiveWord
+// @Accept json
+// @Produce json
+// @Success 201 {object} map[string]interface{}
+// @Router /api/v1/admin/sensitive-words [post]
+func (ctrl *SensitiveWordController) Store(c *gin.Context) {
+	var req services.SensitiveWordSaveDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, "参数错误")
+		return
+	}
+	word, err := services.NewSensitiveWordService().Create(req)
+	if err != nil {
+		abortSensitiveWordError(c, err)
+		return
+	}
+	response.Created(c, gin.H{
+		"word": word,
+	})
+}
+
+// Update 修改敏感词
+// @Summary 修改敏感词
+// @Tags SensitiveWord
+// @Accept json
+// @Produce json
+// @Param id path int true "敏感词ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/sensitive-words/{id} [put]
+func (ctrl *SensitiveWordController) Update(c *gin.Context) {
+	var req services.SensitiveWordSaveDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, "参数错误")
+		return
+	}
+	word, err := services.NewSensitiveWordService().Update(c.Param("id"), req)
+	if err != nil {
+		abortSensitiveWordError(c, err)
+		return
+	}
+	response.Data(c, gin.H{
+		"word": word,
+	})
+}
+
+// Delete 删除敏感词
+// @Summary 删除敏感词
+// @Tags SensitiveWord
+// @Produce json
+// @Param id path int true "敏感词ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/sensitive-words/{id} [delete]
+func (ctrl *SensitiveWordController) Delete(c *gin.Context) {
+	if err := services.NewSensitiveWordService().Delete(c.Param("id")); err != nil {
+		abortSensitiveWordError(c, err)
+		return
+	}
+	response.Data(c, gin.H{
+		"message": "敏感词已删除",
+	})
+}
+
+// Import 批量导入敏感词
+// 上传 CSV 文件（字段 file，表头为 word,category,severity,pinyin,note），或提交 JSON {"words": [...]}
+// @Summary 批量导入敏感词
+// @Tags SensitiveWord
+// @Accept json,mpfd
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/sensitive-words/import [post]
+func (ctrl *SensitiveWordController) Import(c *gin.Context) {
+	var items []services.SensitiveWordSaveDTO
+	if file, err := c.FormFile("file"); err == nil {
+		f, err := file.Open()
+		if err != nil {
+			response.BadRequest(c, err, "文件读取失败")
+			return
+		}
+		defer f.Close()
+		if items, err = readSensitiveWordCSV(f); err != nil {
+			response.BadRequest(c, err, "CSV 格式错误")
+			return
+		}
+	} else {
+		var req struct {
+			Words []services.SensitiveWordSaveDTO `json:"words" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, err, "参数错误")
+			return
+		}
+		items = req.Words
+	}
+	if len(items) == 0 || len(items) > 5000 {
+		response.ApiError(c, http.StatusBadRequest, response.CodeValidationError, "每次导入 1 到 5000 个敏感词")
+		return
+	}
+
+	result, err := services.NewSensitiveWordService().Import(items)
+	if err != nil {
+		abortSensitiveWordError(c, err)
+		return
+	}
+	response.Data(c, result)
+}
+
+// Export 导出敏感词，format=csv 时下载 CSV 文件，默认返回 JSON，可按 category 筛选
+// @Summary 导出敏感词
+// @Tags SensitiveWord
+// @Produce json,text/csv
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/sensitive-words/export [get]
+func (ctrl *SensitiveWordController) Export(c *gin.Context) {
+	words, err := services.NewSensitiveWordService().Export(c.Query("category"))
+	if err != nil {
+		abortSensitiveWordError(c, err)
+		return
+	}
+	if c.Query("format") != "csv" {
+		response.Data(c, gin.H{
+			"words": words,
+		})
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="sensitive_words.csv"`)
+	c.Status(http.StatusOK)
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write(sensitiveWordCSVHeader)
+	for _, word := range words {
+		_ = w.Write([]string{word.Word, word.Category, word.Severity, word.Pinyin, word.Note})
+	}
+	w.Flush()
+}
+
+// readSensitiveWordCSV 解析导入的 CSV，第一行为表头时跳过，缺少的列按默认值处理
+func readSensitiveWordCSV(r io.Reader) ([]services.SensitiveWordSaveDTO, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var items []services.SensitiveWordSaveDTO
+	for line := 0; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 0 && len(record) > 0 && strings.EqualFold(strings.TrimPrefix(record[0], "\ufeff"), "word") {
+			continue
+		}
+		for len(record) < len(sensitiveWordCSVHeader) {
+			record = append(record, "")
+		}
+		items = append(items, services.SensitiveWordSaveDTO{
+			Word:     strings.TrimPrefix(record[0], "\ufeff"),
+			Category: record[1],
+			Severity: record[2],
+			Pinyin:   record[3],
+			Note:     record[4],
+		})
+	}
+	return items, nil
+}
+
+// abortSensitiveWordError 将词库管理的业务错误转换为响应
+func abortSensitiveWordError(c *gin.Context, err *apperrors.AppError) {
+	switch {
+	case err.Type == apperrors.ErrorTypeNotFound:
+		response.Abort404(c, "敏感词不存在")
+	case err.Type == apperrors.ErrorTypeValidation:
+		response.ApiError(c, http.StatusBadRequest, response.CodeValidationError, err.Message)
+	case err.Code == apperrors.CodeConflict:
+		response.ApiError(c, http.StatusConflict, err.Code, err.Message)
+	default:
+		response.Abort500(c, err.Message)
+	}
+}
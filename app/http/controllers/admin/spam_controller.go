@@ -0,0 +1,103 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/auth"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SpamController 垃圾信息检测管理控制器
+type SpamController struct{}
+
+// Settings 当前生效的检测阈值
+// @Summary 获取垃圾信息检测设置
+// @Tags Spam
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/spam/settings [get]
+func (ctrl *SpamController) Settings(c *gin.Context) {
+	response.Data(c, gin.H{
+		"settings": services.NewSpamService().Settings(c.Request.Context()),
+	})
+}
+
+// UpdateSettings 修改检测阈值，未提交的字段保持不变
+// @Summary 修改垃圾信息检测设置
+// @Tags Spam
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/spam/settings [put]
+func (ctrl *SpamController) UpdateSettings(c *gin.Context) {
+	var req services.SpamSettingsDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, "参数错误")
+		return
+	}
+	settings, err := services.NewSpamService().UpdateSettings(c.Request.Context(), req, auth.CurrentUID(c))
+	if err != nil {
+		if err.Type == apperrors.ErrorTypeValidation {
+			response.ApiError(c, http.StatusBadRequest, response.CodeValidationError, err.Message)
+			return
+		}
+		response.Abort500(c, err.Message)
+		return
+	}
+	response.Data(c, gin.H{
+		"settings": settings,
+	})
+}
+
+// Attempts 被拦截和送审的发布记录，可按 action、reason、content_type、user_id 筛选
+// @Summary 获取拦截记录
+// @Tags Spam
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/spam/attempts [get]
+func (ctrl *SpamController) Attempts(c *gin.Context) {
+	perPage := 20
+	if pp := c.Query("per_page"); pp != "" {
+		if ppInt, err := strconv.Atoi(pp); err == nil {
+			perPage = ppInt
+		}
+	}
+
+	attempts, paging, err := services.NewSpamService().Attempts(c, perPage)
+	if err != nil {
+		response.Abort500(c, err.Message)
+		return
+	}
+	response.Data(c, gin.H{
+		"attempts": attempts,
+		"paging":   paging,
+	})
+}
+
+// Stats 最近 days 天（默认 7 天，最多 90 天）每天的拦截次数
+// @Summary 获取拦截统计
+// @Tags Spam
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/spam/stats [get]
+func (ctrl *SpamController) Stats(c *gin.Context) {
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "7"))
+	if days < 1 || days > 90 {
+		response.ApiError(c, http.StatusBadRequest, response.CodeValidationError, "days 只能在 1 到 90 之间")
+		return
+	}
+	stats, err := services.NewSpamService().Stats(c.Request.Context(), days)
+	if err != nil {
+		response.Abort500(c, err.Message)
+		return
+	}
+	response.Data(c, gin.H{
+		"days":  days,
+		"stats": stats,
+	})
+}
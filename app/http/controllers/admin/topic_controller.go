@@ -3,10 +3,16 @@ package admin
 import (
 	"time"
 
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/role"
 	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/repositories"
 	"GoHub-Service/app/requests"
+	"GoHub-Service/app/services"
 	"GoHub-Service/pkg/auth"
 	"GoHub-Service/pkg/database"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
 	"GoHub-Service/pkg/paginator"
 	"GoHub-Service/pkg/response"
 	"net/http"
@@ -39,6 +45,11 @@ func (ctrl *TopicController) Index(c *gin.Context) {
 		db = db.Where("category_id = ?", categoryID)
 	}
 
+	// 被指派了分类的版主只能看到自己管理的分类
+	if ids, scoped := category.ModeratorScope(auth.CurrentUID(c)); scoped {
+		db = db.Where("category_id IN ?", ids)
+	}
+
 	// 用户筛选
 	if userID := c.Query("user_id"); userID != "" {
 		db = db.Where("user_id = ?", userID)
@@ -131,18 +142,18 @@ func (ctrl *TopicController) Update(c *gin.Context) {
 	})
 }
 
-// Delete 删除话题
+// Delete 删除话题，删除后进入回收站
 func (ctrl *TopicController) Delete(c *gin.Context) {
 	topicID := c.Param("id")
 
-	var t topic.Topic
-	if err := database.DB.First(&t, topicID).Error; err != nil {
-		response.Abort404(c, "话题不存在")
+	count, err := services.NewTrashService().Delete(repositories.TrashTopic, []uint64{cast.ToUint64(topicID)}, auth.CurrentUID(c), c.Query("reason"))
+	if err != nil {
+		logger.LogErrorWithContext(c, err, "删除话题失败")
+		response.Abort500(c, "删除失败")
 		return
 	}
-
-	if err := database.DB.Delete(&t).Error; err != nil {
-		response.Abort500(c, "删除失败")
+	if count == 0 {
+		response.Abort404(c, "话题不存在")
 		return
 	}
 
@@ -226,10 +237,11 @@ func (ctrl *TopicController) Unpin(c *gin.Context) {
 	})
 }
 
-// BatchDelete 批量删除话题
+// BatchDelete 批量删除话题，删除后进入回收站
 func (ctrl *TopicController) BatchDelete(c *gin.Context) {
 	type BatchRequest struct {
-		IDs []uint `json:"ids" binding:"required"`
+		IDs    []uint64 `json:"ids" binding:"required"`
+		Reason string   `json:"reason" binding:"max=255"`
 	}
 
 	var req BatchRequest
@@ -238,14 +250,15 @@ func (ctrl *TopicController) BatchDelete(c *gin.Context) {
 		return
 	}
 
-	if err := database.DB.Delete(&topic.Topic{}, req.IDs).Error; err != nil {
-		response.Abort500(c, "批量删除失败")
+	count, err := services.NewTrashService().Delete(repositories.TrashTopic, req.IDs, auth.CurrentUID(c), req.Reason)
+	if err != nil {
+		abortTopicStateError(c, err, "批量删除失败")
 		return
 	}
 
 	response.Data(c, gin.H{
 		"message": "批量删除成功",
-		"count":   len(req.IDs),
+		"count":   count,
 	})
 }
 
@@ -253,61 +266,340 @@ func (ctrl *TopicController) BatchDelete(c *gin.Context) {
 func (ctrl *TopicController) Approve(c *gin.Context) {
 	topicID := c.Param("id")
 
-	var t topic.Topic
-	if err := database.DB.First(&t, topicID).Error; err != nil {
-		response.Abort404(c, "话题不存在")
+	t, err := services.NewModerationService().Approve(topicID, auth.CurrentUID(c))
+	if err != nil {
+		abortTopicStateError(c, err, "审核失败")
 		return
 	}
 
-	// 审核通过状态设置为 1
-	t.Status = 1
-	t.RejectReason = ""
+	response.Data(c, gin.H{
+		"message":  "审核通过",
+		"topic_id": topicID,
+		"status":   t.Status,
+	})
+}
 
-	if err := database.DB.Save(&t).Error; err != nil {
-		response.Abort500(c, "审核失败")
+// Reject 审核拒绝话题，拒绝原因会通知给作者
+func (ctrl *TopicController) Reject(c *gin.Context) {
+	topicID := c.Param("id")
+
+	type RejectRequest struct {
+		Reason string `json:"reason" binding:"required,max=255"`
+	}
+
+	var req RejectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": "参数错误"})
+		return
+	}
+
+	t, err := services.NewModerationService().Reject(topicID, auth.CurrentUID(c), req.Reason)
+	if err != nil {
+		abortTopicStateError(c, err, "审核失败")
 		return
 	}
 
 	response.Data(c, gin.H{
-		"message": "审核通过",
+		"message":  "已拒绝",
 		"topic_id": topicID,
-		"status": t.Status,
+		"status":   t.Status,
+		"reason":   req.Reason,
 	})
 }
 
-// Reject 审核拒绝话题
-func (ctrl *TopicController) Reject(c *gin.Context) {
+// Queue 待审核话题队列，scope=mine 只看自己认领的，scope=unclaimed 只看未认领的
+func (ctrl *TopicController) Queue(c *gin.Context) {
+	perPage := 20
+	if pp := c.Query("per_page"); pp != "" {
+		if ppInt, err := strconv.Atoi(pp); err == nil {
+			perPage = ppInt
+		}
+	}
+
+	// 后台和版主路由共用该方法，分页链接使用当前请求的地址
+	topics, paging, err := services.NewModerationService().Queue(c, c.Request.URL.Path, c.Query("scope"), auth.CurrentUID(c), perPage)
+	if err != nil {
+		logger.LogErrorWithContext(c, err, "获取审核队列失败")
+		response.Abort500(c, "获取审核队列失败")
+		return
+	}
+
+	response.Data(c, gin.H{
+		"topics": topics,
+		"paging": paging,
+	})
+}
+
+// Claim 认领待审核话题
+func (ctrl *TopicController) Claim(c *gin.Context) {
 	topicID := c.Param("id")
 
-	type RejectRequest struct {
-		Reason string `json:"reason" binding:"required"`
+	if err := services.NewModerationService().Claim(topicID, auth.CurrentUID(c)); err != nil {
+		abortTopicStateError(c, err, "认领失败")
+		return
 	}
 
-	var req RejectRequest
+	response.Data(c, gin.H{
+		"message":  "认领成功",
+		"topic_id": topicID,
+	})
+}
+
+// Assign 将待审核话题指派给其他版主
+func (ctrl *TopicController) Assign(c *gin.Context) {
+	topicID := c.Param("id")
+
+	type AssignRequest struct {
+		ModeratorID uint64 `json:"moderator_id" binding:"required"`
+	}
+
+	var req AssignRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": "参数错误"})
 		return
 	}
 
-	var t topic.Topic
-	if err := database.DB.First(&t, topicID).Error; err != nil {
-		response.Abort404(c, "话题不存在")
+	moderatorID := cast.ToString(req.ModeratorID)
+	if err := services.NewModerationService().Assign(topicID, moderatorID); err != nil {
+		abortTopicStateError(c, err, "指派失败")
 		return
 	}
 
-	// 审核拒绝状态设置为 -1
-	t.Status = -1
-	t.RejectReason = req.Reason
+	response.Data(c, gin.H{
+		"message":      "指派成功",
+		"topic_id":     topicID,
+		"moderator_id": moderatorID,
+	})
+}
 
-	if err := database.DB.Save(&t).Error; err != nil {
-		response.Abort500(c, "审核失败")
+// Release 放弃认领，管理员可以释放其他版主的认领
+func (ctrl *TopicController) Release(c *gin.Context) {
+	topicID := c.Param("id")
+	currentUID := auth.CurrentUID(c)
+
+	force := role.UserHasAnyRole(currentUID, "admin")
+	if err := services.NewModerationService().Release(topicID, currentUID, force); err != nil {
+		abortTopicStateError(c, err, "释放认领失败")
 		return
 	}
 
 	response.Data(c, gin.H{
-		"message": "已拒绝",
+		"message":  "已释放认领",
 		"topic_id": topicID,
-		"status": t.Status,
-		"reason":  req.Reason,
 	})
 }
+
+// Lock 锁定话题，锁定后不能再评论
+func (ctrl *TopicController) Lock(c *gin.Context) {
+	topicID := c.Param("id")
+
+	t, err := services.NewTopicService().Lock(topicID, auth.CurrentUID(c))
+	if err != nil {
+		abortTopicStateError(c, err, "锁定失败")
+		return
+	}
+
+	response.Data(c, gin.H{
+		"message":  "话题已锁定",
+		"topic_id": topicID,
+		"state":    t.State,
+	})
+}
+
+// Unlock 解除锁定
+func (ctrl *TopicController) Unlock(c *gin.Context) {
+	topicID := c.Param("id")
+
+	t, err := services.NewTopicService().Unlock(topicID, auth.CurrentUID(c), true)
+	if err != nil {
+		abortTopicStateError(c, err, "解除锁定失败")
+		return
+	}
+
+	response.Data(c, gin.H{
+		"message":  "已解除锁定",
+		"topic_id": topicID,
+		"state":    t.State,
+	})
+}
+
+// Close 关闭话题
+func (ctrl *TopicController) Close(c *gin.Context) {
+	topicID := c.Param("id")
+
+	type CloseRequest struct {
+		Reason string `json:"reason" binding:"required,max=500"`
+	}
+
+	var req CloseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": "参数错误"})
+		return
+	}
+
+	t, err := services.NewTopicService().Close(topicID, auth.CurrentUID(c), req.Reason)
+	if err != nil {
+		abortTopicStateError(c, err, "关闭失败")
+		return
+	}
+
+	response.Data(c, gin.H{
+		"message":  "话题已关闭",
+		"topic_id": topicID,
+		"state":    t.State,
+		"reason":   req.Reason,
+	})
+}
+
+// Reopen 重新开放已关闭或已归档的话题
+func (ctrl *TopicController) Reopen(c *gin.Context) {
+	topicID := c.Param("id")
+
+	t, err := services.NewTopicService().Reopen(topicID, auth.CurrentUID(c), true)
+	if err != nil {
+		abortTopicStateError(c, err, "重新开放失败")
+		return
+	}
+
+	response.Data(c, gin.H{
+		"message":  "话题已重新开放",
+		"topic_id": topicID,
+		"state":    t.State,
+	})
+}
+
+// Move 将话题移动到其他分类
+func (ctrl *TopicController) Move(c *gin.Context) {
+	topicID := c.Param("id")
+
+	type MoveRequest struct {
+		CategoryID uint64 `json:"category_id" binding:"required"`
+		Reason     string `json:"reason" binding:"max=500"`
+	}
+
+	var req MoveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": "参数错误"})
+		return
+	}
+
+	t, err := services.NewModerationService().Move(topicID, cast.ToString(req.CategoryID), auth.CurrentUID(c), req.Reason)
+	if err != nil {
+		abortTopicStateError(c, err, "移动失败")
+		return
+	}
+
+	response.Data(c, gin.H{
+		"message":     "话题已移动",
+		"topic_id":    topicID,
+		"category_id": t.CategoryID,
+	})
+}
+
+// Merge 将话题合并到目标话题
+func (ctrl *TopicController) Merge(c *gin.Context) {
+	topicID := c.Param("id")
+
+	type MergeRequest struct {
+		TargetID uint64 `json:"target_id" binding:"required"`
+		Reason   string `json:"reason" binding:"max=255"`
+	}
+
+	var req MergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": "参数错误"})
+		return
+	}
+
+	t, err := services.NewModerationService().Merge(topicID, cast.ToString(req.TargetID), auth.CurrentUID(c), req.Reason)
+	if err != nil {
+		abortTopicStateError(c, err, "合并失败")
+		return
+	}
+
+	response.Data(c, gin.H{
+		"message":        "话题已合并",
+		"topic_id":       topicID,
+		"target_id":      t.GetStringID(),
+		"like_count":     t.LikeCount,
+		"favorite_count": t.FavoriteCount,
+	})
+}
+
+// Split 将选中的评论拆分为新话题
+func (ctrl *TopicController) Split(c *gin.Context) {
+	topicID := c.Param("id")
+
+	type SplitRequest struct {
+		CommentIDs []uint64 `json:"comment_ids" binding:"required,min=1"`
+		Title      string   `json:"title" binding:"required,min=3,max=255"`
+		CategoryID uint64   `json:"category_id"`
+		Reason     string   `json:"reason" binding:"max=500"`
+	}
+
+	var req SplitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": "参数错误"})
+		return
+	}
+
+	opts := services.SplitOptions{
+		CommentIDs: req.CommentIDs,
+		Title:      req.Title,
+		Reason:     req.Reason,
+	}
+	if req.CategoryID > 0 {
+		opts.CategoryID = cast.ToString(req.CategoryID)
+	}
+
+	t, err := services.NewModerationService().Split(topicID, auth.CurrentUID(c), opts)
+	if err != nil {
+		abortTopicStateError(c, err, "拆分失败")
+		return
+	}
+
+	response.Data(c, gin.H{
+		"message":      "话题已拆分",
+		"topic_id":     topicID,
+		"new_topic_id": t.GetStringID(),
+	})
+}
+
+// Logs 版主操作日志，可按 action、target_type、target_id、moderator_id 筛选
+func (ctrl *TopicController) Logs(c *gin.Context) {
+	perPage := 20
+	if pp := c.Query("per_page"); pp != "" {
+		if ppInt, err := strconv.Atoi(pp); err == nil {
+			perPage = ppInt
+		}
+	}
+
+	logs, paging, err := services.NewModerationService().Logs(c, perPage)
+	if err != nil {
+		logger.LogErrorWithContext(c, err, "获取操作日志失败")
+		response.Abort500(c, "获取操作日志失败")
+		return
+	}
+
+	response.Data(c, gin.H{
+		"logs":   logs,
+		"paging": paging,
+	})
+}
+
+// abortTopicStateError 话题状态操作的错误响应
+func abortTopicStateError(c *gin.Context, err *apperrors.AppError, message string) {
+	switch err.Type {
+	case apperrors.ErrorTypeNotFound:
+		response.Abort404(c, "话题不存在")
+	case apperrors.ErrorTypeAuthorization:
+		response.Abort403(c, err.Message)
+	case apperrors.ErrorTypeBusiness, apperrors.ErrorTypeValidation:
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
+			"message": err.Message,
+		})
+	default:
+		logger.LogErrorWithContext(c, err, message)
+		response.Abort500(c, message)
+	}
+}
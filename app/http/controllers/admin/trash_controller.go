@@ -0,0 +1,101 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"GoHub-Service/app/services"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrashController 回收站管理控制器
+// 路由中的 :type 为 topics、comments 或 users
+type TrashController struct{}
+
+// TrashRequest 恢复或彻底删除的请求
+type TrashRequest struct {
+	IDs []uint64 `json:"ids" binding:"required"`
+}
+
+// Index 回收站列表
+func (ctrl *TrashController) Index(c *gin.Context) {
+	perPage := 20
+	if pp := c.Query("per_page"); pp != "" {
+		if ppInt, err := strconv.Atoi(pp); err == nil {
+			perPage = ppInt
+		}
+	}
+
+	data, paging, err := services.NewTrashService().List(c, trashKind(c), perPage)
+	if err != nil {
+		abortTrashError(c, err, "获取回收站列表失败")
+		return
+	}
+
+	response.Data(c, gin.H{
+		"data":   data,
+		"paging": paging,
+	})
+}
+
+// Restore 从回收站恢复
+func (ctrl *TrashController) Restore(c *gin.Context) {
+	var req TrashRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": "参数错误"})
+		return
+	}
+
+	count, err := services.NewTrashService().Restore(trashKind(c), req.IDs)
+	if err != nil {
+		abortTrashError(c, err, "恢复失败")
+		return
+	}
+
+	response.Data(c, gin.H{
+		"message": "恢复成功",
+		"count":   count,
+	})
+}
+
+// Purge 彻底删除，不可恢复
+func (ctrl *TrashController) Purge(c *gin.Context) {
+	var req TrashRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": "参数错误"})
+		return
+	}
+
+	count, err := services.NewTrashService().Purge(trashKind(c), req.IDs)
+	if err != nil {
+		abortTrashError(c, err, "彻底删除失败")
+		return
+	}
+
+	response.Data(c, gin.H{
+		"message": "已彻底删除",
+		"count":   count,
+	})
+}
+
+// trashKind 将路由中的复数形式转换为资源类型
+func trashKind(c *gin.Context) string {
+	return strings.TrimSuffix(c.Param("type"), "s")
+}
+
+// abortTrashError 回收站操作的错误响应
+func abortTrashError(c *gin.Context, err *apperrors.AppError, message string) {
+	if err.Type == apperrors.ErrorTypeValidation {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
+			"message": err.Message,
+		})
+		return
+	}
+	logger.LogErrorWithContext(c, err, message)
+	response.Abort500(c, message)
+}
@@ -4,9 +4,12 @@ import (
 	"time"
 
 	"GoHub-Service/app/models/user"
+	"GoHub-Service/app/repositories"
 	"GoHub-Service/app/requests"
+	"GoHub-Service/app/services"
 	"GoHub-Service/pkg/auth"
 	"GoHub-Service/pkg/database"
+	"GoHub-Service/pkg/logger"
 	"GoHub-Service/pkg/paginator"
 	"GoHub-Service/pkg/response"
 	"net/http"
@@ -29,6 +32,7 @@ type AdminUserResponse struct {
 	Introduction   string `json:"introduction,omitempty"`
 	Avatar         string `json:"avatar,omitempty"`
 	FollowersCount int64  `json:"followers_count,omitempty"`
+	FollowingCount int64  `json:"following_count,omitempty"`
 	Points         int64  `json:"points,omitempty"`
 	CreatedAt      string `json:"created_at"`
 	UpdatedAt      string `json:"updated_at"`
@@ -45,6 +49,7 @@ func toAdminUserResponse(u user.User) AdminUserResponse {
 		Introduction:   u.Introduction,
 		Avatar:         u.Avatar,
 		FollowersCount: u.FollowersCount,
+		FollowingCount: u.FollowingCount,
 		Points:         u.Points,
 		CreatedAt:      u.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:      u.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
@@ -164,19 +169,18 @@ func (ctrl *UserController) Update(c *gin.Context) {
 	})
 }
 
-// Delete 删除用户（软删除）
+// Delete 删除用户（软删除），删除后进入回收站
 func (ctrl *UserController) Delete(c *gin.Context) {
 	userID := c.Param("id")
 
-	var u user.User
-	if err := database.DB.First(&u, userID).Error; err != nil {
-		response.Abort404(c, "用户不存在")
+	count, err := services.NewTrashService().Delete(repositories.TrashUser, []uint64{cast.ToUint64(userID)}, auth.CurrentUID(c), c.Query("reason"))
+	if err != nil {
+		logger.LogErrorWithContext(c, err, "删除用户失败")
+		response.Abort500(c, "删除失败")
 		return
 	}
-
-	// 软删除
-	if err := database.DB.Delete(&u).Error; err != nil {
-		response.Abort500(c, "删除失败")
+	if count == 0 {
+		response.Abort404(c, "用户不存在")
 		return
 	}
 
@@ -282,10 +286,11 @@ func (ctrl *UserController) Unban(c *gin.Context) {
 	})
 }
 
-// BatchDelete 批量删除用户
+// BatchDelete 批量删除用户，删除后进入回收站
 func (ctrl *UserController) BatchDelete(c *gin.Context) {
 	type BatchRequest struct {
-		IDs []uint `json:"ids" binding:"required"`
+		IDs    []uint64 `json:"ids" binding:"required"`
+		Reason string   `json:"reason" binding:"max=255"`
 	}
 
 	var req BatchRequest
@@ -294,14 +299,16 @@ func (ctrl *UserController) BatchDelete(c *gin.Context) {
 		return
 	}
 
-	if err := database.DB.Delete(&user.User{}, req.IDs).Error; err != nil {
+	count, err := services.NewTrashService().Delete(repositories.TrashUser, req.IDs, auth.CurrentUID(c), req.Reason)
+	if err != nil {
+		logger.LogErrorWithContext(c, err, "批量删除用户失败")
 		response.Abort500(c, "批量删除失败")
 		return
 	}
 
 	response.Data(c, gin.H{
 		"message": "批量删除成功",
-		"count":   len(req.IDs),
+		"count":   count,
 	})
 }
 
@@ -1,6 +1,47 @@
 // Package v1 处理业务逻辑, GoHub 控制器 v1
 package v1
 
+import (
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
 // BaseAPIController 基础控制器
 type BaseAPIController struct {
 }
+
+// respondCursorListError 游标分页列表的错误响应，游标无效返回 422，其余返回 500
+func respondCursorListError(c *gin.Context, err *apperrors.AppError, message string) {
+	if err.Type == apperrors.ErrorTypeValidation {
+		response.ValidationError(c, map[string][]string{"cursor": {err.Message}})
+		return
+	}
+	logger.LogErrorWithContext(c, err, message)
+	response.ApiError(c, 500, err.Code, err.Message)
+}
+
+// respondSpamError 发布被垃圾信息检测拦截时的响应：发布过于频繁返回 429，疑似垃圾信息返回 422
+// 不是拦截错误时返回 false，由调用方继续处理
+func respondSpamError(c *gin.Context, err *apperrors.AppError) bool {
+	switch err.Code {
+	case apperrors.CodePostingTooFrequent:
+		response.ApiError(c, 429, err.Code, err.Message)
+	case apperrors.CodeSpamDetected:
+		response.ApiError(c, 422, err.Code, err.Message)
+	default:
+		return false
+	}
+	return true
+}
+
+// respondPostDenied 没有分类发布权限时返回 403，不是该错误时返回 false
+func respondPostDenied(c *gin.Context, err *apperrors.AppError) bool {
+	if err.Code != apperrors.CodeCategoryPostDenied {
+		return false
+	}
+	response.ApiError(c, 403, err.Code, err.Message)
+	return true
+}
@@ -4,6 +4,7 @@ import (
     "GoHub-Service/app/http/middlewares"
     "GoHub-Service/app/requests"
     "GoHub-Service/app/services"
+    "GoHub-Service/pkg/auth"
     apperrors "GoHub-Service/pkg/errors"
     "GoHub-Service/pkg/logger"
     "GoHub-Service/pkg/response"
@@ -43,10 +44,17 @@ func (ctrl *CategoriesController) Store(c *gin.Context) {
     dto := services.CategoryCreateDTO{
         Name:        request.Name,
         Description: request.Description,
+        IsQA:        request.IsQA != nil && *request.IsQA,
+    }
+    if request.ParentID != nil {
+        dto.ParentID = *request.ParentID
     }
 
     categoryModel, err := ctrl.categoryService.Create(dto)
     if err != nil {
+        if respondCategoryError(c, err) {
+            return
+        }
         logger.LogErrorWithContext(c, err, "创建分类失败")
         if appErr, ok := err.(*apperrors.AppError); ok {
             response.ApiError(c, 500, appErr.Code, appErr.Message)
@@ -82,10 +90,15 @@ func (ctrl *CategoriesController) Update(c *gin.Context) {
     dto := services.CategoryUpdateDTO{
         Name:        &request.Name,
         Description: &request.Description,
+        IsQA:        request.IsQA,
+        ParentID:    request.ParentID,
     }
 
     categoryModel, err := ctrl.categoryService.Update(c.Param("id"), dto)
     if err != nil {
+        if respondCategoryError(c, err) {
+            return
+        }
         if apperrors.IsAppError(err) {
             appErr, _ := apperrors.GetAppError(err)
             appErr.WithRequestID(middlewares.GetRequestID(c))
@@ -124,6 +137,9 @@ func (ctrl *CategoriesController) Index(c *gin.Context) {
 func (ctrl *CategoriesController) Delete(c *gin.Context) {
     err := ctrl.categoryService.Delete(c.Param("id"))
     if err != nil {
+        if respondCategoryError(c, err) {
+            return
+        }
         if apperrors.IsAppError(err) {
             appErr, _ := apperrors.GetAppError(err)
             appErr.WithRequestID(middlewares.GetRequestID(c))
@@ -139,3 +155,25 @@ func (ctrl *CategoriesController) Delete(c *gin.Context) {
 
     response.Success(c)
 }
+
+// Tree 完整的分类树
+func (ctrl *CategoriesController) Tree(c *gin.Context) {
+    tree, err := ctrl.categoryService.Tree(auth.CurrentUID(c))
+    if err != nil {
+        logger.LogErrorWithContext(c, err, "获取分类树失败")
+        response.Abort500(c, "获取分类树失败")
+        return
+    }
+
+    response.Data(c, tree)
+}
+
+// respondCategoryError 上级分类无效、有子分类时不能删除等返回 422，其余错误由调用方处理
+func respondCategoryError(c *gin.Context, err error) bool {
+    appErr, ok := apperrors.GetAppError(err)
+    if !ok || (appErr.Type != apperrors.ErrorTypeValidation && appErr.Type != apperrors.ErrorTypeBusiness) {
+        return false
+    }
+    response.ApiError(c, 422, appErr.Code, appErr.Message)
+    return true
+}
@@ -0,0 +1,225 @@
+package v1
+
+import (
+	"GoHub-Service/app/requests"
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/auth"
+	"GoHub-Service/pkg/config"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CollectionsController 收藏夹和收藏列表接口
+type CollectionsController struct {
+	service *services.CollectionService
+}
+
+// NewCollectionsController 创建控制器
+func NewCollectionsController() *CollectionsController {
+	return &CollectionsController{service: services.NewCollectionService()}
+}
+
+// Favorites 我收藏的话题
+func (ctrl *CollectionsController) Favorites(c *gin.Context) {
+	data, paging, err := ctrl.service.Favorites(c, auth.CurrentUID(c), config.GetInt("paging.perpage"))
+	if err != nil {
+		handleCollectionError(c, err, "获取收藏列表失败")
+		return
+	}
+	response.JSON(c, gin.H{
+		"data":   data,
+		"paging": paging,
+	})
+}
+
+// Index 我的收藏夹
+func (ctrl *CollectionsController) Index(c *gin.Context) {
+	userID := auth.CurrentUID(c)
+	ctrl.listByUser(c, userID, userID)
+}
+
+// UserCollections 用户的公开收藏夹
+func (ctrl *CollectionsController) UserCollections(c *gin.Context) {
+	ctrl.listByUser(c, c.Param("id"), auth.CurrentUID(c))
+}
+
+func (ctrl *CollectionsController) listByUser(c *gin.Context, userID, viewerID string) {
+	data, paging, err := ctrl.service.ListByUser(c, userID, viewerID, config.GetInt("paging.perpage"))
+	if err != nil {
+		handleCollectionError(c, err, "获取收藏夹列表失败")
+		return
+	}
+	response.JSON(c, gin.H{
+		"data":   data,
+		"paging": paging,
+	})
+}
+
+// Following 我关注的收藏夹
+func (ctrl *CollectionsController) Following(c *gin.Context) {
+	data, paging, err := ctrl.service.Following(c, auth.CurrentUID(c), config.GetInt("paging.perpage"))
+	if err != nil {
+		handleCollectionError(c, err, "获取关注的收藏夹失败")
+		return
+	}
+	response.JSON(c, gin.H{
+		"data":   data,
+		"paging": paging,
+	})
+}
+
+// Store 创建收藏夹
+func (ctrl *CollectionsController) Store(c *gin.Context) {
+	request := requests.CollectionRequest{}
+	if ok := requests.Validate(c, &request, requests.CollectionSave); !ok {
+		return
+	}
+
+	collection, err := ctrl.service.Create(auth.CurrentUID(c), services.CollectionSaveDTO{
+		Name:        request.Name,
+		Description: request.Description,
+		IsPublic:    request.IsPublic,
+	})
+	if err != nil {
+		handleCollectionError(c, err, "创建收藏夹失败")
+		return
+	}
+	response.Created(c, collection)
+}
+
+// Show 收藏夹详情
+func (ctrl *CollectionsController) Show(c *gin.Context) {
+	collection, err := ctrl.service.Get(c.Param("id"), auth.CurrentUID(c))
+	if err != nil {
+		handleCollectionError(c, err, "获取收藏夹失败")
+		return
+	}
+	response.Data(c, collection)
+}
+
+// Update 修改收藏夹
+func (ctrl *CollectionsController) Update(c *gin.Context) {
+	request := requests.CollectionRequest{}
+	if ok := requests.Validate(c, &request, requests.CollectionSave); !ok {
+		return
+	}
+
+	collection, err := ctrl.service.Update(c.Param("id"), auth.CurrentUID(c), services.CollectionSaveDTO{
+		Name:        request.Name,
+		Description: request.Description,
+		IsPublic:    request.IsPublic,
+	})
+	if err != nil {
+		handleCollectionError(c, err, "修改收藏夹失败")
+		return
+	}
+	response.Data(c, collection)
+}
+
+// Delete 删除收藏夹
+func (ctrl *CollectionsController) Delete(c *gin.Context) {
+	if err := ctrl.service.Delete(c.Param("id"), auth.CurrentUID(c)); err != nil {
+		handleCollectionError(c, err, "删除收藏夹失败")
+		return
+	}
+	response.Success(c)
+}
+
+// Items 收藏夹中的话题
+func (ctrl *CollectionsController) Items(c *gin.Context) {
+	data, paging, err := ctrl.service.Items(c, c.Param("id"), auth.CurrentUID(c), config.GetInt("paging.perpage"))
+	if err != nil {
+		handleCollectionError(c, err, "获取收藏夹话题失败")
+		return
+	}
+	response.JSON(c, gin.H{
+		"data":   data,
+		"paging": paging,
+	})
+}
+
+// AddItem 将话题加入收藏夹
+func (ctrl *CollectionsController) AddItem(c *gin.Context) {
+	request := requests.CollectionItemRequest{}
+	if ok := requests.Validate(c, &request, requests.CollectionItem); !ok {
+		return
+	}
+
+	if err := ctrl.service.AddItem(c.Param("id"), auth.CurrentUID(c), request.TopicID, request.Note); err != nil {
+		handleCollectionError(c, err, "加入收藏夹失败")
+		return
+	}
+	response.Success(c)
+}
+
+// UpdateItem 修改收藏备注
+func (ctrl *CollectionsController) UpdateItem(c *gin.Context) {
+	request := requests.CollectionItemNoteRequest{}
+	if ok := requests.Validate(c, &request, requests.CollectionItemNote); !ok {
+		return
+	}
+
+	if err := ctrl.service.UpdateItemNote(c.Param("id"), auth.CurrentUID(c), c.Param("topic_id"), request.Note); err != nil {
+		handleCollectionError(c, err, "修改收藏备注失败")
+		return
+	}
+	response.Success(c)
+}
+
+// RemoveItem 从收藏夹中移除话题
+func (ctrl *CollectionsController) RemoveItem(c *gin.Context) {
+	if err := ctrl.service.RemoveItem(c.Param("id"), auth.CurrentUID(c), c.Param("topic_id")); err != nil {
+		handleCollectionError(c, err, "移出收藏夹失败")
+		return
+	}
+	response.Success(c)
+}
+
+// Reorder 调整收藏夹中话题的顺序
+func (ctrl *CollectionsController) Reorder(c *gin.Context) {
+	request := requests.CollectionReorderRequest{}
+	if ok := requests.Validate(c, &request, requests.CollectionReorder); !ok {
+		return
+	}
+
+	if err := ctrl.service.Reorder(c.Param("id"), auth.CurrentUID(c), request.TopicIDs); err != nil {
+		handleCollectionError(c, err, "调整收藏夹顺序失败")
+		return
+	}
+	response.Success(c)
+}
+
+// Follow 关注收藏夹
+func (ctrl *CollectionsController) Follow(c *gin.Context) {
+	if err := ctrl.service.Follow(c.Param("id"), auth.CurrentUID(c)); err != nil {
+		handleCollectionError(c, err, "关注收藏夹失败")
+		return
+	}
+	response.Success(c)
+}
+
+// Unfollow 取消关注收藏夹
+func (ctrl *CollectionsController) Unfollow(c *gin.Context) {
+	if err := ctrl.service.Unfollow(c.Param("id"), auth.CurrentUID(c)); err != nil {
+		handleCollectionError(c, err, "取消关注收藏夹失败")
+		return
+	}
+	response.Success(c)
+}
+
+func handleCollectionError(c *gin.Context, err *apperrors.AppError, message string) {
+	switch err.Type {
+	case apperrors.ErrorTypeValidation:
+		response.ValidationError(c, map[string][]string{"error": {err.Message}})
+	case apperrors.ErrorTypeNotFound:
+		response.Abort404(c)
+	case apperrors.ErrorTypeAuthorization:
+		response.Abort403(c, err.Message)
+	default:
+		logger.LogErrorWithContext(c, err, message)
+		response.ApiError(c, 500, err.Code, err.Message)
+	}
+}
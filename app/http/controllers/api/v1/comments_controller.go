@@ -5,7 +5,9 @@ import (
 	"GoHub-Service/app/services"
 	"GoHub-Service/pkg/auth"
 	ctx "GoHub-Service/pkg/ctx"
+	apperrors "GoHub-Service/pkg/errors"
 	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/paginator"
 	"GoHub-Service/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -43,12 +45,13 @@ func (ctrl *CommentsController) Index(c *gin.Context) {
 	// 从 Gin Context 创建请求 Context
 	requestCtx := ctx.FromGinContext(c)
 
-	listResponse, err := ctrl.commentService.List(requestCtx, c, 15)
+	listResponse, err := ctrl.commentService.List(requestCtx, c, auth.CurrentUID(c), 15)
 	if err != nil {
 		logger.LogErrorWithContext(c, err, "获取评论列表失败")
 		response.ApiError(c, 500, err.Code, err.Message)
 		return
 	}
+	ctrl.commentService.FillListViewerState(requestCtx, auth.CurrentUID(c), listResponse.Comments)
 	response.JSON(c, gin.H{
 		"data":  listResponse.Comments,
 		"pager": listResponse.Paging,
@@ -79,6 +82,11 @@ func (ctrl *CommentsController) Show(c *gin.Context) {
 		}
 		return
 	}
+	if !ctrl.commentService.CanViewTopic(requestCtx, commentModel.TopicID, auth.CurrentUID(c)) {
+		response.Abort404(c)
+		return
+	}
+	ctrl.commentService.FillViewerState(requestCtx, auth.CurrentUID(c), commentModel)
 	response.Data(c, commentModel)
 }
 
@@ -116,6 +124,18 @@ func (ctrl *CommentsController) Store(c *gin.Context) {
 
 	commentModel, err := ctrl.commentService.Create(requestCtx, dto)
 	if err != nil {
+		if respondSpamError(c, err) || respondPostDenied(c, err) {
+			return
+		}
+		switch err.Type {
+		case apperrors.ErrorTypeNotFound:
+			response.Abort404(c, err.Message)
+			return
+		case apperrors.ErrorTypeBusiness:
+			// 话题已锁定、关闭或归档
+			response.ApiError(c, 403, err.Code, err.Message)
+			return
+		}
 		logger.LogErrorWithContext(c, err, "创建评论失败")
 		response.ApiError(c, 500, err.Code, err.Message)
 		return
@@ -126,7 +146,7 @@ func (ctrl *CommentsController) Store(c *gin.Context) {
 
 // Update 更新评论
 // @Summary 更新评论内容
-// @Description 更新指定评论的内容，需要是评论作者
+// @Description 作者在发布后的编辑时限内修改评论，管理员和负责该分类的版主不受限制，修改前的内容保存为历史版本
 // @Tags 评论管理
 // @Accept json
 // @Produce json
@@ -134,7 +154,7 @@ func (ctrl *CommentsController) Store(c *gin.Context) {
 // @Param id path string true "评论ID"
 // @Param comment body requests.CommentRequest true "评论信息"
 // @Success 200 {object} response.Response "更新成功"
-// @Failure 403 {object} response.Response "无权限"
+// @Failure 403 {object} response.Response "无权限或已超过编辑时限"
 // @Failure 404 {object} response.Response "评论不存在"
 // @Failure 422 {object} response.Response "验证失败"
 // @Router /comments/{id} [put]
@@ -152,20 +172,63 @@ func (ctrl *CommentsController) Update(c *gin.Context) {
 	// 从 Gin Context 创建请求 Context
 	requestCtx := ctx.FromGinContext(c)
 
-	commentModel, err := ctrl.commentService.Update(requestCtx, c.Param("id"), dto)
+	currentUID := auth.CurrentUID(c)
+	commentModel, err := ctrl.commentService.Update(requestCtx, c.Param("id"), dto, currentUID)
 	if err != nil {
-		logger.LogErrorWithContext(c, err, "更新评论失败")
-		if err.Code == 4001 {
+		if respondSpamError(c, err) {
+			return
+		}
+		switch err.Type {
+		case apperrors.ErrorTypeNotFound:
 			response.Abort404(c)
-		} else {
-			response.ApiError(c, 500, err.Code, err.Message)
+			return
+		case apperrors.ErrorTypeAuthorization, apperrors.ErrorTypeBusiness:
+			// 不是作者或已超过编辑时限
+			response.ApiError(c, 403, err.Code, err.Message)
+			return
 		}
+		logger.LogErrorWithContext(c, err, "更新评论失败")
+		response.ApiError(c, 500, err.Code, err.Message)
 		return
 	}
 
+	ctrl.commentService.FillViewerState(requestCtx, currentUID, commentModel)
 	response.Data(c, commentModel)
 }
 
+// Revisions 评论编辑历史
+// @Summary 获取评论的编辑历史
+// @Description 返回评论每次修改前的内容，最近的修改在前，只有作者、管理员和负责该分类的版主可以查看
+// @Tags 评论管理
+// @Produce json
+// @Security Bearer
+// @Param id path string true "评论ID"
+// @Success 200 {object} response.Response "成功"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 404 {object} response.Response "评论不存在"
+// @Router /comments/{id}/revisions [get]
+func (ctrl *CommentsController) Revisions(c *gin.Context) {
+	requestCtx := ctx.FromGinContext(c)
+
+	currentUID := auth.CurrentUID(c)
+	revisions, err := ctrl.commentService.Revisions(requestCtx, c.Param("id"), currentUID)
+	if err != nil {
+		switch err.Type {
+		case apperrors.ErrorTypeNotFound:
+			response.Abort404(c)
+			return
+		case apperrors.ErrorTypeAuthorization:
+			response.Abort403(c, err.Message)
+			return
+		}
+		logger.LogErrorWithContext(c, err, "获取评论编辑历史失败")
+		response.ApiError(c, 500, err.Code, err.Message)
+		return
+	}
+
+	response.Data(c, revisions)
+}
+
 // Delete 删除评论
 // @Summary 删除评论
 // @Description 删除指定评论
@@ -182,7 +245,7 @@ func (ctrl *CommentsController) Delete(c *gin.Context) {
 	// 从 Gin Context 创建请求 Context
 	requestCtx := ctx.FromGinContext(c)
 
-	err := ctrl.commentService.Delete(requestCtx, c.Param("id"))
+	err := ctrl.commentService.Delete(requestCtx, c.Param("id"), auth.CurrentUID(c))
 	if err != nil {
 		logger.LogErrorWithContext(c, err, "删除评论失败")
 		if err.Code == 4001 {
@@ -205,6 +268,7 @@ func (ctrl *CommentsController) Delete(c *gin.Context) {
 // @Param id path string true "话题ID"
 // @Param page query int false "页码" default(1)
 // @Param per_page query int false "每页数量" default(15)
+// @Param cursor query string false "游标分页，首页传空值，之后传 next_cursor 或 prev_cursor"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/v1/topics/{id}/comments [get]
 func (ctrl *CommentsController) ListByTopicID(c *gin.Context) {
@@ -218,6 +282,26 @@ func (ctrl *CommentsController) ListByTopicID(c *gin.Context) {
 	// 从 Gin Context 创建请求 Context
 	requestCtx := ctx.FromGinContext(c)
 
+	// 无权查看的分类与话题不存在一样处理
+	if !ctrl.commentService.CanViewTopic(requestCtx, topicID, auth.CurrentUID(c)) {
+		response.Abort404(c)
+		return
+	}
+
+	if paginator.UseCursor(c) {
+		cursorResponse, err := ctrl.commentService.ListByTopicIDCursor(requestCtx, c, topicID, 15)
+		if err != nil {
+			respondCursorListError(c, err, "获取话题评论列表失败")
+			return
+		}
+		ctrl.commentService.FillListViewerState(requestCtx, auth.CurrentUID(c), cursorResponse.Comments)
+		response.JSON(c, gin.H{
+			"data":  cursorResponse.Comments,
+			"pager": cursorResponse.Paging,
+		})
+		return
+	}
+
 	listResponse, err := ctrl.commentService.ListByTopicID(requestCtx, c, topicID, 15)
 	if err != nil {
 		logger.LogErrorWithContext(c, err, "获取话题评论列表失败")
@@ -225,12 +309,54 @@ func (ctrl *CommentsController) ListByTopicID(c *gin.Context) {
 		return
 	}
 
+	ctrl.commentService.FillListViewerState(requestCtx, auth.CurrentUID(c), listResponse.Comments)
 	response.JSON(c, gin.H{
 		"data":  listResponse.Comments,
 		"pager": listResponse.Paging,
 	})
 }
 
+// Tree 获取话题的评论树
+// @Summary 获取话题的评论树
+// @Description 顶级评论分页，每条评论内嵌前几条回复和回复总数，超过最大层数的回复平铺并标注回复对象
+// @Tags 评论管理
+// @Accept json
+// @Produce json
+// @Param id path string true "话题ID"
+// @Param sort_by query string false "排序方式: oldest|newest|likes" default(oldest)
+// @Param page query int false "页码" default(1)
+// @Param per_page query int false "每页数量" default(15)
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/v1/topics/{id}/comments/tree [get]
+func (ctrl *CommentsController) Tree(c *gin.Context) {
+	request := requests.CommentTreeRequest{}
+	if ok := requests.Validate(c, &request, requests.CommentTree); !ok {
+		return
+	}
+
+	requestCtx := ctx.FromGinContext(c)
+	if !ctrl.commentService.CanViewTopic(requestCtx, c.Param("id"), auth.CurrentUID(c)) {
+		response.Abort404(c)
+		return
+	}
+	treeResponse, err := ctrl.commentService.Tree(requestCtx, c, c.Param("id"), request.SortBy, 15)
+	if err != nil {
+		if err.Type == apperrors.ErrorTypeNotFound {
+			response.Abort404(c)
+			return
+		}
+		logger.LogErrorWithContext(c, err, "获取评论树失败")
+		response.ApiError(c, 500, err.Code, err.Message)
+		return
+	}
+
+	ctrl.commentService.FillTreeViewerState(requestCtx, auth.CurrentUID(c), treeResponse.Comments)
+	response.JSON(c, gin.H{
+		"data":  treeResponse.Comments,
+		"pager": treeResponse.Paging,
+	})
+}
+
 // ListByUserID 获取用户的评论列表
 // @Summary 获取用户的评论列表
 // @Description 获取指定用户的所有评论
@@ -240,6 +366,7 @@ func (ctrl *CommentsController) ListByTopicID(c *gin.Context) {
 // @Param id path string true "用户ID"
 // @Param page query int false "页码" default(1)
 // @Param per_page query int false "每页数量" default(15)
+// @Param cursor query string false "游标分页，首页传空值，之后传 next_cursor 或 prev_cursor"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/v1/users/{id}/comments [get]
 func (ctrl *CommentsController) ListByUserID(c *gin.Context) {
@@ -253,13 +380,28 @@ func (ctrl *CommentsController) ListByUserID(c *gin.Context) {
 	// 从 Gin Context 创建请求 Context
 	requestCtx := ctx.FromGinContext(c)
 
-	listResponse, err := ctrl.commentService.ListByUserID(requestCtx, c, userID, 15)
+	if paginator.UseCursor(c) {
+		cursorResponse, err := ctrl.commentService.ListByUserIDCursor(requestCtx, c, userID, auth.CurrentUID(c), 15)
+		if err != nil {
+			respondCursorListError(c, err, "获取用户评论列表失败")
+			return
+		}
+		ctrl.commentService.FillListViewerState(requestCtx, auth.CurrentUID(c), cursorResponse.Comments)
+		response.JSON(c, gin.H{
+			"data":  cursorResponse.Comments,
+			"pager": cursorResponse.Paging,
+		})
+		return
+	}
+
+	listResponse, err := ctrl.commentService.ListByUserID(requestCtx, c, userID, auth.CurrentUID(c), 15)
 	if err != nil {
 		logger.LogErrorWithContext(c, err, "获取用户评论列表失败")
 		response.ApiError(c, 500, err.Code, err.Message)
 		return
 	}
 
+	ctrl.commentService.FillListViewerState(requestCtx, auth.CurrentUID(c), listResponse.Comments)
 	response.JSON(c, gin.H{
 		"data":  listResponse.Comments,
 		"pager": listResponse.Paging,
@@ -288,6 +430,12 @@ func (ctrl *CommentsController) ListReplies(c *gin.Context) {
 	// 从 Gin Context 创建请求 Context
 	requestCtx := ctx.FromGinContext(c)
 
+	if parent, err := ctrl.commentService.GetByID(requestCtx, parentID); err == nil &&
+		!ctrl.commentService.CanViewTopic(requestCtx, parent.TopicID, auth.CurrentUID(c)) {
+		response.Abort404(c)
+		return
+	}
+
 	listResponse, err := ctrl.commentService.ListReplies(requestCtx, c, parentID, 15)
 	if err != nil {
 		logger.LogErrorWithContext(c, err, "获取评论回复列表失败")
@@ -295,6 +443,7 @@ func (ctrl *CommentsController) ListReplies(c *gin.Context) {
 		return
 	}
 
+	ctrl.commentService.FillListViewerState(requestCtx, auth.CurrentUID(c), listResponse.Comments)
 	response.JSON(c, gin.H{
 		"data":  listResponse.Comments,
 		"pager": listResponse.Paging,
@@ -316,8 +465,12 @@ func (ctrl *CommentsController) Like(c *gin.Context) {
 	// 从 Gin Context 创建请求 Context
 	requestCtx := ctx.FromGinContext(c)
 
-	err := ctrl.commentService.LikeComment(requestCtx, c.Param("id"))
+	err := ctrl.commentService.LikeComment(requestCtx, c.Param("id"), auth.CurrentUID(c))
 	if err != nil {
+		if err.Type == apperrors.ErrorTypeNotFound {
+			response.Abort404(c)
+			return
+		}
 		logger.LogErrorWithContext(c, err, "点赞评论失败")
 		response.ApiError(c, 500, err.Code, err.Message)
 		return
@@ -341,8 +494,12 @@ func (ctrl *CommentsController) Unlike(c *gin.Context) {
 	// 从 Gin Context 创建请求 Context
 	requestCtx := ctx.FromGinContext(c)
 
-	err := ctrl.commentService.UnlikeComment(requestCtx, c.Param("id"))
+	err := ctrl.commentService.UnlikeComment(requestCtx, c.Param("id"), auth.CurrentUID(c))
 	if err != nil {
+		if err.Type == apperrors.ErrorTypeNotFound {
+			response.Abort404(c)
+			return
+		}
 		logger.LogErrorWithContext(c, err, "取消点赞失败")
 		response.ApiError(c, 500, err.Code, err.Message)
 		return
@@ -350,3 +507,64 @@ func (ctrl *CommentsController) Unlike(c *gin.Context) {
 
 	response.Success(c)
 }
+
+// Accept 采纳回答
+// @Summary 采纳回答
+// @Description 问答分类中，话题作者或版主将一条顶级评论采纳为答案，已有采纳答案时改为采纳这条，回答者获得积分并收到通知
+// @Tags 评论管理
+// @Produce json
+// @Security Bearer
+// @Param id path string true "评论ID"
+// @Success 200 {object} response.Response "成功"
+// @Failure 403 {object} response.Response "无权限或话题不在问答分类中"
+// @Failure 404 {object} response.Response "评论不存在"
+// @Router /comments/{id}/accept [post]
+func (ctrl *CommentsController) Accept(c *gin.Context) {
+	requestCtx := ctx.FromGinContext(c)
+
+	currentUID := auth.CurrentUID(c)
+	commentModel, err := ctrl.commentService.AcceptAnswer(requestCtx, c.Param("id"), currentUID)
+	if err != nil {
+		respondAcceptError(c, err, "采纳回答失败")
+		return
+	}
+
+	response.Data(c, commentModel)
+}
+
+// Unaccept 取消采纳
+// @Summary 取消采纳回答
+// @Description 话题作者或版主取消采纳，话题回到未解决状态
+// @Tags 评论管理
+// @Produce json
+// @Security Bearer
+// @Param id path string true "评论ID"
+// @Success 200 {object} response.Response "成功"
+// @Failure 403 {object} response.Response "无权限或话题不在问答分类中"
+// @Failure 404 {object} response.Response "评论不存在"
+// @Router /comments/{id}/accept [delete]
+func (ctrl *CommentsController) Unaccept(c *gin.Context) {
+	requestCtx := ctx.FromGinContext(c)
+
+	currentUID := auth.CurrentUID(c)
+	if err := ctrl.commentService.UnacceptAnswer(requestCtx, c.Param("id"), currentUID); err != nil {
+		respondAcceptError(c, err, "取消采纳失败")
+		return
+	}
+
+	response.Success(c)
+}
+
+// respondAcceptError 采纳相关操作的错误响应
+func respondAcceptError(c *gin.Context, err *apperrors.AppError, message string) {
+	switch err.Type {
+	case apperrors.ErrorTypeNotFound:
+		response.Abort404(c)
+		return
+	case apperrors.ErrorTypeAuthorization, apperrors.ErrorTypeBusiness:
+		response.ApiError(c, 403, err.Code, err.Message)
+		return
+	}
+	logger.LogErrorWithContext(c, err, message)
+	response.ApiError(c, 500, err.Code, err.Message)
+}
@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"GoHub-Service/app/requests"
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/auth"
+	"GoHub-Service/pkg/config"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeedController 首页信息流和关注的标签、分类
+type FeedController struct {
+	service *services.FeedService
+}
+
+// NewFeedController 创建控制器
+func NewFeedController() *FeedController {
+	return &FeedController{service: services.NewFeedService()}
+}
+
+// Index 首页信息流
+// @Summary 获取首页信息流
+// @Description 关注的用户发布的话题和回复、关注的标签和分类下的新话题，按时间倒序，使用 next_cursor 翻页
+// @Tags 信息流
+// @Produce json
+// @Param cursor query string false "分页游标"
+// @Param per_page query int false "每页数量" default(20)
+// @Success 200 {object} response.Response "成功"
+// @Router /feed [get]
+func (ctrl *FeedController) Index(c *gin.Context) {
+	data, err := ctrl.service.Home(c, auth.CurrentUID(c), config.GetInt("paging.perpage"))
+	if err != nil {
+		handleFeedError(c, err, "获取信息流失败")
+		return
+	}
+	response.JSON(c, gin.H{
+		"data":   data.Items,
+		"paging": data.Paging,
+	})
+}
+
+// Subscriptions 我关注的标签和分类
+func (ctrl *FeedController) Subscriptions(c *gin.Context) {
+	data, paging, err := ctrl.service.Subscriptions(c, auth.CurrentUID(c), c.Query("type"), config.GetInt("paging.perpage"))
+	if err != nil {
+		handleFeedError(c, err, "获取关注的标签和分类失败")
+		return
+	}
+	response.JSON(c, gin.H{
+		"data":   data,
+		"paging": paging,
+	})
+}
+
+// Subscribe 关注标签或分类
+func (ctrl *FeedController) Subscribe(c *gin.Context) {
+	request := requests.FeedSubscriptionRequest{}
+	if ok := requests.Validate(c, &request, requests.FeedSubscription); !ok {
+		return
+	}
+	data, err := ctrl.service.Subscribe(auth.CurrentUID(c), request.TargetType, request.TargetID)
+	if err != nil {
+		handleFeedError(c, err, "关注失败")
+		return
+	}
+	response.Created(c, data)
+}
+
+// Unsubscribe 取消关注标签或分类
+func (ctrl *FeedController) Unsubscribe(c *gin.Context) {
+	if err := ctrl.service.Unsubscribe(auth.CurrentUID(c), c.Param("type"), c.Param("id")); err != nil {
+		handleFeedError(c, err, "取消关注失败")
+		return
+	}
+	response.Success(c)
+}
+
+func handleFeedError(c *gin.Context, err *apperrors.AppError, message string) {
+	switch err.Type {
+	case apperrors.ErrorTypeValidation:
+		response.ValidationError(c, map[string][]string{"error": {err.Message}})
+	case apperrors.ErrorTypeNotFound:
+		response.Abort404(c)
+	default:
+		logger.LogErrorWithContext(c, err, message)
+		response.ApiError(c, 500, err.Code, err.Message)
+	}
+}
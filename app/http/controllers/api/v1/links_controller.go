@@ -1,7 +1,11 @@
 package v1
 
 import (
+    "net/http"
+
     "GoHub-Service/app/services"
+    "GoHub-Service/pkg/auth"
+    apperrors "GoHub-Service/pkg/errors"
     "GoHub-Service/pkg/response"
 
     "github.com/gin-gonic/gin"
@@ -21,7 +25,7 @@ func NewLinksController() *LinksController {
 
 // Index 友情链接列表
 // @Summary 获取友情链接列表
-// @Description 获取所有激活的友情链接，结果从缓存中获取
+// @Description 获取所有审核通过、已启用且未失效的友情链接，按排序值升序，结果从缓存中获取
 // @Tags 友情链接
 // @Accept json
 // @Produce json
@@ -35,3 +39,54 @@ func (ctrl *LinksController) Index(c *gin.Context) {
     }
     response.Data(c, listResponse)
 }
+
+// Apply 申请友情链接
+// @Summary 申请友情链接
+// @Description 提交后进入待审核状态，管理员审核通过后展示
+// @Tags 友情链接
+// @Accept json
+// @Produce json
+// @Success 201 {object} response.Response "成功"
+// @Router /links/apply [post]
+func (ctrl *LinksController) Apply(c *gin.Context) {
+    var req services.LinkApplyDTO
+    if err := c.ShouldBindJSON(&req); err != nil {
+        response.BadRequest(c, err, "参数错误")
+        return
+    }
+    model, err := ctrl.linkService.Apply(auth.CurrentUID(c), req)
+    if err != nil {
+        switch {
+        case err.Type == apperrors.ErrorTypeValidation:
+            response.ValidationError(c, map[string][]string{"link": {err.Message}})
+        case err.Code == apperrors.CodeConflict:
+            response.ApiError(c, http.StatusConflict, err.Code, err.Message)
+        default:
+            response.ApiError(c, 500, err.Code, err.Message)
+        }
+        return
+    }
+    response.Created(c, gin.H{
+        "id":     model.GetStringID(),
+        "status": model.Status,
+    })
+}
+
+// Go 跳转到友情链接并记录点击次数
+// @Summary 友情链接跳转
+// @Tags 友情链接
+// @Param id path int true "链接ID"
+// @Success 302 "跳转到链接地址"
+// @Router /links/{id}/go [get]
+func (ctrl *LinksController) Go(c *gin.Context) {
+    target, err := ctrl.linkService.Click(c.Param("id"))
+    if err != nil {
+        if err.Type == apperrors.ErrorTypeNotFound {
+            response.Abort404(c, "友情链接不存在")
+            return
+        }
+        response.ApiError(c, 500, err.Code, err.Message)
+        return
+    }
+    c.Redirect(http.StatusFound, target)
+}
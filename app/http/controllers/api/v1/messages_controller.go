@@ -7,6 +7,7 @@ import (
 	"GoHub-Service/pkg/config"
 	apperrors "GoHub-Service/pkg/errors"
 	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/paginator"
 	"GoHub-Service/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -48,6 +49,20 @@ func (ctrl *MessagesController) Conversation(c *gin.Context) {
 
 	currentUserID := auth.CurrentUID(c)
 	perPage := config.GetInt("paging.perpage")
+	if paginator.UseCursor(c) {
+		list, paging, unread, err := ctrl.service.ConversationByCursor(c, currentUserID, request.UserID, perPage)
+		if err != nil {
+			handleMessageError(c, err, "获取会话失败")
+			return
+		}
+		response.JSON(c, gin.H{
+			"data":   list,
+			"paging": paging,
+			"unread": unread,
+		})
+		return
+	}
+
 	list, paging, unread, err := ctrl.service.Conversation(c, currentUserID, request.UserID, perPage)
 	if err != nil {
 		handleMessageError(c, err, "获取会话失败")
@@ -4,6 +4,7 @@ import (
 	"GoHub-Service/app/services"
 	"GoHub-Service/pkg/auth"
 	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/paginator"
 	"GoHub-Service/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -29,11 +30,21 @@ func NewNotificationsController() *NotificationsController {
 // @Security Bearer
 // @Param page query int false "页码" default(1)
 // @Param per_page query int false "每页数量" default(15)
+// @Param cursor query string false "游标分页，首页传空值，之后传 next_cursor 或 prev_cursor"
 // @Success 200 {object} response.Response "成功"
 // @Failure 401 {object} response.Response "未授权"
 // @Router /notifications [get]
 func (ctrl *NotificationsController) Index(c *gin.Context) {
 	userID := auth.CurrentUID(c)
+	if paginator.UseCursor(c) {
+		list, paging, err := ctrl.service.ListByCursor(c, userID, 15)
+		if err != nil {
+			respondCursorListError(c, err, "获取通知列表失败")
+			return
+		}
+		response.JSON(c, gin.H{"data": ctrl.service.ToResponseList(list), "pager": paging})
+		return
+	}
 	list, paging, err := ctrl.service.List(c, userID, 15)
 	if err != nil {
 		logger.LogErrorWithContext(c, err, "获取通知列表失败")
@@ -0,0 +1,107 @@
+package v1
+
+import (
+	"net/http"
+
+	"GoHub-Service/app/requests"
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/auth"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReactionsController 表情回应接口
+type ReactionsController struct {
+	service *services.ReactionService
+}
+
+// NewReactionsController 创建控制器
+func NewReactionsController() *ReactionsController {
+	return &ReactionsController{service: services.NewReactionService()}
+}
+
+// Types 可用的表情列表
+func (ctrl *ReactionsController) Types(c *gin.Context) {
+	types, err := ctrl.service.Types()
+	if err != nil {
+		handleReactionError(c, err, "获取表情列表失败")
+		return
+	}
+	response.Data(c, types)
+}
+
+// ReactTopic 回应话题
+func (ctrl *ReactionsController) ReactTopic(c *gin.Context) {
+	ctrl.react(c, services.ReactionTargetTopic)
+}
+
+// UnreactTopic 取消回应话题
+func (ctrl *ReactionsController) UnreactTopic(c *gin.Context) {
+	ctrl.unreact(c, services.ReactionTargetTopic)
+}
+
+// ReactComment 回应评论
+func (ctrl *ReactionsController) ReactComment(c *gin.Context) {
+	ctrl.react(c, services.ReactionTargetComment)
+}
+
+// UnreactComment 取消回应评论
+func (ctrl *ReactionsController) UnreactComment(c *gin.Context) {
+	ctrl.unreact(c, services.ReactionTargetComment)
+}
+
+// ReactMessage 回应私信
+func (ctrl *ReactionsController) ReactMessage(c *gin.Context) {
+	ctrl.react(c, services.ReactionTargetMessage)
+}
+
+// UnreactMessage 取消回应私信
+func (ctrl *ReactionsController) UnreactMessage(c *gin.Context) {
+	ctrl.unreact(c, services.ReactionTargetMessage)
+}
+
+// react 回应表情并返回目标最新的回应汇总
+func (ctrl *ReactionsController) react(c *gin.Context, targetType string) {
+	request := requests.ReactionRequest{}
+	if ok := requests.Validate(c, &request, requests.Reaction); !ok {
+		return
+	}
+	summary, err := ctrl.service.React(targetType, c.Param("id"), auth.CurrentUID(c), request.Emoji)
+	if err != nil {
+		handleReactionError(c, err, "回应表情失败")
+		return
+	}
+	response.Data(c, summary)
+}
+
+// unreact 取消回应并返回目标最新的回应汇总
+func (ctrl *ReactionsController) unreact(c *gin.Context, targetType string) {
+	request := requests.ReactionRequest{}
+	if ok := requests.Validate(c, &request, requests.Reaction); !ok {
+		return
+	}
+	summary, err := ctrl.service.Unreact(targetType, c.Param("id"), auth.CurrentUID(c), request.Emoji)
+	if err != nil {
+		handleReactionError(c, err, "取消表情回应失败")
+		return
+	}
+	response.Data(c, summary)
+}
+
+// handleReactionError 将表情回应业务错误转换为响应
+func handleReactionError(c *gin.Context, err *apperrors.AppError, message string) {
+	switch {
+	case err.Type == apperrors.ErrorTypeValidation:
+		response.ValidationError(c, map[string][]string{"emoji": {err.Message}})
+	case err.Type == apperrors.ErrorTypeNotFound:
+		response.Abort404(c)
+	case err.Code == apperrors.CodeConflict:
+		response.ApiError(c, http.StatusConflict, err.Code, err.Message)
+	default:
+		logger.LogErrorWithContext(c, err, message)
+		response.ApiError(c, 500, err.Code, err.Message)
+	}
+}
@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"net/http"
+
+	"GoHub-Service/app/requests"
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/auth"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportsController 用户举报接口
+type ReportsController struct {
+	service *services.ReportService
+}
+
+// NewReportsController 创建控制器
+func NewReportsController() *ReportsController {
+	return &ReportsController{service: services.NewReportService()}
+}
+
+// Store 举报话题、评论、用户或私信，同一内容只能举报一次
+func (ctrl *ReportsController) Store(c *gin.Context) {
+	request := requests.ReportRequest{}
+	if ok := requests.Validate(c, &request, requests.Report); !ok {
+		return
+	}
+	rp, err := ctrl.service.Create(services.ReportCreateDTO{
+		ReporterID: auth.CurrentUID(c),
+		TargetType: request.TargetType,
+		TargetID:   request.TargetID,
+		Reason:     request.Reason,
+		Detail:     request.Detail,
+	})
+	if err != nil {
+		switch {
+		case err.Type == apperrors.ErrorTypeValidation:
+			response.ValidationError(c, map[string][]string{"target_id": {err.Message}})
+		case err.Type == apperrors.ErrorTypeNotFound:
+			response.Abort404(c)
+		case err.Code == apperrors.CodeConflict:
+			response.ApiError(c, http.StatusConflict, err.Code, err.Message)
+		default:
+			logger.LogErrorWithContext(c, err, "提交举报失败")
+			response.ApiError(c, 500, err.Code, err.Message)
+		}
+		return
+	}
+	response.Created(c, rp)
+}
+
+// Index 我提交的举报及处理结果
+func (ctrl *ReportsController) Index(c *gin.Context) {
+	request := requests.PaginationRequest{}
+	if ok := requests.Validate(c, &request, requests.Pagination); !ok {
+		return
+	}
+	reports, paging, err := ctrl.service.Mine(c, auth.CurrentUID(c), 20)
+	if err != nil {
+		logger.LogErrorWithContext(c, err, "获取举报列表失败")
+		response.Abort500(c, "获取举报列表失败")
+		return
+	}
+	response.JSON(c, gin.H{
+		"data":   reports,
+		"paging": paging,
+	})
+}
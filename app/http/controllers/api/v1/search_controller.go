@@ -3,6 +3,7 @@ package v1
 import (
 	"GoHub-Service/app/requests"
 	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/auth"
 	"GoHub-Service/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -25,7 +26,7 @@ func (sc *SearchController) Topics(c *gin.Context) {
 		return
 	}
 
-	result, err := sc.SearchService.SearchTopics(c, request.Keyword)
+	result, err := sc.SearchService.SearchTopics(c, request.Keyword, auth.CurrentUID(c))
 	if err != nil {
 		response.Abort500(c)
 		return
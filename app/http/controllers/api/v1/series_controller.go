@@ -0,0 +1,164 @@
+package v1
+
+import (
+	"net/http"
+
+	"GoHub-Service/app/requests"
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/auth"
+	"GoHub-Service/pkg/config"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SeriesController 话题系列接口
+type SeriesController struct {
+	service *services.SeriesService
+}
+
+// NewSeriesController 创建控制器
+func NewSeriesController() *SeriesController {
+	return &SeriesController{service: services.NewSeriesService()}
+}
+
+// UserSeries 作者的系列列表
+func (ctrl *SeriesController) UserSeries(c *gin.Context) {
+	data, paging, err := ctrl.service.ListByUser(c, c.Param("id"), config.GetInt("paging.perpage"))
+	if err != nil {
+		handleSeriesError(c, err, "获取系列列表失败")
+		return
+	}
+	response.JSON(c, gin.H{
+		"data":   data,
+		"paging": paging,
+	})
+}
+
+// Store 创建系列
+func (ctrl *SeriesController) Store(c *gin.Context) {
+	request := requests.SeriesRequest{}
+	if ok := requests.Validate(c, &request, requests.SeriesSave); !ok {
+		return
+	}
+
+	model, err := ctrl.service.Create(auth.CurrentUID(c), services.SeriesSaveDTO{
+		Title:       request.Title,
+		Description: request.Description,
+	})
+	if err != nil {
+		handleSeriesError(c, err, "创建系列失败")
+		return
+	}
+	response.Created(c, model)
+}
+
+// Show 系列页面，包含按顺序排列的话题
+func (ctrl *SeriesController) Show(c *gin.Context) {
+	detail, err := ctrl.service.Show(c.Param("id"), auth.CurrentUID(c))
+	if err != nil {
+		handleSeriesError(c, err, "获取系列失败")
+		return
+	}
+	response.Data(c, detail)
+}
+
+// Update 修改系列
+func (ctrl *SeriesController) Update(c *gin.Context) {
+	request := requests.SeriesRequest{}
+	if ok := requests.Validate(c, &request, requests.SeriesSave); !ok {
+		return
+	}
+
+	model, err := ctrl.service.Update(c.Param("id"), auth.CurrentUID(c), services.SeriesSaveDTO{
+		Title:       request.Title,
+		Description: request.Description,
+	})
+	if err != nil {
+		handleSeriesError(c, err, "修改系列失败")
+		return
+	}
+	response.Data(c, model)
+}
+
+// Delete 删除系列
+func (ctrl *SeriesController) Delete(c *gin.Context) {
+	if err := ctrl.service.Delete(c.Param("id"), auth.CurrentUID(c)); err != nil {
+		handleSeriesError(c, err, "删除系列失败")
+		return
+	}
+	response.Success(c)
+}
+
+// AddTopic 将话题加入系列
+func (ctrl *SeriesController) AddTopic(c *gin.Context) {
+	request := requests.SeriesTopicRequest{}
+	if ok := requests.Validate(c, &request, requests.SeriesTopic); !ok {
+		return
+	}
+
+	if err := ctrl.service.AddTopic(c.Param("id"), auth.CurrentUID(c), request.TopicID); err != nil {
+		handleSeriesError(c, err, "加入系列失败")
+		return
+	}
+	response.Success(c)
+}
+
+// RemoveTopic 将话题移出系列
+func (ctrl *SeriesController) RemoveTopic(c *gin.Context) {
+	if err := ctrl.service.RemoveTopic(c.Param("id"), auth.CurrentUID(c), c.Param("topic_id")); err != nil {
+		handleSeriesError(c, err, "移出系列失败")
+		return
+	}
+	response.Success(c)
+}
+
+// Reorder 调整系列中话题的顺序
+func (ctrl *SeriesController) Reorder(c *gin.Context) {
+	request := requests.SeriesReorderRequest{}
+	if ok := requests.Validate(c, &request, requests.SeriesReorder); !ok {
+		return
+	}
+
+	if err := ctrl.service.Reorder(c.Param("id"), auth.CurrentUID(c), request.TopicIDs); err != nil {
+		handleSeriesError(c, err, "调整系列顺序失败")
+		return
+	}
+	response.Success(c)
+}
+
+// Follow 关注系列，有新的话题时收到通知
+func (ctrl *SeriesController) Follow(c *gin.Context) {
+	if err := ctrl.service.Follow(c.Param("id"), auth.CurrentUID(c)); err != nil {
+		handleSeriesError(c, err, "关注系列失败")
+		return
+	}
+	response.Success(c)
+}
+
+// Unfollow 取消关注系列
+func (ctrl *SeriesController) Unfollow(c *gin.Context) {
+	if err := ctrl.service.Unfollow(c.Param("id"), auth.CurrentUID(c)); err != nil {
+		handleSeriesError(c, err, "取消关注系列失败")
+		return
+	}
+	response.Success(c)
+}
+
+func handleSeriesError(c *gin.Context, err *apperrors.AppError, message string) {
+	switch {
+	case err.Type == apperrors.ErrorTypeValidation:
+		response.ValidationError(c, map[string][]string{"error": {err.Message}})
+	case err.Type == apperrors.ErrorTypeNotFound:
+		response.Abort404(c)
+	case err.Type == apperrors.ErrorTypeAuthorization:
+		response.Abort403(c, err.Message)
+	case err.Code == apperrors.CodeConflict:
+		response.ApiError(c, http.StatusConflict, err.Code, err.Message)
+	default:
+		logger.LogErrorWithContext(c, err, message)
+		response.ApiError(c, 500, err.Code, err.Message)
+	}
+}
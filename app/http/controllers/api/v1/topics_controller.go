@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"net/http"
+
 	"GoHub-Service/app/requests"
 	"GoHub-Service/app/services"
 	"GoHub-Service/pkg/auth"
@@ -8,9 +10,11 @@ import (
 	apperrors "GoHub-Service/pkg/errors"
 	"GoHub-Service/pkg/file"
 	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/paginator"
 	"GoHub-Service/pkg/response"
 
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
 	"go.uber.org/zap"
 )
 
@@ -18,6 +22,8 @@ type TopicsController struct {
 	BaseAPIController
 	topicService       *services.TopicService
 	interactionService *services.InteractionService
+	seriesService      *services.SeriesService
+	reactionService    *services.ReactionService
 }
 
 // NewTopicsController 创建TopicsController实例
@@ -25,28 +31,66 @@ func NewTopicsController() *TopicsController {
 	return &TopicsController{
 		topicService:       services.NewTopicService(),
 		interactionService: services.NewInteractionService(),
+		seriesService:      services.NewSeriesService(),
+		reactionService:    services.NewReactionService(),
 	}
 }
 
 // Index 话题列表
 // @Summary 获取话题列表
-// @Description 分页获取话题列表，支持按分类筛选
+// @Description 分页获取话题列表，支持按分类、作者、标签、状态、问答解决状态、日期筛选，置顶话题默认排在最前
 // @Tags 话题管理
 // @Accept json
 // @Produce json
 // @Param page query int false "页码" default(1)
 // @Param per_page query int false "每页数量" default(10)
 // @Param category_id query string false "分类ID"
-// @Param order query string false "排序方式" default(created_at)
+// @Param user_id query string false "作者ID"
+// @Param tag query string false "标签名称"
+// @Param status query int false "审核状态: -1|0|1"
+// @Param solved query int false "问答解决状态: 1 已采纳回答 | 0 问答分类中未采纳回答"
+// @Param start_date query string false "开始日期 YYYY-MM-DD"
+// @Param end_date query string false "结束日期 YYYY-MM-DD"
+// @Param sort_by query string false "排序方式: latest_reply|created|likes|views" default(latest_reply)
+// @Param pinned_first query int false "置顶优先: 0|1" default(1)
+// @Param cursor query string false "游标分页，首页传空值，之后传 next_cursor 或 prev_cursor"
+// @Param with_total query int false "游标分页时是否统计总数: 0|1"
 // @Success 200 {object} response.Response "成功"
 // @Router /topics [get]
 func (ctrl *TopicsController) Index(c *gin.Context) {
-	request := requests.PaginationRequest{}
-	if ok := requests.Validate(c, &request, requests.Pagination); !ok {
+	request := requests.TopicListRequest{}
+	if ok := requests.Validate(c, &request, requests.TopicList); !ok {
 		return
 	}
 
-	listResponse, err := ctrl.topicService.List(c, 10)
+	query := services.TopicListQueryDTO{
+		CategoryID:  request.CategoryID,
+		Subtree:     request.Subtree == "1",
+		UserID:      request.UserID,
+		Tag:         request.Tag,
+		Status:      request.Status,
+		Solved:      request.Solved,
+		StartDate:   request.StartDate,
+		EndDate:     request.EndDate,
+		SortBy:      request.SortBy,
+		PinnedFirst: request.PinnedFirst != "0",
+		ViewerID:    auth.CurrentUID(c),
+	}
+
+	if paginator.UseCursor(c) {
+		cursorResponse, err := ctrl.topicService.ListByCursor(c, query, 10)
+		if err != nil {
+			respondCursorListError(c, err, "获取话题列表失败")
+			return
+		}
+		response.JSON(c, gin.H{
+			"data":  cursorResponse.Topics,
+			"pager": cursorResponse.Paging,
+		})
+		return
+	}
+
+	listResponse, err := ctrl.topicService.List(c, query, 10)
 	if err != nil {
 		logger.LogErrorWithContext(c, err, "获取话题列表失败")
 		response.ApiError(c, 500, err.Code, err.Message)
@@ -76,21 +120,55 @@ func (ctrl *TopicsController) Index(c *gin.Context) {
 // @Produce json
 // @Param id path string true "话题ID"
 // @Success 200 {object} topic.Topic "成功"
+// @Success 301 "话题已被合并，跳转到目标话题"
 // @Failure 404 {object} map[string]interface{} "话题不存在"
 func (ctrl *TopicsController) Show(c *gin.Context) {
-	topicModel, err := ctrl.topicService.GetByID(c.Param("id"))
+	topicModel, err := ctrl.topicService.GetVisible(c.Param("id"), auth.CurrentUID(c))
 	if err != nil {
 		logger.LogErrorWithContext(c, err, "获取话题失败")
 		if err.Code == 1004 {
+			// 被合并的话题跳转到目标话题
+			if targetID := ctrl.topicService.MergedInto(c.Param("id")); targetID != "" {
+				c.Redirect(http.StatusMovedPermanently, "/api/v1/topics/"+targetID)
+				return
+			}
 			response.Abort404(c)
 		} else {
 			response.ApiError(c, 500, err.Code, err.Message)
 		}
 		return
 	}
+	topicModel.Series = ctrl.seriesService.Navigation(topicModel.ID, auth.CurrentUID(c))
+	topicModel.Mentions = ctrl.topicService.Mentions(topicModel.Body)
+	topicModel.Reactions = ctrl.reactionService.Summary(services.ReactionTargetTopic, topicModel.ID, auth.CurrentUID(c))
 	response.Data(c, topicModel)
 }
 
+// Related 相关话题
+// @Summary 获取相关话题
+// @Description 启用 Elasticsearch 时按内容相似度查询，否则按共同标签、共同点赞用户和同一分类计算
+// @Tags 话题管理
+// @Produce json
+// @Param id path string true "话题ID"
+// @Param limit query int false "返回数量，最多20" default(5)
+// @Success 200 {object} response.Response "成功"
+// @Failure 404 {object} response.Response "话题不存在"
+// @Router /topics/{id}/related [get]
+func (ctrl *TopicsController) Related(c *gin.Context) {
+	limit := cast.ToInt(c.DefaultQuery("limit", "5"))
+	topics, err := ctrl.topicService.Related(c.Param("id"), auth.CurrentUID(c), limit)
+	if err != nil {
+		logger.LogErrorWithContext(c, err, "获取相关话题失败")
+		if err.Type == apperrors.ErrorTypeNotFound {
+			response.Abort404(c)
+		} else {
+			response.ApiError(c, 500, err.Code, err.Message)
+		}
+		return
+	}
+	response.Data(c, gin.H{"topics": topics})
+}
+
 // Store 创建话题
 // @Summary 创建新话题
 // @Description 创建一个新的话题，需要登录
@@ -114,10 +192,14 @@ func (ctrl *TopicsController) Store(c *gin.Context) {
 		Body:       request.Body,
 		CategoryID: request.CategoryID,
 		UserID:     auth.CurrentUID(c),
+		Tags:       request.Tags,
 	}
 
 	topicModel, err := ctrl.topicService.Create(dto)
 	if err != nil {
+		if respondSpamError(c, err) || respondPostDenied(c, err) {
+			return
+		}
 		logger.LogErrorWithContext(c, err, "创建话题失败",
 			zap.String("title", request.Title),
 			zap.String("user_id", auth.CurrentUID(c)),
@@ -185,9 +267,15 @@ func (ctrl *TopicsController) Update(c *gin.Context) {
 		Body:       &request.Body,
 		CategoryID: &request.CategoryID,
 	}
+	if request.Tags != nil {
+		dto.Tags = &request.Tags
+	}
 
 	topicModel, err := ctrl.topicService.Update(topicID, dto)
 	if err != nil {
+		if respondSpamError(c, err) || respondPostDenied(c, err) {
+			return
+		}
 		logger.LogErrorWithContext(c, err, "更新话题失败",
 			zap.String("topic_id", topicID),
 		)
@@ -233,7 +321,7 @@ func (ctrl *TopicsController) Delete(c *gin.Context) {
 	}
 
 	// 删除话题
-	err = ctrl.topicService.Delete(topicID)
+	err = ctrl.topicService.Delete(topicID, currentUserID)
 	if err != nil {
 		logger.LogErrorWithContext(c, err, "删除话题失败",
 			zap.String("topic_id", topicID),
@@ -248,6 +336,141 @@ func (ctrl *TopicsController) Delete(c *gin.Context) {
 	response.Success(c)
 }
 
+// Lock 作者锁定自己的话题
+// @Summary 锁定话题
+// @Description 作者锁定自己的话题，锁定后不能再发表评论
+// @Tags 话题管理
+// @Produce json
+// @Security Bearer
+// @Param id path string true "话题ID"
+// @Success 200 {object} response.Response "成功"
+// @Failure 403 {object} response.Response "无权限"
+// @Router /topics/{id}/lock [post]
+func (ctrl *TopicsController) Lock(c *gin.Context) {
+	topicID := c.Param("id")
+	if !ctrl.authorizeOwner(c, topicID) {
+		return
+	}
+
+	topicModel, err := ctrl.topicService.Lock(topicID, auth.CurrentUID(c))
+	if err != nil {
+		respondTopicStateError(c, err, "锁定话题失败")
+		return
+	}
+	response.Data(c, topicModel)
+}
+
+// Unlock 作者解除锁定，管理员设置的锁定不能由作者解除
+// @Summary 解除锁定
+// @Tags 话题管理
+// @Produce json
+// @Security Bearer
+// @Param id path string true "话题ID"
+// @Success 200 {object} response.Response "成功"
+// @Failure 403 {object} response.Response "无权限"
+// @Router /topics/{id}/unlock [post]
+func (ctrl *TopicsController) Unlock(c *gin.Context) {
+	topicID := c.Param("id")
+	if !ctrl.authorizeOwner(c, topicID) {
+		return
+	}
+
+	topicModel, err := ctrl.topicService.Unlock(topicID, auth.CurrentUID(c), false)
+	if err != nil {
+		respondTopicStateError(c, err, "解除锁定失败")
+		return
+	}
+	response.Data(c, topicModel)
+}
+
+// Close 作者关闭自己的话题
+// @Summary 关闭话题
+// @Description 作者关闭自己的话题，需填写关闭原因
+// @Tags 话题管理
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "话题ID"
+// @Param body body requests.TopicCloseRequest true "关闭原因"
+// @Success 200 {object} response.Response "成功"
+// @Failure 403 {object} response.Response "无权限"
+// @Router /topics/{id}/close [post]
+func (ctrl *TopicsController) Close(c *gin.Context) {
+	request := requests.TopicCloseRequest{}
+	if ok := requests.Validate(c, &request, requests.TopicClose); !ok {
+		return
+	}
+
+	topicID := c.Param("id")
+	if !ctrl.authorizeOwner(c, topicID) {
+		return
+	}
+
+	topicModel, err := ctrl.topicService.Close(topicID, auth.CurrentUID(c), request.Reason)
+	if err != nil {
+		respondTopicStateError(c, err, "关闭话题失败")
+		return
+	}
+	response.Data(c, topicModel)
+}
+
+// Reopen 作者重新开放话题，管理员关闭的话题不能由作者重新开放
+// @Summary 重新开放话题
+// @Tags 话题管理
+// @Produce json
+// @Security Bearer
+// @Param id path string true "话题ID"
+// @Success 200 {object} response.Response "成功"
+// @Failure 403 {object} response.Response "无权限"
+// @Router /topics/{id}/reopen [post]
+func (ctrl *TopicsController) Reopen(c *gin.Context) {
+	topicID := c.Param("id")
+	if !ctrl.authorizeOwner(c, topicID) {
+		return
+	}
+
+	topicModel, err := ctrl.topicService.Reopen(topicID, auth.CurrentUID(c), false)
+	if err != nil {
+		respondTopicStateError(c, err, "重新开放话题失败")
+		return
+	}
+	response.Data(c, topicModel)
+}
+
+// authorizeOwner 校验当前用户是话题作者，失败时直接响应
+func (ctrl *TopicsController) authorizeOwner(c *gin.Context, topicID string) bool {
+	isOwner, err := ctrl.topicService.CheckOwnership(topicID, auth.CurrentUID(c))
+	if err != nil {
+		if err.Type == apperrors.ErrorTypeNotFound {
+			response.Abort404(c)
+			return false
+		}
+		logger.LogErrorWithContext(c, err, "检查话题所有权失败")
+		response.Abort500(c)
+		return false
+	}
+	if !isOwner {
+		response.Abort403(c, "无权限操作")
+		return false
+	}
+	return true
+}
+
+// respondTopicStateError 话题状态操作的错误响应
+func respondTopicStateError(c *gin.Context, err *apperrors.AppError, message string) {
+	switch err.Type {
+	case apperrors.ErrorTypeNotFound:
+		response.Abort404(c)
+	case apperrors.ErrorTypeAuthorization:
+		response.Abort403(c, err.Message)
+	case apperrors.ErrorTypeBusiness:
+		response.ApiError(c, 422, err.Code, err.Message)
+	default:
+		logger.LogErrorWithContext(c, err, message)
+		response.ApiError(c, 500, err.Code, err.Message)
+	}
+}
+
 // Like 点赞话题
 func (ctrl *TopicsController) Like(c *gin.Context) {
 	topicID := c.Param("id")
@@ -300,17 +523,52 @@ func (ctrl *TopicsController) Unfavorite(c *gin.Context) {
 	response.Success(c)
 }
 
-// AddView 增加浏览量
+// AddView 增加浏览量，同一用户或 IP 在去重窗口内只计一次
 func (ctrl *TopicsController) AddView(c *gin.Context) {
 	topicID := c.Param("id")
-	if err := ctrl.interactionService.AddTopicView(topicID); err != nil {
+	viewer := "ip:" + c.ClientIP()
+	if uid := auth.CurrentUID(c); uid != "" {
+		viewer = "u:" + uid
+	}
+	if err := ctrl.interactionService.AddTopicView(topicID, viewer); err != nil {
 		logger.LogErrorWithContext(c, err, "增加浏览量失败")
-		response.ApiError(c, 500, err.Code, err.Message)
+		if err.Type == apperrors.ErrorTypeNotFound {
+			response.Abort404(c)
+		} else {
+			response.ApiError(c, 500, err.Code, err.Message)
+		}
 		return
 	}
 	response.Success(c)
 }
 
+// ViewHistory 话题每日浏览量，仅作者本人可查看
+// @Summary 话题浏览量历史
+// @Description 返回最近 days 天的每日浏览量，没有浏览的日期为 0
+// @Tags 话题管理
+// @Produce json
+// @Security Bearer
+// @Param id path string true "话题ID"
+// @Param days query int false "天数" default(30)
+// @Success 200 {object} response.Response "成功"
+// @Failure 403 {object} response.Response "无权限"
+// @Router /topics/{id}/views/history [get]
+func (ctrl *TopicsController) ViewHistory(c *gin.Context) {
+	topicID := c.Param("id")
+	if !ctrl.authorizeOwner(c, topicID) {
+		return
+	}
+
+	days := cast.ToInt(c.DefaultQuery("days", "30"))
+	history, err := ctrl.interactionService.TopicViewHistory(topicID, days)
+	if err != nil {
+		logger.LogErrorWithContext(c, err, "获取浏览量历史失败")
+		response.Abort500(c)
+		return
+	}
+	response.Data(c, gin.H{"history": history})
+}
+
 // UploadImage 上传话题配图
 func (ctrl *TopicsController) UploadImage(c *gin.Context) {
 	request := requests.TopicImageUploadRequest{}
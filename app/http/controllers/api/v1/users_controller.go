@@ -5,17 +5,21 @@ import (
 	"GoHub-Service/app/services"
 	"GoHub-Service/pkg/auth"
 	"GoHub-Service/pkg/config"
+	apperrors "GoHub-Service/pkg/errors"
 	"GoHub-Service/pkg/file"
 	"GoHub-Service/pkg/logger"
 	"GoHub-Service/pkg/response"
 
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
 )
 
 type UsersController struct {
 	BaseAPIController
 	userService        *services.UserService
 	interactionService *services.InteractionService
+	mentionService     *services.MentionService
+	followService      *services.FollowService
 }
 
 // NewUsersController 创建UsersController实例
@@ -23,6 +27,8 @@ func NewUsersController() *UsersController {
 	return &UsersController{
 		userService:        services.NewUserService(),
 		interactionService: services.NewInteractionService(),
+		mentionService:     services.NewMentionService(),
+		followService:      services.NewFollowService(),
 	}
 }
 
@@ -205,3 +211,103 @@ func (ctrl *UsersController) Unfollow(c *gin.Context) {
 
 	response.Success(c)
 }
+
+// Followers 用户的粉丝列表
+// @Summary 获取用户粉丝列表
+// @Description 按关注时间倒序分页，登录后返回当前用户是否关注了列表中的用户以及是否互相关注
+// @Tags 用户管理
+// @Produce json
+// @Param id path int true "用户ID"
+// @Param page query int false "页码" default(1)
+// @Param per_page query int false "每页数量" default(20)
+// @Success 200 {object} response.Response "成功"
+// @Router /users/{id}/followers [get]
+func (ctrl *UsersController) Followers(c *gin.Context) {
+	users, paging, err := ctrl.followService.Followers(c, c.Param("id"), auth.CurrentUID(c), 20)
+	if err != nil {
+		handleUserInteractionError(c, err, "获取粉丝列表失败")
+		return
+	}
+	response.JSON(c, gin.H{
+		"data":  users,
+		"pager": paging,
+	})
+}
+
+// Following 用户关注的人
+// @Summary 获取用户关注列表
+// @Description 按关注时间倒序分页，登录后返回当前用户是否关注了列表中的用户以及是否互相关注
+// @Tags 用户管理
+// @Produce json
+// @Param id path int true "用户ID"
+// @Param page query int false "页码" default(1)
+// @Param per_page query int false "每页数量" default(20)
+// @Success 200 {object} response.Response "成功"
+// @Router /users/{id}/following [get]
+func (ctrl *UsersController) Following(c *gin.Context) {
+	users, paging, err := ctrl.followService.Following(c, c.Param("id"), auth.CurrentUID(c), 20)
+	if err != nil {
+		handleUserInteractionError(c, err, "获取关注列表失败")
+		return
+	}
+	response.JSON(c, gin.H{
+		"data":  users,
+		"pager": paging,
+	})
+}
+
+// FollowRelation 当前用户与目标用户的关注关系，包括是否互相关注
+// @Summary 获取关注关系
+// @Tags 用户管理
+// @Produce json
+// @Param id path int true "用户ID"
+// @Success 200 {object} response.Response "成功"
+// @Router /users/{id}/relation [get]
+func (ctrl *UsersController) FollowRelation(c *gin.Context) {
+	relation, err := ctrl.followService.Relation(c.Param("id"), auth.CurrentUID(c))
+	if err != nil {
+		handleUserInteractionError(c, err, "获取关注关系失败")
+		return
+	}
+	response.Data(c, relation)
+}
+
+// Block 屏蔽用户
+func (ctrl *UsersController) Block(c *gin.Context) {
+	if err := ctrl.interactionService.BlockUser(auth.CurrentUID(c), c.Param("id")); err != nil {
+		handleUserInteractionError(c, err, "屏蔽用户失败")
+		return
+	}
+	response.Success(c)
+}
+
+// Unblock 取消屏蔽用户
+func (ctrl *UsersController) Unblock(c *gin.Context) {
+	if err := ctrl.interactionService.UnblockUser(auth.CurrentUID(c), c.Param("id")); err != nil {
+		handleUserInteractionError(c, err, "取消屏蔽用户失败")
+		return
+	}
+	response.Success(c)
+}
+
+// Autocomplete 输入 @ 时按用户名前缀补全，q 为前缀，limit 最多 20
+func (ctrl *UsersController) Autocomplete(c *gin.Context) {
+	users, err := ctrl.mentionService.Autocomplete(c.Query("q"), cast.ToInt(c.DefaultQuery("limit", "10")))
+	if err != nil {
+		handleUserInteractionError(c, err, "查找用户失败")
+		return
+	}
+	response.Data(c, users)
+}
+
+func handleUserInteractionError(c *gin.Context, err *apperrors.AppError, message string) {
+	switch err.Type {
+	case apperrors.ErrorTypeValidation:
+		response.ValidationError(c, map[string][]string{"error": {err.Message}})
+	case apperrors.ErrorTypeNotFound:
+		response.Abort404(c)
+	default:
+		logger.LogErrorWithContext(c, err, message)
+		response.ApiError(c, 500, err.Code, err.Message)
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/auth"
 	"GoHub-Service/pkg/elasticsearch"
 	"GoHub-Service/pkg/response"
 )
@@ -62,6 +64,8 @@ func (sc *SearchController) SearchTopics(c *gin.Context) {
 		Page:       pageInt,
 		PageSize:   pageSizeInt,
 		SortBy:     sortBy,
+
+		ExcludeCategoryIDs: services.HiddenCategoryIDs(auth.CurrentUID(c)),
 	}
 
 	// 执行搜索
@@ -110,7 +114,7 @@ func (sc *SearchController) SearchSuggestions(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
 	defer cancel()
 
-	suggestions, err := sc.searchService.SuggestTopics(ctx, query, limit)
+	suggestions, err := sc.searchService.SuggestTopics(ctx, query, limit, services.HiddenCategoryIDs(auth.CurrentUID(c))...)
 	if err != nil {
 		response.ApiError(c, 500, response.CodeServerError, "获取建议失败")
 		return
@@ -141,7 +145,7 @@ func (sc *SearchController) GetHotTopics(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
 	defer cancel()
 
-	topics, err := sc.searchService.GetHotTopics(ctx, limit)
+	topics, err := sc.searchService.GetHotTopics(ctx, limit, services.HiddenCategoryIDs(auth.CurrentUID(c))...)
 	if err != nil {
 		response.ApiError(c, 500, response.CodeServerError, "获取热门话题失败")
 		return
@@ -38,3 +38,25 @@ func AuthJWT() gin.HandlerFunc {
         c.Next()
     }
 }
+
+// AuthJWTOptional 可选登录：携带有效 token 时设置当前用户，否则按游客继续处理
+// 用于公开接口中需要区分作者本人的场景，如查看待审核的话题
+func AuthJWTOptional() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        if c.GetHeader("Authorization") == "" {
+            c.Next()
+            return
+        }
+
+        claims, err := jwt.NewJWT().ParserToken(c)
+        if err == nil {
+            if userModel := user.Get(claims.UserID); userModel.ID != 0 {
+                c.Set("current_user_id", userModel.GetStringID())
+                c.Set("current_user_name", userModel.Name)
+                c.Set("current_user", userModel)
+            }
+        }
+
+        c.Next()
+    }
+}
@@ -0,0 +1,54 @@
+package middlewares
+
+import (
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/report"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/pkg/auth"
+	"GoHub-Service/pkg/database"
+	"GoHub-Service/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireTopicCategoryModerator 中间件：要求当前用户可以管理路由 :id 对应话题所在的分类
+// 与 RequireRole("moderator") 配合使用，被指派了分类的版主只能操作这些分类及其子分类下的话题
+// 话题不存在时交给控制器返回 404
+func RequireTopicCategoryModerator() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := auth.CurrentUID(c)
+		if userID == "" {
+			response.Abort403(c, "未登录")
+			return
+		}
+
+		var categoryIDs []string
+		database.DB.Model(&topic.Topic{}).Where("id = ?", c.Param("id")).Pluck("category_id", &categoryIDs)
+		if len(categoryIDs) > 0 && !category.UserCanModerate(userID, categoryIDs[0]) {
+			response.Abort403(c, "无权管理该分类下的话题")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireReportCategoryModerator 中间件：要求当前用户可以处理路由 :id 对应的举报
+// 被指派了分类的版主只能处理其管理的分类下的话题和评论举报，举报不存在时交给控制器返回 404
+func RequireReportCategoryModerator() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := auth.CurrentUID(c)
+		if userID == "" {
+			response.Abort403(c, "未登录")
+			return
+		}
+
+		var rp report.Report
+		if database.DB.Where("id = ?", c.Param("id")).Limit(1).Find(&rp).RowsAffected > 0 && !report.UserCanHandle(userID, &rp) {
+			response.Abort403(c, "无权处理该分类下的举报")
+			return
+		}
+
+		c.Next()
+	}
+}
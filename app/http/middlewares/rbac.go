@@ -75,12 +75,7 @@ func RequireAdmin() gin.HandlerFunc {
 
 // hasRole 检查用户是否拥有指定角色
 func hasRole(userID, roleName string) bool {
-	var count int64
-	database.DB.Table("user_roles").
-		Joins("JOIN roles ON roles.id = user_roles.role_id").
-		Where("user_roles.user_id = ? AND roles.name = ?", userID, roleName).
-		Count(&count)
-	return count > 0
+	return role.UserHasAnyRole(userID, roleName)
 }
 
 // hasPermission 检查用户是否拥有指定权限（通过角色）
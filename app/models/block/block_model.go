@@ -0,0 +1,21 @@
+// Package block 用户屏蔽关系
+package block
+
+import (
+	"GoHub-Service/app/models"
+)
+
+// Block 用户屏蔽了另一个用户，被屏蔽者的提及等互动不再通知屏蔽者
+type Block struct {
+	models.BaseModel
+
+	UserID    uint64 `gorm:"uniqueIndex:uidx_user_block;not null;comment:屏蔽者ID" json:"user_id"`
+	BlockedID uint64 `gorm:"uniqueIndex:uidx_user_block;index;not null;comment:被屏蔽者ID" json:"blocked_id"`
+
+	models.CommonTimestampsField
+}
+
+// TableName 指定表名
+func (Block) TableName() string {
+	return "user_blocks"
+}
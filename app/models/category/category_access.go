@@ -0,0 +1,70 @@
+package category
+
+import "strings"
+
+// 可见范围
+const (
+	VisibilityPublic  = "public"  // 所有人可见
+	VisibilityMembers = "members" // 登录用户可见
+	VisibilityRoles   = "roles"   // 拥有指定角色的用户可见
+)
+
+// 发布权限，对发布话题和评论同样生效
+const (
+	PostAnyone   = "anyone"   // 所有登录用户
+	PostApproved = "approved" // 已有通过审核话题的用户
+	PostRoles    = "roles"    // 拥有指定角色的用户
+)
+
+// SplitRoles 解析逗号分隔的角色名
+func SplitRoles(roles string) []string {
+	var names []string
+	for _, name := range strings.Split(roles, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// JoinRoles 规范化后以逗号拼接角色名
+func JoinRoles(names []string) string {
+	var cleaned []string
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" && !seen[name] {
+			seen[name] = true
+			cleaned = append(cleaned, name)
+		}
+	}
+	return strings.Join(cleaned, ",")
+}
+
+// VisibleTo 只判断本分类的可见范围，不考虑上级分类
+// loggedIn 表示是否登录，roles 为用户的角色名
+func (category Category) VisibleTo(loggedIn bool, roles []string) bool {
+	switch category.Visibility {
+	case VisibilityMembers:
+		return loggedIn
+	case VisibilityRoles:
+		return loggedIn && hasAnyRole(roles, SplitRoles(category.VisibleRoles))
+	default:
+		return true
+	}
+}
+
+// PostableByRoles 发布权限为 roles 时用户是否拥有任意一个允许的角色
+func (category Category) PostableByRoles(roles []string) bool {
+	return hasAnyRole(roles, SplitRoles(category.PostRoles))
+}
+
+func hasAnyRole(roles, allowed []string) bool {
+	for _, r := range roles {
+		for _, a := range allowed {
+			if r == a {
+				return true
+			}
+		}
+	}
+	return false
+}
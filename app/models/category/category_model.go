@@ -12,6 +12,17 @@ type Category struct {
     Name        string `gorm:"index" json:"name,omitempty"`
     Description string `json:"description,omitempty"`
     SortOrder   int    `gorm:"type:int;default:0;index;comment:排序顺序" json:"sort_order,omitempty"`
+    TopicsCount int64  `gorm:"default:0;comment:已发布话题数" json:"topics_count"`
+    IsQA        bool   `gorm:"type:boolean;default:false;comment:是否问答分类" json:"is_qa"`
+    ParentID    uint64 `gorm:"index;default:0;comment:上级分类ID，0 表示顶级分类" json:"parent_id"`
+    Path        string `gorm:"type:varchar(255);index;comment:从顶级分类到本分类的ID路径，如 /1/4/9/" json:"path"`
+    Depth       int    `gorm:"default:0;comment:层级，顶级分类为 0" json:"depth"`
+
+    // 访问控制，上级分类不可见时子分类同样不可见
+    Visibility   string `gorm:"type:varchar(16);default:public;comment:可见范围 public/members/roles" json:"visibility"`
+    VisibleRoles string `gorm:"type:varchar(255);comment:可见的角色，逗号分隔" json:"visible_roles,omitempty"`
+    PostPolicy   string `gorm:"type:varchar(16);default:anyone;comment:发布权限 anyone/approved/roles" json:"post_policy"`
+    PostRoles    string `gorm:"type:varchar(255);comment:可发布的角色，逗号分隔" json:"post_roles,omitempty"`
 
     models.CommonTimestampsField
 }
@@ -0,0 +1,115 @@
+package category
+
+import (
+	"strings"
+
+	"GoHub-Service/app/models"
+	"GoHub-Service/app/models/role"
+	"GoHub-Service/pkg/database"
+
+	"github.com/spf13/cast"
+)
+
+// Moderator 分类版主，负责该分类及其全部子分类
+type Moderator struct {
+	models.BaseModel
+
+	CategoryID uint64 `gorm:"uniqueIndex:uidx_category_moderator;not null;comment:分类ID" json:"category_id"`
+	UserID     uint64 `gorm:"uniqueIndex:uidx_category_moderator;index;not null;comment:版主用户ID" json:"user_id"`
+	AssignedBy uint64 `gorm:"default:0;comment:指派人" json:"assigned_by"`
+
+	models.CommonTimestampsField
+}
+
+// TableName 指定表名
+func (Moderator) TableName() string {
+	return "category_moderators"
+}
+
+// PathOf 分类的 ID 路径，parentPath 为空表示顶级分类
+func PathOf(parentPath string, id uint64) string {
+	if parentPath == "" {
+		parentPath = "/"
+	}
+	return parentPath + cast.ToString(id) + "/"
+}
+
+// PathIDs 解析 ID 路径，从顶级分类到本分类
+func PathIDs(path string) []uint64 {
+	var ids []uint64
+	for _, part := range strings.Split(strings.Trim(path, "/"), "/") {
+		if id := cast.ToUint64(part); id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// AncestorIDs 从顶级分类到本分类的 ID 列表，包含本分类
+func (category Category) AncestorIDs() []uint64 {
+	if ids := PathIDs(category.Path); len(ids) > 0 {
+		return ids
+	}
+	return []uint64{category.ID}
+}
+
+// ModeratedIDs 用户被指派管理的分类
+func ModeratedIDs(userID string) (ids []uint64) {
+	database.DB.Model(&Moderator{}).Where("user_id = ?", userID).Pluck("category_id", &ids)
+	return
+}
+
+// ModeratorScope 版主可以管理的分类，包含被指派分类的全部子分类
+// 管理员和没有被指派任何分类的版主不受限制，scoped 返回 false
+func ModeratorScope(userID string) (ids []uint64, scoped bool) {
+	if role.UserHasAnyRole(userID, "admin") {
+		return nil, false
+	}
+	assigned := ModeratedIDs(userID)
+	if len(assigned) == 0 {
+		return nil, false
+	}
+
+	query := database.DB.Model(&Category{})
+	for i, id := range assigned {
+		cond := "path LIKE ?"
+		if i == 0 {
+			query = query.Where(cond, "%/"+cast.ToString(id)+"/%")
+		} else {
+			query = query.Or(cond, "%/"+cast.ToString(id)+"/%")
+		}
+	}
+	query.Pluck("id", &ids)
+	return ids, true
+}
+
+// UserCanModerate 用户是否可以管理该分类下的内容
+// 管理员可以管理全部分类；版主被指派过分类时只能管理这些分类及其子分类，否则可以管理全部分类
+func UserCanModerate(userID, categoryID string) bool {
+	if userID == "" {
+		return false
+	}
+	if role.UserHasAnyRole(userID, "admin") {
+		return true
+	}
+	if !role.UserHasAnyRole(userID, "moderator") {
+		return false
+	}
+	assigned := ModeratedIDs(userID)
+	if len(assigned) == 0 {
+		return true
+	}
+
+	cat := Get(categoryID)
+	if cat.ID == 0 {
+		return false
+	}
+	for _, ancestor := range cat.AncestorIDs() {
+		for _, id := range assigned {
+			if ancestor == id {
+				return true
+			}
+		}
+	}
+	return false
+}
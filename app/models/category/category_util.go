@@ -39,3 +39,15 @@ func Paginate(c *gin.Context, perPage int) (categories []Category, paging pagina
     )
     return
 }
+
+// RecountTopics 按话题表重新统计分类下已发布的话题数
+// 移动、合并、拆分、删除话题后调用，保证计数与实际一致
+func RecountTopics(ids ...string) error {
+    if len(ids) == 0 {
+        return nil
+    }
+    return database.DB.Exec(`UPDATE categories SET topics_count = (
+        SELECT COUNT(*) FROM topics
+        WHERE topics.category_id = categories.id AND topics.status = 1 AND topics.deleted_at IS NULL
+    ) WHERE id IN ?`, ids).Error
+}
@@ -0,0 +1,97 @@
+// Package collection 收藏夹模型
+package collection
+
+import (
+	"time"
+
+	"GoHub-Service/app/models"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/pkg/database"
+
+	"github.com/spf13/cast"
+)
+
+// Collection 用户创建的收藏夹，公开的收藏夹可以被其他用户浏览和关注
+type Collection struct {
+	models.BaseModel
+
+	UserID         uint64 `gorm:"index;not null;comment:创建者ID" json:"user_id"`
+	Name           string `gorm:"type:varchar(100);not null;comment:名称" json:"name"`
+	Description    string `gorm:"type:varchar(500);comment:描述" json:"description,omitempty"`
+	IsPublic       bool   `gorm:"index;default:false;comment:是否公开" json:"is_public"`
+	ItemsCount     int64  `gorm:"default:0;comment:话题数" json:"items_count"`
+	FollowersCount int64  `gorm:"default:0;comment:关注数" json:"followers_count"`
+
+	models.CommonTimestampsField
+}
+
+// TableName 指定表名
+func (Collection) TableName() string {
+	return "collections"
+}
+
+// Create 创建收藏夹
+func (c *Collection) Create() {
+	database.DB.Create(&c)
+}
+
+// GetStringID 获取字符串格式的ID
+func (c *Collection) GetStringID() string {
+	return cast.ToString(c.ID)
+}
+
+// VisibleTo 公开的收藏夹所有人可见，私有的只有创建者可见
+func (c *Collection) VisibleTo(userID string) bool {
+	return c.IsPublic || (userID != "" && cast.ToString(c.UserID) == userID)
+}
+
+// Item 收藏夹中的话题，按 Position 升序排列
+type Item struct {
+	models.BaseModel
+
+	CollectionID uint64 `gorm:"uniqueIndex:uidx_collection_item;not null;comment:收藏夹ID" json:"collection_id"`
+	TopicID      uint64 `gorm:"uniqueIndex:uidx_collection_item;index;not null;comment:话题ID" json:"topic_id"`
+	Note         string `gorm:"type:varchar(500);comment:备注" json:"note,omitempty"`
+	Position     int    `gorm:"default:0;comment:排序位置" json:"position"`
+
+	Topic topic.Topic `gorm:"foreignKey:TopicID" json:"topic"`
+
+	models.CommonTimestampsField
+}
+
+// TableName 指定表名
+func (Item) TableName() string {
+	return "collection_items"
+}
+
+// Follow 用户关注的公开收藏夹
+type Follow struct {
+	models.BaseModel
+
+	CollectionID uint64 `gorm:"uniqueIndex:uidx_collection_follow;not null;comment:收藏夹ID" json:"collection_id"`
+	UserID       uint64 `gorm:"uniqueIndex:uidx_collection_follow;index;not null;comment:关注者ID" json:"user_id"`
+
+	Collection Collection `gorm:"foreignKey:CollectionID" json:"collection"`
+
+	models.CommonTimestampsField
+}
+
+// TableName 指定表名
+func (Follow) TableName() string {
+	return "collection_follows"
+}
+
+// Favorite 用户收藏的话题，对应 topic_favorites 表，用于收藏列表
+type Favorite struct {
+	ID        uint64    `json:"-"`
+	TopicID   uint64    `json:"topic_id"`
+	UserID    uint64    `json:"-"`
+	CreatedAt time.Time `json:"favorited_at"`
+
+	Topic topic.Topic `gorm:"foreignKey:TopicID" json:"topic"`
+}
+
+// TableName 指定表名
+func (Favorite) TableName() string {
+	return "topic_favorites"
+}
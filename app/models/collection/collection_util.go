@@ -0,0 +1,91 @@
+package collection
+
+import (
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/pkg/database"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Get 根据 ID 获取收藏夹
+func Get(idstr string) (c Collection) {
+	database.DB.Where("id = ?", idstr).First(&c)
+	return
+}
+
+// visibleTopics 已审核通过、未删除且不在 hiddenCategoryIDs 分类中的话题，收藏列表中只展示这些话题
+func visibleTopics(hiddenCategoryIDs []uint64) *gorm.DB {
+	query := database.DB.Model(&topic.Topic{}).Select("id").Where("status = ?", topic.StatusApproved)
+	if len(hiddenCategoryIDs) > 0 {
+		query = query.Where("category_id NOT IN ?", hiddenCategoryIDs)
+	}
+	return query
+}
+
+// PaginateByUser 分页获取用户的收藏夹，publicOnly 为 true 时只返回公开的收藏夹
+func PaginateByUser(c *gin.Context, userID string, publicOnly bool, baseURL string, perPage int) (collections []Collection, paging paginator.Paging) {
+	query := database.DB.Model(&Collection{}).Where("user_id = ?", userID)
+	if publicOnly {
+		query = query.Where("is_public = ?", true)
+	}
+	paging = paginator.Paginate(c, query.Order("id DESC"), &collections, baseURL, perPage)
+	return
+}
+
+// PaginateItems 分页获取收藏夹中的话题，按排序位置升序
+func PaginateItems(c *gin.Context, collectionID string, perPage int, hiddenCategoryIDs []uint64) (items []Item, paging paginator.Paging) {
+	query := database.DB.Model(&Item{}).
+		Where("collection_id = ? AND topic_id IN (?)", collectionID, visibleTopics(hiddenCategoryIDs)).
+		Order("position ASC")
+	paging = paginator.Paginate(c, query, &items, "/api/v1/collections/"+collectionID+"/items", perPage)
+	return
+}
+
+// PaginateFollowing 分页获取用户关注的公开收藏夹，按关注时间倒序
+func PaginateFollowing(c *gin.Context, userID string, perPage int) (follows []Follow, paging paginator.Paging) {
+	public := database.DB.Model(&Collection{}).Select("id").Where("is_public = ?", true)
+	query := database.DB.Model(&Follow{}).
+		Where("user_id = ? AND collection_id IN (?)", userID, public).
+		Order("id DESC")
+	paging = paginator.Paginate(c, query, &follows, "/api/v1/collections/following", perPage)
+	return
+}
+
+// PaginateFavorites 分页获取用户收藏的话题，按收藏时间倒序
+func PaginateFavorites(c *gin.Context, userID string, perPage int, hiddenCategoryIDs []uint64) (favorites []Favorite, paging paginator.Paging) {
+	query := database.DB.Model(&Favorite{}).
+		Where("user_id = ? AND topic_id IN (?)", userID, visibleTopics(hiddenCategoryIDs)).
+		Order("id DESC")
+	paging = paginator.Paginate(c, query, &favorites, "/api/v1/favorites", perPage)
+	return
+}
+
+// RemoveTopicForUser 从用户的所有收藏夹中移除话题并刷新话题数，取消收藏时调用
+func RemoveTopicForUser(tx *gorm.DB, userID, topicID string) error {
+	owned := tx.Model(&Collection{}).Select("id").Where("user_id = ?", userID)
+
+	var collectionIDs []uint64
+	if err := tx.Model(&Item{}).
+		Where("topic_id = ? AND collection_id IN (?)", topicID, owned).
+		Pluck("collection_id", &collectionIDs).Error; err != nil {
+		return err
+	}
+	if len(collectionIDs) == 0 {
+		return nil
+	}
+	if err := tx.Where("topic_id = ? AND collection_id IN ?", topicID, collectionIDs).Delete(&Item{}).Error; err != nil {
+		return err
+	}
+	return RecountItems(tx, collectionIDs...)
+}
+
+// RecountItems 重新统计收藏夹的话题数
+func RecountItems(tx *gorm.DB, ids ...uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return tx.Model(&Collection{}).Where("id IN ?", ids).
+		UpdateColumn("items_count", gorm.Expr("(SELECT COUNT(*) FROM collection_items WHERE collection_items.collection_id = collections.id)")).Error
+}
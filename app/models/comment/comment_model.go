@@ -2,6 +2,8 @@
 package comment
 
 import (
+	"time"
+
 	"GoHub-Service/app/models"
 	"GoHub-Service/app/models/topic"
 	"GoHub-Service/app/models/user"
@@ -17,6 +19,16 @@ type Comment struct {
 	ParentID  string `json:"parent_id,omitempty"`
 	LikeCount int64  `json:"like_count,omitempty"`
 
+	// 问答分类中被话题作者或版主采纳为答案，列表中排在最前
+	IsAccepted bool `json:"is_accepted,omitempty"`
+
+	// 编辑记录，内容被修改过时 EditedAt 不为空，历史版本保存在 comment_revisions
+	EditCount int        `json:"edit_count,omitempty"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+
+	// 因举报被隐藏的时间，隐藏后保留在讨论串中但不再展示内容
+	HiddenAt *time.Time `json:"hidden_at,omitempty"`
+
 	// 关联用户
 	User user.User `json:"user"`
 
@@ -24,6 +36,7 @@ type Comment struct {
 	Topic topic.Topic `json:"topic"`
 
 	models.CommonTimestampsField
+	models.SoftDeletes
 }
 
 func (comment *Comment) Create() {
@@ -0,0 +1,21 @@
+package comment
+
+import (
+	"GoHub-Service/app/models"
+)
+
+// Revision 评论的历史版本，每次修改内容前保存一份旧内容
+type Revision struct {
+	models.BaseModel
+
+	CommentID uint64 `gorm:"index;not null;comment:评论ID" json:"comment_id"`
+	EditorID  uint64 `gorm:"index;not null;comment:编辑人ID" json:"editor_id"`
+	Content   string `gorm:"type:text;not null;comment:修改前的内容" json:"content"`
+
+	models.CommonTimestampsField
+}
+
+// TableName 指定表名
+func (Revision) TableName() string {
+	return "comment_revisions"
+}
@@ -0,0 +1,167 @@
+package comment
+
+import (
+	"sort"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// 评论树排序方式
+const (
+	TreeSortOldest = "oldest"
+	TreeSortNewest = "newest"
+	TreeSortLikes  = "likes"
+)
+
+// TreeOptions 评论树的构建参数
+type TreeOptions struct {
+	Sort string
+	// MaxDepth 展示的最大层数，顶级评论为第 1 层，不小于 2
+	// 第 MaxDepth 层及更深的回复都平铺在第 MaxDepth-1 层评论下，通过 ReplyTo 指向实际回复的评论
+	MaxDepth int
+	// Preview 每条评论内嵌的回复数
+	Preview int
+	// MaxNodes 单次最多加载的回复节点数，防止超大讨论串拖慢查询
+	MaxNodes int
+}
+
+// OrderSQL 顶级评论的排序语句，被采纳的回答总是排在最前
+func (o TreeOptions) OrderSQL() string {
+	switch o.Sort {
+	case TreeSortNewest:
+		return "is_accepted DESC, created_at DESC, id DESC"
+	case TreeSortLikes:
+		return "is_accepted DESC, like_count DESC, created_at ASC, id ASC"
+	default:
+		return "is_accepted DESC, created_at ASC, id ASC"
+	}
+}
+
+// Node 构建评论树时只加载的轻量字段
+type Node struct {
+	ID        uint64    `json:"id"`
+	ParentID  string    `json:"parent_id"`
+	UserID    string    `json:"user_id"`
+	LikeCount int64     `json:"like_count"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TreeItem 评论树中的一条评论
+type TreeItem struct {
+	Comment Comment `json:"comment"`
+	// ReplyTo 平铺展示的深层回复实际回复的评论，直接回复上一层时为 nil
+	ReplyTo *Node `json:"reply_to,omitempty"`
+	// ReplyCount 全部回复数，平铺展示时包含所有更深层的回复；Replies 只包含其中前 Preview 条
+	ReplyCount int        `json:"reply_count"`
+	Replies    []TreeItem `json:"replies"`
+}
+
+// TreePlan 根据顶级评论和它们的全部回复节点决定每条评论内嵌哪些回复
+// 返回的 TreeItem 只填充了 Comment.ID，由调用方按 IDs 加载评论内容
+func TreePlan(rootIDs []uint64, nodes []Node, opts TreeOptions) (items []TreeItem, ids []uint64) {
+	children := make(map[string][]Node)
+	byID := make(map[uint64]Node, len(nodes))
+	for _, n := range nodes {
+		children[n.ParentID] = append(children[n.ParentID], n)
+		byID[n.ID] = n
+	}
+	for parentID := range children {
+		sortNodes(children[parentID], opts.Sort)
+	}
+
+	ids = append(ids, rootIDs...)
+	var build func(id uint64, depth int) TreeItem
+	build = func(id uint64, depth int) TreeItem {
+		item := TreeItem{Replies: []TreeItem{}}
+		item.Comment.ID = id
+
+		var replies []Node
+		if depth < opts.MaxDepth-1 {
+			replies = children[cast.ToString(id)]
+		} else {
+			replies = descendants(children, id)
+			sortNodes(replies, opts.Sort)
+		}
+		item.ReplyCount = len(replies)
+		if len(replies) > opts.Preview {
+			replies = replies[:opts.Preview]
+		}
+
+		for _, reply := range replies {
+			ids = append(ids, reply.ID)
+			var child TreeItem
+			if depth < opts.MaxDepth-1 {
+				child = build(reply.ID, depth+1)
+			} else {
+				child = TreeItem{Replies: []TreeItem{}}
+				child.Comment.ID = reply.ID
+				if parentID := cast.ToUint64(reply.ParentID); parentID != id {
+					if parent, ok := byID[parentID]; ok {
+						child.ReplyTo = &parent
+					}
+				}
+			}
+			item.Replies = append(item.Replies, child)
+		}
+		return item
+	}
+
+	items = make([]TreeItem, 0, len(rootIDs))
+	for _, id := range rootIDs {
+		items = append(items, build(id, 1))
+	}
+	return items, ids
+}
+
+// Fill 用加载好的评论填充评论树，已删除等原因未加载到的回复会被移除
+func Fill(items []TreeItem, comments map[uint64]Comment) []TreeItem {
+	filled := make([]TreeItem, 0, len(items))
+	for _, item := range items {
+		c, ok := comments[item.Comment.ID]
+		if !ok {
+			continue
+		}
+		item.Comment = c
+		item.Replies = Fill(item.Replies, comments)
+		filled = append(filled, item)
+	}
+	return filled
+}
+
+// descendants 平铺收集评论下的全部回复
+func descendants(children map[string][]Node, id uint64) []Node {
+	var result []Node
+	queue := []uint64{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, child := range children[cast.ToString(current)] {
+			result = append(result, child)
+			queue = append(queue, child.ID)
+		}
+	}
+	return result
+}
+
+// sortNodes 按排序方式排列同一层的回复
+func sortNodes(nodes []Node, mode string) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, b := nodes[i], nodes[j]
+		switch mode {
+		case TreeSortNewest:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+			return a.ID > b.ID
+		case TreeSortLikes:
+			if a.LikeCount != b.LikeCount {
+				return a.LikeCount > b.LikeCount
+			}
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	})
+}
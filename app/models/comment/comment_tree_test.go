@@ -0,0 +1,107 @@
+package comment
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// treeNodes 顶级评论 1 和 2，1 下有三层嵌套回复，99 的父评论不在本次加载范围内
+func treeNodes() []Node {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return base.Add(time.Duration(minutes) * time.Minute) }
+	return []Node{
+		{ID: 10, ParentID: "1", CreatedAt: at(1)},
+		{ID: 11, ParentID: "1", CreatedAt: at(2), LikeCount: 5},
+		{ID: 12, ParentID: "1", CreatedAt: at(3)},
+		{ID: 20, ParentID: "10", CreatedAt: at(4)},
+		{ID: 30, ParentID: "20", CreatedAt: at(5)},
+		{ID: 40, ParentID: "30", CreatedAt: at(6)},
+		{ID: 99, ParentID: "999", CreatedAt: at(7)},
+	}
+}
+
+// describeTree 将评论树写成 1[10[20] 11>10] 的形式，>后为平铺回复实际回复的评论
+func describeTree(items []TreeItem) string {
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		s := fmt.Sprint(item.Comment.ID)
+		if item.ReplyTo != nil {
+			s += fmt.Sprintf(">%d", item.ReplyTo.ID)
+		}
+		if len(item.Replies) > 0 {
+			s += "[" + describeTree(item.Replies) + "]"
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestTreePlan(t *testing.T) {
+	cases := []struct {
+		name      string
+		opts      TreeOptions
+		want      string
+		wantCount int // 顶级评论 1 的 ReplyCount
+	}{
+		{
+			name:      "超过层数限制的回复平铺在上一层",
+			opts:      TreeOptions{Sort: TreeSortOldest, MaxDepth: 4, Preview: 10},
+			want:      "1[10[20[30 40>30]] 11 12] 2",
+			wantCount: 3,
+		},
+		{
+			name:      "两层时全部回复平铺在顶级评论下并按点赞排序",
+			opts:      TreeOptions{Sort: TreeSortLikes, MaxDepth: 2, Preview: 2},
+			want:      "1[11 10] 2",
+			wantCount: 6,
+		},
+		{
+			name:      "按最新排序",
+			opts:      TreeOptions{Sort: TreeSortNewest, MaxDepth: 3, Preview: 10},
+			want:      "1[12 11 10[40>30 30>20 20]] 2",
+			wantCount: 3,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			items, ids := TreePlan([]uint64{1, 2}, treeNodes(), tc.opts)
+			if got := describeTree(items); got != tc.want {
+				t.Errorf("tree = %s, want %s", got, tc.want)
+			}
+			if items[0].ReplyCount != tc.wantCount {
+				t.Errorf("ReplyCount = %d, want %d", items[0].ReplyCount, tc.wantCount)
+			}
+			if len(ids) < 2 || ids[0] != 1 || ids[1] != 2 {
+				t.Errorf("ids 应以顶级评论开头，got %v", ids)
+			}
+			for _, id := range ids {
+				if id == 99 {
+					t.Errorf("父评论不在树中的回复不应被加载，ids = %v", ids)
+				}
+			}
+		})
+	}
+}
+
+func TestTreePlan_IDsMatchTree(t *testing.T) {
+	_, ids := TreePlan([]uint64{1, 2}, treeNodes(), TreeOptions{Sort: TreeSortOldest, MaxDepth: 4, Preview: 2})
+	want := []uint64{1, 2, 10, 20, 30, 40, 11}
+	if fmt.Sprint(ids) != fmt.Sprint(want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+}
+
+func TestFill_DropsMissingComments(t *testing.T) {
+	items, _ := TreePlan([]uint64{1}, treeNodes(), TreeOptions{Sort: TreeSortOldest, MaxDepth: 4, Preview: 10})
+	loaded := map[uint64]Comment{}
+	for _, id := range []uint64{1, 10, 11, 30} {
+		c := Comment{Content: fmt.Sprint("评论", id)}
+		c.ID = id
+		loaded[id] = c
+	}
+	if got, want := describeTree(Fill(items, loaded)), "1[10 11]"; got != want {
+		t.Errorf("tree = %s, want %s", got, want)
+	}
+}
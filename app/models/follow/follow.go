@@ -3,13 +3,16 @@ package follow
 import (
 	"GoHub-Service/app/models"
 	"GoHub-Service/pkg/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-// Follow 关注关系模型
+// Follow 关注关系模型，用户之间的关注关系都保存在 follows 表，同一对用户只有一条记录
 type Follow struct {
 	models.BaseModel
-	UserID   string `gorm:"index:idx_user_follow;not null" json:"user_id"`        // 关注者ID
-	FollowID string `gorm:"index:idx_user_follow;not null" json:"follow_id"`      // 被关注者ID
+	UserID   string `gorm:"uniqueIndex:uidx_user_follow;not null" json:"user_id"`   // 关注者ID
+	FollowID string `gorm:"uniqueIndex:uidx_user_follow;not null" json:"follow_id"` // 被关注者ID
 	models.CommonTimestampsField
 }
 
@@ -58,3 +61,76 @@ func HasFollowed(userID, followID string) bool {
 		Count(&count)
 	return count > 0
 }
+
+// Add 在事务中创建关注关系并维护双方的关注数和粉丝数，已关注时返回 false
+// 关注关系只通过 Add 和 Remove 修改，保证 users 表中的计数与 follows 表一致
+func Add(tx *gorm.DB, userID, followID string) (bool, error) {
+	f := Follow{UserID: userID, FollowID: followID}
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&f)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+	if err := tx.Table("users").Where("id = ?", followID).
+		UpdateColumn("followers_count", gorm.Expr("followers_count + 1")).Error; err != nil {
+		return false, err
+	}
+	if err := tx.Table("users").Where("id = ?", userID).
+		UpdateColumn("following_count", gorm.Expr("following_count + 1")).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Remove 在事务中删除关注关系并维护双方计数，未关注时返回 false
+func Remove(tx *gorm.DB, userID, followID string) (bool, error) {
+	result := tx.Where("user_id = ? AND follow_id = ?", userID, followID).Delete(&Follow{})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+	if err := tx.Table("users").Where("id = ? AND followers_count > 0", followID).
+		UpdateColumn("followers_count", gorm.Expr("followers_count - 1")).Error; err != nil {
+		return false, err
+	}
+	if err := tx.Table("users").Where("id = ? AND following_count > 0", userID).
+		UpdateColumn("following_count", gorm.Expr("following_count - 1")).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// FollowingSet userIDs 中被 userID 关注的用户
+func FollowingSet(userID string, userIDs []string) map[string]bool {
+	set := make(map[string]bool)
+	if userID == "" || len(userIDs) == 0 {
+		return set
+	}
+	var ids []string
+	database.DB.Model(&Follow{}).
+		Where("user_id = ? AND follow_id IN ?", userID, userIDs).
+		Pluck("follow_id", &ids)
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+// FollowerSet userIDs 中关注了 userID 的用户
+func FollowerSet(userID string, userIDs []string) map[string]bool {
+	set := make(map[string]bool)
+	if userID == "" || len(userIDs) == 0 {
+		return set
+	}
+	var ids []string
+	database.DB.Model(&Follow{}).
+		Where("follow_id = ? AND user_id IN ?", userID, userIDs).
+		Pluck("user_id", &ids)
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+// IsMutual 两个用户是否互相关注
+func IsMutual(userID, otherID string) bool {
+	return HasFollowed(userID, otherID) && HasFollowed(otherID, userID)
+}
@@ -5,12 +5,18 @@ import (
 	"GoHub-Service/pkg/database"
 )
 
+// 点赞目标类型
+const (
+	TargetTopic   = "topic"
+	TargetComment = "comment"
+)
+
 // Like 点赞模型
 type Like struct {
 	models.BaseModel
-	UserID     string `gorm:"index:idx_like_target;not null" json:"user_id"`      // 点赞者ID
-	TargetType string `gorm:"index:idx_like_target;not null" json:"target_type"`  // 目标类型 (topic/comment)
-	TargetID   string `gorm:"index:idx_like_target;not null" json:"target_id"`    // 目标ID
+	UserID     string `gorm:"uniqueIndex:uidx_like_target_user;not null" json:"user_id"`      // 点赞者ID
+	TargetType string `gorm:"uniqueIndex:uidx_like_target_user;not null" json:"target_type"`  // 目标类型 (topic/comment)
+	TargetID   string `gorm:"uniqueIndex:uidx_like_target_user;not null" json:"target_id"`    // 目标ID
 	models.CommonTimestampsField
 }
 
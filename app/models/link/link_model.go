@@ -12,11 +12,40 @@ import (
 
 )
 
+// 友情链接状态，用户提交的申请为 pending，管理员审核后变为 approved 或 rejected
+const (
+    StatusPending  = "pending"
+    StatusApproved = "approved"
+    StatusRejected = "rejected"
+)
+
+// cacheKey 前台友情链接列表缓存
+const cacheKey = "links:all"
+
 type Link struct {
     models.BaseModel
 
-    Name string `gorm:"index" json:"name,omitempty"`
-    URL  string `gorm:"index" json:"url,omitempty"`
+    Name        string `gorm:"index" json:"name,omitempty"`
+    URL         string `gorm:"index" json:"url,omitempty"`
+    Logo        string `json:"logo,omitempty"`
+    Description string `json:"description,omitempty"`
+    SortOrder   int    `gorm:"default:0" json:"sort_order"`
+    // 不设置 GORM 默认值，否则创建时 false 会被替换为 true，创建方需显式赋值
+    IsEnabled   bool   `json:"is_enabled"`
+
+    // 申请信息，管理员直接添加的链接 ApplicantID 为 0
+    Status       string `gorm:"default:approved" json:"status"`
+    ApplicantID  uint64 `json:"applicant_id,omitempty"`
+    ContactEmail string `json:"contact_email,omitempty"`
+    RejectReason string `json:"reject_reason,omitempty"`
+
+    ClickCount int64 `gorm:"default:0" json:"click_count"`
+
+    // 可用性检测，连续失败达到阈值后标记为失效，不在前台展示
+    IsBroken      bool       `gorm:"default:false" json:"is_broken"`
+    FailCount     int        `gorm:"default:0" json:"fail_count"`
+    CheckError    string     `json:"check_error,omitempty"`
+    LastCheckedAt *time.Time `json:"last_checked_at,omitempty"`
 
     models.CommonTimestampsField
 }
@@ -35,9 +64,12 @@ func (link *Link) Delete() (rowsAffected int64) {
     return result.RowsAffected
 }
 
+// Visible 是否在前台展示：审核通过、已启用且未失效
+func (link *Link) Visible() bool {
+    return link.Status == StatusApproved && link.IsEnabled && !link.IsBroken
+}
+
 func AllCached() (links []Link) {
-	// 设置缓存 key
-	cacheKey := "links:all"
 	// 设置过期时间
 	expireTime := 120 * time.Minute
 	// 取数据
@@ -46,7 +78,7 @@ func AllCached() (links []Link) {
 	// 如果数据为空
 	if helpers.Empty(links) {
 		// 查询数据库
-		links = All()
+		links = AllVisible()
 		if helpers.Empty(links) {
 			return links
 		}
@@ -55,3 +87,8 @@ func AllCached() (links []Link) {
 	}
 	return
 }
+
+// ForgetCache 清除前台友情链接缓存，链接增删改、审核或检测状态变化后调用
+func ForgetCache() {
+	cache.Forget(context.Background(), cacheKey)
+}
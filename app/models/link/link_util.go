@@ -23,6 +23,14 @@ func All() (links []Link) {
     return 
 }
 
+// AllVisible 前台展示的链接，按排序值和 ID 升序
+func AllVisible() (links []Link) {
+    database.DB.Where("status = ? AND is_enabled = ? AND is_broken = ?", StatusApproved, true, false).
+        Order("sort_order ASC, id ASC").
+        Find(&links)
+    return
+}
+
 func IsExist(field, value string) bool {
     var count int64
     database.DB.Model(Link{}).Where("? = ?", field, value).Count(&count)
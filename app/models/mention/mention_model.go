@@ -0,0 +1,32 @@
+// Package mention 正文中 @用户 的提及记录
+package mention
+
+import (
+	"time"
+
+	"GoHub-Service/app/models"
+)
+
+// 提及来源类型
+const (
+	SourceTopic   = "topic"
+	SourceComment = "comment"
+)
+
+// Mention 话题或评论中提及的用户，用于编辑时只通知新增的提及
+type Mention struct {
+	models.BaseModel
+
+	SourceType string     `gorm:"type:varchar(20);uniqueIndex:uidx_mention_source;not null;comment:来源类型" json:"source_type"`
+	SourceID   uint64     `gorm:"uniqueIndex:uidx_mention_source;not null;comment:来源ID" json:"source_id"`
+	UserID     uint64     `gorm:"uniqueIndex:uidx_mention_source;index;not null;comment:被提及用户ID" json:"user_id"`
+	ActorID    uint64     `gorm:"not null;comment:提及者ID" json:"actor_id"`
+	NotifiedAt *time.Time `gorm:"comment:通知时间，为空表示尚未通知" json:"notified_at,omitempty"`
+
+	models.CommonTimestampsField
+}
+
+// TableName 指定表名
+func (Mention) TableName() string {
+	return "mentions"
+}
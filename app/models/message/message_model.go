@@ -16,6 +16,7 @@ type Message struct {
 	ReceiverID     string     `json:"receiver_id,omitempty"`
 	Body           string     `json:"body,omitempty"`
 	ReadAt         *time.Time `json:"read_at,omitempty"`
+	HiddenAt       *time.Time `json:"hidden_at,omitempty"` // 因举报被隐藏，隐藏后不再展示内容
 
 	models.CommonTimestampsField
 }
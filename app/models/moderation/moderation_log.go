@@ -0,0 +1,69 @@
+// Package moderation 版主操作日志模型
+package moderation
+
+import (
+	"GoHub-Service/app/models"
+	"GoHub-Service/pkg/database"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 操作类型
+const (
+	ActionApprove = "approve" // 审核通过
+	ActionReject  = "reject"  // 审核拒绝
+	ActionMove    = "move"    // 移动分类
+	ActionMerge   = "merge"   // 合并话题
+	ActionSplit   = "split"   // 拆分话题
+	ActionHide    = "hide"    // 隐藏内容（话题撤回审核队列）
+	ActionDelete  = "delete"  // 删除内容
+	ActionBan     = "ban"     // 封禁用户
+	ActionDismiss = "dismiss" // 驳回举报
+
+	ActionAutoReview = "auto_review" // 审核流水线判定需要人工审核
+	ActionAutoReject = "auto_reject" // 审核流水线判定直接拒绝
+)
+
+// Log 版主操作日志
+type Log struct {
+	models.BaseModel
+
+	ModeratorID uint64 `gorm:"index;not null;comment:操作人ID" json:"moderator_id"`
+	Action      string `gorm:"type:varchar(32);index;not null;comment:操作类型" json:"action"`
+	TargetType  string `gorm:"type:varchar(32);index:idx_moderation_log_target;not null;comment:目标类型" json:"target_type"`
+	TargetID    uint64 `gorm:"index:idx_moderation_log_target;not null;comment:目标ID" json:"target_id"`
+	Reason      string `gorm:"type:varchar(500);comment:操作原因" json:"reason,omitempty"`
+	Detail      string `gorm:"type:text;comment:操作详情(JSON)" json:"detail,omitempty"`
+
+	models.CommonTimestampsField
+}
+
+// TableName 指定表名
+func (Log) TableName() string {
+	return "moderation_logs"
+}
+
+// Create 创建日志
+func (l *Log) Create() {
+	database.DB.Create(&l)
+}
+
+// Paginate 分页获取操作日志，可按操作类型、目标和操作人筛选
+func Paginate(c *gin.Context, perPage int) (logs []Log, paging paginator.Paging) {
+	query := database.DB.Model(&Log{})
+	if action := c.Query("action"); action != "" {
+		query = query.Where("action = ?", action)
+	}
+	if targetType := c.Query("target_type"); targetType != "" {
+		query = query.Where("target_type = ?", targetType)
+	}
+	if targetID := c.Query("target_id"); targetID != "" {
+		query = query.Where("target_id = ?", targetID)
+	}
+	if moderatorID := c.Query("moderator_id"); moderatorID != "" {
+		query = query.Where("moderator_id = ?", moderatorID)
+	}
+	paging = paginator.Paginate(c, query.Order("id DESC"), &logs, "/api/v1/moderator/logs", perPage)
+	return
+}
@@ -0,0 +1,53 @@
+// Package reaction 表情回应模型
+package reaction
+
+import (
+	"GoHub-Service/app/models"
+)
+
+// 回应目标类型
+const (
+	TargetTopic   = "topic"
+	TargetComment = "comment"
+	TargetMessage = "message"
+)
+
+// Type 管理员配置的可用表情，停用后不能再回应，已有的回应保留
+type Type struct {
+	models.BaseModel
+
+	Emoji    string `gorm:"type:varchar(32);uniqueIndex;not null;comment:表情" json:"emoji"`
+	Name     string `gorm:"type:varchar(50);not null;comment:名称" json:"name"`
+	Position int    `gorm:"default:0;comment:排序位置" json:"position"`
+	Enabled  bool   `gorm:"comment:是否启用" json:"enabled"` // 不设置 GORM 默认值，否则创建时 false 会被替换为 true
+
+	models.CommonTimestampsField
+}
+
+// TableName 指定表名
+func (Type) TableName() string {
+	return "reaction_types"
+}
+
+// Reaction 用户对话题、评论或私信的表情回应，同一用户对同一目标可以回应多个不同的表情
+type Reaction struct {
+	models.BaseModel
+
+	TargetType string `gorm:"type:varchar(20);uniqueIndex:uidx_reaction;not null;comment:目标类型" json:"target_type"`
+	TargetID   uint64 `gorm:"uniqueIndex:uidx_reaction;not null;comment:目标ID" json:"target_id"`
+	UserID     uint64 `gorm:"uniqueIndex:uidx_reaction;index;not null;comment:用户ID" json:"user_id"`
+	Emoji      string `gorm:"type:varchar(32);uniqueIndex:uidx_reaction;not null;comment:表情" json:"emoji"`
+
+	models.CommonTimestampsField
+}
+
+// TableName 指定表名
+func (Reaction) TableName() string {
+	return "reactions"
+}
+
+// Count 目标上某个表情的回应数
+type Count struct {
+	Emoji string `json:"emoji"`
+	Count int64  `json:"count"`
+}
@@ -0,0 +1,76 @@
+// Package report 用户举报模型
+package report
+
+import (
+	"time"
+
+	"GoHub-Service/app/models"
+)
+
+// 举报目标类型
+const (
+	TargetTopic   = "topic"
+	TargetComment = "comment"
+	TargetUser    = "user"
+	TargetMessage = "message"
+)
+
+// 举报原因分类
+const (
+	ReasonSpam       = "spam"       // 垃圾广告
+	ReasonAbuse      = "abuse"      // 辱骂攻击
+	ReasonHarassment = "harassment" // 骚扰
+	ReasonPorn       = "porn"       // 色情低俗
+	ReasonIllegal    = "illegal"    // 违法违规
+	ReasonMisleading = "misleading" // 不实信息
+	ReasonOther      = "other"      // 其他
+
+	ReasonAutoModeration = "auto" // 审核流水线提交，举报人为 0
+)
+
+// Reasons 全部举报原因，用于校验
+var Reasons = []string{ReasonSpam, ReasonAbuse, ReasonHarassment, ReasonPorn, ReasonIllegal, ReasonMisleading, ReasonOther}
+
+// 举报处理状态：open 待处理，triaged 已分拣待处理，actioned 已处置，dismissed 已驳回
+const (
+	StatusOpen      = "open"
+	StatusTriaged   = "triaged"
+	StatusActioned  = "actioned"
+	StatusDismissed = "dismissed"
+)
+
+// 处置方式
+const (
+	ActionDelete = "delete" // 删除内容
+	ActionHide   = "hide"   // 隐藏内容
+	ActionBan    = "ban"    // 封禁作者
+)
+
+// Report 用户举报，同一用户对同一目标只能举报一次
+type Report struct {
+	models.BaseModel
+
+	ReporterID  uint64     `gorm:"uniqueIndex:uidx_report_reporter;not null;comment:举报人ID" json:"reporter_id"`
+	TargetType  string     `gorm:"type:varchar(20);uniqueIndex:uidx_report_reporter;index:idx_report_target;not null;comment:目标类型" json:"target_type"`
+	TargetID    uint64     `gorm:"uniqueIndex:uidx_report_reporter;index:idx_report_target;not null;comment:目标ID" json:"target_id"`
+	OwnerID     uint64     `gorm:"index;default:0;comment:被举报内容的作者ID" json:"owner_id"`
+	Reason      string     `gorm:"type:varchar(20);index;not null;comment:举报原因" json:"reason"`
+	Detail      string     `gorm:"type:varchar(1000);comment:补充说明" json:"detail,omitempty"`
+	Status      string     `gorm:"type:varchar(20);index;not null;default:open;comment:处理状态" json:"status"`
+	Action      string     `gorm:"type:varchar(20);comment:处置方式" json:"action,omitempty"`
+	HandledBy   uint64     `gorm:"default:0;comment:处理人ID" json:"handled_by,omitempty"`
+	HandledAt   *time.Time `gorm:"comment:处理时间" json:"handled_at,omitempty"`
+	HandlerNote string     `gorm:"type:varchar(500);comment:处理说明" json:"handler_note,omitempty"`
+
+	models.CommonTimestampsField
+}
+
+// TableName 指定表名
+func (Report) TableName() string {
+	return "reports"
+}
+
+// IsPending 是否仍待处理
+func (r *Report) IsPending() bool {
+	return r.Status == StatusOpen || r.Status == StatusTriaged
+}
@@ -0,0 +1,49 @@
+package report
+
+import (
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/comment"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// CategoryOf 被举报内容所在的分类，话题和评论以外的目标或内容已不存在时返回空字符串
+// 已进入回收站的内容仍按原分类计算
+func CategoryOf(targetType string, targetID uint64) string {
+	var topicID interface{}
+	switch targetType {
+	case TargetTopic:
+		topicID = targetID
+	case TargetComment:
+		topicID = database.DB.Unscoped().Model(&comment.Comment{}).Select("topic_id").Where("id = ?", targetID)
+	default:
+		return ""
+	}
+
+	var categoryIDs []string
+	database.DB.Unscoped().Model(&topic.Topic{}).Where("id = (?)", topicID).Pluck("category_id", &categoryIDs)
+	if len(categoryIDs) == 0 {
+		return ""
+	}
+	return categoryIDs[0]
+}
+
+// UserCanHandle 用户能否处理该举报：话题和评论的举报需要能管理内容所在的分类
+// 用户和私信不属于任何分类，被指派了分类的版主不能处理
+func UserCanHandle(userID string, rp *Report) bool {
+	if categoryID := CategoryOf(rp.TargetType, rp.TargetID); categoryID != "" {
+		return category.UserCanModerate(userID, categoryID)
+	}
+	_, scoped := category.ModeratorScope(userID)
+	return !scoped
+}
+
+// ScopeToCategories 只保留分类范围内的话题和评论举报，用于被指派了分类的版主
+func ScopeToCategories(query *gorm.DB, categoryIDs []uint64) *gorm.DB {
+	topicIDs := database.DB.Unscoped().Model(&topic.Topic{}).Select("id").Where("category_id IN ?", categoryIDs)
+	commentIDs := database.DB.Unscoped().Model(&comment.Comment{}).Select("id").Where("topic_id IN (?)", topicIDs)
+	return query.Where("((target_type = ? AND target_id IN (?)) OR (target_type = ? AND target_id IN (?)))",
+		TargetTopic, topicIDs, TargetComment, commentIDs)
+}
@@ -63,3 +63,28 @@ func IsExist(name string) bool {
 	database.DB.Model(&Role{}).Where("name = ?", name).Count(&count)
 	return count > 0
 }
+
+// UserHasAnyRole 检查用户是否拥有任意一个指定角色
+func UserHasAnyRole(userID string, names ...string) bool {
+	if userID == "" || len(names) == 0 {
+		return false
+	}
+	var count int64
+	database.DB.Table("user_roles").
+		Joins("JOIN roles ON roles.id = user_roles.role_id").
+		Where("user_roles.user_id = ? AND roles.name IN ?", userID, names).
+		Count(&count)
+	return count > 0
+}
+
+// UserRoleNames 用户拥有的全部角色名
+func UserRoleNames(userID string) (names []string) {
+	if userID == "" {
+		return nil
+	}
+	database.DB.Table("user_roles").
+		Joins("JOIN roles ON roles.id = user_roles.role_id").
+		Where("user_roles.user_id = ?", userID).
+		Pluck("roles.name", &names)
+	return
+}
@@ -0,0 +1,51 @@
+// Package sensitive 敏感词词库模型
+package sensitive
+
+import (
+	"strings"
+
+	"GoHub-Service/app/models"
+	"GoHub-Service/pkg/security"
+)
+
+// Severities 全部处理方式，用于校验
+var Severities = []string{security.SeverityBlock, security.SeverityReplace, security.SeverityReview}
+
+// Word 管理员维护的敏感词
+type Word struct {
+	models.BaseModel
+
+	Word     string `gorm:"type:varchar(100);uniqueIndex;not null;comment:敏感词" json:"word"`
+	Category string `gorm:"type:varchar(20);index;not null;default:other;comment:分类" json:"category"`
+	Severity string `gorm:"type:varchar(20);index;not null;default:replace;comment:处理方式 block/replace/review" json:"severity"`
+	Pinyin   string `gorm:"type:varchar(255);comment:拼音等变体，多个用逗号分隔" json:"pinyin,omitempty"`
+	Note     string `gorm:"type:varchar(255);comment:备注" json:"note,omitempty"`
+
+	models.CommonTimestampsField
+}
+
+// TableName 指定表名
+func (Word) TableName() string {
+	return "sensitive_words"
+}
+
+// PinyinList 拼音变体列表
+func (w Word) PinyinList() []string {
+	var list []string
+	for _, item := range strings.Split(w.Pinyin, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// Entry 转换为过滤器使用的词条
+func (w Word) Entry() security.WordEntry {
+	return security.WordEntry{
+		Word:     w.Word,
+		Category: w.Category,
+		Severity: w.Severity,
+		Pinyin:   w.PinyinList(),
+	}
+}
@@ -0,0 +1,76 @@
+// Package series 话题系列模型
+package series
+
+import (
+	"GoHub-Service/app/models"
+	"GoHub-Service/pkg/database"
+
+	"github.com/spf13/cast"
+)
+
+// Series 作者将多篇话题组织成的系列，如分多篇发布的教程
+type Series struct {
+	models.BaseModel
+
+	UserID         uint64 `gorm:"index;not null;comment:作者ID" json:"user_id"`
+	Title          string `gorm:"type:varchar(100);not null;comment:标题" json:"title"`
+	Description    string `gorm:"type:varchar(500);comment:描述" json:"description,omitempty"`
+	TopicsCount    int64  `gorm:"default:0;comment:话题数" json:"topics_count"`
+	FollowersCount int64  `gorm:"default:0;comment:关注数" json:"followers_count"`
+
+	models.CommonTimestampsField
+}
+
+// TableName 指定表名
+func (Series) TableName() string {
+	return "series"
+}
+
+// Create 创建系列
+func (s *Series) Create() {
+	database.DB.Create(&s)
+}
+
+// GetStringID 获取字符串格式的ID
+func (s *Series) GetStringID() string {
+	return cast.ToString(s.ID)
+}
+
+// Item 系列中的话题，一个话题最多属于一个系列，按 Position 升序排列
+type Item struct {
+	models.BaseModel
+
+	SeriesID uint64 `gorm:"index;not null;comment:系列ID" json:"series_id"`
+	TopicID  uint64 `gorm:"uniqueIndex;not null;comment:话题ID" json:"topic_id"`
+	Position int    `gorm:"default:0;comment:排序位置" json:"position"`
+
+	models.CommonTimestampsField
+}
+
+// TableName 指定表名
+func (Item) TableName() string {
+	return "series_items"
+}
+
+// Follow 关注系列的用户，系列有新的话题时收到通知
+type Follow struct {
+	models.BaseModel
+
+	SeriesID uint64 `gorm:"uniqueIndex:uidx_series_follow;not null;comment:系列ID" json:"series_id"`
+	UserID   uint64 `gorm:"uniqueIndex:uidx_series_follow;index;not null;comment:关注者ID" json:"user_id"`
+
+	models.CommonTimestampsField
+}
+
+// TableName 指定表名
+func (Follow) TableName() string {
+	return "series_follows"
+}
+
+// Part 系列中的一篇话题，用于系列页面和上一篇/下一篇导航
+type Part struct {
+	TopicID  uint64 `json:"topic_id"`
+	Title    string `json:"title"`
+	Position int    `json:"position"`
+	Status   int    `json:"-"`
+}
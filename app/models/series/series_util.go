@@ -0,0 +1,61 @@
+package series
+
+import (
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/pkg/database"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Get 根据 ID 获取系列
+func Get(idstr string) (s Series) {
+	database.DB.Where("id = ?", idstr).First(&s)
+	return
+}
+
+// Parts 系列中的话题，按排序位置升序，已删除和 hiddenCategoryIDs 分类中的话题不返回
+// approvedOnly 为 true 时只返回已审核通过的话题
+func Parts(seriesID uint64, approvedOnly bool, hiddenCategoryIDs []uint64) (parts []Part, err error) {
+	query := database.DB.Table("series_items").
+		Select("series_items.topic_id, topics.title, series_items.position, topics.status").
+		Joins("JOIN topics ON topics.id = series_items.topic_id AND topics.deleted_at IS NULL").
+		Where("series_items.series_id = ?", seriesID)
+	if approvedOnly {
+		query = query.Where("topics.status = ?", topic.StatusApproved)
+	}
+	if len(hiddenCategoryIDs) > 0 {
+		query = query.Where("topics.category_id NOT IN ?", hiddenCategoryIDs)
+	}
+	err = query.Order("series_items.position ASC, series_items.id ASC").Scan(&parts).Error
+	return
+}
+
+// SeriesIDOfTopic 话题所属的系列 ID，不属于任何系列时返回 0
+func SeriesIDOfTopic(topicID string) (seriesID uint64, err error) {
+	err = database.DB.Model(&Item{}).Select("series_id").Where("topic_id = ?", topicID).Scan(&seriesID).Error
+	return
+}
+
+// PaginateByUser 分页获取作者的系列
+func PaginateByUser(c *gin.Context, userID string, perPage int) (list []Series, paging paginator.Paging) {
+	query := database.DB.Model(&Series{}).Where("user_id = ?", userID).Order("id DESC")
+	paging = paginator.Paginate(c, query, &list, "/api/v1/users/"+userID+"/series", perPage)
+	return
+}
+
+// FollowerIDs 关注系列的用户 ID
+func FollowerIDs(seriesID uint64) (ids []uint64, err error) {
+	err = database.DB.Model(&Follow{}).Where("series_id = ?", seriesID).Pluck("user_id", &ids).Error
+	return
+}
+
+// RecountTopics 重新统计系列的话题数
+func RecountTopics(tx *gorm.DB, ids ...uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return tx.Model(&Series{}).Where("id IN ?", ids).
+		UpdateColumn("topics_count", gorm.Expr("(SELECT COUNT(*) FROM series_items WHERE series_items.series_id = series.id)")).Error
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+
+	"GoHub-Service/pkg/database"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SoftDeletes 软删除字段
+// 嵌入后 GORM 的查询默认排除已删除记录，Delete() 只写入 deleted_at
+type SoftDeletes struct {
+	DeletedAt    gorm.DeletedAt `gorm:"column:deleted_at;index" json:"deleted_at,omitempty"`
+	DeletedBy    uint64         `gorm:"column:deleted_by;default:0;comment:删除操作人ID" json:"deleted_by,omitempty"`
+	DeleteReason string         `gorm:"column:delete_reason;type:varchar(255);comment:删除原因" json:"delete_reason,omitempty"`
+}
+
+// IsDeleted 是否已被软删除
+func (s SoftDeletes) IsDeleted() bool {
+	return s.DeletedAt.Valid
+}
+
+// SoftDelete 软删除指定 ID 的记录，同时记录操作人和原因，已删除的记录不受影响
+func SoftDelete[T any](ids []uint64, operatorID uint64, reason string) (int64, error) {
+	result := database.DB.Model(new(T)).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"deleted_at":    time.Now(),
+			"deleted_by":    operatorID,
+			"delete_reason": reason,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// Restore 恢复已软删除的记录
+func Restore[T any](ids []uint64) (int64, error) {
+	result := database.DB.Unscoped().Model(new(T)).
+		Where("id IN ? AND deleted_at IS NOT NULL", ids).
+		Updates(map[string]interface{}{
+			"deleted_at":    nil,
+			"deleted_by":    0,
+			"delete_reason": "",
+		})
+	return result.RowsAffected, result.Error
+}
+
+// Purge 彻底删除已软删除的记录，未进入回收站的记录不会被删除
+func Purge[T any](ids []uint64) (int64, error) {
+	result := database.DB.Unscoped().
+		Where("id IN ? AND deleted_at IS NOT NULL", ids).
+		Delete(new(T))
+	return result.RowsAffected, result.Error
+}
+
+// TrashedBefore 删除时间早于 before 的记录 ID，用于定期清理回收站
+func TrashedBefore[T any](before time.Time, limit int) (ids []uint64, err error) {
+	err = database.DB.Unscoped().Model(new(T)).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return
+}
+
+// PaginateTrashed 回收站分页，按删除时间倒序
+func PaginateTrashed[T any](c *gin.Context, baseURL string, perPage int) (data []T, paging paginator.Paging) {
+	query := database.DB.Unscoped().Model(new(T)).
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC")
+	paging = paginator.Paginate(c, query, &data, baseURL, perPage)
+	return
+}
@@ -0,0 +1,117 @@
+// Package spam 垃圾信息检测模型
+package spam
+
+import (
+	"GoHub-Service/app/models"
+)
+
+// 内容类型
+const (
+	ContentTopic   = "topic"
+	ContentComment = "comment"
+)
+
+// 拦截原因
+const (
+	ReasonVelocity = "velocity" // 发布频率超过限制
+	ReasonSpam     = "spam"     // 垃圾信息分数过高
+)
+
+// 处理结果
+const (
+	ActionBlocked = "blocked" // 拒绝发布
+	ActionReview  = "review"  // 已发布，进入人工审核
+)
+
+// Fingerprint 话题和评论的 SimHash 指纹，按 16 位分段建索引用于查找近似内容
+type Fingerprint struct {
+	models.BaseModel
+
+	ContentType string `gorm:"type:varchar(20);uniqueIndex:uidx_spam_fingerprint_content;index:idx_spam_fingerprint_user;not null;comment:内容类型" json:"content_type"`
+	ContentID   uint64 `gorm:"uniqueIndex:uidx_spam_fingerprint_content;not null;comment:内容ID" json:"content_id"`
+	UserID      uint64 `gorm:"index:idx_spam_fingerprint_user;not null;comment:作者ID" json:"user_id"`
+	SimHash     uint64 `gorm:"column:simhash;type:bigint unsigned;not null;comment:SimHash 指纹" json:"simhash"`
+	Band0       uint16 `gorm:"index;not null;comment:指纹第 1 段" json:"-"`
+	Band1       uint16 `gorm:"index;not null;comment:指纹第 2 段" json:"-"`
+	Band2       uint16 `gorm:"index;not null;comment:指纹第 3 段" json:"-"`
+	Band3       uint16 `gorm:"index;not null;comment:指纹第 4 段" json:"-"`
+	Length      int    `gorm:"not null;default:0;comment:去掉空白和标点后的字数" json:"length"`
+
+	models.CommonTimestampsField
+}
+
+// TableName 指定表名
+func (Fingerprint) TableName() string {
+	return "spam_fingerprints"
+}
+
+// Attempt 被拦截或送审的发布记录
+type Attempt struct {
+	models.BaseModel
+
+	UserID      uint64  `gorm:"index;not null;comment:作者ID" json:"user_id"`
+	ContentType string  `gorm:"type:varchar(20);index;not null;comment:内容类型" json:"content_type"`
+	ContentID   uint64  `gorm:"default:0;comment:内容ID，拒绝发布时为 0" json:"content_id"`
+	Action      string  `gorm:"type:varchar(20);index;not null;comment:处理结果 blocked/review" json:"action"`
+	Reason      string  `gorm:"type:varchar(20);index;not null;comment:拦截原因 velocity/spam" json:"reason"`
+	Score       float64 `gorm:"not null;default:0;comment:垃圾信息分数" json:"score"`
+	Detail      string  `gorm:"type:varchar(500);comment:判定依据" json:"detail,omitempty"`
+	Excerpt     string  `gorm:"type:varchar(200);comment:内容摘要" json:"excerpt,omitempty"`
+
+	models.CommonTimestampsField
+}
+
+// TableName 指定表名
+func (Attempt) TableName() string {
+	return "spam_attempts"
+}
+
+// Settings 管理员调整的检测阈值，只有一行，没有记录时使用 spam 配置中的默认值
+// 时间窗口内的发布数量限制按账号注册天数分为新用户和普通用户两档，0 表示不限制
+type Settings struct {
+	models.BaseModel
+
+	Enabled               bool    `gorm:"not null;comment:是否开启" json:"enabled"`
+	NewAccountDays        int     `gorm:"not null;comment:注册不满多少天视为新用户" json:"new_account_days"`
+	VelocityWindowMinutes int     `gorm:"not null;comment:发布频率统计窗口（分钟）" json:"velocity_window_minutes"`
+	NewUserMaxTopics      int     `gorm:"not null;comment:新用户窗口内最多发布话题数" json:"new_user_max_topics"`
+	NewUserMaxComments    int     `gorm:"not null;comment:新用户窗口内最多发布评论数" json:"new_user_max_comments"`
+	MaxTopics             int     `gorm:"not null;comment:普通用户窗口内最多发布话题数" json:"max_topics"`
+	MaxComments           int     `gorm:"not null;comment:普通用户窗口内最多发布评论数" json:"max_comments"`
+	DuplicateDistance     int     `gorm:"not null;comment:汉明距离不超过该值视为近似内容" json:"duplicate_distance"`
+	DuplicateWindowHours  int     `gorm:"not null;comment:近似内容查找范围（小时）" json:"duplicate_window_hours"`
+	DuplicateMinLength    int     `gorm:"not null;comment:少于该字数的内容不做近似判断" json:"duplicate_min_length"`
+	MaxLinks              int     `gorm:"not null;comment:链接数上限" json:"max_links"`
+	MaxLinkDensity        float64 `gorm:"not null;comment:链接字符占比上限" json:"max_link_density"`
+	ReviewScore           float64 `gorm:"not null;comment:进入人工审核的分数" json:"review_score"`
+	RejectScore           float64 `gorm:"not null;comment:拒绝发布的分数" json:"reject_score"`
+	UpdatedBy             uint64  `gorm:"default:0;comment:最后修改人" json:"updated_by"`
+
+	models.CommonTimestampsField
+}
+
+// TableName 指定表名
+func (Settings) TableName() string {
+	return "spam_settings"
+}
+
+// MaxPosts 指定内容类型在统计窗口内允许发布的数量
+func (s Settings) MaxPosts(contentType string, newAccount bool) int {
+	switch {
+	case contentType == ContentTopic && newAccount:
+		return s.NewUserMaxTopics
+	case contentType == ContentTopic:
+		return s.MaxTopics
+	case newAccount:
+		return s.NewUserMaxComments
+	}
+	return s.MaxComments
+}
+
+// AttemptStat 按日期、处理结果和原因汇总的拦截次数
+type AttemptStat struct {
+	Date   string `json:"date"`
+	Action string `json:"action"`
+	Reason string `json:"reason"`
+	Count  int64  `json:"count"`
+}
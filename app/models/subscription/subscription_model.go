@@ -0,0 +1,33 @@
+// Package subscription 用户关注的标签和分类
+package subscription
+
+import (
+	"GoHub-Service/app/models"
+)
+
+// 关注对象类型
+const (
+	TargetTag      = "tag"
+	TargetCategory = "category"
+)
+
+// Subscription 用户关注了标签或分类，相关的新话题会出现在首页信息流中
+type Subscription struct {
+	models.BaseModel
+
+	UserID     uint64 `gorm:"uniqueIndex:uidx_subscription;not null;comment:用户ID" json:"user_id"`
+	TargetType string `gorm:"type:varchar(16);uniqueIndex:uidx_subscription;index:idx_subscription_target;not null;comment:关注对象类型 tag/category" json:"target_type"`
+	TargetID   uint64 `gorm:"uniqueIndex:uidx_subscription;index:idx_subscription_target;not null;comment:关注对象ID" json:"target_id"`
+
+	models.CommonTimestampsField
+}
+
+// TableName 指定表名
+func (Subscription) TableName() string {
+	return "subscriptions"
+}
+
+// ValidTarget 是否为支持的关注对象类型
+func ValidTarget(targetType string) bool {
+	return targetType == TargetTag || targetType == TargetCategory
+}
@@ -0,0 +1,31 @@
+// Package tag 标签模型
+package tag
+
+import (
+	"GoHub-Service/app/models"
+	"GoHub-Service/pkg/database"
+)
+
+// Tag 话题标签
+type Tag struct {
+	models.BaseModel
+
+	Name        string `gorm:"type:varchar(50);uniqueIndex;not null" json:"name,omitempty"`
+	TopicsCount int64  `gorm:"type:int;default:0;comment:话题数" json:"topics_count,omitempty"`
+
+	models.CommonTimestampsField
+}
+
+func (tag *Tag) Create() {
+	database.DB.Create(&tag)
+}
+
+func (tag *Tag) Save() (rowsAffected int64) {
+	result := database.DB.Save(&tag)
+	return result.RowsAffected
+}
+
+func (tag *Tag) Delete() (rowsAffected int64) {
+	result := database.DB.Delete(&tag)
+	return result.RowsAffected
+}
@@ -0,0 +1,57 @@
+package tag
+
+import (
+	"strings"
+
+	"GoHub-Service/pkg/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func Get(idstr string) (tag Tag) {
+	database.DB.Where("id", idstr).First(&tag)
+	return
+}
+
+func GetByName(name string) (tag Tag) {
+	database.DB.Where("name = ?", name).First(&tag)
+	return
+}
+
+// NormalizeNames 去除首尾空白、转小写并去重，保持原有顺序
+func NormalizeNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	return result
+}
+
+// FirstOrCreateByNames 按名称获取标签，不存在的自动创建
+func FirstOrCreateByNames(tx *gorm.DB, names []string) ([]Tag, error) {
+	names = NormalizeNames(names)
+	if len(names) == 0 {
+		return []Tag{}, nil
+	}
+
+	tags := make([]Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, Tag{Name: name})
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	var result []Tag
+	if err := tx.Where("name IN ?", names).Find(&result).Error; err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"GoHub-Service/app/models"
 	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/tag"
 	"GoHub-Service/app/models/user"
 	"GoHub-Service/pkg/database"
 
@@ -29,8 +30,34 @@ type Topic struct {
 	PinnedBy uint64     `gorm:"comment:置顶操作员ID" json:"pinned_by,omitempty"`
 
 	// 审核相关字段
-	Status       int    `gorm:"type:int;default:1;index;comment:状态:0待审核,1已通过,-1已拒绝" json:"status,omitempty"`
-	RejectReason string `gorm:"type:varchar(500);comment:拒绝原因" json:"reject_reason,omitempty"`
+	Status        int        `gorm:"type:int;index;comment:状态:0待审核,1已通过,-1已拒绝" json:"status,omitempty"`
+	RejectReason  string     `gorm:"type:varchar(500);comment:拒绝原因" json:"reject_reason,omitempty"`
+	PendingReason string     `gorm:"type:varchar(255);comment:进入审核队列的原因" json:"pending_reason,omitempty"`
+	ClaimedBy     uint64     `gorm:"index;default:0;comment:认领审核的版主ID" json:"claimed_by,omitempty"`
+	ClaimedAt     *time.Time `gorm:"comment:认领时间" json:"claimed_at,omitempty"`
+	ReviewedBy    uint64     `gorm:"comment:审核人ID" json:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time `gorm:"comment:审核时间" json:"reviewed_at,omitempty"`
+	// 首次审核通过的时间，撤回后重新通过不会再次推送信息流和发送通知
+	ApprovedAt *time.Time `gorm:"comment:首次审核通过时间" json:"approved_at,omitempty"`
+
+	// 锁定、关闭、归档：任一状态下都不能再评论
+	IsLocked    bool       `gorm:"type:boolean;default:false;comment:是否锁定" json:"is_locked,omitempty"`
+	LockedAt    *time.Time `gorm:"comment:锁定时间" json:"locked_at,omitempty"`
+	LockedBy    uint64     `gorm:"comment:锁定操作人ID" json:"locked_by,omitempty"`
+	ClosedAt    *time.Time `gorm:"index;comment:关闭时间" json:"closed_at,omitempty"`
+	ClosedBy    uint64     `gorm:"comment:关闭操作人ID" json:"closed_by,omitempty"`
+	CloseReason string     `gorm:"type:varchar(500);comment:关闭原因" json:"close_reason,omitempty"`
+	ArchivedAt  *time.Time `gorm:"index;comment:归档时间" json:"archived_at,omitempty"`
+
+	// 被合并到的目标话题，合并后原话题进入回收站，访问时跳转到目标话题
+	MergedIntoID uint64 `gorm:"index;default:0;comment:合并到的话题ID" json:"merged_into_id,omitempty"`
+
+	// 问答分类中被采纳的回答，不为 0 时话题视为已解决
+	AcceptedCommentID uint64     `gorm:"index;default:0;comment:采纳的回答ID" json:"accepted_comment_id,omitempty"`
+	SolvedAt          *time.Time `gorm:"comment:采纳回答的时间" json:"solved_at,omitempty"`
+
+	// 最后回复时间，用于按最新回复排序，创建时与 created_at 相同
+	LastRepliedAt *time.Time `gorm:"index;comment:最后回复时间" json:"last_replied_at,omitempty"`
 
 	// 通过 user_id 关联用户
 	User user.User `json:"user"`
@@ -38,7 +65,55 @@ type Topic struct {
 	// 通过 category_id 关联分类
 	Category category.Category `json:"category"`
 
+	// 通过 topic_tags 关联标签
+	Tags []tag.Tag `gorm:"many2many:topic_tags;" json:"tags,omitempty"`
+
 	models.CommonTimestampsField
+	models.SoftDeletes
+}
+
+// 审核状态
+const (
+	StatusRejected = -1
+	StatusPending  = 0
+	StatusApproved = 1
+)
+
+// IsApproved 是否已审核通过，未通过的话题只有作者本人可见
+func (topic *Topic) IsApproved() bool {
+	return topic.Status == StatusApproved
+}
+
+// 话题讨论状态，优先级：归档 > 关闭 > 锁定
+const (
+	StateOpen     = "open"
+	StateLocked   = "locked"
+	StateClosed   = "closed"
+	StateArchived = "archived"
+)
+
+// State 返回话题当前的讨论状态
+func (topic *Topic) State() string {
+	switch {
+	case topic.ArchivedAt != nil:
+		return StateArchived
+	case topic.ClosedAt != nil:
+		return StateClosed
+	case topic.IsLocked:
+		return StateLocked
+	default:
+		return StateOpen
+	}
+}
+
+// IsSolved 问答话题是否已采纳回答
+func (topic *Topic) IsSolved() bool {
+	return topic.AcceptedCommentID > 0
+}
+
+// AcceptsComments 是否允许发表新评论
+func (topic *Topic) AcceptsComments() bool {
+	return topic.State() == StateOpen
 }
 
 func (topic *Topic) Create() {
@@ -1,6 +1,14 @@
 package topic
 
 import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/tag"
 	"GoHub-Service/pkg/app"
 	"GoHub-Service/pkg/database"
 	"GoHub-Service/pkg/paginator"
@@ -9,15 +17,201 @@ import (
 	"gorm.io/gorm"
 )
 
+// 列表查询的字段，详情和列表保持一致
+var listColumns = []string{
+	"id", "title", "body", "user_id", "category_id", "like_count", "favorite_count", "view_count",
+	"is_pinned", "pinned_at", "status", "reject_reason", "pending_reason", "claimed_by", "claimed_at",
+	"reviewed_by", "reviewed_at", "approved_at", "is_locked", "locked_at", "locked_by", "closed_at", "closed_by", "close_reason", "archived_at",
+	"merged_into_id", "accepted_comment_id", "solved_at", "last_replied_at", "created_at", "updated_at",
+}
+
+// 列表排序方式
+const (
+	SortLatestReply = "latest_reply" // 最新回复
+	SortCreated     = "created"      // 最新发布
+	SortLikes       = "likes"        // 点赞最多
+	SortViews       = "views"        // 浏览最多
+)
+
+// ListFilter 话题列表筛选与排序条件
+type ListFilter struct {
+	CategoryID  string
+	Subtree     bool // 按分类筛选时包含所有子分类
+	UserID      string
+	Tag         string
+	Status      string
+	Solved      string // 1: 已采纳回答 0: 问答分类中尚未采纳回答
+	StartDate   string // 格式 2006-01-02，包含当天
+	EndDate     string // 格式 2006-01-02，包含当天
+	SortBy      string
+	PinnedFirst bool
+
+	HiddenCategoryIDs []uint64 // 对当前用户不可见的分类，需升序以保证缓存 key 稳定
+}
+
+// Values 返回规范化后的查询参数，空值不输出，排序方式补全默认值
+// 用于拼接分页链接和生成列表缓存 key，相同条件总是得到相同结果
+func (f ListFilter) Values() url.Values {
+	values := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	set("category_id", f.CategoryID)
+	if f.Subtree && f.CategoryID != "" {
+		values.Set("subtree", "1")
+	}
+	set("user_id", f.UserID)
+	set("tag", f.Tag)
+	set("status", f.Status)
+	set("solved", f.Solved)
+	set("start_date", f.StartDate)
+	set("end_date", f.EndDate)
+	set("sort_by", f.sortBy())
+	if f.PinnedFirst {
+		values.Set("pinned_first", "1")
+	} else {
+		values.Set("pinned_first", "0")
+	}
+	return values
+}
+
+// HiddenKey 不可见分类拼接成的字符串，与 Values 一起生成列表缓存 key
+// 不放入 Values，避免出现在分页链接中
+func (f ListFilter) HiddenKey() string {
+	hidden := make([]string, 0, len(f.HiddenCategoryIDs))
+	for _, id := range f.HiddenCategoryIDs {
+		hidden = append(hidden, strconv.FormatUint(id, 10))
+	}
+	return strings.Join(hidden, ",")
+}
+
+func (f ListFilter) sortBy() string {
+	switch f.SortBy {
+	case SortLatestReply, SortCreated, SortLikes, SortViews:
+		return f.SortBy
+	default:
+		return SortLatestReply
+	}
+}
+
+// Apply 将筛选和排序条件应用到查询上
+func (f ListFilter) Apply(db *gorm.DB) *gorm.DB {
+	db = f.Where(db)
+	for _, col := range f.SortColumns() {
+		if col.Desc {
+			db = db.Order(col.Name + " DESC")
+		} else {
+			db = db.Order(col.Name + " ASC")
+		}
+	}
+	return db
+}
+
+// Where 只应用筛选条件
+func (f ListFilter) Where(db *gorm.DB) *gorm.DB {
+	if f.CategoryID != "" && f.Subtree {
+		subtree := database.DB.Table("categories AS c").
+			Select("c.id").
+			Joins("JOIN categories AS root ON c.path LIKE CONCAT(root.path, '%')").
+			Where("root.id = ?", f.CategoryID)
+		db = db.Where("category_id IN (?)", subtree)
+	} else if f.CategoryID != "" {
+		db = db.Where("category_id = ?", f.CategoryID)
+	}
+	if len(f.HiddenCategoryIDs) > 0 {
+		db = db.Where("category_id NOT IN ?", f.HiddenCategoryIDs)
+	}
+	if f.UserID != "" {
+		db = db.Where("user_id = ?", f.UserID)
+	}
+	if f.Status != "" {
+		db = db.Where("status = ?", f.Status)
+	}
+	switch f.Solved {
+	case "1":
+		db = db.Where("accepted_comment_id > 0")
+	case "0":
+		questions := database.DB.Table("categories").Select("id").Where("is_qa = ?", true)
+		db = db.Where("accepted_comment_id = 0 AND category_id IN (?)", questions)
+	}
+	if f.Tag != "" {
+		tagged := database.DB.Table("topic_tags").
+			Select("topic_tags.topic_id").
+			Joins("JOIN tags ON tags.id = topic_tags.tag_id").
+			Where("tags.name = ?", f.Tag)
+		db = db.Where("id IN (?)", tagged)
+	}
+	if start, ok := parseDate(f.StartDate); ok {
+		db = db.Where("created_at >= ?", start)
+	}
+	if end, ok := parseDate(f.EndDate); ok {
+		db = db.Where("created_at < ?", end.AddDate(0, 0, 1))
+	}
+	return db
+}
+
+// SortColumns 返回排序字段，最后以 id 兜底保证顺序稳定，游标分页也依赖于此
+func (f ListFilter) SortColumns() []paginator.SortColumn {
+	var columns []paginator.SortColumn
+	if f.PinnedFirst {
+		columns = append(columns, paginator.SortColumn{Name: "is_pinned", Desc: true})
+	}
+	switch f.sortBy() {
+	case SortCreated:
+		columns = append(columns, paginator.SortColumn{Name: "created_at", Desc: true})
+	case SortLikes:
+		columns = append(columns, paginator.SortColumn{Name: "like_count", Desc: true})
+	case SortViews:
+		columns = append(columns, paginator.SortColumn{Name: "view_count", Desc: true})
+	default:
+		columns = append(columns, paginator.SortColumn{Name: "last_replied_at", Desc: true})
+	}
+	return append(columns, paginator.SortColumn{Name: "id", Desc: true})
+}
+
+// sortValues 按排序字段返回话题的排序值，用于生成游标
+func (topic *Topic) sortValues(columns []paginator.SortColumn) []interface{} {
+	values := make([]interface{}, 0, len(columns))
+	for _, col := range columns {
+		switch col.Name {
+		case "is_pinned":
+			values = append(values, topic.IsPinned)
+		case "created_at":
+			values = append(values, topic.CreatedAt)
+		case "like_count":
+			values = append(values, topic.LikeCount)
+		case "view_count":
+			values = append(values, topic.ViewCount)
+		case "last_replied_at":
+			values = append(values, topic.LastRepliedAt)
+		default:
+			values = append(values, topic.ID)
+		}
+	}
+	return values
+}
+
+// parseDate 解析 2006-01-02 或 2006/01/02 格式的日期
+func parseDate(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation("2006-01-02", strings.ReplaceAll(value, "/", "-"), time.Local)
+	return t, err == nil
+}
+
 func Get(idstr string) (topic Topic) {
 	database.DB.
-		Select("id", "title", "body", "user_id", "category_id", "like_count", "favorite_count", "view_count", "created_at", "updated_at").
+		Select(listColumns).
 		Preload("User", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "name", "email", "avatar")
 		}).
 		Preload("Category", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "name", "description")
 		}).
+		Preload("Tags").
 		Where("id", idstr).First(&topic)
 	return
 }
@@ -38,27 +232,264 @@ func IsExist(field, value string) bool {
 	return count > 0
 }
 
-func Paginate(c *gin.Context, perPage int) (topics []Topic, paging paginator.Paging) {
+// Paginate 按筛选条件分页获取话题，分页链接会带上筛选参数
+func Paginate(c *gin.Context, filter ListFilter, perPage int) (topics []Topic, paging paginator.Paging) {
 	query := database.DB.Model(Topic{}).
-		Select("id", "title", "body", "user_id", "category_id", "like_count", "favorite_count", "view_count", "created_at", "updated_at").
+		Select(listColumns).
 		Preload("User", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "name", "email", "avatar")
 		}).
 		Preload("Category", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "name", "description")
-		}).
-		Order("created_at DESC")
+		})
+	query = filter.Apply(query)
 
 	paging = paginator.Paginate(
 		c,
 		query,
 		&topics,
-		app.V1URL(database.TableName(&Topic{})),
+		app.V1URL(database.TableName(&Topic{}))+"?"+filter.Values().Encode(),
 		perPage,
 	)
 	return
 }
 
+// CursorPaginate 按筛选条件游标分页获取话题
+func CursorPaginate(c *gin.Context, filter ListFilter, perPage int) ([]Topic, paginator.CursorPaging, error) {
+	query := database.DB.Model(Topic{}).
+		Select(listColumns).
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "name", "email", "avatar")
+		}).
+		Preload("Category", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "name", "description")
+		}).
+		Preload("Tags")
+	query = filter.Where(query)
+
+	columns := filter.SortColumns()
+	return paginator.CursorPaginate(c, query, paginator.CursorOptions{
+		Columns: columns,
+		BaseURL: app.V1URL(database.TableName(&Topic{})) + "?" + filter.Values().Encode(),
+		PerPage: perPage,
+	}, func(t *Topic) []interface{} {
+		return t.sortValues(columns)
+	})
+}
+
+// 审核队列范围
+const (
+	QueueAll       = ""          // 全部待审核
+	QueueMine      = "mine"      // 我认领的
+	QueueUnclaimed = "unclaimed" // 未认领或认领已过期
+)
+
+// PaginatePending 分页获取待审核话题，按提交时间先后排列
+// 认领时间早于 staleBefore 的视为未认领，baseURL 为请求的队列地址，后台和版主路由各不相同
+func PaginatePending(c *gin.Context, baseURL, scope, moderatorID string, staleBefore time.Time, perPage int) (topics []Topic, paging paginator.Paging) {
+	query := database.DB.Model(Topic{}).
+		Select(listColumns).
+		Where("status = ?", StatusPending).
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "name", "avatar", "points", "created_at")
+		}).
+		Preload("Category", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "name")
+		})
+
+	// 被指派了分类的版主只看到自己管理的分类
+	if ids, scoped := category.ModeratorScope(moderatorID); scoped {
+		query = query.Where("category_id IN ?", ids)
+	}
+
+	switch scope {
+	case QueueMine:
+		query = query.Where("claimed_by = ?", moderatorID)
+	case QueueUnclaimed:
+		query = query.Where("claimed_by = 0 OR claimed_at < ?", staleBefore)
+	}
+
+	if scope != QueueAll {
+		baseURL += "?scope=" + scope
+	}
+	paging = paginator.Paginate(c, query, &topics, baseURL, perPage)
+	return
+}
+
+// Claim 版主认领待审核话题，已被他人认领且未过期时返回 false
+func Claim(id string, moderatorID uint64, staleBefore time.Time) (bool, error) {
+	result := database.DB.Model(&Topic{}).
+		Where("id = ? AND status = ?", id, StatusPending).
+		Where("claimed_by = 0 OR claimed_by = ? OR claimed_at < ?", moderatorID, staleBefore).
+		Updates(map[string]interface{}{"claimed_by": moderatorID, "claimed_at": time.Now()})
+	return result.RowsAffected > 0, result.Error
+}
+
+// CountApprovedByUser 统计用户已审核通过的话题数
+func CountApprovedByUser(userID string) (count int64) {
+	database.DB.Model(&Topic{}).Where("user_id = ? AND status = ?", userID, StatusApproved).Count(&count)
+	return
+}
+
+// 相关话题打分权重：共同标签 > 共同点赞用户 > 同一分类
+const (
+	relatedTagWeight      = 3
+	relatedCoLikeWeight   = 2
+	relatedCategoryWeight = 1
+	relatedCandidateLimit = 50 // 每种来源最多取的候选数
+)
+
+// relatedScore 候选话题及其得分
+type relatedScore struct {
+	TopicID uint64
+	Score   int
+}
+
+// RelatedIDs 根据共同标签、共同点赞用户和同一分类为话题打分，返回得分最高的 limit 个话题 ID
+// 候选只包含已审核通过且未删除的话题，不包含话题本身
+func RelatedIDs(t *Topic, limit int) ([]uint64, error) {
+	scores := make(map[uint64]int)
+
+	var byTag []relatedScore
+	if err := database.DB.Raw(`SELECT tt2.topic_id AS topic_id, COUNT(*) AS score
+		FROM topic_tags tt1 JOIN topic_tags tt2 ON tt2.tag_id = tt1.tag_id AND tt2.topic_id <> tt1.topic_id
+		WHERE tt1.topic_id = ? GROUP BY tt2.topic_id ORDER BY score DESC LIMIT ?`,
+		t.ID, relatedCandidateLimit).Scan(&byTag).Error; err != nil {
+		return nil, err
+	}
+	for _, s := range byTag {
+		scores[s.TopicID] += s.Score * relatedTagWeight
+	}
+
+	var byLike []relatedScore
+	if err := database.DB.Raw(`SELECT l2.topic_id AS topic_id, COUNT(*) AS score
+		FROM topic_likes l1 JOIN topic_likes l2 ON l2.user_id = l1.user_id AND l2.topic_id <> l1.topic_id
+		WHERE l1.topic_id = ? GROUP BY l2.topic_id ORDER BY score DESC LIMIT ?`,
+		t.ID, relatedCandidateLimit).Scan(&byLike).Error; err != nil {
+		return nil, err
+	}
+	for _, s := range byLike {
+		scores[s.TopicID] += s.Score * relatedCoLikeWeight
+	}
+
+	var sameCategory []uint64
+	if err := database.DB.Model(&Topic{}).
+		Where("category_id = ? AND id <> ? AND status = ?", t.CategoryID, t.ID, StatusApproved).
+		Order("like_count DESC, id DESC").
+		Limit(relatedCandidateLimit).
+		Pluck("id", &sameCategory).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range sameCategory {
+		scores[id] += relatedCategoryWeight
+	}
+	if len(scores) == 0 {
+		return nil, nil
+	}
+
+	// 过滤未审核、已删除的候选
+	candidates := make([]uint64, 0, len(scores))
+	for id := range scores {
+		candidates = append(candidates, id)
+	}
+	var visible []uint64
+	if err := database.DB.Model(&Topic{}).
+		Where("id IN ? AND status = ?", candidates, StatusApproved).
+		Pluck("id", &visible).Error; err != nil {
+		return nil, err
+	}
+
+	sort.Slice(visible, func(i, j int) bool {
+		if scores[visible[i]] != scores[visible[j]] {
+			return scores[visible[i]] > scores[visible[j]]
+		}
+		return visible[i] > visible[j]
+	})
+	if len(visible) > limit {
+		visible = visible[:limit]
+	}
+	return visible, nil
+}
+
+// GetApprovedByIDs 按给定 ID 的顺序获取已审核通过的话题，不存在或不可见的 ID 会被跳过
+func GetApprovedByIDs(ids []uint64) ([]Topic, error) {
+	if len(ids) == 0 {
+		return []Topic{}, nil
+	}
+	var topics []Topic
+	if err := database.DB.Model(Topic{}).
+		Select(listColumns).
+		Where("id IN ? AND status = ?", ids, StatusApproved).
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "name", "avatar")
+		}).
+		Preload("Category", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "name")
+		}).
+		Preload("Tags").
+		Find(&topics).Error; err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint64]Topic, len(topics))
+	for _, t := range topics {
+		byID[t.ID] = t
+	}
+	ordered := make([]Topic, 0, len(topics))
+	for _, id := range ids {
+		if t, ok := byID[id]; ok {
+			ordered = append(ordered, t)
+		}
+	}
+	return ordered, nil
+}
+
+// SyncTags 将话题的标签替换为给定名称的标签，并刷新相关标签的话题数
+func SyncTags(tx *gorm.DB, t *Topic, names []string) error {
+	tags, err := tag.FirstOrCreateByNames(tx, names)
+	if err != nil {
+		return err
+	}
+
+	// 记录变更前的标签，用于刷新计数
+	var oldTagIDs []uint64
+	if err := tx.Table("topic_tags").Where("topic_id = ?", t.ID).Pluck("tag_id", &oldTagIDs).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(t).Association("Tags").Replace(tags); err != nil {
+		return err
+	}
+
+	affected := oldTagIDs
+	for _, tg := range tags {
+		affected = append(affected, tg.ID)
+	}
+	if len(affected) == 0 {
+		return nil
+	}
+	return tx.Model(&tag.Tag{}).
+		Where("id IN ?", affected).
+		UpdateColumn("topics_count", gorm.Expr("(SELECT COUNT(*) FROM topic_tags WHERE topic_tags.tag_id = tags.id)")).Error
+}
+
+// ArchiveInactive 归档 before 之后再无回复的话题（置顶话题除外），返回被归档的话题 ID
+func ArchiveInactive(before time.Time) ([]uint64, error) {
+	var ids []uint64
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Topic{}).
+			Where("archived_at IS NULL AND is_pinned = ? AND last_replied_at < ?", false, before).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return tx.Model(&Topic{}).Where("id IN ?", ids).UpdateColumn("archived_at", time.Now()).Error
+	})
+	return ids, err
+}
+
 // BatchCreate 批量创建话题（使用事务和批量插入优化）
 func BatchCreate(topics []Topic) error {
 	if len(topics) == 0 {
@@ -0,0 +1,87 @@
+package topic
+
+import (
+	"time"
+
+	"GoHub-Service/pkg/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DailyView 话题每日浏览量，供作者查看浏览趋势
+type DailyView struct {
+	TopicID uint64 `gorm:"primaryKey;autoIncrement:false" json:"topic_id"`
+	Date    string `gorm:"primaryKey;type:date" json:"date"` // 格式 2006-01-02
+	Views   int64  `gorm:"not null;default:0" json:"views"`
+}
+
+// TableName 每日浏览量表名
+func (DailyView) TableName() string {
+	return "topic_daily_views"
+}
+
+// ViewFlush 已写库的浏览量批次，同一批次重试时不再重复累加
+type ViewFlush struct {
+	BatchID   string    `gorm:"primaryKey;type:varchar(32)" json:"batch_id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// TableName 浏览量批次表名
+func (ViewFlush) TableName() string {
+	return "topic_view_flushes"
+}
+
+// viewFlushRetention 批次记录保留的时间，远超过缓冲区重试的间隔
+const viewFlushRetention = 7 * 24 * time.Hour
+
+// ViewDelta 一个话题在某一天新增的浏览量
+type ViewDelta struct {
+	TopicID uint64
+	Date    string
+	Views   int64
+}
+
+// AddViews 批量累加话题浏览量和每日浏览量
+// batchID 与计数在同一事务中记录，写库成功但缓冲区未能清除时，下一轮重试同一批次不会重复累加
+func AddViews(batchID string, deltas []ViewDelta) error {
+	if len(deltas) == 0 {
+		return nil
+	}
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&ViewFlush{BatchID: batchID, CreatedAt: time.Now()})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if err := tx.Where("created_at < ?", time.Now().Add(-viewFlushRetention)).Delete(&ViewFlush{}).Error; err != nil {
+			return err
+		}
+
+		totals := make(map[uint64]int64, len(deltas))
+		for _, d := range deltas {
+			totals[d.TopicID] += d.Views
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "topic_id"}, {Name: "date"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{"views": gorm.Expr("views + ?", d.Views)}),
+			}).Create(&DailyView{TopicID: d.TopicID, Date: d.Date, Views: d.Views}).Error; err != nil {
+				return err
+			}
+		}
+		for topicID, views := range totals {
+			if err := tx.Model(&Topic{}).Where("id = ?", topicID).
+				UpdateColumn("view_count", gorm.Expr("view_count + ?", views)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DailyViews 话题在 [from, to] 日期范围内的每日浏览量，按日期升序
+func DailyViews(topicID string, from, to time.Time) (views []DailyView, err error) {
+	err = database.DB.Where("topic_id = ? AND date BETWEEN ? AND ?",
+		topicID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("date ASC").
+		Find(&views).Error
+	return
+}
@@ -19,6 +19,7 @@ type User struct {
 	Introduction   string `json:"introduction,omitempty"`
 	Avatar         string `json:"avatar,omitempty"`
 	FollowersCount int64  `json:"followers_count,omitempty"`
+	FollowingCount int64  `json:"following_count,omitempty"`
 	Points         int64  `json:"points,omitempty"`
 
 	Email    string `gorm:"uniqueIndex" json:"-"`
@@ -33,6 +34,7 @@ type User struct {
 	BanUntil  *time.Time `gorm:"comment:封禁截止时间" json:"ban_until,omitempty"`
 
 	models.CommonTimestampsField
+	models.SoftDeletes
 }
 
 // Create 创建用户，通过 User.ID 来判断是否创建成功
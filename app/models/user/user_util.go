@@ -1,6 +1,8 @@
 package user
 
 import (
+    "strings"
+
     "GoHub-Service/pkg/app"
     "GoHub-Service/pkg/database"
     "GoHub-Service/pkg/paginator"
@@ -67,3 +69,27 @@ func Paginate(c *gin.Context, perPage int) (users []User, paging paginator.Pagin
     )
     return
 }
+
+// GetByNamesFold 按用户名批量获取用户，不区分大小写
+// 依赖 users.name 列 utf8mb4 默认排序规则不区分大小写，直接比较以便使用 name 索引
+func GetByNamesFold(names []string) (users []User, err error) {
+    if len(names) == 0 {
+        return
+    }
+    err = database.DB.Where("name IN ?", names).Find(&users).Error
+    return
+}
+
+// SearchByNamePrefix 按用户名前缀查找未封禁的用户，粉丝多的排在前面
+func SearchByNamePrefix(prefix string, limit int) (users []User, err error) {
+    replacer := strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_")
+    err = database.DB.
+        Select("id", "name", "avatar", "followers_count").
+        Where("LOWER(name) LIKE ?", replacer.Replace(strings.ToLower(prefix))+"%").
+        Where("is_banned = ?", false).
+        Order("followers_count DESC").
+        Order("name ASC").
+        Limit(limit).
+        Find(&users).Error
+    return
+}
@@ -0,0 +1,63 @@
+// Package repositories 用户屏蔽数据访问层
+package repositories
+
+import (
+	"context"
+
+	"GoHub-Service/app/models/block"
+	"GoHub-Service/pkg/database"
+)
+
+// BlockRepository 用户屏蔽仓储接口
+type BlockRepository interface {
+	Block(ctx context.Context, userID, blockedID uint64) (bool, error)
+	Unblock(ctx context.Context, userID, blockedID uint64) (bool, error)
+	// BlockersOf 在 userIDs 中找出屏蔽了 blockedID 的用户
+	BlockersOf(ctx context.Context, blockedID uint64, userIDs []uint64) ([]uint64, error)
+}
+
+// blockRepository 用户屏蔽仓储实现
+type blockRepository struct{}
+
+// NewBlockRepository 创建用户屏蔽仓储实例
+func NewBlockRepository() BlockRepository {
+	return &blockRepository{}
+}
+
+// Block 屏蔽用户，已屏蔽时返回 false
+func (r *blockRepository) Block(ctx context.Context, userID, blockedID uint64) (bool, error) {
+	var count int64
+	db := database.DB.WithContext(ctx)
+	if err := db.Model(&block.Block{}).
+		Where("user_id = ? AND blocked_id = ?", userID, blockedID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return false, nil
+	}
+	if err := db.Create(&block.Block{UserID: userID, BlockedID: blockedID}).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Unblock 取消屏蔽，未屏蔽时返回 false
+func (r *blockRepository) Unblock(ctx context.Context, userID, blockedID uint64) (bool, error) {
+	result := database.DB.WithContext(ctx).
+		Where("user_id = ? AND blocked_id = ?", userID, blockedID).
+		Delete(&block.Block{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// BlockersOf 在 userIDs 中找出屏蔽了 blockedID 的用户
+func (r *blockRepository) BlockersOf(ctx context.Context, blockedID uint64, userIDs []uint64) ([]uint64, error) {
+	var blockers []uint64
+	if len(userIDs) == 0 {
+		return blockers, nil
+	}
+	err := database.DB.WithContext(ctx).Model(&block.Block{}).
+		Where("blocked_id = ? AND user_id IN ?", blockedID, userIDs).
+		Pluck("user_id", &blockers).Error
+	return blockers, err
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // CategoryRepository 聚合了分类的 CRUD、批处理和简单缓存操作的接口定义.
@@ -31,6 +32,24 @@ type CategoryRepository interface {
 	GetAllCached() ([]category.Category, error)
 	SetListCache(categories []category.Category) error
 	FlushCache() error
+	// RecountTopics 重新统计分类话题数并清除对应缓存
+	RecountTopics(ids ...string) error
+
+	// All 全部分类，按排序顺序和 ID 排列
+	All() ([]category.Category, error)
+	// CountChildren 统计直接子分类数
+	CountChildren(id string) (int64, error)
+	// CreateWithPath 创建分类并写入包含自身 ID 的路径，parentPath 为上级分类路径，顶级分类为空
+	CreateWithPath(cat *category.Category, parentPath string) error
+	// MoveSubtree 保存分类的新上级和路径，并同步更新全部子分类的路径和层级
+	MoveSubtree(cat *category.Category, oldPath string, depthDelta int) error
+
+	// Moderators 分类版主列表
+	Moderators(categoryID string) ([]category.Moderator, error)
+	// AddModerator 指派分类版主，已指派时返回 false
+	AddModerator(m *category.Moderator) (bool, error)
+	// RemoveModerator 取消分类版主，未指派时返回 false
+	RemoveModerator(categoryID, userID string) (bool, error)
 }
 
 // categoryRepository 基于 GORM + Redis 的 Category 仓储实现.
@@ -160,6 +179,17 @@ func (r *categoryRepository) SetListCache(categories []category.Category) error
 	return nil
 }
 
+// RecountTopics 按话题表重新统计分类话题数，并清除分类详情与列表缓存.
+func (r *categoryRepository) RecountTopics(ids ...string) error {
+	if err := category.RecountTopics(ids...); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		_ = redis.Redis.Del(context.Background(), fmt.Sprintf(r.cacheKeyCategory, id))
+	}
+	return r.FlushCache()
+}
+
 // FlushCache 清空分类相关缓存键，粗粒度策略即可满足当前读多写少场景.
 func (r *categoryRepository) FlushCache() error {
 	_ = redis.Redis.Del(context.Background(), r.cacheKeyList)
@@ -167,3 +197,74 @@ func (r *categoryRepository) FlushCache() error {
 	_ = pattern // 简化实现
 	return nil
 }
+
+// All 全部分类
+func (r *categoryRepository) All() ([]category.Category, error) {
+	var categories []category.Category
+	err := database.DB.Order("sort_order ASC, id ASC").Find(&categories).Error
+	return categories, err
+}
+
+// CountChildren 统计直接子分类数
+func (r *categoryRepository) CountChildren(id string) (int64, error) {
+	var count int64
+	err := database.DB.Model(&category.Category{}).Where("parent_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// CreateWithPath 创建分类并写入路径（事务包裹），路径包含自身 ID，需创建后才能确定
+func (r *categoryRepository) CreateWithPath(cat *category.Category, parentPath string) error {
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(cat).Error; err != nil {
+			return err
+		}
+		cat.Path = category.PathOf(parentPath, cat.ID)
+		return tx.Model(cat).UpdateColumn("path", cat.Path).Error
+	})
+	if err != nil {
+		return apperrors.DatabaseError("创建分类", err)
+	}
+
+	// 清除列表缓存
+	_ = r.FlushCache()
+
+	return nil
+}
+
+// MoveSubtree 移动分类及其子分类（事务包裹），子分类路径中的旧前缀替换为新路径
+func (r *categoryRepository) MoveSubtree(cat *category.Category, oldPath string, depthDelta int) error {
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(cat).Error; err != nil {
+			return err
+		}
+		return tx.Model(&category.Category{}).
+			Where("path LIKE ? AND id <> ?", oldPath+"%", cat.ID).
+			Updates(map[string]interface{}{
+				"path":  gorm.Expr("CONCAT(?, SUBSTRING(path, ?))", cat.Path, len(oldPath)+1),
+				"depth": gorm.Expr("depth + ?", depthDelta),
+			}).Error
+	})
+	if err != nil {
+		return err
+	}
+	return r.FlushCache()
+}
+
+// Moderators 分类版主列表
+func (r *categoryRepository) Moderators(categoryID string) ([]category.Moderator, error) {
+	var moderators []category.Moderator
+	err := database.DB.Where("category_id = ?", categoryID).Order("id ASC").Find(&moderators).Error
+	return moderators, err
+}
+
+// AddModerator 指派分类版主
+func (r *categoryRepository) AddModerator(m *category.Moderator) (bool, error) {
+	result := database.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(m)
+	return result.RowsAffected > 0, result.Error
+}
+
+// RemoveModerator 取消分类版主
+func (r *categoryRepository) RemoveModerator(categoryID, userID string) (bool, error) {
+	result := database.DB.Where("category_id = ? AND user_id = ?", categoryID, userID).Delete(&category.Moderator{})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,214 @@
+// Package repositories 收藏夹数据访问层
+package repositories
+
+import (
+	"context"
+
+	"GoHub-Service/app/models/collection"
+	"GoHub-Service/pkg/database"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CollectionRepository 收藏夹仓储接口
+type CollectionRepository interface {
+	Create(ctx context.Context, c *collection.Collection) error
+	GetByID(ctx context.Context, id string) (*collection.Collection, error)
+	UpdateColumns(ctx context.Context, c *collection.Collection, columns ...string) error
+	Delete(ctx context.Context, id uint64) error
+	ListByUser(ctx context.Context, c *gin.Context, userID string, publicOnly bool, baseURL string, perPage int) ([]collection.Collection, *paginator.Paging, error)
+	ListItems(ctx context.Context, c *gin.Context, collectionID string, perPage int, hiddenCategoryIDs []uint64) ([]collection.Item, *paginator.Paging, error)
+	AddItem(ctx context.Context, collectionID, topicID uint64, note string) (bool, error)
+	UpdateItemNote(ctx context.Context, collectionID, topicID uint64, note string) (bool, error)
+	RemoveItem(ctx context.Context, collectionID, topicID uint64) (bool, error)
+	Reorder(ctx context.Context, collectionID uint64, topicIDs []uint64) error
+	Follow(ctx context.Context, collectionID, userID uint64) (bool, error)
+	Unfollow(ctx context.Context, collectionID, userID uint64) (bool, error)
+	IsFollowing(ctx context.Context, collectionID, userID uint64) (bool, error)
+	ListFollowing(ctx context.Context, c *gin.Context, userID string, perPage int) ([]collection.Follow, *paginator.Paging, error)
+	ListFavorites(ctx context.Context, c *gin.Context, userID string, perPage int, hiddenCategoryIDs []uint64) ([]collection.Favorite, *paginator.Paging, error)
+}
+
+// collectionRepository 收藏夹仓储实现
+type collectionRepository struct{}
+
+// NewCollectionRepository 创建收藏夹仓储实例
+func NewCollectionRepository() CollectionRepository {
+	return &collectionRepository{}
+}
+
+// Create 创建收藏夹
+func (r *collectionRepository) Create(ctx context.Context, c *collection.Collection) error {
+	c.Create()
+	if c.ID == 0 {
+		return NewCreateError("收藏夹", nil)
+	}
+	return nil
+}
+
+// GetByID 根据ID获取收藏夹，不存在时返回 nil
+func (r *collectionRepository) GetByID(ctx context.Context, id string) (*collection.Collection, error) {
+	c := collection.Get(id)
+	if c.ID == 0 {
+		return nil, nil
+	}
+	return &c, nil
+}
+
+// UpdateColumns 只更新指定字段
+func (r *collectionRepository) UpdateColumns(ctx context.Context, c *collection.Collection, columns ...string) error {
+	return database.DB.WithContext(ctx).Model(c).Select(columns).Updates(c).Error
+}
+
+// Delete 删除收藏夹及其中的话题和关注关系，话题本身仍保留在收藏中
+func (r *collectionRepository) Delete(ctx context.Context, id uint64) error {
+	return database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("collection_id = ?", id).Delete(&collection.Item{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("collection_id = ?", id).Delete(&collection.Follow{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&collection.Collection{}, id).Error
+	})
+}
+
+// ListByUser 分页获取用户的收藏夹
+func (r *collectionRepository) ListByUser(ctx context.Context, c *gin.Context, userID string, publicOnly bool, baseURL string, perPage int) ([]collection.Collection, *paginator.Paging, error) {
+	data, paging := collection.PaginateByUser(c, userID, publicOnly, baseURL, perPage)
+	return data, &paging, nil
+}
+
+// ListItems 分页获取收藏夹中的话题
+func (r *collectionRepository) ListItems(ctx context.Context, c *gin.Context, collectionID string, perPage int, hiddenCategoryIDs []uint64) ([]collection.Item, *paginator.Paging, error) {
+	data, paging := collection.PaginateItems(c, collectionID, perPage, hiddenCategoryIDs)
+	return data, &paging, nil
+}
+
+// AddItem 将话题加入收藏夹末尾，已存在时只更新备注，返回是否新加入
+func (r *collectionRepository) AddItem(ctx context.Context, collectionID, topicID uint64, note string) (added bool, err error) {
+	err = database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing collection.Item
+		if err := tx.Where("collection_id = ? AND topic_id = ?", collectionID, topicID).
+			Limit(1).Find(&existing).Error; err != nil {
+			return err
+		}
+		if existing.ID != 0 {
+			return tx.Model(&existing).UpdateColumn("note", note).Error
+		}
+
+		var maxPosition int
+		if err := tx.Model(&collection.Item{}).
+			Select("COALESCE(MAX(position), 0)").
+			Where("collection_id = ?", collectionID).
+			Scan(&maxPosition).Error; err != nil {
+			return err
+		}
+		item := collection.Item{CollectionID: collectionID, TopicID: topicID, Note: note, Position: maxPosition + 1}
+		if err := tx.Create(&item).Error; err != nil {
+			return err
+		}
+		added = true
+		return collection.RecountItems(tx, collectionID)
+	})
+	return
+}
+
+// UpdateItemNote 修改收藏夹中话题的备注，话题不在收藏夹中时返回 false
+func (r *collectionRepository) UpdateItemNote(ctx context.Context, collectionID, topicID uint64, note string) (bool, error) {
+	result := database.DB.WithContext(ctx).Model(&collection.Item{}).
+		Where("collection_id = ? AND topic_id = ?", collectionID, topicID).
+		UpdateColumn("note", note)
+	return result.RowsAffected > 0, result.Error
+}
+
+// RemoveItem 从收藏夹中移除话题，话题仍保留在收藏中
+func (r *collectionRepository) RemoveItem(ctx context.Context, collectionID, topicID uint64) (removed bool, err error) {
+	err = database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("collection_id = ? AND topic_id = ?", collectionID, topicID).Delete(&collection.Item{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		removed = true
+		return collection.RecountItems(tx, collectionID)
+	})
+	return
+}
+
+// Reorder 按给定话题顺序重排收藏夹，未列出的话题排在最后并保持原有顺序
+func (r *collectionRepository) Reorder(ctx context.Context, collectionID uint64, topicIDs []uint64) error {
+	return database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&collection.Item{}).
+			Where("collection_id = ? AND topic_id NOT IN ?", collectionID, topicIDs).
+			UpdateColumn("position", gorm.Expr("position + ?", len(topicIDs))).Error; err != nil {
+			return err
+		}
+		for i, topicID := range topicIDs {
+			if err := tx.Model(&collection.Item{}).
+				Where("collection_id = ? AND topic_id = ?", collectionID, topicID).
+				UpdateColumn("position", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Follow 关注收藏夹，已关注时返回 false
+func (r *collectionRepository) Follow(ctx context.Context, collectionID, userID uint64) (followed bool, err error) {
+	err = database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var exists int64
+		if err := tx.Model(&collection.Follow{}).
+			Where("collection_id = ? AND user_id = ?", collectionID, userID).
+			Count(&exists).Error; err != nil {
+			return err
+		}
+		if exists > 0 {
+			return nil
+		}
+		if err := tx.Create(&collection.Follow{CollectionID: collectionID, UserID: userID}).Error; err != nil {
+			return err
+		}
+		followed = true
+		return tx.Model(&collection.Collection{}).Where("id = ?", collectionID).
+			UpdateColumn("followers_count", gorm.Expr("followers_count + 1")).Error
+	})
+	return
+}
+
+// Unfollow 取消关注收藏夹，未关注时返回 false
+func (r *collectionRepository) Unfollow(ctx context.Context, collectionID, userID uint64) (unfollowed bool, err error) {
+	err = database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("collection_id = ? AND user_id = ?", collectionID, userID).Delete(&collection.Follow{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		unfollowed = true
+		return tx.Model(&collection.Collection{}).Where("id = ? AND followers_count > 0", collectionID).
+			UpdateColumn("followers_count", gorm.Expr("followers_count - 1")).Error
+	})
+	return
+}
+
+// IsFollowing 用户是否关注了收藏夹
+func (r *collectionRepository) IsFollowing(ctx context.Context, collectionID, userID uint64) (bool, error) {
+	var count int64
+	err := database.DB.WithContext(ctx).Model(&collection.Follow{}).
+		Where("collection_id = ? AND user_id = ?", collectionID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ListFollowing 分页获取用户关注的收藏夹
+func (r *collectionRepository) ListFollowing(ctx context.Context, c *gin.Context, userID string, perPage int) ([]collection.Follow, *paginator.Paging, error) {
+	data, paging := collection.PaginateFollowing(c, userID, perPage)
+	return data, &paging, nil
+}
+
+// ListFavorites 分页获取用户收藏的话题
+func (r *collectionRepository) ListFavorites(ctx context.Context, c *gin.Context, userID string, perPage int, hiddenCategoryIDs []uint64) ([]collection.Favorite, *paginator.Paging, error) {
+	data, paging := collection.PaginateFavorites(c, userID, perPage, hiddenCategoryIDs)
+	return data, &paging, nil
+}
@@ -4,30 +4,48 @@ package repositories
 import (
 	"context"
 	"errors"
+	"time"
 
 	"GoHub-Service/app/models/comment"
+	"GoHub-Service/app/models/like"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/models/user"
 	"GoHub-Service/pkg/database"
 	"GoHub-Service/pkg/paginator"
 
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // CommentRepository 评论仓储接口
 type CommentRepository interface {
 	GetByID(ctx context.Context, id string) (*comment.Comment, error)
-	List(ctx context.Context, c *gin.Context, perPage int) ([]comment.Comment, *paginator.Paging, error)
+	List(ctx context.Context, c *gin.Context, perPage int, hiddenCategoryIDs []uint64) ([]comment.Comment, *paginator.Paging, error)
 	ListByTopicID(ctx context.Context, c *gin.Context, topicID string, perPage int) ([]comment.Comment, *paginator.Paging, error)
-	ListByUserID(ctx context.Context, c *gin.Context, userID string, perPage int) ([]comment.Comment, *paginator.Paging, error)
+	ListByUserID(ctx context.Context, c *gin.Context, userID string, perPage int, hiddenCategoryIDs []uint64) ([]comment.Comment, *paginator.Paging, error)
 	ListReplies(ctx context.Context, c *gin.Context, parentID string, perPage int) ([]comment.Comment, *paginator.Paging, error)
+	ListByTopicIDCursor(ctx context.Context, c *gin.Context, topicID string, perPage int) ([]comment.Comment, *paginator.CursorPaging, error)
+	ListByUserIDCursor(ctx context.Context, c *gin.Context, userID string, perPage int, hiddenCategoryIDs []uint64) ([]comment.Comment, *paginator.CursorPaging, error)
+	Tree(ctx context.Context, c *gin.Context, topicID string, opts comment.TreeOptions, perPage int) ([]comment.TreeItem, *paginator.Paging, error)
 	Create(ctx context.Context, comment *comment.Comment) error
 	Update(ctx context.Context, comment *comment.Comment) error
+	Edit(ctx context.Context, comment *comment.Comment, revision *comment.Revision) error
+	Revisions(ctx context.Context, commentID uint64) ([]comment.Revision, error)
+	Hide(ctx context.Context, id uint64) error
+	// Unhide 恢复被隐藏的评论
+	Unhide(ctx context.Context, id uint64) error
+	Accept(ctx context.Context, topicID string, commentID uint64, points int64) (previous *comment.Comment, err error)
 	Delete(ctx context.Context, id string) error
 	BatchCreate(ctx context.Context, comments []comment.Comment) error
 	BatchDelete(ctx context.Context, ids []string) error
-	IncrementLikeCount(ctx context.Context, id string) error
-	DecrementLikeCount(ctx context.Context, id string) error
+	Like(ctx context.Context, userID, id string) (bool, error)
+	Unlike(ctx context.Context, userID, id string) (bool, error)
+	LikedIDs(ctx context.Context, userID string, ids []uint64) (map[uint64]bool, error)
+	RecountLikes(ctx context.Context) (int64, error)
 	CountByTopicID(ctx context.Context, topicID string) (int64, error)
+	GetByIDs(ctx context.Context, ids []uint64) ([]comment.Comment, error)
 }
 
 // commentRepository 评论仓储实现
@@ -42,7 +60,7 @@ func NewCommentRepository() CommentRepository {
 func (r *commentRepository) GetByID(ctx context.Context, id string) (*comment.Comment, error) {
 	var commentModel comment.Comment
 	if err := database.DB.WithContext(ctx).
-		Select("id", "topic_id", "user_id", "content", "parent_id", "like_count", "created_at", "updated_at").
+		Select(database.SelectFields["comment_full"]).
 		Preload("User", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "name", "email", "avatar")
 		}).
@@ -59,10 +77,10 @@ func (r *commentRepository) GetByID(ctx context.Context, id string) (*comment.Co
 }
 
 // List 获取评论列表
-func (r *commentRepository) List(ctx context.Context, c *gin.Context, perPage int) ([]comment.Comment, *paginator.Paging, error) {
+func (r *commentRepository) List(ctx context.Context, c *gin.Context, perPage int, hiddenCategoryIDs []uint64) ([]comment.Comment, *paginator.Paging, error) {
 	var comments []comment.Comment
 	query := database.DB.WithContext(ctx).Model(&comment.Comment{}).
-		Select("id", "topic_id", "user_id", "content", "parent_id", "like_count", "created_at", "updated_at").
+		Select(database.SelectFields["comment_full"]).
 		Preload("User", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "name", "email", "avatar")
 		}).
@@ -70,6 +88,7 @@ func (r *commentRepository) List(ctx context.Context, c *gin.Context, perPage in
 			return db.Select("id", "title", "user_id", "category_id")
 		}).
 		Order("created_at DESC")
+	query = excludeHiddenCategories(query, hiddenCategoryIDs)
 
 	paging := paginator.Paginate(
 		c,
@@ -86,12 +105,12 @@ func (r *commentRepository) List(ctx context.Context, c *gin.Context, perPage in
 func (r *commentRepository) ListByTopicID(ctx context.Context, c *gin.Context, topicID string, perPage int) ([]comment.Comment, *paginator.Paging, error) {
 	var comments []comment.Comment
 	query := database.DB.WithContext(ctx).Model(&comment.Comment{}).
-		Select("id", "topic_id", "user_id", "content", "parent_id", "like_count", "created_at", "updated_at").
+		Select(database.SelectFields["comment_full"]).
 		Where("topic_id = ? AND parent_id = ?", topicID, "0").
 		Preload("User", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "name", "email", "avatar")
 		}).
-		Order("created_at DESC")
+		Order("is_accepted DESC, created_at DESC")
 
 	paging := paginator.Paginate(
 		c,
@@ -105,10 +124,10 @@ func (r *commentRepository) ListByTopicID(ctx context.Context, c *gin.Context, t
 }
 
 // ListByUserID 获取指定用户的评论列表
-func (r *commentRepository) ListByUserID(ctx context.Context, c *gin.Context, userID string, perPage int) ([]comment.Comment, *paginator.Paging, error) {
+func (r *commentRepository) ListByUserID(ctx context.Context, c *gin.Context, userID string, perPage int, hiddenCategoryIDs []uint64) ([]comment.Comment, *paginator.Paging, error) {
 	var comments []comment.Comment
 	query := database.DB.WithContext(ctx).Model(&comment.Comment{}).
-		Select("id", "topic_id", "user_id", "content", "parent_id", "like_count", "created_at", "updated_at").
+		Select(database.SelectFields["comment_full"]).
 		Where("user_id = ?", userID).
 		Preload("User", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "name", "email", "avatar")
@@ -117,6 +136,7 @@ func (r *commentRepository) ListByUserID(ctx context.Context, c *gin.Context, us
 			return db.Select("id", "title", "user_id", "category_id")
 		}).
 		Order("created_at DESC")
+	query = excludeHiddenCategories(query, hiddenCategoryIDs)
 
 	paging := paginator.Paginate(
 		c,
@@ -133,7 +153,7 @@ func (r *commentRepository) ListByUserID(ctx context.Context, c *gin.Context, us
 func (r *commentRepository) ListReplies(ctx context.Context, c *gin.Context, parentID string, perPage int) ([]comment.Comment, *paginator.Paging, error) {
 	var comments []comment.Comment
 	query := database.DB.WithContext(ctx).Model(&comment.Comment{}).
-		Select("id", "topic_id", "user_id", "content", "parent_id", "like_count", "created_at", "updated_at").
+		Select(database.SelectFields["comment_full"]).
 		Where("parent_id = ?", parentID).
 		Preload("User", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "name", "email", "avatar")
@@ -151,6 +171,131 @@ func (r *commentRepository) ListReplies(ctx context.Context, c *gin.Context, par
 	return comments, &paging, nil
 }
 
+// ListByTopicIDCursor 游标分页获取指定话题的评论列表
+func (r *commentRepository) ListByTopicIDCursor(ctx context.Context, c *gin.Context, topicID string, perPage int) ([]comment.Comment, *paginator.CursorPaging, error) {
+	query := database.DB.WithContext(ctx).Model(&comment.Comment{}).
+		Select(database.SelectFields["comment_full"]).
+		Where("topic_id = ? AND parent_id = ?", topicID, "0").
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "name", "email", "avatar")
+		})
+
+	return commentCursorPaginate(c, query, "/api/v1/topics/"+topicID+"/comments", perPage, true)
+}
+
+// ListByUserIDCursor 游标分页获取指定用户的评论列表
+func (r *commentRepository) ListByUserIDCursor(ctx context.Context, c *gin.Context, userID string, perPage int, hiddenCategoryIDs []uint64) ([]comment.Comment, *paginator.CursorPaging, error) {
+	query := database.DB.WithContext(ctx).Model(&comment.Comment{}).
+		Select(database.SelectFields["comment_full"]).
+		Where("user_id = ?", userID).
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "name", "email", "avatar")
+		}).
+		Preload("Topic", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "title", "user_id", "category_id")
+		})
+	query = excludeHiddenCategories(query, hiddenCategoryIDs)
+
+	return commentCursorPaginate(c, query, "/api/v1/users/"+userID+"/comments", perPage, false)
+}
+
+// excludeHiddenCategories 排除不可见分类下话题的评论
+func excludeHiddenCategories(query *gorm.DB, categoryIDs []uint64) *gorm.DB {
+	if len(categoryIDs) == 0 {
+		return query
+	}
+	hidden := database.DB.Table("topics").Select("id").Where("category_id IN ?", categoryIDs)
+	return query.Where("topic_id NOT IN (?)", hidden)
+}
+
+// Tree 分页获取话题的评论树
+// 顶级评论分页查询一次，回复按层批量加载轻量字段（每层一次查询），在内存中决定
+// 每条评论内嵌哪些回复后，再一次性加载这些回复的内容，查询次数只与层数有关
+func (r *commentRepository) Tree(ctx context.Context, c *gin.Context, topicID string, opts comment.TreeOptions, perPage int) ([]comment.TreeItem, *paginator.Paging, error) {
+	columns := database.SelectFields["comment_full"]
+	db := database.DB.WithContext(ctx)
+
+	var roots []comment.Comment
+	query := db.Model(&comment.Comment{}).
+		Select(columns).
+		Where("topic_id = ? AND parent_id = ?", topicID, "0").
+		Order(opts.OrderSQL())
+	paging := paginator.Paginate(
+		c,
+		query,
+		&roots,
+		"/api/v1/topics/"+topicID+"/comments/tree?sort_by="+opts.Sort,
+		perPage,
+	)
+
+	rootIDs := make([]uint64, 0, len(roots))
+	frontier := make([]string, 0, len(roots))
+	for _, root := range roots {
+		rootIDs = append(rootIDs, root.ID)
+		frontier = append(frontier, root.GetStringID())
+	}
+
+	// 逐层加载回复节点，超过 MaxNodes 后不再向下加载，过深部分的回复数会偏小
+	nodes := make([]comment.Node, 0)
+	for len(frontier) > 0 && len(nodes) < opts.MaxNodes {
+		var level []comment.Node
+		if err := db.Model(&comment.Comment{}).
+			Select("id", "parent_id", "user_id", "like_count", "created_at").
+			Where("parent_id IN ?", frontier).
+			Order("id ASC").
+			Limit(opts.MaxNodes - len(nodes)).
+			Find(&level).Error; err != nil {
+			return nil, nil, err
+		}
+		frontier = frontier[:0]
+		for _, n := range level {
+			frontier = append(frontier, cast.ToString(n.ID))
+		}
+		nodes = append(nodes, level...)
+	}
+
+	items, ids := comment.TreePlan(rootIDs, nodes, opts)
+	loaded := make(map[uint64]comment.Comment, len(ids))
+	for _, root := range roots {
+		// 分页时预加载的话题与评论树无关，不随评论树缓存
+		root.Topic = topic.Topic{}
+		loaded[root.ID] = root
+	}
+	if replyIDs := ids[len(rootIDs):]; len(replyIDs) > 0 {
+		var replies []comment.Comment
+		if err := db.Select(columns).Where("id IN ?", replyIDs).Find(&replies).Error; err != nil {
+			return nil, nil, err
+		}
+		for _, reply := range replies {
+			loaded[reply.ID] = reply
+		}
+	}
+
+	return comment.Fill(items, loaded), &paging, nil
+}
+
+// commentCursorPaginate 评论游标分页，按发布时间倒序，acceptedFirst 时被采纳的回答排在最前
+func commentCursorPaginate(c *gin.Context, query *gorm.DB, baseURL string, perPage int, acceptedFirst bool) ([]comment.Comment, *paginator.CursorPaging, error) {
+	columns := []paginator.SortColumn{{Name: "created_at", Desc: true}, {Name: "id", Desc: true}}
+	if acceptedFirst {
+		columns = append([]paginator.SortColumn{{Name: "is_accepted", Desc: true}}, columns...)
+	}
+	comments, paging, err := paginator.CursorPaginate(c, query, paginator.CursorOptions{
+		Columns: columns,
+		BaseURL: baseURL,
+		PerPage: perPage,
+	}, func(cm *comment.Comment) []interface{} {
+		if acceptedFirst {
+			return []interface{}{cm.IsAccepted, cm.CreatedAt, cm.ID}
+		}
+		return []interface{}{cm.CreatedAt, cm.ID}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return comments, &paging, nil
+}
+
 // Create 创建评论
 func (r *commentRepository) Create(ctx context.Context, c *comment.Comment) error {
 	c.Create()
@@ -169,6 +314,111 @@ func (r *commentRepository) Update(ctx context.Context, c *comment.Comment) erro
 	return nil
 }
 
+// Edit 修改评论内容，在同一事务中保存修改前的版本
+// 调用方需先设置好 comment 的 Content、EditCount 和 EditedAt
+func (r *commentRepository) Edit(ctx context.Context, c *comment.Comment, revision *comment.Revision) error {
+	return database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&comment.Comment{}).
+			Where("id = ?", c.ID).
+			Updates(map[string]interface{}{
+				"content":    c.Content,
+				"edit_count": c.EditCount,
+				"edited_at":  c.EditedAt,
+				"updated_at": c.EditedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return NewUpdateError("评论", c.ID, nil)
+		}
+		return nil
+	})
+}
+
+// Revisions 获取评论的历史版本，最近的修改在前
+func (r *commentRepository) Revisions(ctx context.Context, commentID uint64) ([]comment.Revision, error) {
+	var revisions []comment.Revision
+	err := database.DB.WithContext(ctx).
+		Where("comment_id = ?", commentID).
+		Order("id DESC").
+		Find(&revisions).Error
+	return revisions, err
+}
+
+// Hide 隐藏评论内容，评论仍保留在讨论串中
+func (r *commentRepository) Hide(ctx context.Context, id uint64) error {
+	return database.DB.WithContext(ctx).Model(&comment.Comment{}).
+		Where("id = ? AND hidden_at IS NULL", id).
+		UpdateColumn("hidden_at", time.Now()).Error
+}
+
+// Unhide 恢复被隐藏的评论
+func (r *commentRepository) Unhide(ctx context.Context, id uint64) error {
+	return database.DB.WithContext(ctx).Model(&comment.Comment{}).
+		Where("id = ? AND hidden_at IS NOT NULL", id).
+		UpdateColumn("hidden_at", nil).Error
+}
+
+// Accept 将评论设为话题的采纳答案，commentID 为 0 时取消采纳
+// 同一事务中撤销之前的采纳，并相应调整回答者的积分（话题作者自答不计积分）
+// 返回之前被采纳的评论，没有时为 nil
+func (r *commentRepository) Accept(ctx context.Context, topicID string, commentID uint64, points int64) (previous *comment.Comment, err error) {
+	err = database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var t topic.Topic
+		if err := tx.Select("id", "user_id", "accepted_comment_id").First(&t, topicID).Error; err != nil {
+			return err
+		}
+		if t.AcceptedCommentID == commentID {
+			return nil
+		}
+
+		credit := func(id uint64, accepted bool) (*comment.Comment, error) {
+			// 之前采纳的回答可能已在回收站中，同样需要撤销
+			var cm comment.Comment
+			if err := tx.Unscoped().Select("id", "topic_id", "user_id").First(&cm, id).Error; err != nil {
+				return nil, err
+			}
+			if err := tx.Unscoped().Model(&comment.Comment{}).Where("id = ?", id).
+				UpdateColumn("is_accepted", accepted).Error; err != nil {
+				return nil, err
+			}
+			if points > 0 && cm.UserID != t.UserID {
+				delta := points
+				if !accepted {
+					delta = -points
+				}
+				if err := tx.Model(&user.User{}).Where("id = ?", cm.UserID).
+					UpdateColumn("points", gorm.Expr("points + ?", delta)).Error; err != nil {
+					return nil, err
+				}
+			}
+			return &cm, nil
+		}
+
+		if t.AcceptedCommentID > 0 {
+			prev, err := credit(t.AcceptedCommentID, false)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			previous = prev
+		}
+
+		updates := map[string]interface{}{"accepted_comment_id": commentID, "solved_at": nil}
+		if commentID > 0 {
+			if _, err := credit(commentID, true); err != nil {
+				return err
+			}
+			updates["solved_at"] = time.Now()
+		}
+		return tx.Model(&topic.Topic{}).Where("id = ?", t.ID).UpdateColumns(updates).Error
+	})
+	return previous, err
+}
+
 // Delete 删除评论
 func (r *commentRepository) Delete(ctx context.Context, id string) error {
 	var commentModel comment.Comment
@@ -186,18 +436,96 @@ func (r *commentRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-// IncrementLikeCount 增加点赞数
-func (r *commentRepository) IncrementLikeCount(ctx context.Context, id string) error {
-	return database.DB.WithContext(ctx).Model(&comment.Comment{}).
-		Where("id = ?", id).
-		UpdateColumn("like_count", gorm.Expr("like_count + ?", 1)).Error
+// Like 记录用户对评论的点赞并增加点赞数，已点赞时返回 false
+func (r *commentRepository) Like(ctx context.Context, userID, id string) (liked bool, err error) {
+	err = database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 依赖 (user_id, target_type, target_id) 唯一索引去重，并发的重复点赞不会插入
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&like.Like{UserID: userID, TargetType: like.TargetComment, TargetID: id})
+		if result.Error != nil || result.RowsAffected != 1 {
+			return result.Error
+		}
+		liked = true
+		return tx.Model(&comment.Comment{}).Where("id = ?", id).
+			UpdateColumn("like_count", gorm.Expr("like_count + ?", 1)).Error
+	})
+	return
 }
 
-// DecrementLikeCount 减少点赞数
-func (r *commentRepository) DecrementLikeCount(ctx context.Context, id string) error {
-	return database.DB.WithContext(ctx).Model(&comment.Comment{}).
-		Where("id = ? AND like_count > ?", id, 0).
-		UpdateColumn("like_count", gorm.Expr("like_count - ?", 1)).Error
+// Unlike 取消用户对评论的点赞并减少点赞数，未点赞时返回 false
+func (r *commentRepository) Unlike(ctx context.Context, userID, id string) (unliked bool, err error) {
+	err = database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ? AND target_type = ? AND target_id = ?", userID, like.TargetComment, id).
+			Delete(&like.Like{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		unliked = true
+		return tx.Model(&comment.Comment{}).Where("id = ? AND like_count > ?", id, 0).
+			UpdateColumn("like_count", gorm.Expr("like_count - ?", 1)).Error
+	})
+	return
+}
+
+// LikedIDs 用户点赞过的评论
+func (r *commentRepository) LikedIDs(ctx context.Context, userID string, ids []uint64) (map[uint64]bool, error) {
+	liked := make(map[uint64]bool)
+	if userID == "" || len(ids) == 0 {
+		return liked, nil
+	}
+	targetIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		targetIDs = append(targetIDs, cast.ToString(id))
+	}
+	var likedIDs []string
+	if err := database.DB.WithContext(ctx).Model(&like.Like{}).
+		Where("user_id = ? AND target_type = ? AND target_id IN ?", userID, like.TargetComment, targetIDs).
+		Pluck("target_id", &likedIDs).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range likedIDs {
+		liked[cast.ToUint64(id)] = true
+	}
+	return liked, nil
+}
+
+// RecountLikes 按点赞记录重新计算所有评论的点赞数，返回修正的评论数
+func (r *commentRepository) RecountLikes(ctx context.Context) (int64, error) {
+	db := database.DB.WithContext(ctx)
+	var rows []struct {
+		TargetID string
+		Total    int64
+	}
+	if err := db.Model(&like.Like{}).
+		Select("target_id, COUNT(*) AS total").
+		Where("target_type = ?", like.TargetComment).
+		Group("target_id").
+		Scan(&rows).Error; err != nil {
+		return 0, err
+	}
+	counts := make(map[uint64]int64, len(rows))
+	for _, row := range rows {
+		counts[cast.ToUint64(row.TargetID)] = row.Total
+	}
+
+	var fixed int64
+	var batch []comment.Comment
+	err := db.Model(&comment.Comment{}).
+		Select("id", "like_count").
+		FindInBatches(&batch, 500, func(tx *gorm.DB, _ int) error {
+			for _, c := range batch {
+				if c.LikeCount == counts[c.ID] {
+					continue
+				}
+				if err := db.Model(&comment.Comment{}).Where("id = ?", c.ID).
+					UpdateColumn("like_count", counts[c.ID]).Error; err != nil {
+					return err
+				}
+				fixed++
+			}
+			return nil
+		}).Error
+	return fixed, err
 }
 
 // CountByTopicID 统计话题的评论数
@@ -207,6 +535,16 @@ func (r *commentRepository) CountByTopicID(ctx context.Context, topicID string)
 	return count, err
 }
 
+// GetByIDs 批量获取评论，按发布时间正序
+func (r *commentRepository) GetByIDs(ctx context.Context, ids []uint64) ([]comment.Comment, error) {
+	var comments []comment.Comment
+	err := database.DB.WithContext(ctx).
+		Where("id IN ?", ids).
+		Order("created_at ASC, id ASC").
+		Find(&comments).Error
+	return comments, err
+}
+
 // BatchCreate 批量创建评论（使用事务和批量插入优化）
 func (r *commentRepository) BatchCreate(ctx context.Context, comments []comment.Comment) error {
 	if len(comments) == 0 {
@@ -0,0 +1,493 @@
+// Package repositories 首页信息流数据访问层
+package repositories
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"GoHub-Service/app/models/block"
+	"GoHub-Service/app/models/comment"
+	"GoHub-Service/app/models/follow"
+	"GoHub-Service/app/models/subscription"
+	"GoHub-Service/app/models/tag"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/models/user"
+	"GoHub-Service/pkg/database"
+	"GoHub-Service/pkg/feed"
+	"GoHub-Service/pkg/paginator"
+	"GoHub-Service/pkg/redis"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// feedInboxKey 用户收件箱，保存关注的普通作者推送的动态，新动态在列表头部
+func feedInboxKey(userID uint64) string {
+	return "feed:inbox:" + strconv.FormatUint(userID, 10)
+}
+
+// FeedRepository 首页信息流仓储接口
+type FeedRepository interface {
+	// Push 将动态推送到多个用户的收件箱头部，超过 size 条的旧动态被裁剪
+	Push(ctx context.Context, userIDs []uint64, size int, ttl time.Duration, entries ...feed.Entry) error
+	// Append 将较早的动态追加到收件箱尾部，用于关注后补充和收件箱重建
+	Append(ctx context.Context, userID uint64, size int, ttl time.Duration, entries []feed.Entry) error
+	// Inbox 读取收件箱中的全部动态，收件箱不存在（从未写入或已过期）时 exists 为 false
+	Inbox(ctx context.Context, userID uint64) (entries []feed.Entry, exists bool, err error)
+	// RemoveFromInbox 从多个用户的收件箱中删除满足条件的动态
+	RemoveFromInbox(ctx context.Context, userIDs []uint64, match func(feed.Entry) bool) error
+
+	// FollowedAuthors 用户关注的作者，按粉丝数是否达到 maxFollowers 分为推送和拉取两组
+	FollowedAuthors(ctx context.Context, userID uint64, maxFollowers int64) (pushed, pulled []uint64, err error)
+	// FollowerIDs 作者的全部粉丝
+	FollowerIDs(ctx context.Context, authorID uint64) ([]uint64, error)
+	// FollowersCount 作者的粉丝数
+	FollowersCount(ctx context.Context, authorID uint64) (int64, error)
+	// BlockedIDs 用户屏蔽的人，他们的动态不出现在信息流中
+	BlockedIDs(ctx context.Context, userID uint64) ([]uint64, error)
+
+	// Subscribe 关注标签或分类，已关注时返回 false
+	Subscribe(ctx context.Context, userID uint64, targetType string, targetID uint64) (bool, error)
+	// Unsubscribe 取消关注标签或分类，未关注时返回 false
+	Unsubscribe(ctx context.Context, userID uint64, targetType string, targetID uint64) (bool, error)
+	// SubscribedIDs 用户关注的某类对象 ID
+	SubscribedIDs(ctx context.Context, userID uint64, targetType string) ([]uint64, error)
+	// ListSubscriptions 分页获取用户关注的标签和分类，targetType 为空时返回全部
+	ListSubscriptions(ctx context.Context, c *gin.Context, userID uint64, targetType string, perPage int) ([]subscription.Subscription, *paginator.Paging, error)
+	// TagNames 按 ID 获取标签名称
+	TagNames(ctx context.Context, ids []uint64) (map[uint64]string, error)
+
+	// TopicsByAuthors 作者们最近发布的已通过审核的话题，before 不为空时只返回不晚于该时间的话题
+	TopicsByAuthors(ctx context.Context, authorIDs []uint64, before *time.Time, limit int) ([]feed.Entry, error)
+	// CommentsByAuthors 作者们最近发表的一级回复
+	CommentsByAuthors(ctx context.Context, authorIDs []uint64, before *time.Time, limit int) ([]feed.Entry, error)
+	// TopicsByTags 带有这些标签的最近话题
+	TopicsByTags(ctx context.Context, tagIDs []uint64, before *time.Time, limit int) ([]feed.Entry, error)
+	// TopicsByCategories 这些分类及其子分类下的最近话题
+	TopicsByCategories(ctx context.Context, categoryIDs []uint64, before *time.Time, limit int) ([]feed.Entry, error)
+
+	// Topics 按 ID 获取已通过审核且未删除的话题，包含标签
+	Topics(ctx context.Context, ids []uint64) ([]topic.Topic, error)
+	// Comments 按 ID 获取未隐藏且未删除的评论
+	Comments(ctx context.Context, ids []uint64) ([]comment.Comment, error)
+	// Users 按 ID 获取未注销的用户
+	Users(ctx context.Context, ids []uint64) ([]user.User, error)
+	// ContentAuthors 话题或评论的作者，包含已删除的内容，kind 为 feed.KindTopic 或 feed.KindComment
+	ContentAuthors(ctx context.Context, kind string, ids []uint64) ([]uint64, error)
+	// Existing 未删除的话题或评论 ID，包含待审核、被拒绝的话题和被隐藏的评论
+	Existing(ctx context.Context, kind string, ids []uint64) ([]uint64, error)
+}
+
+// feedRepository 首页信息流仓储实现，收件箱保存在 Redis，其余数据来自数据库
+type feedRepository struct{}
+
+// NewFeedRepository 创建信息流仓储实例
+func NewFeedRepository() FeedRepository {
+	return &feedRepository{}
+}
+
+// Push 推送到收件箱头部
+func (r *feedRepository) Push(ctx context.Context, userIDs []uint64, size int, ttl time.Duration, entries ...feed.Entry) error {
+	if len(userIDs) == 0 || len(entries) == 0 {
+		return nil
+	}
+	values := make([]interface{}, len(entries))
+	for i, e := range entries {
+		values[i] = e.Encode()
+	}
+
+	pipe := redis.Redis.Client.Pipeline()
+	for _, userID := range userIDs {
+		key := feedInboxKey(userID)
+		pipe.LPush(ctx, key, values...)
+		pipe.LTrim(ctx, key, 0, int64(size-1))
+		pipe.Expire(ctx, key, ttl)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Append 追加到收件箱尾部
+func (r *feedRepository) Append(ctx context.Context, userID uint64, size int, ttl time.Duration, entries []feed.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	values := make([]interface{}, len(entries))
+	for i, e := range entries {
+		values[i] = e.Encode()
+	}
+
+	key := feedInboxKey(userID)
+	pipe := redis.Redis.Client.Pipeline()
+	pipe.RPush(ctx, key, values...)
+	pipe.LTrim(ctx, key, 0, int64(size-1))
+	pipe.Expire(ctx, key, ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Inbox 读取收件箱，无法解析的条目直接跳过
+func (r *feedRepository) Inbox(ctx context.Context, userID uint64) ([]feed.Entry, bool, error) {
+	key := feedInboxKey(userID)
+	raws, err := redis.Redis.Client.LRange(ctx, key, 0, -1).Result()
+	if err != nil {
+		return nil, false, err
+	}
+	if len(raws) == 0 {
+		exists, err := redis.Redis.Client.Exists(ctx, key).Result()
+		return nil, exists > 0, err
+	}
+	entries := make([]feed.Entry, 0, len(raws))
+	for _, raw := range raws {
+		if e, ok := feed.Parse(raw); ok {
+			entries = append(entries, e)
+		}
+	}
+	return entries, true, nil
+}
+
+// RemoveFromInbox 逐个收件箱找出满足条件的条目后删除
+func (r *feedRepository) RemoveFromInbox(ctx context.Context, userIDs []uint64, match func(feed.Entry) bool) error {
+	client := redis.Redis.Client
+	for _, userID := range userIDs {
+		key := feedInboxKey(userID)
+		raws, err := client.LRange(ctx, key, 0, -1).Result()
+		if err != nil {
+			return err
+		}
+		pipe := client.Pipeline()
+		removed := 0
+		for _, raw := range raws {
+			if e, ok := feed.Parse(raw); !ok || match(e) {
+				pipe.LRem(ctx, key, 0, raw)
+				removed++
+			}
+		}
+		if removed == 0 {
+			continue
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FollowedAuthors 用户关注的作者，不包含已注销的用户
+func (r *feedRepository) FollowedAuthors(ctx context.Context, userID uint64, maxFollowers int64) ([]uint64, []uint64, error) {
+	var rows []struct {
+		ID             uint64
+		FollowersCount int64
+	}
+	followed := database.DB.Model(&follow.Follow{}).Select("follow_id").Where("user_id = ?", strconv.FormatUint(userID, 10))
+	if err := database.DB.WithContext(ctx).Model(&user.User{}).
+		Select("id", "followers_count").
+		Where("id IN (?)", followed).
+		Find(&rows).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var pushed, pulled []uint64
+	for _, row := range rows {
+		if row.FollowersCount >= maxFollowers {
+			pulled = append(pulled, row.ID)
+		} else {
+			pushed = append(pushed, row.ID)
+		}
+	}
+	return pushed, pulled, nil
+}
+
+// FollowerIDs 作者的全部粉丝
+func (r *feedRepository) FollowerIDs(ctx context.Context, authorID uint64) ([]uint64, error) {
+	var ids []string
+	err := database.DB.WithContext(ctx).Model(&follow.Follow{}).
+		Where("follow_id = ?", strconv.FormatUint(authorID, 10)).
+		Pluck("user_id", &ids).Error
+	return toUint64s(ids), err
+}
+
+// FollowersCount 作者的粉丝数
+func (r *feedRepository) FollowersCount(ctx context.Context, authorID uint64) (int64, error) {
+	var counts []int64
+	err := database.DB.WithContext(ctx).Model(&user.User{}).
+		Where("id = ?", authorID).
+		Pluck("followers_count", &counts).Error
+	if err != nil || len(counts) == 0 {
+		return 0, err
+	}
+	return counts[0], nil
+}
+
+// BlockedIDs 用户屏蔽的人
+func (r *feedRepository) BlockedIDs(ctx context.Context, userID uint64) ([]uint64, error) {
+	var ids []uint64
+	err := database.DB.WithContext(ctx).Model(&block.Block{}).
+		Where("user_id = ?", userID).
+		Pluck("blocked_id", &ids).Error
+	return ids, err
+}
+
+// Subscribe 关注标签或分类
+func (r *feedRepository) Subscribe(ctx context.Context, userID uint64, targetType string, targetID uint64) (bool, error) {
+	result := database.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&subscription.Subscription{UserID: userID, TargetType: targetType, TargetID: targetID})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Unsubscribe 取消关注标签或分类
+func (r *feedRepository) Unsubscribe(ctx context.Context, userID uint64, targetType string, targetID uint64) (bool, error) {
+	result := database.DB.WithContext(ctx).
+		Where("user_id = ? AND target_type = ? AND target_id = ?", userID, targetType, targetID).
+		Delete(&subscription.Subscription{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// SubscribedIDs 用户关注的某类对象 ID
+func (r *feedRepository) SubscribedIDs(ctx context.Context, userID uint64, targetType string) ([]uint64, error) {
+	var ids []uint64
+	err := database.DB.WithContext(ctx).Model(&subscription.Subscription{}).
+		Where("user_id = ? AND target_type = ?", userID, targetType).
+		Pluck("target_id", &ids).Error
+	return ids, err
+}
+
+// ListSubscriptions 分页获取用户关注的标签和分类，按关注时间倒序
+func (r *feedRepository) ListSubscriptions(ctx context.Context, c *gin.Context, userID uint64, targetType string, perPage int) ([]subscription.Subscription, *paginator.Paging, error) {
+	query := database.DB.WithContext(ctx).Model(&subscription.Subscription{}).Where("user_id = ?", userID)
+	if targetType != "" {
+		query = query.Where("target_type = ?", targetType)
+	}
+
+	var subs []subscription.Subscription
+	paging := paginator.Paginate(c, query.Order("id DESC"), &subs, "/api/v1/feed/subscriptions", perPage)
+	return subs, &paging, nil
+}
+
+// TagNames 按 ID 获取标签名称
+func (r *feedRepository) TagNames(ctx context.Context, ids []uint64) (map[uint64]string, error) {
+	names := make(map[uint64]string, len(ids))
+	if len(ids) == 0 {
+		return names, nil
+	}
+	var tags []tag.Tag
+	if err := database.DB.WithContext(ctx).Select("id", "name").Where("id IN ?", ids).Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	for _, t := range tags {
+		names[t.ID] = t.Name
+	}
+	return names, nil
+}
+
+// feedTopicRow 拉取来源查询结果
+type feedTopicRow struct {
+	ID        uint64
+	UserID    string
+	TopicID   uint64
+	SourceID  uint64
+	CreatedAt time.Time
+}
+
+// TopicsByAuthors 作者们最近发布的话题
+func (r *feedRepository) TopicsByAuthors(ctx context.Context, authorIDs []uint64, before *time.Time, limit int) ([]feed.Entry, error) {
+	if len(authorIDs) == 0 {
+		return nil, nil
+	}
+	query := r.approvedTopics(ctx, before).
+		Select("topics.id, topics.user_id, topics.created_at").
+		Where("topics.user_id IN ?", toStrings(authorIDs))
+
+	var rows []feedTopicRow
+	if err := query.Order("topics.created_at DESC").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	entries := make([]feed.Entry, 0, len(rows))
+	for _, row := range rows {
+		actor := cast.ToUint64(row.UserID)
+		entries = append(entries, feed.Entry{
+			At: row.CreatedAt.UnixMilli(), Kind: feed.KindTopic, ID: row.ID, ActorID: actor, TopicID: row.ID,
+			Reason: feed.ReasonUser, SourceID: actor,
+		})
+	}
+	return entries, nil
+}
+
+// CommentsByAuthors 作者们最近发表的一级回复，不包含被隐藏的回复
+func (r *feedRepository) CommentsByAuthors(ctx context.Context, authorIDs []uint64, before *time.Time, limit int) ([]feed.Entry, error) {
+	if len(authorIDs) == 0 {
+		return nil, nil
+	}
+	query := database.DB.WithContext(ctx).Model(&comment.Comment{}).
+		Select("comments.id, comments.user_id, comments.topic_id, comments.created_at").
+		Where("comments.user_id IN ?", toStrings(authorIDs)).
+		Where("comments.parent_id IN ('', '0') AND comments.hidden_at IS NULL")
+	if before != nil {
+		query = query.Where("comments.created_at <= ?", *before)
+	}
+
+	var rows []feedTopicRow
+	if err := query.Order("comments.created_at DESC").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	entries := make([]feed.Entry, 0, len(rows))
+	for _, row := range rows {
+		actor := cast.ToUint64(row.UserID)
+		entries = append(entries, feed.Entry{
+			At: row.CreatedAt.UnixMilli(), Kind: feed.KindComment, ID: row.ID, ActorID: actor, TopicID: row.TopicID,
+			Reason: feed.ReasonUser, SourceID: actor,
+		})
+	}
+	return entries, nil
+}
+
+// TopicsByTags 带有这些标签的最近话题，同一话题命中多个标签时可能返回多条，由调用方去重
+func (r *feedRepository) TopicsByTags(ctx context.Context, tagIDs []uint64, before *time.Time, limit int) ([]feed.Entry, error) {
+	if len(tagIDs) == 0 {
+		return nil, nil
+	}
+	query := r.approvedTopics(ctx, before).
+		Select("topics.id, topics.user_id, topics.created_at, topic_tags.tag_id AS source_id").
+		Joins("JOIN topic_tags ON topic_tags.topic_id = topics.id").
+		Where("topic_tags.tag_id IN ?", tagIDs)
+
+	var rows []feedTopicRow
+	if err := query.Order("topics.created_at DESC").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return topicEntries(rows, feed.ReasonTag), nil
+}
+
+// TopicsByCategories 这些分类及其子分类下的最近话题
+func (r *feedRepository) TopicsByCategories(ctx context.Context, categoryIDs []uint64, before *time.Time, limit int) ([]feed.Entry, error) {
+	if len(categoryIDs) == 0 {
+		return nil, nil
+	}
+	subtree := database.DB.Table("categories AS c").
+		Select("c.id").
+		Joins("JOIN categories AS root ON c.path LIKE CONCAT(root.path, '%')").
+		Where("root.id IN ?", categoryIDs)
+	query := r.approvedTopics(ctx, before).
+		Select("topics.id, topics.user_id, topics.created_at, topics.category_id AS source_id").
+		Where("topics.category_id IN (?)", subtree)
+
+	var rows []feedTopicRow
+	if err := query.Order("topics.created_at DESC").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return topicEntries(rows, feed.ReasonCategory), nil
+}
+
+// approvedTopics 已通过审核且未删除的话题
+func (r *feedRepository) approvedTopics(ctx context.Context, before *time.Time) *gorm.DB {
+	query := database.DB.WithContext(ctx).Model(&topic.Topic{}).
+		Where("topics.status = ?", topic.StatusApproved)
+	if before != nil {
+		query = query.Where("topics.created_at <= ?", *before)
+	}
+	return query
+}
+
+// Topics 按 ID 获取话题
+func (r *feedRepository) Topics(ctx context.Context, ids []uint64) ([]topic.Topic, error) {
+	var topics []topic.Topic
+	if len(ids) == 0 {
+		return topics, nil
+	}
+	err := r.approvedTopics(ctx, nil).Preload("Tags").Where("topics.id IN ?", ids).Find(&topics).Error
+	return topics, err
+}
+
+// Comments 按 ID 获取评论
+func (r *feedRepository) Comments(ctx context.Context, ids []uint64) ([]comment.Comment, error) {
+	var comments []comment.Comment
+	if len(ids) == 0 {
+		return comments, nil
+	}
+	err := database.DB.WithContext(ctx).
+		Where("id IN ? AND hidden_at IS NULL", ids).
+		Find(&comments).Error
+	return comments, err
+}
+
+// Users 按 ID 获取用户
+func (r *feedRepository) Users(ctx context.Context, ids []uint64) ([]user.User, error) {
+	var users []user.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	err := database.DB.WithContext(ctx).
+		Select("id", "name", "avatar").
+		Where("id IN ?", ids).
+		Find(&users).Error
+	return users, err
+}
+
+// ContentAuthors 话题或评论的作者，内容已被删除时仍能查到
+func (r *feedRepository) ContentAuthors(ctx context.Context, kind string, ids []uint64) ([]uint64, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var model interface{} = &topic.Topic{}
+	if kind == feed.KindComment {
+		model = &comment.Comment{}
+	}
+	var authorIDs []string
+	err := database.DB.WithContext(ctx).Unscoped().Model(model).
+		Where("id IN ?", ids).
+		Distinct().
+		Pluck("user_id", &authorIDs).Error
+	return toUint64s(authorIDs), err
+}
+
+// Existing 未删除的话题或评论 ID
+func (r *feedRepository) Existing(ctx context.Context, kind string, ids []uint64) ([]uint64, error) {
+	var existing []uint64
+	if len(ids) == 0 {
+		return existing, nil
+	}
+	var model interface{} = &topic.Topic{}
+	if kind == feed.KindComment {
+		model = &comment.Comment{}
+	}
+	err := database.DB.WithContext(ctx).Model(model).
+		Where("id IN ?", ids).
+		Pluck("id", &existing).Error
+	return existing, err
+}
+
+// topicEntries 将话题查询结果转换为信息流条目
+func topicEntries(rows []feedTopicRow, reason string) []feed.Entry {
+	entries := make([]feed.Entry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, feed.Entry{
+			At: row.CreatedAt.UnixMilli(), Kind: feed.KindTopic, ID: row.ID, ActorID: cast.ToUint64(row.UserID), TopicID: row.ID,
+			Reason: reason, SourceID: row.SourceID,
+		})
+	}
+	return entries
+}
+
+// toUint64s 字符串 ID 转换为数字，忽略无法转换的值
+func toUint64s(ids []string) []uint64 {
+	result := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		if n := cast.ToUint64(id); n > 0 {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// toStrings 数字 ID 转换为字符串，用于以字符串保存的 user_id 等字段
+func toStrings(ids []uint64) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = strconv.FormatUint(id, 10)
+	}
+	return result
+}
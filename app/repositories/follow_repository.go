@@ -2,15 +2,22 @@ package repositories
 
 import (
 	"GoHub-Service/app/models/follow"
+	"GoHub-Service/app/models/user"
 	"GoHub-Service/pkg/database"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // FollowRepository 关注仓储接口
 type FollowRepository interface {
-	// Create 创建关注关系
+	// Create 创建关注关系，同时维护双方计数
 	Create(f *follow.Follow) error
-	// Delete 删除关注关系
+	// Delete 删除关注关系，同时维护双方计数
 	Delete(userID, followID string) error
+	// DeleteByID 按关注记录 ID 删除，同时维护双方计数
+	DeleteByID(id string) error
 	// GetByID 根据ID获取关注
 	GetByID(id string) (*follow.Follow, error)
 	// GetFollowers 获取粉丝列表
@@ -27,6 +34,14 @@ type FollowRepository interface {
 	CountFollowing(userID string) (int64, error)
 	// HasFollowed 检查是否已关注
 	HasFollowed(userID, followID string) (bool, error)
+	// PaginateFollowers 分页获取粉丝，按关注时间倒序，不包含已注销的用户
+	PaginateFollowers(c *gin.Context, userID string, perPage int) ([]follow.Follow, *paginator.Paging, error)
+	// PaginateFollowing 分页获取关注的人，按关注时间倒序，不包含已注销的用户
+	PaginateFollowing(c *gin.Context, userID string, perPage int) ([]follow.Follow, *paginator.Paging, error)
+	// CountMutual 互相关注的用户对数
+	CountMutual() (int64, error)
+	// Profiles 关注列表中展示的用户资料，不包含已注销的用户
+	Profiles(ids []string) ([]user.User, error)
 }
 
 type FollowRepositoryImpl struct{}
@@ -38,19 +53,27 @@ func NewFollowRepository() FollowRepository {
 
 // Create 创建关注关系
 func (r *FollowRepositoryImpl) Create(f *follow.Follow) error {
-	if err := database.DB.Create(f).Error; err != nil {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		_, err := follow.Add(tx, f.UserID, f.FollowID)
 		return err
-	}
-	return nil
+	})
 }
 
 // Delete 删除关注关系
 func (r *FollowRepositoryImpl) Delete(userID, followID string) error {
-	if err := database.DB.Where("user_id = ? AND follow_id = ?", userID, followID).
-		Delete(&follow.Follow{}).Error; err != nil {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		_, err := follow.Remove(tx, userID, followID)
+		return err
+	})
+}
+
+// DeleteByID 按关注记录 ID 删除
+func (r *FollowRepositoryImpl) DeleteByID(id string) error {
+	f, err := r.GetByID(id)
+	if err != nil {
 		return err
 	}
-	return nil
+	return r.Delete(f.UserID, f.FollowID)
 }
 
 // GetByID 根据ID获取关注
@@ -67,7 +90,7 @@ func (r *FollowRepositoryImpl) GetFollowers(userID string, offset, limit int) ([
 	var followers []follow.Follow
 	var count int64
 
-	if err := database.DB.Where("follow_id = ?", userID).Count(&count).Error; err != nil {
+	if err := database.DB.Model(&follow.Follow{}).Where("follow_id = ?", userID).Count(&count).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -88,7 +111,7 @@ func (r *FollowRepositoryImpl) GetFollowing(userID string, offset, limit int) ([
 	var following []follow.Follow
 	var count int64
 
-	if err := database.DB.Where("user_id = ?", userID).Count(&count).Error; err != nil {
+	if err := database.DB.Model(&follow.Follow{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -161,3 +184,49 @@ func (r *FollowRepositoryImpl) HasFollowed(userID, followID string) (bool, error
 	}
 	return count > 0, nil
 }
+
+// PaginateFollowers 分页获取粉丝
+func (r *FollowRepositoryImpl) PaginateFollowers(c *gin.Context, userID string, perPage int) ([]follow.Follow, *paginator.Paging, error) {
+	query := database.DB.Model(&follow.Follow{}).
+		Where("follow_id = ?", userID).
+		Where("user_id IN (SELECT id FROM users WHERE deleted_at IS NULL)").
+		Order("id DESC")
+
+	var follows []follow.Follow
+	paging := paginator.Paginate(c, query, &follows, "/api/v1/users/"+userID+"/followers", perPage)
+	return follows, &paging, nil
+}
+
+// PaginateFollowing 分页获取关注的人
+func (r *FollowRepositoryImpl) PaginateFollowing(c *gin.Context, userID string, perPage int) ([]follow.Follow, *paginator.Paging, error) {
+	query := database.DB.Model(&follow.Follow{}).
+		Where("user_id = ?", userID).
+		Where("follow_id IN (SELECT id FROM users WHERE deleted_at IS NULL)").
+		Order("id DESC")
+
+	var follows []follow.Follow
+	paging := paginator.Paginate(c, query, &follows, "/api/v1/users/"+userID+"/following", perPage)
+	return follows, &paging, nil
+}
+
+// CountMutual 互相关注的用户对数，每对只计算一次
+func (r *FollowRepositoryImpl) CountMutual() (int64, error) {
+	var count int64
+	err := database.DB.Table("follows AS a").
+		Joins("JOIN follows AS b ON a.user_id = b.follow_id AND a.follow_id = b.user_id").
+		Where("a.id < b.id").
+		Count(&count).Error
+	return count, err
+}
+
+// Profiles 关注列表中展示的用户资料
+func (r *FollowRepositoryImpl) Profiles(ids []string) ([]user.User, error) {
+	var users []user.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	err := database.DB.Select("id", "name", "avatar", "introduction", "followers_count", "following_count").
+		Where("id IN ?", ids).
+		Find(&users).Error
+	return users, err
+}
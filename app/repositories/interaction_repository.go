@@ -5,6 +5,8 @@ import (
 	"errors"
 	"time"
 
+	"GoHub-Service/app/models/collection"
+	"GoHub-Service/app/models/follow"
 	"GoHub-Service/app/models/topic"
 	"GoHub-Service/app/models/user"
 	"GoHub-Service/pkg/database"
@@ -20,7 +22,6 @@ type InteractionRepository interface {
 	UnfavoriteTopic(userID, topicID string) error
 	FollowUser(followerID, followeeID string) error
 	UnfollowUser(followerID, followeeID string) error
-	IncrementTopicView(topicID string) error
 }
 
 // TopicLike 话题点赞记录
@@ -49,19 +50,6 @@ func (TopicFavorite) TableName() string {
 	return "topic_favorites"
 }
 
-// UserFollow 用户关注关系
-type UserFollow struct {
-	ID         uint64 `gorm:"primaryKey;autoIncrement"`
-	FollowerID string `gorm:"type:bigint;not null;index:idx_user_follow_follower;uniqueIndex:uidx_user_follow_pair"`
-	FolloweeID string `gorm:"type:bigint;not null;index:idx_user_follow_followee;uniqueIndex:uidx_user_follow_pair"`
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
-}
-
-func (UserFollow) TableName() string {
-	return "user_follows"
-}
-
 // interactionRepository 实现
 type interactionRepository struct{}
 
@@ -149,26 +137,19 @@ func (r *interactionRepository) UnfavoriteTopic(userID, topicID string) error {
 			UpdateColumn("favorite_count", gorm.Expr("favorite_count - 1")).Error; err != nil {
 			return err
 		}
-		return nil
+		// 取消收藏后话题不再保留在任何收藏夹中
+		return collection.RemoveTopicForUser(tx, userID, topicID)
 	})
 }
 
+// FollowUser 关注用户，关注关系保存在 follows 表，双方计数由 follow.Add 维护
 func (r *interactionRepository) FollowUser(followerID, followeeID string) error {
 	if followerID == followeeID {
 		return errors.New("cannot follow yourself")
 	}
 	return database.DB.Transaction(func(tx *gorm.DB) error {
-		var exists int64
-		if err := tx.Model(&UserFollow{}).Where("follower_id = ? AND followee_id = ?", followerID, followeeID).Count(&exists).Error; err != nil {
-			return err
-		}
-		if exists > 0 {
-			return nil
-		}
-		if err := tx.Create(&UserFollow{FollowerID: followerID, FolloweeID: followeeID}).Error; err != nil {
-			return err
-		}
-		if err := tx.Model(&user.User{}).Where("id = ?", followeeID).UpdateColumn("followers_count", gorm.Expr("followers_count + 1")).Error; err != nil {
+		created, err := follow.Add(tx, followerID, followeeID)
+		if err != nil || !created {
 			return err
 		}
 		_ = tx.Model(&user.User{}).Where("id = ?", followeeID).UpdateColumn("points", gorm.Expr("points + 2")).Error
@@ -176,27 +157,13 @@ func (r *interactionRepository) FollowUser(followerID, followeeID string) error
 	})
 }
 
+// UnfollowUser 取消关注
 func (r *interactionRepository) UnfollowUser(followerID, followeeID string) error {
 	if followerID == followeeID {
 		return errors.New("cannot follow yourself")
 	}
 	return database.DB.Transaction(func(tx *gorm.DB) error {
-		res := tx.Where("follower_id = ? AND followee_id = ?", followerID, followeeID).Delete(&UserFollow{})
-		if res.Error != nil {
-			return res.Error
-		}
-		if res.RowsAffected == 0 {
-			return nil
-		}
-		if err := tx.Model(&user.User{}).
-			Where("id = ? AND followers_count > 0", followeeID).
-			UpdateColumn("followers_count", gorm.Expr("followers_count - 1")).Error; err != nil {
-			return err
-		}
-		return nil
+		_, err := follow.Remove(tx, followerID, followeeID)
+		return err
 	})
 }
-
-func (r *interactionRepository) IncrementTopicView(topicID string) error {
-	return database.DB.Model(&topic.Topic{}).Where("id = ?", topicID).UpdateColumn("view_count", gorm.Expr("view_count + 1")).Error
-}
@@ -0,0 +1,166 @@
+// Package repositories 友情链接数据访问层
+package repositories
+
+import (
+	"context"
+
+	"GoHub-Service/app/models/link"
+	"GoHub-Service/pkg/database"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// LinkRepository 友情链接仓储接口
+type LinkRepository interface {
+	// List 后台链接列表，可按 status、enabled、broken、keyword 筛选
+	List(ctx context.Context, c *gin.Context, perPage int) ([]link.Link, *paginator.Paging, error)
+	GetByID(ctx context.Context, id string) (*link.Link, error)
+	// GetByURL 按地址获取，不存在时返回 nil
+	GetByURL(ctx context.Context, url string) (*link.Link, error)
+	Create(ctx context.Context, l *link.Link) error
+	Save(ctx context.Context, l *link.Link) error
+	Delete(ctx context.Context, l *link.Link) error
+	// UpdateSort 在一个事务中批量修改排序值，key 为链接 ID
+	UpdateSort(ctx context.Context, orders map[uint64]int) error
+	// IncrementClicks 点击次数加一
+	IncrementClicks(ctx context.Context, id uint64) error
+	// CountPending 用户待审核的申请数
+	CountPending(ctx context.Context, applicantID uint64) (int64, error)
+	// Checkable 需要检测可用性的链接：审核通过且已启用，包括已标记失效的链接以便恢复
+	Checkable(ctx context.Context) ([]link.Link, error)
+	// SaveCheckResult 只更新检测相关字段，避免覆盖检测期间管理员的修改
+	// ok 为 false 时失败次数在 SQL 中加一，达到 threshold 时标记为失效，更新后的结果写回 l
+	SaveCheckResult(ctx context.Context, l *link.Link, ok bool, threshold int) error
+}
+
+// linkRepository 友情链接仓储实现
+type linkRepository struct{}
+
+// NewLinkRepository 创建友情链接仓储实例
+func NewLinkRepository() LinkRepository {
+	return &linkRepository{}
+}
+
+// List 后台链接列表，按排序值升序
+func (r *linkRepository) List(ctx context.Context, c *gin.Context, perPage int) ([]link.Link, *paginator.Paging, error) {
+	query := database.DB.WithContext(ctx).Model(&link.Link{})
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if enabled := c.Query("enabled"); enabled != "" {
+		query = query.Where("is_enabled = ?", enabled == "1" || enabled == "true")
+	}
+	if broken := c.Query("broken"); broken != "" {
+		query = query.Where("is_broken = ?", broken == "1" || broken == "true")
+	}
+	if keyword := c.Query("keyword"); keyword != "" {
+		like := "%" + keyword + "%"
+		query = query.Where("name LIKE ? OR url LIKE ?", like, like)
+	}
+
+	var links []link.Link
+	paging := paginator.Paginate(c, query.Order("sort_order ASC, id DESC"), &links, "/api/v1/admin/links", perPage)
+	return links, &paging, nil
+}
+
+// GetByID 获取链接，不存在时返回 nil
+func (r *linkRepository) GetByID(ctx context.Context, id string) (*link.Link, error) {
+	var l link.Link
+	result := database.DB.WithContext(ctx).Where("id = ?", id).Limit(1).Find(&l)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return &l, nil
+}
+
+// GetByURL 按地址获取
+func (r *linkRepository) GetByURL(ctx context.Context, url string) (*link.Link, error) {
+	var l link.Link
+	result := database.DB.WithContext(ctx).Where("url = ?", url).Limit(1).Find(&l)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return &l, nil
+}
+
+// Create 创建链接
+func (r *linkRepository) Create(ctx context.Context, l *link.Link) error {
+	return database.DB.WithContext(ctx).Create(l).Error
+}
+
+// Save 保存链接
+func (r *linkRepository) Save(ctx context.Context, l *link.Link) error {
+	return database.DB.WithContext(ctx).Save(l).Error
+}
+
+// Delete 删除链接
+func (r *linkRepository) Delete(ctx context.Context, l *link.Link) error {
+	return database.DB.WithContext(ctx).Delete(l).Error
+}
+
+// UpdateSort 批量修改排序值
+func (r *linkRepository) UpdateSort(ctx context.Context, orders map[uint64]int) error {
+	return database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for id, order := range orders {
+			if err := tx.Model(&link.Link{}).Where("id = ?", id).Update("sort_order", order).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// IncrementClicks 点击次数加一
+func (r *linkRepository) IncrementClicks(ctx context.Context, id uint64) error {
+	return database.DB.WithContext(ctx).Model(&link.Link{}).
+		Where("id = ?", id).
+		UpdateColumn("click_count", gorm.Expr("click_count + 1")).Error
+}
+
+// CountPending 用户待审核的申请数
+func (r *linkRepository) CountPending(ctx context.Context, applicantID uint64) (int64, error) {
+	var count int64
+	err := database.DB.WithContext(ctx).Model(&link.Link{}).
+		Where("applicant_id = ? AND status = ?", applicantID, link.StatusPending).
+		Count(&count).Error
+	return count, err
+}
+
+// Checkable 需要检测可用性的链接
+func (r *linkRepository) Checkable(ctx context.Context) ([]link.Link, error) {
+	var links []link.Link
+	err := database.DB.WithContext(ctx).
+		Where("status = ? AND is_enabled = ?", link.StatusApproved, true).
+		Order("id ASC").
+		Find(&links).Error
+	return links, err
+}
+
+// SaveCheckResult 保存检测结果，失败次数原子累加，多个实例同时检测时不会丢失计数
+func (r *linkRepository) SaveCheckResult(ctx context.Context, l *link.Link, ok bool, threshold int) error {
+	return database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{
+			"check_error":     l.CheckError,
+			"last_checked_at": l.LastCheckedAt,
+		}
+		if ok {
+			updates["fail_count"] = 0
+			updates["is_broken"] = false
+		} else {
+			updates["fail_count"] = gorm.Expr("fail_count + 1")
+		}
+		if err := tx.Model(&link.Link{}).Where("id = ?", l.ID).UpdateColumns(updates).Error; err != nil {
+			return err
+		}
+		if !ok {
+			if err := tx.Model(&link.Link{}).
+				Where("id = ? AND fail_count >= ?", l.ID, threshold).
+				UpdateColumn("is_broken", true).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&link.Link{}).Select("fail_count", "is_broken").Where("id = ?", l.ID).Take(l).Error
+	})
+}
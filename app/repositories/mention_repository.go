@@ -0,0 +1,110 @@
+// Package repositories 提及数据访问层
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"GoHub-Service/app/models/mention"
+	"GoHub-Service/app/models/user"
+	"GoHub-Service/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// MentionRepository 提及仓储接口
+type MentionRepository interface {
+	// Sync 将来源中的提及同步为 userIDs，返回新增的被提及用户
+	Sync(ctx context.Context, sourceType string, sourceID, actorID uint64, userIDs []uint64) ([]uint64, error)
+	Pending(ctx context.Context, sourceType string, sourceID uint64) ([]mention.Mention, error)
+	MarkNotified(ctx context.Context, ids []uint64) error
+	FindUsersByNames(ctx context.Context, names []string) ([]user.User, error)
+	SearchUsers(ctx context.Context, prefix string, limit int) ([]user.User, error)
+}
+
+// mentionRepository 提及仓储实现
+type mentionRepository struct{}
+
+// NewMentionRepository 创建提及仓储实例
+func NewMentionRepository() MentionRepository {
+	return &mentionRepository{}
+}
+
+// Sync 删除正文中已不存在的提及，插入新增的提及
+func (r *mentionRepository) Sync(ctx context.Context, sourceType string, sourceID, actorID uint64, userIDs []uint64) (added []uint64, err error) {
+	err = database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []uint64
+		if err := tx.Model(&mention.Mention{}).
+			Where("source_type = ? AND source_id = ?", sourceType, sourceID).
+			Pluck("user_id", &existing).Error; err != nil {
+			return err
+		}
+
+		keep := make(map[uint64]bool, len(userIDs))
+		for _, id := range userIDs {
+			keep[id] = true
+		}
+		stale := make([]uint64, 0)
+		for _, id := range existing {
+			if keep[id] {
+				delete(keep, id)
+				continue
+			}
+			stale = append(stale, id)
+		}
+		if len(stale) > 0 {
+			if err := tx.Where("source_type = ? AND source_id = ? AND user_id IN ?", sourceType, sourceID, stale).
+				Delete(&mention.Mention{}).Error; err != nil {
+				return err
+			}
+		}
+
+		// 按正文中的顺序插入
+		for _, id := range userIDs {
+			if !keep[id] {
+				continue
+			}
+			if err := tx.Create(&mention.Mention{
+				SourceType: sourceType,
+				SourceID:   sourceID,
+				UserID:     id,
+				ActorID:    actorID,
+			}).Error; err != nil {
+				return err
+			}
+			added = append(added, id)
+		}
+		return nil
+	})
+	return
+}
+
+// Pending 来源中尚未通知的提及
+func (r *mentionRepository) Pending(ctx context.Context, sourceType string, sourceID uint64) ([]mention.Mention, error) {
+	var mentions []mention.Mention
+	err := database.DB.WithContext(ctx).
+		Where("source_type = ? AND source_id = ? AND notified_at IS NULL", sourceType, sourceID).
+		Order("id ASC").
+		Find(&mentions).Error
+	return mentions, err
+}
+
+// MarkNotified 标记提及已通知
+func (r *mentionRepository) MarkNotified(ctx context.Context, ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return database.DB.WithContext(ctx).Model(&mention.Mention{}).
+		Where("id IN ?", ids).
+		UpdateColumn("notified_at", time.Now()).Error
+}
+
+// FindUsersByNames 按用户名查找用户，不区分大小写
+func (r *mentionRepository) FindUsersByNames(ctx context.Context, names []string) ([]user.User, error) {
+	return user.GetByNamesFold(names)
+}
+
+// SearchUsers 按用户名前缀查找用户，用于输入 @ 时的自动补全
+func (r *mentionRepository) SearchUsers(ctx context.Context, prefix string, limit int) ([]user.User, error) {
+	return user.SearchByNamePrefix(prefix, limit)
+}
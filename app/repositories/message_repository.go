@@ -13,10 +13,15 @@ import (
 
 // MessageRepository 私信仓储接口
 type MessageRepository interface {
+	GetByID(id string) (*message.Message, error)
 	Create(msg *message.Message) error
 	ListConversation(c *gin.Context, conversationID string, participantID string, perPage int) ([]message.Message, *paginator.Paging, error)
+	ListConversationCursor(c *gin.Context, conversationID string, participantID string, perPage int) ([]message.Message, *paginator.CursorPaging, error)
 	MarkConversationRead(conversationID, receiverID string) (int64, error)
 	CountUnread(receiverID string) (int64, error)
+	Hide(id string) error
+	Unhide(id string) error
+	Delete(id string) error
 }
 
 type messageRepository struct{}
@@ -26,6 +31,16 @@ func NewMessageRepository() MessageRepository {
 	return &messageRepository{}
 }
 
+// GetByID 根据ID获取私信，不存在时返回 nil
+func (r *messageRepository) GetByID(id string) (*message.Message, error) {
+	var msg message.Message
+	result := database.DB.Where("id = ?", id).Limit(1).Find(&msg)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return &msg, nil
+}
+
 func (r *messageRepository) Create(msg *message.Message) error {
 	msg.Create()
 	if msg.ID == 0 {
@@ -44,6 +59,24 @@ func (r *messageRepository) ListConversation(c *gin.Context, conversationID stri
 	return messages, &paging, nil
 }
 
+// ListConversationCursor 游标分页获取会话消息，按发送时间正序
+func (r *messageRepository) ListConversationCursor(c *gin.Context, conversationID string, participantID string, perPage int) ([]message.Message, *paginator.CursorPaging, error) {
+	query := database.DB.Model(&message.Message{}).
+		Where("conversation_id = ? AND (sender_id = ? OR receiver_id = ?)", conversationID, participantID, participantID)
+
+	messages, paging, err := paginator.CursorPaginate(c, query, paginator.CursorOptions{
+		Columns: []paginator.SortColumn{{Name: "created_at"}, {Name: "id"}},
+		BaseURL: "/api/v1/messages?user_id=" + c.Query("user_id"),
+		PerPage: perPage,
+	}, func(m *message.Message) []interface{} {
+		return []interface{}{m.CreatedAt, m.ID}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return messages, &paging, nil
+}
+
 func (r *messageRepository) MarkConversationRead(conversationID, receiverID string) (int64, error) {
 	now := time.Now()
 	result := database.DB.Model(&message.Message{}).
@@ -61,3 +94,22 @@ func (r *messageRepository) CountUnread(receiverID string) (int64, error) {
 	}
 	return count, nil
 }
+
+// Hide 隐藏私信内容
+func (r *messageRepository) Hide(id string) error {
+	return database.DB.Model(&message.Message{}).
+		Where("id = ? AND hidden_at IS NULL", id).
+		UpdateColumn("hidden_at", time.Now()).Error
+}
+
+// Unhide 恢复被隐藏的私信
+func (r *messageRepository) Unhide(id string) error {
+	return database.DB.Model(&message.Message{}).
+		Where("id = ? AND hidden_at IS NOT NULL", id).
+		UpdateColumn("hidden_at", nil).Error
+}
+
+// Delete 删除私信，私信没有回收站
+func (r *messageRepository) Delete(id string) error {
+	return database.DB.Where("id = ?", id).Delete(&message.Message{}).Error
+}
@@ -0,0 +1,40 @@
+// Package repositories 版主操作日志数据访问层
+package repositories
+
+import (
+	"context"
+
+	"GoHub-Service/app/models/moderation"
+	"GoHub-Service/pkg/database"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ModerationLogRepository 版主操作日志仓储接口
+type ModerationLogRepository interface {
+	Create(ctx context.Context, log *moderation.Log) error
+	List(ctx context.Context, c *gin.Context, perPage int) ([]moderation.Log, *paginator.Paging, error)
+}
+
+// moderationLogRepository 版主操作日志仓储实现
+type moderationLogRepository struct{}
+
+// NewModerationLogRepository 创建版主操作日志仓储实例
+func NewModerationLogRepository() ModerationLogRepository {
+	return &moderationLogRepository{}
+}
+
+// Create 写入操作日志
+func (r *moderationLogRepository) Create(ctx context.Context, log *moderation.Log) error {
+	if err := database.DB.WithContext(ctx).Create(log).Error; err != nil {
+		return NewCreateError("操作日志", err)
+	}
+	return nil
+}
+
+// List 分页获取操作日志
+func (r *moderationLogRepository) List(ctx context.Context, c *gin.Context, perPage int) ([]moderation.Log, *paginator.Paging, error) {
+	logs, paging := moderation.Paginate(c, perPage)
+	return logs, &paging, nil
+}
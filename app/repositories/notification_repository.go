@@ -18,6 +18,7 @@ import (
 type NotificationRepository interface {
 	Create(userID, actorID, typ string, data map[string]interface{}) error
 	ListByUser(c *gin.Context, userID string, perPage int) ([]notification.Notification, *paginator.Paging, error)
+	ListByUserCursor(c *gin.Context, userID string, perPage int) ([]notification.Notification, *paginator.CursorPaging, error)
 	MarkRead(id, userID string) error
 	MarkAllRead(userID string) error
 }
@@ -52,6 +53,24 @@ func (r *notificationRepository) ListByUser(c *gin.Context, userID string, perPa
 	return notifications, &paging, nil
 }
 
+// ListByUserCursor 游标分页获取用户通知列表，新通知到达不会导致翻页重复
+func (r *notificationRepository) ListByUserCursor(c *gin.Context, userID string, perPage int) ([]notification.Notification, *paginator.CursorPaging, error) {
+	query := database.DB.Model(&notification.Notification{}).
+		Where("user_id = ?", userID)
+
+	notifications, paging, err := paginator.CursorPaginate(c, query, paginator.CursorOptions{
+		Columns: []paginator.SortColumn{{Name: "created_at", Desc: true}, {Name: "id", Desc: true}},
+		BaseURL: "/api/v1/notifications",
+		PerPage: perPage,
+	}, func(n *notification.Notification) []interface{} {
+		return []interface{}{n.CreatedAt, n.ID}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return notifications, &paging, nil
+}
+
 // MarkRead 标记单条通知已读（限制用户）
 func (r *notificationRepository) MarkRead(id, userID string) error {
 	readAt := sql.NullTime{Time: time.Now(), Valid: true}
@@ -0,0 +1,160 @@
+// Package repositories 表情回应数据访问层
+package repositories
+
+import (
+	"context"
+
+	"GoHub-Service/app/models/reaction"
+	"GoHub-Service/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// ReactionRepository 表情回应仓储接口
+type ReactionRepository interface {
+	// Types 可用表情列表，按 Position 升序；enabledOnly 为 false 时包含已停用的表情
+	Types(ctx context.Context, enabledOnly bool) ([]reaction.Type, error)
+	GetType(ctx context.Context, id string) (*reaction.Type, error)
+	GetTypeByEmoji(ctx context.Context, emoji string) (*reaction.Type, error)
+	CreateType(ctx context.Context, t *reaction.Type) error
+	SaveType(ctx context.Context, t *reaction.Type) error
+	DeleteType(ctx context.Context, t *reaction.Type) error
+
+	Add(ctx context.Context, targetType string, targetID, userID uint64, emoji string) (bool, error)
+	Remove(ctx context.Context, targetType string, targetID, userID uint64, emoji string) (bool, error)
+	// Counts 批量统计目标上各表情的回应数，按首次回应的时间排序
+	Counts(ctx context.Context, targetType string, targetIDs []uint64) (map[uint64][]reaction.Count, error)
+	// Mine 批量获取用户在目标上回应过的表情
+	Mine(ctx context.Context, targetType string, targetIDs []uint64, userID uint64) (map[uint64][]string, error)
+}
+
+// reactionRepository 表情回应仓储实现
+type reactionRepository struct{}
+
+// NewReactionRepository 创建表情回应仓储实例
+func NewReactionRepository() ReactionRepository {
+	return &reactionRepository{}
+}
+
+// Types 可用表情列表
+func (r *reactionRepository) Types(ctx context.Context, enabledOnly bool) ([]reaction.Type, error) {
+	var types []reaction.Type
+	query := database.DB.WithContext(ctx).Model(&reaction.Type{})
+	if enabledOnly {
+		query = query.Where("enabled = ?", true)
+	}
+	err := query.Order("position ASC, id ASC").Find(&types).Error
+	return types, err
+}
+
+// GetType 获取表情，不存在时返回 nil
+func (r *reactionRepository) GetType(ctx context.Context, id string) (*reaction.Type, error) {
+	var t reaction.Type
+	result := database.DB.WithContext(ctx).Where("id = ?", id).Limit(1).Find(&t)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return &t, nil
+}
+
+// GetTypeByEmoji 按表情字符获取，不存在时返回 nil
+func (r *reactionRepository) GetTypeByEmoji(ctx context.Context, emoji string) (*reaction.Type, error) {
+	var t reaction.Type
+	result := database.DB.WithContext(ctx).Where("emoji = ?", emoji).Limit(1).Find(&t)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return &t, nil
+}
+
+// CreateType 创建表情
+func (r *reactionRepository) CreateType(ctx context.Context, t *reaction.Type) error {
+	return database.DB.WithContext(ctx).Create(t).Error
+}
+
+// SaveType 保存表情
+func (r *reactionRepository) SaveType(ctx context.Context, t *reaction.Type) error {
+	return database.DB.WithContext(ctx).Save(t).Error
+}
+
+// DeleteType 删除表情，已有的回应一并删除
+func (r *reactionRepository) DeleteType(ctx context.Context, t *reaction.Type) error {
+	return database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("emoji = ?", t.Emoji).Delete(&reaction.Reaction{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(t).Error
+	})
+}
+
+// Add 添加回应，已回应过该表情时返回 false
+func (r *reactionRepository) Add(ctx context.Context, targetType string, targetID, userID uint64, emoji string) (bool, error) {
+	var count int64
+	db := database.DB.WithContext(ctx)
+	if err := db.Model(&reaction.Reaction{}).
+		Where("target_type = ? AND target_id = ? AND user_id = ? AND emoji = ?", targetType, targetID, userID, emoji).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return false, nil
+	}
+	model := &reaction.Reaction{TargetType: targetType, TargetID: targetID, UserID: userID, Emoji: emoji}
+	if err := db.Create(model).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Remove 取消回应，未回应过该表情时返回 false
+func (r *reactionRepository) Remove(ctx context.Context, targetType string, targetID, userID uint64, emoji string) (bool, error) {
+	result := database.DB.WithContext(ctx).
+		Where("target_type = ? AND target_id = ? AND user_id = ? AND emoji = ?", targetType, targetID, userID, emoji).
+		Delete(&reaction.Reaction{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Counts 批量统计目标上各表情的回应数
+func (r *reactionRepository) Counts(ctx context.Context, targetType string, targetIDs []uint64) (map[uint64][]reaction.Count, error) {
+	counts := make(map[uint64][]reaction.Count, len(targetIDs))
+	if len(targetIDs) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		TargetID uint64
+		Emoji    string
+		Count    int64
+	}
+	if err := database.DB.WithContext(ctx).Model(&reaction.Reaction{}).
+		Select("target_id, emoji, COUNT(*) AS count").
+		Where("target_type = ? AND target_id IN ?", targetType, targetIDs).
+		Group("target_id, emoji").
+		Order("MIN(id) ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.TargetID] = append(counts[row.TargetID], reaction.Count{Emoji: row.Emoji, Count: row.Count})
+	}
+	return counts, nil
+}
+
+// Mine 批量获取用户在目标上回应过的表情
+func (r *reactionRepository) Mine(ctx context.Context, targetType string, targetIDs []uint64, userID uint64) (map[uint64][]string, error) {
+	mine := make(map[uint64][]string)
+	if userID == 0 || len(targetIDs) == 0 {
+		return mine, nil
+	}
+	var reactions []reaction.Reaction
+	if err := database.DB.WithContext(ctx).
+		Select("target_id, emoji").
+		Where("target_type = ? AND target_id IN ? AND user_id = ?", targetType, targetIDs, userID).
+		Order("id ASC").
+		Find(&reactions).Error; err != nil {
+		return nil, err
+	}
+	for _, re := range reactions {
+		mine[re.TargetID] = append(mine[re.TargetID], re.Emoji)
+	}
+	return mine, nil
+}
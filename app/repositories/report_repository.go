@@ -0,0 +1,187 @@
+// Package repositories 举报数据访问层
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/report"
+	"GoHub-Service/pkg/database"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ReportRepository 举报仓储接口
+type ReportRepository interface {
+	GetByID(ctx context.Context, id string) (*report.Report, error)
+	// GetByReporter 获取用户对目标的举报，不存在时返回 nil
+	GetByReporter(ctx context.Context, reporterID uint64, targetType string, targetID uint64) (*report.Report, error)
+	Create(ctx context.Context, r *report.Report) error
+	// List 举报队列，可按状态、目标类型、原因和目标筛选，被指派了分类的版主只看到其管理的分类
+	List(ctx context.Context, c *gin.Context, moderatorID string, perPage int) ([]report.Report, *paginator.Paging, error)
+	ListByReporter(ctx context.Context, c *gin.Context, reporterID uint64, perPage int) ([]report.Report, *paginator.Paging, error)
+	// CountPendingReporters 统计目标上仍待处理的举报人数
+	CountPendingReporters(ctx context.Context, targetType string, targetID uint64) (int64, error)
+	// HasAction 目标上是否有以指定方式处置过的举报
+	HasAction(ctx context.Context, targetType string, targetID uint64, action string) (bool, error)
+	Triage(ctx context.Context, id uint64, moderatorID uint64) error
+	// Reopen 重新打开已处理的举报，用于同一举报人再次举报同一目标
+	Reopen(ctx context.Context, id uint64, detail string) error
+	// Close 关闭目标上所有待处理的举报，返回被关闭的举报
+	Close(ctx context.Context, targetType string, targetID uint64, status, action string, moderatorID uint64, note string) ([]report.Report, error)
+}
+
+// reportRepository 举报仓储实现
+type reportRepository struct{}
+
+// NewReportRepository 创建举报仓储实例
+func NewReportRepository() ReportRepository {
+	return &reportRepository{}
+}
+
+// GetByID 获取举报，不存在时返回 nil
+func (r *reportRepository) GetByID(ctx context.Context, id string) (*report.Report, error) {
+	var rp report.Report
+	result := database.DB.WithContext(ctx).Where("id = ?", id).Limit(1).Find(&rp)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return &rp, nil
+}
+
+// GetByReporter 获取用户对目标的举报
+func (r *reportRepository) GetByReporter(ctx context.Context, reporterID uint64, targetType string, targetID uint64) (*report.Report, error) {
+	var rp report.Report
+	result := database.DB.WithContext(ctx).
+		Where("reporter_id = ? AND target_type = ? AND target_id = ?", reporterID, targetType, targetID).
+		Limit(1).Find(&rp)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return &rp, nil
+}
+
+// Create 创建举报
+func (r *reportRepository) Create(ctx context.Context, rp *report.Report) error {
+	if err := database.DB.WithContext(ctx).Create(rp).Error; err != nil {
+		return err
+	}
+	if rp.ID == 0 {
+		return NewCreateError("举报", nil)
+	}
+	return nil
+}
+
+// List 举报队列，默认只看待处理的举报，最早的在前
+func (r *reportRepository) List(ctx context.Context, c *gin.Context, moderatorID string, perPage int) ([]report.Report, *paginator.Paging, error) {
+	query := database.DB.WithContext(ctx).Model(&report.Report{})
+	switch status := c.Query("status"); status {
+	case "", "pending":
+		query = query.Where("status IN ?", []string{report.StatusOpen, report.StatusTriaged})
+	case "all":
+	default:
+		query = query.Where("status = ?", status)
+	}
+	if targetType := c.Query("target_type"); targetType != "" {
+		query = query.Where("target_type = ?", targetType)
+	}
+	if targetID := c.Query("target_id"); targetID != "" {
+		query = query.Where("target_id = ?", targetID)
+	}
+	if reason := c.Query("reason"); reason != "" {
+		query = query.Where("reason = ?", reason)
+	}
+	if ids, scoped := category.ModeratorScope(moderatorID); scoped {
+		query = report.ScopeToCategories(query, ids)
+	}
+
+	var reports []report.Report
+	paging := paginator.Paginate(c, query.Order("id ASC"), &reports, "/api/v1/moderator/reports", perPage)
+	return reports, &paging, nil
+}
+
+// ListByReporter 用户提交的举报，最近的在前
+func (r *reportRepository) ListByReporter(ctx context.Context, c *gin.Context, reporterID uint64, perPage int) ([]report.Report, *paginator.Paging, error) {
+	query := database.DB.WithContext(ctx).Model(&report.Report{}).
+		Where("reporter_id = ?", reporterID).
+		Order("id DESC")
+
+	var reports []report.Report
+	paging := paginator.Paginate(c, query, &reports, "/api/v1/reports", perPage)
+	return reports, &paging, nil
+}
+
+// CountPendingReporters 统计目标上仍待处理的举报人数，每个用户对同一目标只有一条举报
+func (r *reportRepository) CountPendingReporters(ctx context.Context, targetType string, targetID uint64) (int64, error) {
+	var count int64
+	err := database.DB.WithContext(ctx).Model(&report.Report{}).
+		Where("target_type = ? AND target_id = ? AND status IN ?", targetType, targetID, []string{report.StatusOpen, report.StatusTriaged}).
+		Count(&count).Error
+	return count, err
+}
+
+// HasAction 目标上是否有以指定方式处置过的举报
+func (r *reportRepository) HasAction(ctx context.Context, targetType string, targetID uint64, action string) (bool, error) {
+	var count int64
+	err := database.DB.WithContext(ctx).Model(&report.Report{}).
+		Where("target_type = ? AND target_id = ? AND status = ? AND action = ?", targetType, targetID, report.StatusActioned, action).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Triage 分拣举报，记录分拣人
+func (r *reportRepository) Triage(ctx context.Context, id uint64, moderatorID uint64) error {
+	return database.DB.WithContext(ctx).Model(&report.Report{}).
+		Where("id = ? AND status = ?", id, report.StatusOpen).
+		Updates(map[string]interface{}{"status": report.StatusTriaged, "handled_by": moderatorID}).Error
+}
+
+// Reopen 重新打开举报并清除处理结果
+func (r *reportRepository) Reopen(ctx context.Context, id uint64, detail string) error {
+	return database.DB.WithContext(ctx).Model(&report.Report{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       report.StatusOpen,
+			"detail":       detail,
+			"action":       "",
+			"handled_by":   0,
+			"handled_at":   nil,
+			"handler_note": "",
+		}).Error
+}
+
+// Close 关闭目标上所有待处理的举报
+func (r *reportRepository) Close(ctx context.Context, targetType string, targetID uint64, status, action string, moderatorID uint64, note string) (closed []report.Report, err error) {
+	err = database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		pending := tx.Model(&report.Report{}).
+			Where("target_type = ? AND target_id = ? AND status IN ?", targetType, targetID, []string{report.StatusOpen, report.StatusTriaged})
+		if err := pending.Session(&gorm.Session{}).Find(&closed).Error; err != nil {
+			return err
+		}
+		if len(closed) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		ids := make([]uint64, 0, len(closed))
+		for i := range closed {
+			ids = append(ids, closed[i].ID)
+			closed[i].Status = status
+			closed[i].Action = action
+			closed[i].HandledBy = moderatorID
+			closed[i].HandledAt = &now
+			closed[i].HandlerNote = note
+		}
+		return tx.Model(&report.Report{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"status":       status,
+			"action":       action,
+			"handled_by":   moderatorID,
+			"handled_at":   now,
+			"handler_note": note,
+		}).Error
+	})
+	return closed, err
+}
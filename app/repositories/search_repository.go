@@ -12,7 +12,7 @@ import (
 
 // SearchRepository 搜索仓储接口
 type SearchRepository interface {
-	SearchTopics(c *gin.Context, keyword string, perPage int) ([]topic.Topic, *paginator.Paging, error)
+	SearchTopics(c *gin.Context, keyword string, perPage int, hiddenCategoryIDs []uint64) ([]topic.Topic, *paginator.Paging, error)
 	SearchUsers(c *gin.Context, keyword string, perPage int) ([]user.User, *paginator.Paging, error)
 }
 
@@ -23,14 +23,19 @@ func NewSearchRepository() SearchRepository {
 	return &searchRepository{}
 }
 
-func (r *searchRepository) SearchTopics(c *gin.Context, keyword string, perPage int) ([]topic.Topic, *paginator.Paging, error) {
+// SearchTopics 搜索已发布的话题，hiddenCategoryIDs 为当前用户不可见的分类
+func (r *searchRepository) SearchTopics(c *gin.Context, keyword string, perPage int, hiddenCategoryIDs []uint64) ([]topic.Topic, *paginator.Paging, error) {
 	var topics []topic.Topic
 	like := "%" + keyword + "%"
 	query := database.DB.Model(&topic.Topic{}).
+		Where("status = ?", topic.StatusApproved).
 		Where("title LIKE ? OR body LIKE ?", like, like).
 		Preload("User").
 		Preload("Category").
 		Order("created_at DESC")
+	if len(hiddenCategoryIDs) > 0 {
+		query = query.Where("category_id NOT IN ?", hiddenCategoryIDs)
+	}
 
 	paging := paginator.Paginate(c, query, &topics, "/api/v1/search/topics", perPage)
 	return topics, &paging, nil
@@ -0,0 +1,133 @@
+// Package repositories 敏感词数据访问层
+package repositories
+
+import (
+	"context"
+
+	"GoHub-Service/app/models/sensitive"
+	"GoHub-Service/pkg/database"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SensitiveWordRepository 敏感词仓储接口
+type SensitiveWordRepository interface {
+	// List 后台词库列表，可按分类、处理方式和关键字筛选
+	List(ctx context.Context, c *gin.Context, perPage int) ([]sensitive.Word, *paginator.Paging, error)
+	// All 全部敏感词，category 不为空时只返回该分类
+	All(ctx context.Context, category string) ([]sensitive.Word, error)
+	GetByID(ctx context.Context, id string) (*sensitive.Word, error)
+	GetByWord(ctx context.Context, word string) (*sensitive.Word, error)
+	Create(ctx context.Context, w *sensitive.Word) error
+	Save(ctx context.Context, w *sensitive.Word) error
+	Delete(ctx context.Context, w *sensitive.Word) error
+	// Import 批量导入，已存在的词更新分类、处理方式和变体，返回新增和更新的数量
+	Import(ctx context.Context, words []sensitive.Word) (created, updated int, err error)
+}
+
+// sensitiveWordRepository 敏感词仓储实现
+type sensitiveWordRepository struct{}
+
+// NewSensitiveWordRepository 创建敏感词仓储实例
+func NewSensitiveWordRepository() SensitiveWordRepository {
+	return &sensitiveWordRepository{}
+}
+
+// List 后台词库列表
+func (r *sensitiveWordRepository) List(ctx context.Context, c *gin.Context, perPage int) ([]sensitive.Word, *paginator.Paging, error) {
+	query := database.DB.WithContext(ctx).Model(&sensitive.Word{})
+	if category := c.Query("category"); category != "" {
+		query = query.Where("category = ?", category)
+	}
+	if severity := c.Query("severity"); severity != "" {
+		query = query.Where("severity = ?", severity)
+	}
+	if keyword := c.Query("keyword"); keyword != "" {
+		like := "%" + keyword + "%"
+		query = query.Where("word LIKE ? OR pinyin LIKE ?", like, like)
+	}
+
+	var words []sensitive.Word
+	paging := paginator.Paginate(c, query.Order("id DESC"), &words, "/api/v1/admin/sensitive-words", perPage)
+	return words, &paging, nil
+}
+
+// All 全部敏感词
+func (r *sensitiveWordRepository) All(ctx context.Context, category string) ([]sensitive.Word, error) {
+	var words []sensitive.Word
+	query := database.DB.WithContext(ctx).Model(&sensitive.Word{})
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+	err := query.Order("id ASC").Find(&words).Error
+	return words, err
+}
+
+// GetByID 获取敏感词，不存在时返回 nil
+func (r *sensitiveWordRepository) GetByID(ctx context.Context, id string) (*sensitive.Word, error) {
+	var w sensitive.Word
+	result := database.DB.WithContext(ctx).Where("id = ?", id).Limit(1).Find(&w)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return &w, nil
+}
+
+// GetByWord 按词获取，不存在时返回 nil
+func (r *sensitiveWordRepository) GetByWord(ctx context.Context, word string) (*sensitive.Word, error) {
+	var w sensitive.Word
+	result := database.DB.WithContext(ctx).Where("word = ?", word).Limit(1).Find(&w)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return &w, nil
+}
+
+// Create 创建敏感词
+func (r *sensitiveWordRepository) Create(ctx context.Context, w *sensitive.Word) error {
+	return database.DB.WithContext(ctx).Create(w).Error
+}
+
+// Save 保存敏感词
+func (r *sensitiveWordRepository) Save(ctx context.Context, w *sensitive.Word) error {
+	return database.DB.WithContext(ctx).Save(w).Error
+}
+
+// Delete 删除敏感词
+func (r *sensitiveWordRepository) Delete(ctx context.Context, w *sensitive.Word) error {
+	return database.DB.WithContext(ctx).Delete(w).Error
+}
+
+// Import 在一个事务中批量导入
+func (r *sensitiveWordRepository) Import(ctx context.Context, words []sensitive.Word) (created, updated int, err error) {
+	err = database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		created, updated = 0, 0
+		for i := range words {
+			var existing sensitive.Word
+			result := tx.Where("word = ?", words[i].Word).Limit(1).Find(&existing)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				if err := tx.Create(&words[i]).Error; err != nil {
+					return err
+				}
+				created++
+				continue
+			}
+			if err := tx.Model(&existing).Updates(map[string]interface{}{
+				"category": words[i].Category,
+				"severity": words[i].Severity,
+				"pinyin":   words[i].Pinyin,
+				"note":     words[i].Note,
+			}).Error; err != nil {
+				return err
+			}
+			updated++
+		}
+		return nil
+	})
+	return created, updated, err
+}
@@ -0,0 +1,190 @@
+// Package repositories 话题系列数据访问层
+package repositories
+
+import (
+	"context"
+
+	"GoHub-Service/app/models/series"
+	"GoHub-Service/pkg/database"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SeriesRepository 话题系列仓储接口
+type SeriesRepository interface {
+	Create(ctx context.Context, s *series.Series) error
+	GetByID(ctx context.Context, id string) (*series.Series, error)
+	UpdateColumns(ctx context.Context, s *series.Series, columns ...string) error
+	Delete(ctx context.Context, id uint64) error
+	ListByUser(ctx context.Context, c *gin.Context, userID string, perPage int) ([]series.Series, *paginator.Paging, error)
+	Parts(ctx context.Context, seriesID uint64, approvedOnly bool, hiddenCategoryIDs []uint64) ([]series.Part, error)
+	SeriesIDOfTopic(ctx context.Context, topicID string) (uint64, error)
+	AddTopic(ctx context.Context, seriesID, topicID uint64) error
+	RemoveTopic(ctx context.Context, seriesID, topicID uint64) (bool, error)
+	Reorder(ctx context.Context, seriesID uint64, topicIDs []uint64) error
+	Follow(ctx context.Context, seriesID, userID uint64) (bool, error)
+	Unfollow(ctx context.Context, seriesID, userID uint64) (bool, error)
+	IsFollowing(ctx context.Context, seriesID, userID uint64) (bool, error)
+	FollowerIDs(ctx context.Context, seriesID uint64) ([]uint64, error)
+}
+
+// seriesRepository 话题系列仓储实现
+type seriesRepository struct{}
+
+// NewSeriesRepository 创建话题系列仓储实例
+func NewSeriesRepository() SeriesRepository {
+	return &seriesRepository{}
+}
+
+// Create 创建系列
+func (r *seriesRepository) Create(ctx context.Context, s *series.Series) error {
+	s.Create()
+	if s.ID == 0 {
+		return NewCreateError("系列", nil)
+	}
+	return nil
+}
+
+// GetByID 根据ID获取系列，不存在时返回 nil
+func (r *seriesRepository) GetByID(ctx context.Context, id string) (*series.Series, error) {
+	s := series.Get(id)
+	if s.ID == 0 {
+		return nil, nil
+	}
+	return &s, nil
+}
+
+// UpdateColumns 只更新指定字段
+func (r *seriesRepository) UpdateColumns(ctx context.Context, s *series.Series, columns ...string) error {
+	return database.DB.WithContext(ctx).Model(s).Select(columns).Updates(s).Error
+}
+
+// Delete 删除系列及其话题关系和关注关系，话题本身不受影响
+func (r *seriesRepository) Delete(ctx context.Context, id uint64) error {
+	return database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("series_id = ?", id).Delete(&series.Item{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("series_id = ?", id).Delete(&series.Follow{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&series.Series{}, id).Error
+	})
+}
+
+// ListByUser 分页获取作者的系列
+func (r *seriesRepository) ListByUser(ctx context.Context, c *gin.Context, userID string, perPage int) ([]series.Series, *paginator.Paging, error) {
+	data, paging := series.PaginateByUser(c, userID, perPage)
+	return data, &paging, nil
+}
+
+// Parts 系列中的话题
+func (r *seriesRepository) Parts(ctx context.Context, seriesID uint64, approvedOnly bool, hiddenCategoryIDs []uint64) ([]series.Part, error) {
+	return series.Parts(seriesID, approvedOnly, hiddenCategoryIDs)
+}
+
+// SeriesIDOfTopic 话题所属的系列 ID
+func (r *seriesRepository) SeriesIDOfTopic(ctx context.Context, topicID string) (uint64, error) {
+	return series.SeriesIDOfTopic(topicID)
+}
+
+// AddTopic 将话题追加到系列末尾
+func (r *seriesRepository) AddTopic(ctx context.Context, seriesID, topicID uint64) error {
+	return database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var maxPosition int
+		if err := tx.Model(&series.Item{}).
+			Select("COALESCE(MAX(position), 0)").
+			Where("series_id = ?", seriesID).
+			Scan(&maxPosition).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&series.Item{SeriesID: seriesID, TopicID: topicID, Position: maxPosition + 1}).Error; err != nil {
+			return err
+		}
+		return series.RecountTopics(tx, seriesID)
+	})
+}
+
+// RemoveTopic 将话题移出系列
+func (r *seriesRepository) RemoveTopic(ctx context.Context, seriesID, topicID uint64) (removed bool, err error) {
+	err = database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("series_id = ? AND topic_id = ?", seriesID, topicID).Delete(&series.Item{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		removed = true
+		return series.RecountTopics(tx, seriesID)
+	})
+	return
+}
+
+// Reorder 按给定话题顺序重排系列，未列出的话题排在最后并保持原有顺序
+func (r *seriesRepository) Reorder(ctx context.Context, seriesID uint64, topicIDs []uint64) error {
+	return database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&series.Item{}).
+			Where("series_id = ? AND topic_id NOT IN ?", seriesID, topicIDs).
+			UpdateColumn("position", gorm.Expr("position + ?", len(topicIDs))).Error; err != nil {
+			return err
+		}
+		for i, topicID := range topicIDs {
+			if err := tx.Model(&series.Item{}).
+				Where("series_id = ? AND topic_id = ?", seriesID, topicID).
+				UpdateColumn("position", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Follow 关注系列，已关注时返回 false
+func (r *seriesRepository) Follow(ctx context.Context, seriesID, userID uint64) (followed bool, err error) {
+	err = database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var exists int64
+		if err := tx.Model(&series.Follow{}).
+			Where("series_id = ? AND user_id = ?", seriesID, userID).
+			Count(&exists).Error; err != nil {
+			return err
+		}
+		if exists > 0 {
+			return nil
+		}
+		if err := tx.Create(&series.Follow{SeriesID: seriesID, UserID: userID}).Error; err != nil {
+			return err
+		}
+		followed = true
+		return tx.Model(&series.Series{}).Where("id = ?", seriesID).
+			UpdateColumn("followers_count", gorm.Expr("followers_count + 1")).Error
+	})
+	return
+}
+
+// Unfollow 取消关注系列，未关注时返回 false
+func (r *seriesRepository) Unfollow(ctx context.Context, seriesID, userID uint64) (unfollowed bool, err error) {
+	err = database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("series_id = ? AND user_id = ?", seriesID, userID).Delete(&series.Follow{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		unfollowed = true
+		return tx.Model(&series.Series{}).Where("id = ? AND followers_count > 0", seriesID).
+			UpdateColumn("followers_count", gorm.Expr("followers_count - 1")).Error
+	})
+	return
+}
+
+// IsFollowing 用户是否关注了系列
+func (r *seriesRepository) IsFollowing(ctx context.Context, seriesID, userID uint64) (bool, error) {
+	var count int64
+	err := database.DB.WithContext(ctx).Model(&series.Follow{}).
+		Where("series_id = ? AND user_id = ?", seriesID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FollowerIDs 关注系列的用户 ID
+func (r *seriesRepository) FollowerIDs(ctx context.Context, seriesID uint64) ([]uint64, error) {
+	return series.FollowerIDs(seriesID)
+}
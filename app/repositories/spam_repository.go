@@ -0,0 +1,127 @@
+// Package repositories 垃圾信息检测数据访问层
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"GoHub-Service/app/models/spam"
+	"GoHub-Service/pkg/database"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm/clause"
+)
+
+// SpamRepository 垃圾信息检测仓储接口
+type SpamRepository interface {
+	// SaveFingerprint 保存内容指纹，内容修改后覆盖原指纹
+	SaveFingerprint(ctx context.Context, f *spam.Fingerprint) error
+	// Candidates 查找 since 之后发布的、指纹任一分段相同的内容
+	Candidates(ctx context.Context, bands [4]uint16, since time.Time, limit int) ([]spam.Fingerprint, error)
+	// CountRecent 统计用户 since 之后发布的指定类型内容数，已删除的内容也计入
+	CountRecent(ctx context.Context, userID uint64, contentType string, since time.Time) (int64, error)
+
+	CreateAttempt(ctx context.Context, a *spam.Attempt) error
+	// Attempts 后台拦截记录列表，可按 action、reason、content_type、user_id 筛选
+	Attempts(ctx context.Context, c *gin.Context, perPage int) ([]spam.Attempt, *paginator.Paging, error)
+	// AttemptStats 按日期汇总 since 之后的拦截次数
+	AttemptStats(ctx context.Context, since time.Time) ([]spam.AttemptStat, error)
+
+	// GetSettings 获取后台设置，未设置过时返回 nil
+	GetSettings(ctx context.Context) (*spam.Settings, error)
+	SaveSettings(ctx context.Context, s *spam.Settings) error
+}
+
+// spamRepository 垃圾信息检测仓储实现
+type spamRepository struct{}
+
+// NewSpamRepository 创建垃圾信息检测仓储实例
+func NewSpamRepository() SpamRepository {
+	return &spamRepository{}
+}
+
+// SaveFingerprint 按内容类型和 ID 写入或更新指纹
+func (r *spamRepository) SaveFingerprint(ctx context.Context, f *spam.Fingerprint) error {
+	return database.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "content_type"}, {Name: "content_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"simhash", "band0", "band1", "band2", "band3", "length", "updated_at"}),
+	}).Create(f).Error
+}
+
+// Candidates 查找近似内容的候选，汉明距离由调用方计算
+func (r *spamRepository) Candidates(ctx context.Context, bands [4]uint16, since time.Time, limit int) ([]spam.Fingerprint, error) {
+	var list []spam.Fingerprint
+	err := database.DB.WithContext(ctx).
+		Where("created_at >= ?", since).
+		Where("band0 = ? OR band1 = ? OR band2 = ? OR band3 = ?", bands[0], bands[1], bands[2], bands[3]).
+		Order("id DESC").
+		Limit(limit).
+		Find(&list).Error
+	return list, err
+}
+
+// CountRecent 统计用户近期发布数
+func (r *spamRepository) CountRecent(ctx context.Context, userID uint64, contentType string, since time.Time) (int64, error) {
+	var count int64
+	err := database.DB.WithContext(ctx).Model(&spam.Fingerprint{}).
+		Where("user_id = ? AND content_type = ? AND created_at >= ?", userID, contentType, since).
+		Count(&count).Error
+	return count, err
+}
+
+// CreateAttempt 记录拦截
+func (r *spamRepository) CreateAttempt(ctx context.Context, a *spam.Attempt) error {
+	return database.DB.WithContext(ctx).Create(a).Error
+}
+
+// Attempts 后台拦截记录列表
+func (r *spamRepository) Attempts(ctx context.Context, c *gin.Context, perPage int) ([]spam.Attempt, *paginator.Paging, error) {
+	query := database.DB.WithContext(ctx).Model(&spam.Attempt{})
+	if action := c.Query("action"); action != "" {
+		query = query.Where("action = ?", action)
+	}
+	if reason := c.Query("reason"); reason != "" {
+		query = query.Where("reason = ?", reason)
+	}
+	if contentType := c.Query("content_type"); contentType != "" {
+		query = query.Where("content_type = ?", contentType)
+	}
+	if userID := c.Query("user_id"); userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	var attempts []spam.Attempt
+	paging := paginator.Paginate(c, query.Order("id DESC"), &attempts, "/api/v1/admin/spam/attempts", perPage)
+	return attempts, &paging, nil
+}
+
+// AttemptStats 按日期、处理结果和原因汇总
+func (r *spamRepository) AttemptStats(ctx context.Context, since time.Time) ([]spam.AttemptStat, error) {
+	var stats []spam.AttemptStat
+	err := database.DB.WithContext(ctx).Model(&spam.Attempt{}).
+		Select("DATE_FORMAT(created_at, '%Y-%m-%d') AS date, action, reason, COUNT(*) AS count").
+		Where("created_at >= ?", since).
+		Group("date, action, reason").
+		Order("date ASC").
+		Scan(&stats).Error
+	return stats, err
+}
+
+// GetSettings 获取后台设置
+func (r *spamRepository) GetSettings(ctx context.Context) (*spam.Settings, error) {
+	var s spam.Settings
+	result := database.DB.WithContext(ctx).Order("id ASC").Limit(1).Find(&s)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return &s, nil
+}
+
+// SaveSettings 保存后台设置
+func (r *spamRepository) SaveSettings(ctx context.Context, s *spam.Settings) error {
+	if s.ID == 0 {
+		return database.DB.WithContext(ctx).Create(s).Error
+	}
+	return database.DB.WithContext(ctx).Save(s).Error
+}
@@ -3,9 +3,14 @@ package repositories
 
 import (
 	"context"
+	"strconv"
+	"time"
+
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/comment"
 	"GoHub-Service/app/models/topic"
-	"GoHub-Service/pkg/paginator"
 	"GoHub-Service/pkg/database"
+	"GoHub-Service/pkg/paginator"
 	"gorm.io/gorm"
 
 	"github.com/gin-gonic/gin"
@@ -14,9 +19,22 @@ import (
 // TopicRepository 话题仓储接口
 type TopicRepository interface {
 	GetByID(ctx context.Context, id string) (*topic.Topic, error)
-	List(ctx context.Context, c *gin.Context, perPage int) ([]topic.Topic, *paginator.Paging, error)
+	List(ctx context.Context, c *gin.Context, filter topic.ListFilter, perPage int) ([]topic.Topic, *paginator.Paging, error)
+	ListByCursor(ctx context.Context, c *gin.Context, filter topic.ListFilter, perPage int) ([]topic.Topic, *paginator.CursorPaging, error)
 	Create(ctx context.Context, topic *topic.Topic) error
 	Update(ctx context.Context, topic *topic.Topic) error
+	UpdateColumns(ctx context.Context, t *topic.Topic, columns ...string) error
+	SyncTags(ctx context.Context, topic *topic.Topic, names []string) error
+	TouchLastReply(ctx context.Context, topicID string, at time.Time) error
+	ArchiveInactive(ctx context.Context, before time.Time) ([]uint64, error)
+	ListPending(ctx context.Context, c *gin.Context, baseURL, scope, moderatorID string, staleBefore time.Time, perPage int) ([]topic.Topic, *paginator.Paging, error)
+	Claim(ctx context.Context, id string, moderatorID uint64, staleBefore time.Time) (bool, error)
+	CountApprovedByUser(ctx context.Context, userID string) (int64, error)
+	Merge(ctx context.Context, source, target *topic.Topic, operatorID uint64, reason string) error
+	Split(ctx context.Context, sourceID string, newTopic *topic.Topic, bodyID uint64, commentIDs []uint64) (int64, error)
+	MergedInto(ctx context.Context, id string) (uint64, error)
+	RelatedIDs(ctx context.Context, t *topic.Topic, limit int) ([]uint64, error)
+	GetApprovedByIDs(ctx context.Context, ids []uint64) ([]topic.Topic, error)
 	Delete(ctx context.Context, id string) error
 	BatchCreate(ctx context.Context, topics []topic.Topic) error
 	BatchDelete(ctx context.Context, ids []string) error
@@ -59,17 +77,26 @@ func (r *topicRepository) GetByID(ctx context.Context, id string) (*topic.Topic,
 	return &topicModel, nil
 }
 
-// List 获取话题列表
-func (r *topicRepository) List(ctx context.Context, c *gin.Context, perPage int) ([]topic.Topic, *paginator.Paging, error) {
-	data, pager := topic.Paginate(c, perPage)
+// List 按筛选条件获取话题列表
+func (r *topicRepository) List(ctx context.Context, c *gin.Context, filter topic.ListFilter, perPage int) ([]topic.Topic, *paginator.Paging, error) {
+	data, pager := topic.Paginate(c, filter, perPage)
+	return data, &pager, nil
+}
+
+// ListByCursor 按筛选条件游标分页获取话题列表
+func (r *topicRepository) ListByCursor(ctx context.Context, c *gin.Context, filter topic.ListFilter, perPage int) ([]topic.Topic, *paginator.CursorPaging, error) {
+	data, pager, err := topic.CursorPaginate(c, filter, perPage)
+	if err != nil {
+		return nil, nil, err
+	}
 	return data, &pager, nil
 }
 
 // Create 创建话题
 func (r *topicRepository) Create(ctx context.Context, t *topic.Topic) error {
-	t.Create()
-	if t.ID == 0 {
-		return NewCreateError("话题", nil)
+	err := database.DB.WithContext(ctx).Create(t).Error
+	if err != nil || t.ID == 0 {
+		return NewCreateError("话题", err)
 	}
 	return nil
 }
@@ -83,6 +110,171 @@ func (r *topicRepository) Update(ctx context.Context, t *topic.Topic) error {
 	return nil
 }
 
+// UpdateColumns 只更新指定字段，避免列表字段之外的值被零值覆盖
+func (r *topicRepository) UpdateColumns(ctx context.Context, t *topic.Topic, columns ...string) error {
+	return database.DB.WithContext(ctx).Model(t).Select(columns).Updates(t).Error
+}
+
+// ArchiveInactive 归档长期无回复的话题
+func (r *topicRepository) ArchiveInactive(ctx context.Context, before time.Time) ([]uint64, error) {
+	return topic.ArchiveInactive(before)
+}
+
+// ListPending 获取审核队列
+func (r *topicRepository) ListPending(ctx context.Context, c *gin.Context, baseURL, scope, moderatorID string, staleBefore time.Time, perPage int) ([]topic.Topic, *paginator.Paging, error) {
+	data, pager := topic.PaginatePending(c, baseURL, scope, moderatorID, staleBefore, perPage)
+	return data, &pager, nil
+}
+
+// Claim 认领待审核话题
+func (r *topicRepository) Claim(ctx context.Context, id string, moderatorID uint64, staleBefore time.Time) (bool, error) {
+	return topic.Claim(id, moderatorID, staleBefore)
+}
+
+// CountApprovedByUser 统计用户已通过审核的话题数
+func (r *topicRepository) CountApprovedByUser(ctx context.Context, userID string) (int64, error) {
+	return topic.CountApprovedByUser(userID), nil
+}
+
+// Merge 将 source 合并到 target（事务包裹）
+// 评论改挂到目标话题，点赞和收藏按用户去重后转移并重新计数，原话题记录跳转目标后进入回收站
+// 目标话题在问答分类中且还没有采纳的回答时沿用原话题的采纳，否则移过去的评论取消采纳
+func (r *topicRepository) Merge(ctx context.Context, source, target *topic.Topic, operatorID uint64, reason string) error {
+	sourceID, targetID := source.GetStringID(), target.GetStringID()
+	return database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		carryAccepted := false
+		if source.AcceptedCommentID != 0 && target.AcceptedCommentID == 0 {
+			var qa int64
+			if err := tx.Model(&category.Category{}).Where("id = ? AND is_qa = ?", target.CategoryID, true).Count(&qa).Error; err != nil {
+				return err
+			}
+			carryAccepted = qa > 0
+		}
+		clearAccepted := tx.Unscoped().Model(&comment.Comment{}).Where("topic_id = ? AND is_accepted = ?", sourceID, true)
+		if carryAccepted {
+			clearAccepted = clearAccepted.Where("id <> ?", source.AcceptedCommentID)
+		}
+		if err := clearAccepted.Update("is_accepted", false).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Model(&comment.Comment{}).
+			Where("topic_id = ?", sourceID).
+			Update("topic_id", targetID).Error; err != nil {
+			return err
+		}
+
+		// 同一用户对两个话题都点过赞或收藏时只保留一条
+		for _, table := range []string{"topic_likes", "topic_favorites"} {
+			if err := tx.Exec("UPDATE "+table+" SET topic_id = ? WHERE topic_id = ? AND user_id NOT IN "+
+				"(SELECT user_id FROM (SELECT user_id FROM "+table+" WHERE topic_id = ?) AS existing)",
+				targetID, sourceID, targetID).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec("DELETE FROM "+table+" WHERE topic_id = ?", sourceID).Error; err != nil {
+				return err
+			}
+		}
+
+		updates := map[string]interface{}{
+			"like_count":     tx.Model(&TopicLike{}).Select("COUNT(*)").Where("topic_id = ?", targetID),
+			"favorite_count": tx.Model(&TopicFavorite{}).Select("COUNT(*)").Where("topic_id = ?", targetID),
+			"view_count":     gorm.Expr("view_count + ?", source.ViewCount),
+		}
+		if source.LastRepliedAt != nil && (target.LastRepliedAt == nil || source.LastRepliedAt.After(*target.LastRepliedAt)) {
+			updates["last_replied_at"] = source.LastRepliedAt
+		}
+		if carryAccepted {
+			updates["accepted_comment_id"] = source.AcceptedCommentID
+			updates["solved_at"] = source.SolvedAt
+		}
+		if err := tx.Model(&topic.Topic{}).Where("id = ?", targetID).UpdateColumns(updates).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&topic.Topic{}).Where("id = ?", sourceID).UpdateColumns(map[string]interface{}{
+			"merged_into_id":      target.ID,
+			"accepted_comment_id": 0,
+			"solved_at":           nil,
+			"deleted_at":          time.Now(),
+			"deleted_by":          operatorID,
+			"delete_reason":       reason,
+		}).Error
+	})
+}
+
+// Split 将 commentIDs 指定的评论拆分到新话题（事务包裹），返回实际移动的评论数
+// bodyID 为作为新话题正文的评论，从原话题删除，回复它的评论改为顶级评论
+// 父评论没有一起移动的回复改为顶级评论，原话题中父评论被移走的回复同样处理
+func (r *topicRepository) Split(ctx context.Context, sourceID string, newTopic *topic.Topic, bodyID uint64, commentIDs []uint64) (moved int64, err error) {
+	err = database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(newTopic).Error; err != nil {
+			return err
+		}
+		newID := newTopic.GetStringID()
+
+		if err := tx.Where("id = ? AND topic_id = ?", bodyID, sourceID).Delete(&comment.Comment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&comment.Comment{}).
+			Where("parent_id = ?", strconv.FormatUint(bodyID, 10)).
+			Update("parent_id", "0").Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&comment.Comment{}).
+			Where("id IN ? AND topic_id = ?", commentIDs, sourceID).
+			Update("topic_id", newID)
+		if result.Error != nil {
+			return result.Error
+		}
+		moved = result.RowsAffected
+
+		for _, topicID := range []string{newID, sourceID} {
+			if err := tx.Exec("UPDATE comments SET parent_id = '0' WHERE topic_id = ? AND parent_id NOT IN ('0', '') "+
+				"AND parent_id NOT IN (SELECT id FROM (SELECT CAST(id AS CHAR) AS id FROM comments WHERE topic_id = ?) AS kept)",
+				topicID, topicID).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return
+}
+
+// MergedInto 已合并话题的目标话题 ID，未合并时返回 0
+func (r *topicRepository) MergedInto(ctx context.Context, id string) (targetID uint64, err error) {
+	err = database.DB.WithContext(ctx).Unscoped().Model(&topic.Topic{}).
+		Select("merged_into_id").
+		Where("id = ?", id).
+		Scan(&targetID).Error
+	return
+}
+
+// RelatedIDs 基于标签、共同点赞和分类计算的相关话题 ID
+func (r *topicRepository) RelatedIDs(ctx context.Context, t *topic.Topic, limit int) ([]uint64, error) {
+	return topic.RelatedIDs(t, limit)
+}
+
+// GetApprovedByIDs 按 ID 顺序获取已审核通过的话题
+func (r *topicRepository) GetApprovedByIDs(ctx context.Context, ids []uint64) ([]topic.Topic, error) {
+	return topic.GetApprovedByIDs(ids)
+}
+
+// SyncTags 同步话题标签（事务包裹）
+func (r *topicRepository) SyncTags(ctx context.Context, t *topic.Topic, names []string) error {
+	return database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return topic.SyncTags(tx, t, names)
+	})
+}
+
+// TouchLastReply 更新话题最后回复时间
+func (r *topicRepository) TouchLastReply(ctx context.Context, topicID string, at time.Time) error {
+	return database.DB.WithContext(ctx).Model(&topic.Topic{}).
+		Where("id = ?", topicID).
+		UpdateColumn("last_replied_at", at).Error
+}
+
 // Delete 删除话题
 func (r *topicRepository) Delete(ctx context.Context, id string) error {
 	topicModel := topic.Get(id)
@@ -0,0 +1,138 @@
+// Package repositories 话题浏览量数据访问层
+package repositories
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/pkg/helpers"
+	"GoHub-Service/pkg/redis"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/spf13/cast"
+)
+
+// 浏览量缓冲区 key：待写入的计数累加在 pending 中，写库前整体转移到 flushing，
+// 写库失败时 flushing 保留到下一轮重试
+const (
+	topicViewPendingKey  = "topic:views:pending"
+	topicViewFlushingKey = "topic:views:flushing"
+	topicViewLockKey     = "topic:views:flush:lock"
+	// topicViewBatchField flushing 中记录批次 ID 的字段，写库时用于识别重试的批次
+	topicViewBatchField = "batch"
+)
+
+// unlockScript 只释放自己持有的锁，锁过期后被其他实例取得时不误删
+var unlockScript = goredis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// TopicViewRepository 话题浏览量仓储接口
+type TopicViewRepository interface {
+	MarkViewed(ctx context.Context, topicID, viewer string, window time.Duration) (bool, error)
+	Buffer(ctx context.Context, topicID string, at time.Time) error
+	// LockFlush 获取写库锁，多个实例中只有持有锁的实例可以取出缓冲区并写库，返回的 token 用于释放
+	LockFlush(ctx context.Context, ttl time.Duration) (string, bool, error)
+	UnlockFlush(ctx context.Context, token string) error
+	// TakeBuffered 取出待写库的计数和所属批次，上一轮未确认的批次重试时批次 ID 不变
+	TakeBuffered(ctx context.Context) (batchID string, deltas []topic.ViewDelta, err error)
+	AckBuffered(ctx context.Context) error
+	Save(ctx context.Context, batchID string, deltas []topic.ViewDelta) error
+	DailyViews(ctx context.Context, topicID string, from, to time.Time) ([]topic.DailyView, error)
+}
+
+// topicViewRepository 话题浏览量仓储实现
+type topicViewRepository struct{}
+
+// NewTopicViewRepository 创建话题浏览量仓储实例
+func NewTopicViewRepository() TopicViewRepository {
+	return &topicViewRepository{}
+}
+
+// MarkViewed 记录访客在时间窗口内浏览过话题，窗口内首次浏览返回 true
+func (r *topicViewRepository) MarkViewed(ctx context.Context, topicID, viewer string, window time.Duration) (bool, error) {
+	key := "topic:viewed:" + topicID + ":" + viewer
+	return redis.Redis.Client.SetNX(ctx, key, 1, window).Result()
+}
+
+// Buffer 在缓冲区中累加一次浏览，按话题和日期分别计数
+func (r *topicViewRepository) Buffer(ctx context.Context, topicID string, at time.Time) error {
+	return redis.Redis.Client.HIncrBy(ctx, topicViewPendingKey, topicID+":"+at.Format("2006-01-02"), 1).Err()
+}
+
+// LockFlush 获取写库锁，锁在 ttl 后自动过期，避免持有锁的实例退出后无法继续写库
+func (r *topicViewRepository) LockFlush(ctx context.Context, ttl time.Duration) (string, bool, error) {
+	token := helpers.RandomString(16)
+	locked, err := redis.Redis.Client.SetNX(ctx, topicViewLockKey, token, ttl).Result()
+	return token, locked, err
+}
+
+// UnlockFlush 释放写库锁
+func (r *topicViewRepository) UnlockFlush(ctx context.Context, token string) error {
+	return unlockScript.Run(ctx, redis.Redis.Client, []string{topicViewLockKey}, token).Err()
+}
+
+// TakeBuffered 取出缓冲区中的计数，调用方需持有写库锁
+// 上一轮未确认的计数优先返回；否则将 pending 整体转移到 flushing，期间新增的浏览写入新的 pending
+func (r *topicViewRepository) TakeBuffered(ctx context.Context) (string, []topic.ViewDelta, error) {
+	client := redis.Redis.Client
+	exists, err := client.Exists(ctx, topicViewFlushingKey).Result()
+	if err != nil {
+		return "", nil, err
+	}
+	if exists == 0 {
+		moved, err := client.RenameNX(ctx, topicViewPendingKey, topicViewFlushingKey).Result()
+		if err != nil {
+			// pending 不存在说明没有新的浏览
+			if strings.Contains(err.Error(), "no such key") {
+				return "", nil, nil
+			}
+			return "", nil, err
+		}
+		if !moved {
+			return "", nil, nil
+		}
+	}
+	// 批次 ID 只在首次取出时生成，重试时沿用
+	if err := client.HSetNX(ctx, topicViewFlushingKey, topicViewBatchField, helpers.RandomString(16)).Err(); err != nil {
+		return "", nil, err
+	}
+
+	fields, err := client.HGetAll(ctx, topicViewFlushingKey).Result()
+	if err != nil {
+		return "", nil, err
+	}
+	batchID := fields[topicViewBatchField]
+	deltas := make([]topic.ViewDelta, 0, len(fields))
+	for field, count := range fields {
+		topicID, date, ok := strings.Cut(field, ":")
+		if !ok {
+			continue
+		}
+		deltas = append(deltas, topic.ViewDelta{
+			TopicID: cast.ToUint64(topicID),
+			Date:    date,
+			Views:   cast.ToInt64(count),
+		})
+	}
+	return batchID, deltas, nil
+}
+
+// AckBuffered 计数写库成功后清除 flushing
+func (r *topicViewRepository) AckBuffered(ctx context.Context) error {
+	return redis.Redis.Client.Del(ctx, topicViewFlushingKey).Err()
+}
+
+// Save 将浏览量增量写入话题表和每日浏览量表，已写入过的批次直接跳过
+func (r *topicViewRepository) Save(ctx context.Context, batchID string, deltas []topic.ViewDelta) error {
+	return topic.AddViews(batchID, deltas)
+}
+
+// DailyViews 话题每日浏览量
+func (r *topicViewRepository) DailyViews(ctx context.Context, topicID string, from, to time.Time) ([]topic.DailyView, error) {
+	return topic.DailyViews(topicID, from, to)
+}
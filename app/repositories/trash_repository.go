@@ -0,0 +1,342 @@
+// Package repositories 回收站数据访问层
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"GoHub-Service/app/models"
+	"GoHub-Service/app/models/collection"
+	"GoHub-Service/app/models/comment"
+	"GoHub-Service/app/models/follow"
+	"GoHub-Service/app/models/like"
+	"GoHub-Service/app/models/mention"
+	"GoHub-Service/app/models/reaction"
+	"GoHub-Service/app/models/report"
+	"GoHub-Service/app/models/series"
+	"GoHub-Service/app/models/spam"
+	"GoHub-Service/app/models/tag"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/models/user"
+	"GoHub-Service/pkg/database"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// 回收站支持的资源类型
+const (
+	TrashTopic   = "topic"
+	TrashComment = "comment"
+	TrashUser    = "user"
+)
+
+// TrashRepository 回收站仓储接口
+type TrashRepository interface {
+	SoftDelete(ctx context.Context, kind string, ids []uint64, operatorID uint64, reason string) (int64, error)
+	Restore(ctx context.Context, kind string, ids []uint64) (int64, error)
+	Purge(ctx context.Context, kind string, ids []uint64) (int64, error)
+	List(ctx context.Context, c *gin.Context, kind string, perPage int) (interface{}, *paginator.Paging, error)
+	TrashedBefore(ctx context.Context, kind string, before time.Time, limit int) ([]uint64, error)
+	CommentTopicIDs(ctx context.Context, ids []uint64) ([]string, error)
+	TopicCategoryIDs(ctx context.Context, ids []uint64) ([]string, error)
+}
+
+// trashRepository 回收站仓储实现
+type trashRepository struct{}
+
+// NewTrashRepository 创建回收站仓储实例
+func NewTrashRepository() TrashRepository {
+	return &trashRepository{}
+}
+
+// errUnknownTrashKind 不支持的资源类型
+func errUnknownTrashKind(kind string) error {
+	return fmt.Errorf("unknown trash kind: %s", kind)
+}
+
+// SoftDelete 软删除并记录操作人和原因
+func (r *trashRepository) SoftDelete(ctx context.Context, kind string, ids []uint64, operatorID uint64, reason string) (int64, error) {
+	switch kind {
+	case TrashTopic:
+		return models.SoftDelete[topic.Topic](ids, operatorID, reason)
+	case TrashComment:
+		return models.SoftDelete[comment.Comment](ids, operatorID, reason)
+	case TrashUser:
+		return models.SoftDelete[user.User](ids, operatorID, reason)
+	}
+	return 0, errUnknownTrashKind(kind)
+}
+
+// Restore 从回收站恢复
+func (r *trashRepository) Restore(ctx context.Context, kind string, ids []uint64) (int64, error) {
+	switch kind {
+	case TrashTopic:
+		return models.Restore[topic.Topic](ids)
+	case TrashComment:
+		return models.Restore[comment.Comment](ids)
+	case TrashUser:
+		return models.Restore[user.User](ids)
+	}
+	return 0, errUnknownTrashKind(kind)
+}
+
+// Purge 彻底删除回收站中的记录
+// 话题会连同评论、标签、点赞、收藏、表情回应、收藏夹和系列条目、提及、浏览统计、内容指纹和举报一起删除，
+// 评论会连同全部回复及其点赞、表情回应、编辑记录、提及、内容指纹和举报一起删除，
+// 用户会连同关注关系一起删除，避免留下孤儿数据
+func (r *trashRepository) Purge(ctx context.Context, kind string, ids []uint64) (int64, error) {
+	switch kind {
+	case TrashTopic:
+		return r.purgeTopics(ctx, ids)
+	case TrashComment:
+		return r.purgeComments(ctx, ids)
+	case TrashUser:
+		return r.purgeUsers(ctx, ids)
+	}
+	return 0, errUnknownTrashKind(kind)
+}
+
+// purgeUsers 彻底删除用户，并删除其关注和被关注记录，同时维护对方的关注数和粉丝数
+func (r *trashRepository) purgeUsers(ctx context.Context, ids []uint64) (rowsAffected int64, err error) {
+	err = database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var trashed []uint64
+		if err := tx.Unscoped().Model(&user.User{}).
+			Where("id IN ? AND deleted_at IS NOT NULL", ids).
+			Pluck("id", &trashed).Error; err != nil {
+			return err
+		}
+		if len(trashed) == 0 {
+			return nil
+		}
+
+		var follows []follow.Follow
+		if err := tx.Where("user_id IN ? OR follow_id IN ?", trashed, trashed).Find(&follows).Error; err != nil {
+			return err
+		}
+		for _, f := range follows {
+			if _, err := follow.Remove(tx, f.UserID, f.FollowID); err != nil {
+				return err
+			}
+		}
+
+		result := tx.Unscoped().Where("id IN ?", trashed).Delete(&user.User{})
+		rowsAffected = result.RowsAffected
+		return result.Error
+	})
+	return rowsAffected, err
+}
+
+// purgeTopics 彻底删除话题及其评论和全部关联记录
+func (r *trashRepository) purgeTopics(ctx context.Context, ids []uint64) (rowsAffected int64, err error) {
+	err = database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 只处理已在回收站中的话题
+		var trashed []uint64
+		if err := tx.Unscoped().Model(&topic.Topic{}).
+			Where("id IN ? AND deleted_at IS NOT NULL", ids).
+			Pluck("id", &trashed).Error; err != nil {
+			return err
+		}
+		if len(trashed) == 0 {
+			return nil
+		}
+
+		var commentIDs []uint64
+		if err := tx.Unscoped().Model(&comment.Comment{}).
+			Where("topic_id IN ?", trashed).
+			Pluck("id", &commentIDs).Error; err != nil {
+			return err
+		}
+		if _, err := deleteComments(tx, commentIDs); err != nil {
+			return err
+		}
+		if err := deleteTopicRelations(tx, trashed); err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("id IN ?", trashed).Delete(&topic.Topic{})
+		rowsAffected = result.RowsAffected
+		return result.Error
+	})
+	return
+}
+
+// deleteTopicRelations 删除指向话题的关联记录，并重新统计受影响的收藏夹、系列和标签的话题数
+func deleteTopicRelations(tx *gorm.DB, ids []uint64) error {
+	var collectionIDs, seriesIDs, tagIDs []uint64
+	if err := tx.Model(&collection.Item{}).Where("topic_id IN ?", ids).Distinct("collection_id").Pluck("collection_id", &collectionIDs).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&series.Item{}).Where("topic_id IN ?", ids).Distinct("series_id").Pluck("series_id", &seriesIDs).Error; err != nil {
+		return err
+	}
+	if err := tx.Table("topic_tags").Where("topic_id IN ?", ids).Distinct("tag_id").Pluck("tag_id", &tagIDs).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("topic_id IN ?", ids).Delete(&TopicLike{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("topic_id IN ?", ids).Delete(&TopicFavorite{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("target_type = ? AND target_id IN ?", like.TargetTopic, toStrings(ids)).Delete(&like.Like{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("target_type = ? AND target_id IN ?", reaction.TargetTopic, ids).Delete(&reaction.Reaction{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("topic_id IN ?", ids).Delete(&collection.Item{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("topic_id IN ?", ids).Delete(&series.Item{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("source_type = ? AND source_id IN ?", mention.SourceTopic, ids).Delete(&mention.Mention{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("topic_id IN ?", ids).Delete(&topic.DailyView{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("content_type = ? AND content_id IN ?", spam.ContentTopic, ids).Delete(&spam.Fingerprint{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("target_type = ? AND target_id IN ?", report.TargetTopic, ids).Delete(&report.Report{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM topic_tags WHERE topic_id IN ?", ids).Error; err != nil {
+		return err
+	}
+
+	if err := collection.RecountItems(tx, collectionIDs...); err != nil {
+		return err
+	}
+	if err := series.RecountTopics(tx, seriesIDs...); err != nil {
+		return err
+	}
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	return tx.Model(&tag.Tag{}).Where("id IN ?", tagIDs).
+		UpdateColumn("topics_count", gorm.Expr("(SELECT COUNT(*) FROM topic_tags WHERE topic_tags.tag_id = tags.id)")).Error
+}
+
+// purgeComments 彻底删除评论及其全部回复，回复不论是否在回收站中都一起删除，避免 parent_id 指向不存在的评论
+func (r *trashRepository) purgeComments(ctx context.Context, ids []uint64) (rowsAffected int64, err error) {
+	err = database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var trashed []uint64
+		if err := tx.Unscoped().Model(&comment.Comment{}).
+			Where("id IN ? AND deleted_at IS NOT NULL", ids).
+			Pluck("id", &trashed).Error; err != nil {
+			return err
+		}
+		if len(trashed) == 0 {
+			return nil
+		}
+
+		// 逐层查找回复，直到没有新的回复
+		subtree := append([]uint64{}, trashed...)
+		seen := make(map[uint64]bool, len(trashed))
+		for _, id := range trashed {
+			seen[id] = true
+		}
+		for parents := trashed; len(parents) > 0; {
+			var children []uint64
+			if err := tx.Unscoped().Model(&comment.Comment{}).
+				Where("parent_id IN ?", toStrings(parents)).
+				Pluck("id", &children).Error; err != nil {
+				return err
+			}
+			var next []uint64
+			for _, id := range children {
+				if !seen[id] {
+					seen[id] = true
+					next = append(next, id)
+				}
+			}
+			subtree = append(subtree, next...)
+			parents = next
+		}
+
+		rowsAffected, err = deleteComments(tx, subtree)
+		return err
+	})
+	return
+}
+
+// deleteComments 彻底删除评论及其点赞、表情回应、编辑记录、提及、内容指纹和举报
+func deleteComments(tx *gorm.DB, ids []uint64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	if err := tx.Where("source_type = ? AND source_id IN ?", mention.SourceComment, ids).Delete(&mention.Mention{}).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Where("content_type = ? AND content_id IN ?", spam.ContentComment, ids).Delete(&spam.Fingerprint{}).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Where("target_type = ? AND target_id IN ?", report.TargetComment, ids).Delete(&report.Report{}).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Where("target_type = ? AND target_id IN ?", like.TargetComment, toStrings(ids)).Delete(&like.Like{}).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Where("target_type = ? AND target_id IN ?", reaction.TargetComment, ids).Delete(&reaction.Reaction{}).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Where("comment_id IN ?", ids).Delete(&comment.Revision{}).Error; err != nil {
+		return 0, err
+	}
+	result := tx.Unscoped().Where("id IN ?", ids).Delete(&comment.Comment{})
+	return result.RowsAffected, result.Error
+}
+
+// List 回收站列表，按删除时间倒序
+func (r *trashRepository) List(ctx context.Context, c *gin.Context, kind string, perPage int) (interface{}, *paginator.Paging, error) {
+	baseURL := "/api/v1/admin/trash/" + kind + "s"
+	switch kind {
+	case TrashTopic:
+		data, paging := models.PaginateTrashed[topic.Topic](c, baseURL, perPage)
+		return data, &paging, nil
+	case TrashComment:
+		data, paging := models.PaginateTrashed[comment.Comment](c, baseURL, perPage)
+		return data, &paging, nil
+	case TrashUser:
+		data, paging := models.PaginateTrashed[user.User](c, baseURL, perPage)
+		return data, &paging, nil
+	}
+	return nil, nil, errUnknownTrashKind(kind)
+}
+
+// TrashedBefore 删除时间早于 before 的记录 ID
+func (r *trashRepository) TrashedBefore(ctx context.Context, kind string, before time.Time, limit int) ([]uint64, error) {
+	switch kind {
+	case TrashTopic:
+		return models.TrashedBefore[topic.Topic](before, limit)
+	case TrashComment:
+		return models.TrashedBefore[comment.Comment](before, limit)
+	case TrashUser:
+		return models.TrashedBefore[user.User](before, limit)
+	}
+	return nil, errUnknownTrashKind(kind)
+}
+
+// CommentTopicIDs 评论所属的话题 ID（包含已删除的评论），用于清理评论缓存
+func (r *trashRepository) CommentTopicIDs(ctx context.Context, ids []uint64) (topicIDs []string, err error) {
+	err = database.DB.WithContext(ctx).Unscoped().Model(&comment.Comment{}).
+		Where("id IN ?", ids).
+		Distinct("topic_id").
+		Pluck("topic_id", &topicIDs).Error
+	return
+}
+
+// TopicCategoryIDs 话题所属的分类 ID（包含已删除的话题），用于重新统计分类话题数
+func (r *trashRepository) TopicCategoryIDs(ctx context.Context, ids []uint64) (categoryIDs []string, err error) {
+	err = database.DB.WithContext(ctx).Unscoped().Model(&topic.Topic{}).
+		Where("id IN ?", ids).
+		Distinct("category_id").
+		Pluck("category_id", &categoryIDs).Error
+	return
+}
@@ -28,6 +28,9 @@ type UserRepository interface {
 	GetFromCache(id string) (*user.User, error)
 	SetCache(user *user.User) error
 	DeleteCache(id string) error
+	// Ban 封禁用户，until 为 nil 时永久封禁
+	Ban(id string, operatorID uint64, reason string, until *time.Time) error
+	ClearProfile(id string) error
 }
 
 // userRepository 基于 GORM + Redis 的用户仓储实现.
@@ -126,3 +129,31 @@ func (r *userRepository) DeleteCache(id string) error {
 	redis.Redis.Del(context.Background(), key)
 	return nil
 }
+
+// Ban 封禁用户并清除用户缓存
+func (r *userRepository) Ban(id string, operatorID uint64, reason string, until *time.Time) error {
+	err := database.DB.Model(&user.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"is_banned":  true,
+			"banned_at":  time.Now(),
+			"banned_by":  operatorID,
+			"ban_reason": reason,
+			"ban_until":  until,
+		}).Error
+	if err != nil {
+		return err
+	}
+	return r.DeleteCache(id)
+}
+
+// ClearProfile 清空用户填写的城市和个人简介，用于处理违规资料
+func (r *userRepository) ClearProfile(id string) error {
+	err := database.DB.Model(&user.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"city": "", "introduction": ""}).Error
+	if err != nil {
+		return err
+	}
+	return r.DeleteCache(id)
+}
@@ -6,8 +6,10 @@ import (
 )
 
 type CategoryRequest struct {
-    Name        string `valid:"name" json:"name"`
-    Description string `valid:"description" json:"description,omitempty"`
+    Name        string  `valid:"name" json:"name"`
+    Description string  `valid:"description" json:"description,omitempty"`
+    IsQA        *bool   `json:"is_qa,omitempty"`
+    ParentID    *string `valid:"parent_id" json:"parent_id,omitempty"` // 0 表示顶级分类
 }
 
 func CategorySave(data interface{}, c *gin.Context) map[string][]string {
@@ -15,6 +17,7 @@ func CategorySave(data interface{}, c *gin.Context) map[string][]string {
     rules := govalidator.MapData{
         "name":        []string{"required", "min_cn:2", "max_cn:8", "not_exists:categories,name"},
         "description": []string{"min_cn:3", "max_cn:255"},
+        "parent_id":   []string{"numeric"},
     }
     messages := govalidator.MapData{
         "name": []string{
@@ -27,6 +30,9 @@ func CategorySave(data interface{}, c *gin.Context) map[string][]string {
             "min_cn:分类描述长度需至少 3 个字",
             "max_cn:分类描述长度不能超过 255 个字",
         },
+        "parent_id": []string{
+            "numeric:上级分类ID必须为数字",
+        },
     }
     return validate(data, rules, messages)
 }
@@ -0,0 +1,92 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+// CollectionRequest 创建或修改收藏夹请求
+type CollectionRequest struct {
+	Name        string `json:"name" valid:"name"`
+	Description string `json:"description" valid:"description"`
+	IsPublic    bool   `json:"is_public"`
+}
+
+// CollectionItemRequest 将话题加入收藏夹请求
+type CollectionItemRequest struct {
+	TopicID string `json:"topic_id" valid:"topic_id"`
+	Note    string `json:"note" valid:"note"`
+}
+
+// CollectionItemNoteRequest 修改收藏备注请求
+type CollectionItemNoteRequest struct {
+	Note string `json:"note" valid:"note"`
+}
+
+// CollectionReorderRequest 调整收藏夹话题顺序请求
+type CollectionReorderRequest struct {
+	TopicIDs []string `json:"topic_ids" valid:"topic_ids"`
+}
+
+// CollectionSave 验证创建或修改收藏夹
+func CollectionSave(data interface{}, c *gin.Context) map[string][]string {
+	rules := govalidator.MapData{
+		"name":        []string{"required", "min_cn:1", "max_cn:50"},
+		"description": []string{"max_cn:200"},
+	}
+	messages := govalidator.MapData{
+		"name": []string{
+			"required:收藏夹名称为必填项",
+			"min_cn:收藏夹名称不能为空",
+			"max_cn:收藏夹名称不能超过50字",
+		},
+		"description": []string{
+			"max_cn:描述不能超过200字",
+		},
+	}
+	return validate(data, rules, messages)
+}
+
+// CollectionItem 验证加入收藏夹
+func CollectionItem(data interface{}, c *gin.Context) map[string][]string {
+	rules := govalidator.MapData{
+		"topic_id": []string{"required", "exists:topics,id"},
+		"note":     []string{"max_cn:200"},
+	}
+	messages := govalidator.MapData{
+		"topic_id": []string{
+			"required:话题ID必填",
+			"exists:话题不存在",
+		},
+		"note": []string{
+			"max_cn:备注不能超过200字",
+		},
+	}
+	return validate(data, rules, messages)
+}
+
+// CollectionItemNote 验证修改收藏备注
+func CollectionItemNote(data interface{}, c *gin.Context) map[string][]string {
+	rules := govalidator.MapData{
+		"note": []string{"max_cn:200"},
+	}
+	messages := govalidator.MapData{
+		"note": []string{
+			"max_cn:备注不能超过200字",
+		},
+	}
+	return validate(data, rules, messages)
+}
+
+// CollectionReorder 验证调整收藏夹话题顺序
+func CollectionReorder(data interface{}, c *gin.Context) map[string][]string {
+	rules := govalidator.MapData{
+		"topic_ids": []string{"required"},
+	}
+	messages := govalidator.MapData{
+		"topic_ids": []string{
+			"required:话题ID列表不能为空",
+		},
+	}
+	return validate(data, rules, messages)
+}
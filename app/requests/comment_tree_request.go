@@ -0,0 +1,39 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+// CommentTreeRequest 评论树查询参数
+type CommentTreeRequest struct {
+	SortBy  string `valid:"sort_by" form:"sort_by"`
+	PerPage string `valid:"per_page" form:"per_page"`
+	Sort    string `valid:"sort" form:"sort"`
+	Order   string `valid:"order" form:"order"`
+}
+
+// CommentTree 验证评论树查询参数
+func CommentTree(data interface{}, c *gin.Context) map[string][]string {
+	rules := govalidator.MapData{
+		"sort_by":  []string{"in:oldest,newest,likes"},
+		"per_page": []string{"numeric_between:2,100"},
+		"sort":     []string{"in:id,created_at,updated_at"},
+		"order":    []string{"in:asc,desc"},
+	}
+	messages := govalidator.MapData{
+		"sort_by": []string{
+			"in:排序方式仅支持 oldest,newest,likes",
+		},
+		"per_page": []string{
+			"numeric_between:每页条数的值介于 2~100 之间",
+		},
+		"sort": []string{
+			"in:排序字段仅支持 id,created_at,updated_at",
+		},
+		"order": []string{
+			"in:排序规则仅支持 asc（正序）,desc（倒序）",
+		},
+	}
+	return validate(data, rules, messages)
+}
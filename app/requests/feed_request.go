@@ -0,0 +1,31 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+// FeedSubscriptionRequest 关注标签或分类请求
+type FeedSubscriptionRequest struct {
+	TargetType string `json:"target_type" valid:"target_type"`
+	TargetID   string `json:"target_id" valid:"target_id"`
+}
+
+// FeedSubscription 验证关注标签或分类
+func FeedSubscription(data interface{}, c *gin.Context) map[string][]string {
+	rules := govalidator.MapData{
+		"target_type": []string{"required", "in:tag,category"},
+		"target_id":   []string{"required", "numeric"},
+	}
+	messages := govalidator.MapData{
+		"target_type": []string{
+			"required:关注类型为必填项",
+			"in:关注类型只能是 tag 或 category",
+		},
+		"target_id": []string{
+			"required:关注对象ID为必填项",
+			"numeric:关注对象ID格式错误",
+		},
+	}
+	return validate(data, rules, messages)
+}
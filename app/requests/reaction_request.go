@@ -0,0 +1,25 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+// ReactionRequest 回应或取消回应表情请求，取消时也可以通过 query 传递
+type ReactionRequest struct {
+	Emoji string `json:"emoji" form:"emoji" valid:"emoji"`
+}
+
+// Reaction 验证表情回应
+func Reaction(data interface{}, c *gin.Context) map[string][]string {
+	rules := govalidator.MapData{
+		"emoji": []string{"required", "max:32"},
+	}
+	messages := govalidator.MapData{
+		"emoji": []string{
+			"required:表情为必填项",
+			"max:表情长度不能超过 32",
+		},
+	}
+	return validate(data, rules, messages)
+}
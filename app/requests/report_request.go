@@ -0,0 +1,46 @@
+package requests
+
+import (
+	"strings"
+
+	"GoHub-Service/app/models/report"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+// ReportRequest 提交举报请求
+type ReportRequest struct {
+	TargetType string `json:"target_type" valid:"target_type"`
+	TargetID   string `json:"target_id" valid:"target_id"`
+	Reason     string `json:"reason" valid:"reason"`
+	Detail     string `json:"detail,omitempty" valid:"detail"`
+}
+
+// Report 验证举报
+func Report(data interface{}, c *gin.Context) map[string][]string {
+	rules := govalidator.MapData{
+		"target_type": []string{"required", "in:topic,comment,user,message"},
+		"target_id":   []string{"required", "numeric"},
+		"reason":      []string{"required", "in:" + strings.Join(report.Reasons, ",")},
+		"detail":      []string{"max_cn:500"},
+	}
+	messages := govalidator.MapData{
+		"target_type": []string{
+			"required:举报对象类型为必填项",
+			"in:举报对象类型只能是 topic、comment、user 或 message",
+		},
+		"target_id": []string{
+			"required:举报对象为必填项",
+			"numeric:举报对象ID格式错误",
+		},
+		"reason": []string{
+			"required:举报原因为必填项",
+			"in:不支持的举报原因",
+		},
+		"detail": []string{
+			"max_cn:补充说明长度不能超过 500 个字",
+		},
+	}
+	return validate(data, rules, messages)
+}
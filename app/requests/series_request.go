@@ -0,0 +1,68 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+// SeriesRequest 创建或修改系列请求
+type SeriesRequest struct {
+	Title       string `json:"title" valid:"title"`
+	Description string `json:"description" valid:"description"`
+}
+
+// SeriesTopicRequest 将话题加入系列请求
+type SeriesTopicRequest struct {
+	TopicID string `json:"topic_id" valid:"topic_id"`
+}
+
+// SeriesReorderRequest 调整系列话题顺序请求
+type SeriesReorderRequest struct {
+	TopicIDs []string `json:"topic_ids" valid:"topic_ids"`
+}
+
+// SeriesSave 验证创建或修改系列
+func SeriesSave(data interface{}, c *gin.Context) map[string][]string {
+	rules := govalidator.MapData{
+		"title":       []string{"required", "min_cn:2", "max_cn:50"},
+		"description": []string{"max_cn:200"},
+	}
+	messages := govalidator.MapData{
+		"title": []string{
+			"required:系列标题为必填项",
+			"min_cn:系列标题长度需大于 2",
+			"max_cn:系列标题不能超过50字",
+		},
+		"description": []string{
+			"max_cn:描述不能超过200字",
+		},
+	}
+	return validate(data, rules, messages)
+}
+
+// SeriesTopic 验证将话题加入系列
+func SeriesTopic(data interface{}, c *gin.Context) map[string][]string {
+	rules := govalidator.MapData{
+		"topic_id": []string{"required", "exists:topics,id"},
+	}
+	messages := govalidator.MapData{
+		"topic_id": []string{
+			"required:话题ID必填",
+			"exists:话题不存在",
+		},
+	}
+	return validate(data, rules, messages)
+}
+
+// SeriesReorder 验证调整系列话题顺序
+func SeriesReorder(data interface{}, c *gin.Context) map[string][]string {
+	rules := govalidator.MapData{
+		"topic_ids": []string{"required"},
+	}
+	messages := govalidator.MapData{
+		"topic_ids": []string{
+			"required:话题ID列表不能为空",
+		},
+	}
+	return validate(data, rules, messages)
+}
@@ -1,14 +1,17 @@
 package requests
 
 import (
+    "GoHub-Service/app/requests/validators"
+
     "github.com/gin-gonic/gin"
     "github.com/thedevsaddam/govalidator"
 )
 
 type TopicRequest struct {
-    Title      string `json:"title,omitempty" valid:"title"`
-    Body       string `json:"body,omitempty" valid:"body"`
-    CategoryID string `json:"category_id,omitempty" valid:"category_id"`
+    Title      string   `json:"title,omitempty" valid:"title"`
+    Body       string   `json:"body,omitempty" valid:"body"`
+    CategoryID string   `json:"category_id,omitempty" valid:"category_id"`
+    Tags       []string `json:"tags,omitempty" form:"tags"`
 }
 
 func TopicSave(data interface{}, c *gin.Context) map[string][]string {
@@ -33,5 +36,111 @@ func TopicSave(data interface{}, c *gin.Context) map[string][]string {
             "exists:帖子分类未找到",
         },
     }
+    errs := validate(data, rules, messages)
+
+    _data := data.(*TopicRequest)
+    errs = validators.ValidateTags(_data.Tags, errs)
+    return errs
+}
+
+// TopicListRequest 话题列表筛选请求
+type TopicListRequest struct {
+    Sort        string `valid:"sort" form:"sort"`
+    Order       string `valid:"order" form:"order"`
+    PerPage     string `valid:"per_page" form:"per_page"`
+    CategoryID  string `valid:"category_id" form:"category_id"`
+    Subtree     string `valid:"subtree" form:"subtree"`
+    UserID      string `valid:"user_id" form:"user_id"`
+    Tag         string `valid:"tag" form:"tag"`
+    Status      string `valid:"status" form:"status"`
+    Solved      string `valid:"solved" form:"solved"`
+    StartDate   string `valid:"start_date" form:"start_date"`
+    EndDate     string `valid:"end_date" form:"end_date"`
+    SortBy      string `valid:"sort_by" form:"sort_by"`
+    PinnedFirst string `valid:"pinned_first" form:"pinned_first"`
+}
+
+func TopicList(data interface{}, c *gin.Context) map[string][]string {
+
+    rules := govalidator.MapData{
+        "sort":         []string{"in:id,created_at,updated_at"},
+        "order":        []string{"in:asc,desc"},
+        "per_page":     []string{"numeric_between:2,100"},
+        "category_id":  []string{"numeric"},
+        "subtree":      []string{"in:0,1"},
+        "user_id":      []string{"numeric"},
+        "tag":          []string{"max_cn:20"},
+        "status":       []string{"in:-1,0,1"},
+        "solved":       []string{"in:0,1"},
+        "start_date":   []string{"date"},
+        "end_date":     []string{"date"},
+        "sort_by":      []string{"in:latest_reply,created,likes,views"},
+        "pinned_first": []string{"in:0,1"},
+    }
+    messages := govalidator.MapData{
+        "sort": []string{
+            "in:排序字段仅支持 id,created_at,updated_at",
+        },
+        "order": []string{
+            "in:排序规则仅支持 asc（正序）,desc（倒序）",
+        },
+        "per_page": []string{
+            "numeric_between:每页条数的值介于 2~100 之间",
+        },
+        "category_id": []string{
+            "numeric:分类ID必须为数字",
+        },
+        "subtree": []string{
+            "in:subtree 仅支持 0 或 1",
+        },
+        "user_id": []string{
+            "numeric:作者ID必须为数字",
+        },
+        "tag": []string{
+            "max_cn:标签长度不能超过 20",
+        },
+        "status": []string{
+            "in:状态仅支持 -1（已拒绝）,0（待审核）,1（已通过）",
+        },
+        "solved": []string{
+            "in:solved 仅支持 0（未解决）或 1（已解决）",
+        },
+        "start_date": []string{
+            "date:开始日期格式应为 YYYY-MM-DD",
+        },
+        "end_date": []string{
+            "date:结束日期格式应为 YYYY-MM-DD",
+        },
+        "sort_by": []string{
+            "in:排序方式仅支持 latest_reply,created,likes,views",
+        },
+        "pinned_first": []string{
+            "in:pinned_first 仅支持 0 或 1",
+        },
+    }
+    errs := validate(data, rules, messages)
+
+    _data := data.(*TopicListRequest)
+    errs = validators.ValidateDateRange(_data.StartDate, _data.EndDate, errs)
+    return errs
+}
+
+// TopicCloseRequest 关闭话题请求
+type TopicCloseRequest struct {
+    Reason string `json:"reason,omitempty" valid:"reason"`
+}
+
+func TopicClose(data interface{}, c *gin.Context) map[string][]string {
+
+    rules := govalidator.MapData{
+        "reason": []string{"required", "min_cn:2", "max_cn:500"},
+    }
+    messages := govalidator.MapData{
+        "reason": []string{
+            "required:关闭原因为必填项",
+            "min_cn:关闭原因长度需至少 2 个字",
+            "max_cn:关闭原因长度不能超过 500 个字",
+        },
+    }
     return validate(data, rules, messages)
 }
@@ -2,6 +2,10 @@
 package validators
 
 import (
+    "strings"
+    "time"
+    "unicode/utf8"
+
     "GoHub-Service/pkg/captcha"
     "GoHub-Service/pkg/verifycode"
 )
@@ -29,3 +33,38 @@ func ValidateVerifyCode(key, answer string, errs map[string][]string) map[string
     }
     return errs
 }
+
+// ValidateTags 自定义规则，检查话题标签数量和长度
+func ValidateTags(tags []string, errs map[string][]string) map[string][]string {
+    if len(tags) > 5 {
+        errs["tags"] = append(errs["tags"], "标签最多 5 个")
+        return errs
+    }
+    for _, tag := range tags {
+        if n := utf8.RuneCountInString(strings.TrimSpace(tag)); n == 0 || n > 20 {
+            errs["tags"] = append(errs["tags"], "标签长度需在 1~20 之间")
+            break
+        }
+    }
+    return errs
+}
+
+// ValidateDateRange 自定义规则，检查开始日期不晚于结束日期
+// 与话题列表的筛选条件一样，日期中的 / 按 - 处理；格式错误由 date 规则提示，这里不重复报错
+func ValidateDateRange(startDate, endDate string, errs map[string][]string) map[string][]string {
+    start, okStart := parseDate(startDate)
+    end, okEnd := parseDate(endDate)
+    if okStart && okEnd && start.After(end) {
+        errs["end_date"] = append(errs["end_date"], "结束日期不能早于开始日期")
+    }
+    return errs
+}
+
+// parseDate 解析 YYYY-MM-DD 或 YYYY/MM/DD 格式的日期
+func parseDate(value string) (time.Time, bool) {
+    if value == "" {
+        return time.Time{}, false
+    }
+    t, err := time.ParseInLocation("2006-01-02", strings.ReplaceAll(value, "/", "-"), time.Local)
+    return t, err == nil
+}
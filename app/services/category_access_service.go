@@ -0,0 +1,115 @@
+package services
+
+import (
+	"sort"
+
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/role"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/pkg/config"
+	apperrors "GoHub-Service/pkg/errors"
+
+	"github.com/spf13/cast"
+)
+
+// categoryViewer 访问分类的用户身份，角色在第一次用到时才查询
+type categoryViewer struct {
+	userID string
+	roles  []string
+	loaded bool
+}
+
+func newCategoryViewer(userID string) *categoryViewer {
+	return &categoryViewer{userID: userID}
+}
+
+func (v *categoryViewer) roleNames() []string {
+	if !v.loaded {
+		v.roles = role.UserRoleNames(v.userID)
+		v.loaded = true
+	}
+	return v.roles
+}
+
+// privileged 管理员和版主不受分类访问控制限制
+func (v *categoryViewer) privileged() bool {
+	for _, name := range v.roleNames() {
+		if name == "admin" || name == "moderator" {
+			return true
+		}
+	}
+	return false
+}
+
+// canView 分类及其所有上级分类都对用户可见
+func (v *categoryViewer) canView(index map[uint64]category.Category, c category.Category) bool {
+	for _, id := range c.AncestorIDs() {
+		ancestor, ok := index[id]
+		if !ok {
+			continue
+		}
+		if ancestor.Visibility == "" || ancestor.Visibility == category.VisibilityPublic {
+			continue
+		}
+		if v.userID == "" {
+			return false
+		}
+		if !ancestor.VisibleTo(true, v.roleNames()) && !v.privileged() {
+			return false
+		}
+	}
+	return true
+}
+
+// HiddenCategoryIDs 对该用户不可见的分类，按 ID 升序，可以直接用于生成缓存 key
+// 所有分类都公开时不查询用户角色
+func HiddenCategoryIDs(viewerID string) []uint64 {
+	index := categoryIndex()
+	viewer := newCategoryViewer(viewerID)
+	var ids []uint64
+	for id, c := range index {
+		if !viewer.canView(index, c) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// CanViewCategory 用户是否可以查看该分类下的话题和评论，分类不存在时视为可见
+func CanViewCategory(viewerID, categoryID string) bool {
+	index := categoryIndex()
+	c, ok := index[cast.ToUint64(categoryID)]
+	if !ok {
+		return true
+	}
+	return newCategoryViewer(viewerID).canView(index, c)
+}
+
+// CheckCategoryPost 检查用户是否可以在该分类发布话题或评论
+func CheckCategoryPost(userID, categoryID string) *apperrors.AppError {
+	index := categoryIndex()
+	c, ok := index[cast.ToUint64(categoryID)]
+	if !ok {
+		return nil
+	}
+	viewer := newCategoryViewer(userID)
+	if !viewer.canView(index, c) {
+		return apperrors.BusinessError(apperrors.CodeCategoryPostDenied, "无权在该分类发布内容")
+	}
+	if viewer.privileged() {
+		return nil
+	}
+
+	switch c.PostPolicy {
+	case category.PostApproved:
+		if topic.CountApprovedByUser(userID) < config.GetInt64("category.approved_min_topics", 1) {
+			return apperrors.BusinessError(apperrors.CodeCategoryPostDenied, "该分类仅限已有话题通过审核的用户发布")
+		}
+	case category.PostRoles:
+		if !c.PostableByRoles(viewer.roleNames()) {
+			return apperrors.BusinessError(apperrors.CodeCategoryPostDenied, "该分类仅限指定角色的用户发布")
+		}
+	}
+	return nil
+}
@@ -2,18 +2,38 @@
 package services
 
 import (
+	"context"
+	"sync"
 	"time"
 
 	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/role"
+	"GoHub-Service/app/models/user"
 	"GoHub-Service/app/repositories"
 	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
 	"GoHub-Service/pkg/mapper"
 	"GoHub-Service/pkg/paginator"
+	"GoHub-Service/pkg/redis"
 
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
 	"go.uber.org/zap"
 )
 
+// categoryIndexTTL 分类索引在各实例的缓存时间，用于生成面包屑和访问控制
+// 修改分类后通过 CategoryIndexChannel 通知所有实例刷新，通知丢失时最迟在过期后刷新
+const categoryIndexTTL = time.Minute
+
+// CategoryIndexChannel 分类变更通知频道，所有实例收到后使分类索引失效
+const CategoryIndexChannel = "categories:changed"
+
+var (
+	categoryIndexMu      sync.RWMutex
+	categoryIndexCache   map[uint64]category.Category
+	categoryIndexExpires time.Time
+)
+
 // CategoryService 负责分类的读写和缓存穿透保护等业务流.
 type CategoryService struct {
 	repo   repositories.CategoryRepository
@@ -26,11 +46,18 @@ func NewCategoryService() *CategoryService {
 	// 定义DTO转换函数（只需一次）
 	converter := func(c *category.Category) *CategoryResponseDTO {
 		return &CategoryResponseDTO{
-			ID:          c.GetStringID(),
-			Name:        c.Name,
-			Description: c.Description,
-			CreatedAt:   c.CreatedAt,
-			UpdatedAt:   c.UpdatedAt,
+			ID:           c.GetStringID(),
+			Name:         c.Name,
+			Description:  c.Description,
+			IsQA:         c.IsQA,
+			ParentID:     cast.ToString(c.ParentID),
+			Depth:        c.Depth,
+			Visibility:   c.Visibility,
+			VisibleRoles: category.SplitRoles(c.VisibleRoles),
+			PostPolicy:   c.PostPolicy,
+			PostRoles:    category.SplitRoles(c.PostRoles),
+			CreatedAt:    c.CreatedAt,
+			UpdatedAt:    c.UpdatedAt,
 		}
 	}
 
@@ -45,21 +72,63 @@ func NewCategoryService() *CategoryService {
 type CategoryCreateDTO struct {
 	Name        string `json:"name" binding:"required,min=2,max=255"`
 	Description string `json:"description"`
+	IsQA        bool   `json:"is_qa"`
+	ParentID    string `json:"parent_id"` // 为空或 0 表示顶级分类
+	CategoryAccessDTO
+}
+
+// CategoryAccessDTO 分类访问控制设置，为空的字段不修改，新建时默认所有人可见、可发布
+type CategoryAccessDTO struct {
+	Visibility   *string   `json:"visibility,omitempty"`    // public/members/roles
+	VisibleRoles *[]string `json:"visible_roles,omitempty"` // visibility 为 roles 时可见的角色
+	PostPolicy   *string   `json:"post_policy,omitempty"`   // anyone/approved/roles
+	PostRoles    *[]string `json:"post_roles,omitempty"`    // post_policy 为 roles 时可发布的角色
 }
 
 // CategoryUpdateDTO 更新分类数据传输对象
 type CategoryUpdateDTO struct {
 	Name        *string `json:"name,omitempty" binding:"omitempty,min=2,max=255"`
 	Description *string `json:"description,omitempty"`
+	IsQA        *bool   `json:"is_qa,omitempty"`
+	ParentID    *string `json:"parent_id,omitempty"` // 修改上级分类，0 表示移到顶级，子分类随之移动
+	CategoryAccessDTO
 }
 
 // CategoryResponseDTO 分类响应DTO
 type CategoryResponseDTO struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	IsQA         bool      `json:"is_qa"` // 问答分类，话题作者可以采纳回答
+	ParentID     string    `json:"parent_id"`
+	Depth        int       `json:"depth"`
+	Visibility   string    `json:"visibility"`
+	VisibleRoles []string  `json:"visible_roles,omitempty"`
+	PostPolicy   string    `json:"post_policy"`
+	PostRoles    []string  `json:"post_roles,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// CategoryTreeDTO 分类树节点
+type CategoryTreeDTO struct {
+	CategoryResponseDTO
+	Children []*CategoryTreeDTO `json:"children"`
+}
+
+// CategoryCrumbDTO 面包屑中的分类
+type CategoryCrumbDTO struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// CategoryModeratorDTO 分类版主
+type CategoryModeratorDTO struct {
+	UserID     string    `json:"user_id"`
+	Name       string    `json:"name"`
+	Avatar     string    `json:"avatar,omitempty"`
+	AssignedBy string    `json:"assigned_by"`
+	AssignedAt time.Time `json:"assigned_at"`
 }
 
 // CategoryListResponseDTO 分类列表响应DTO
@@ -101,16 +170,33 @@ func (s *CategoryService) List(c *gin.Context, perPage int) (*CategoryListRespon
 	}, nil
 }
 
-// Create 创建分类
+// Create 创建分类，指定上级分类时作为其子分类
 func (s *CategoryService) Create(dto CategoryCreateDTO) (*CategoryResponseDTO, error) {
 	categoryModel := &category.Category{
 		Name:        dto.Name,
 		Description: dto.Description,
+		IsQA:        dto.IsQA,
+		Visibility:  category.VisibilityPublic,
+		PostPolicy:  category.PostAnyone,
+	}
+	if appErr := applyCategoryAccess(categoryModel, dto.CategoryAccessDTO); appErr != nil {
+		return nil, appErr
+	}
+	parentPath := ""
+	if parentID := cast.ToUint64(dto.ParentID); parentID > 0 {
+		parent, err := s.repo.GetByID(dto.ParentID)
+		if err != nil {
+			return nil, apperrors.ValidationError("上级分类不存在", map[string]interface{}{"parent_id": dto.ParentID})
+		}
+		categoryModel.ParentID = parent.ID
+		categoryModel.Depth = parent.Depth + 1
+		parentPath = parent.Path
 	}
 
-	if err := s.repo.Create(categoryModel); err != nil {
+	if err := s.repo.CreateWithPath(categoryModel, parentPath); err != nil {
 		return nil, apperrors.WrapError(err, "创建分类失败")
 	}
+	invalidateCategoryIndex()
 
 	return s.toResponseDTO(categoryModel), nil
 }
@@ -129,15 +215,265 @@ func (s *CategoryService) Update(id string, dto CategoryUpdateDTO) (*CategoryRes
 	if dto.Description != nil {
 		categoryModel.Description = *dto.Description
 	}
+	if dto.IsQA != nil {
+		categoryModel.IsQA = *dto.IsQA
+	}
+	if appErr := applyCategoryAccess(categoryModel, dto.CategoryAccessDTO); appErr != nil {
+		return nil, appErr
+	}
 
-	if err := s.repo.Update(categoryModel); err != nil {
+	if dto.ParentID != nil && cast.ToUint64(*dto.ParentID) != categoryModel.ParentID {
+		if err := s.move(categoryModel, *dto.ParentID); err != nil {
+			return nil, err
+		}
+	} else if err := s.repo.Update(categoryModel); err != nil {
 		return nil, apperrors.WrapError(err, "更新分类失败")
 	}
+	invalidateCategoryIndex()
 
 	return s.toResponseDTO(categoryModel), nil
 }
 
-// Delete 删除分类
+// applyCategoryAccess 校验并写入访问控制设置，限定角色时至少需要一个角色
+func applyCategoryAccess(categoryModel *category.Category, dto CategoryAccessDTO) *apperrors.AppError {
+	if dto.Visibility != nil {
+		categoryModel.Visibility = *dto.Visibility
+	}
+	if dto.VisibleRoles != nil {
+		categoryModel.VisibleRoles = category.JoinRoles(*dto.VisibleRoles)
+	}
+	if dto.PostPolicy != nil {
+		categoryModel.PostPolicy = *dto.PostPolicy
+	}
+	if dto.PostRoles != nil {
+		categoryModel.PostRoles = category.JoinRoles(*dto.PostRoles)
+	}
+
+	switch categoryModel.Visibility {
+	case category.VisibilityPublic, category.VisibilityMembers:
+	case category.VisibilityRoles:
+		if categoryModel.VisibleRoles == "" {
+			return apperrors.ValidationError("请指定可见的角色", map[string]interface{}{"visible_roles": dto.VisibleRoles})
+		}
+	default:
+		return apperrors.ValidationError("可见范围仅支持 public、members、roles", map[string]interface{}{"visibility": categoryModel.Visibility})
+	}
+	switch categoryModel.PostPolicy {
+	case category.PostAnyone, category.PostApproved:
+	case category.PostRoles:
+		if categoryModel.PostRoles == "" {
+			return apperrors.ValidationError("请指定可发布的角色", map[string]interface{}{"post_roles": dto.PostRoles})
+		}
+	default:
+		return apperrors.ValidationError("发布权限仅支持 anyone、approved、roles", map[string]interface{}{"post_policy": categoryModel.PostPolicy})
+	}
+	return nil
+}
+
+// move 将分类连同子分类移动到新的上级分类下，不能移动到自己或自己的子分类下
+func (s *CategoryService) move(categoryModel *category.Category, parentID string) error {
+	oldPath := categoryModel.Path
+	if oldPath == "" {
+		oldPath = category.PathOf("", categoryModel.ID)
+	}
+	parentPath, depth := "", 0
+	if cast.ToUint64(parentID) > 0 {
+		parent, err := s.repo.GetByID(parentID)
+		if err != nil {
+			return apperrors.ValidationError("上级分类不存在", map[string]interface{}{"parent_id": parentID})
+		}
+		for _, id := range parent.AncestorIDs() {
+			if id == categoryModel.ID {
+				return apperrors.ValidationError("不能移动到自己或自己的子分类下", map[string]interface{}{"parent_id": parentID})
+			}
+		}
+		categoryModel.ParentID = parent.ID
+		parentPath, depth = parent.Path, parent.Depth+1
+	} else {
+		categoryModel.ParentID = 0
+	}
+
+	depthDelta := depth - categoryModel.Depth
+	categoryModel.Path = category.PathOf(parentPath, categoryModel.ID)
+	categoryModel.Depth = depth
+	if err := s.repo.MoveSubtree(categoryModel, oldPath, depthDelta); err != nil {
+		return apperrors.DatabaseError("移动分类", err)
+	}
+	return nil
+}
+
+// Delete 删除分类，有子分类时不能删除
 func (s *CategoryService) Delete(id string) error {
-	return s.repo.Delete(id)
+	count, err := s.repo.CountChildren(id)
+	if err != nil {
+		return apperrors.DatabaseError("统计子分类", err)
+	}
+	if count > 0 {
+		return apperrors.BusinessError(apperrors.CodeCategoryHasChildren, "该分类下还有子分类，无法删除")
+	}
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+	invalidateCategoryIndex()
+	return nil
+}
+
+// Tree 对当前用户可见的分类树，同级按排序顺序排列
+func (s *CategoryService) Tree(viewerID string) ([]*CategoryTreeDTO, error) {
+	categories, err := s.repo.All()
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取分类树", err)
+	}
+	hidden := make(map[uint64]bool)
+	for _, id := range HiddenCategoryIDs(viewerID) {
+		hidden[id] = true
+	}
+	nodes := make(map[uint64]*CategoryTreeDTO, len(categories))
+	for i := range categories {
+		if hidden[categories[i].ID] {
+			continue
+		}
+		nodes[categories[i].ID] = &CategoryTreeDTO{
+			CategoryResponseDTO: *s.toResponseDTO(&categories[i]),
+			Children:            []*CategoryTreeDTO{},
+		}
+	}
+	roots := []*CategoryTreeDTO{}
+	for _, c := range categories {
+		node, ok := nodes[c.ID]
+		if !ok {
+			continue
+		}
+		if parent, ok := nodes[c.ParentID]; ok && c.ParentID != 0 {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return roots, nil
+}
+
+// Breadcrumbs 从顶级分类到该分类的路径
+func (s *CategoryService) Breadcrumbs(id string) []CategoryCrumbDTO {
+	return CategoryBreadcrumbs(id)
+}
+
+// Moderators 分类版主列表
+func (s *CategoryService) Moderators(id string) ([]CategoryModeratorDTO, error) {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, err
+	}
+	moderators, err := s.repo.Moderators(id)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取分类版主", err)
+	}
+	list := make([]CategoryModeratorDTO, 0, len(moderators))
+	for _, m := range moderators {
+		u := user.Get(cast.ToString(m.UserID))
+		list = append(list, CategoryModeratorDTO{
+			UserID:     cast.ToString(m.UserID),
+			Name:       u.Name,
+			Avatar:     u.Avatar,
+			AssignedBy: cast.ToString(m.AssignedBy),
+			AssignedAt: m.CreatedAt,
+		})
+	}
+	return list, nil
+}
+
+// AddModerator 指派分类版主，用户需要已经拥有版主角色
+// 被指派后该版主只能管理指派给他的分类及其子分类
+func (s *CategoryService) AddModerator(id, userID, operatorID string) error {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return err
+	}
+	if u := user.Get(userID); u.ID == 0 {
+		return apperrors.NotFoundError("用户").WithDetails(map[string]interface{}{"user_id": userID})
+	}
+	if !role.UserHasAnyRole(userID, "moderator") {
+		return apperrors.ValidationError("该用户不是版主，请先分配版主角色", map[string]interface{}{"user_id": userID})
+	}
+	created, err := s.repo.AddModerator(&category.Moderator{
+		CategoryID: cast.ToUint64(id),
+		UserID:     cast.ToUint64(userID),
+		AssignedBy: cast.ToUint64(operatorID),
+	})
+	if err != nil {
+		return apperrors.DatabaseError("指派分类版主", err)
+	}
+	if !created {
+		return apperrors.BusinessError(apperrors.CodeConflict, "该用户已是此分类的版主")
+	}
+	return nil
+}
+
+// RemoveModerator 取消分类版主
+func (s *CategoryService) RemoveModerator(id, userID string) error {
+	removed, err := s.repo.RemoveModerator(id, userID)
+	if err != nil {
+		return apperrors.DatabaseError("取消分类版主", err)
+	}
+	if !removed {
+		return apperrors.NotFoundError("分类版主").WithDetails(map[string]interface{}{"category_id": id, "user_id": userID})
+	}
+	return nil
+}
+
+// CategoryBreadcrumbs 从顶级分类到该分类的路径，分类不存在时返回空
+func CategoryBreadcrumbs(id string) []CategoryCrumbDTO {
+	index := categoryIndex()
+	current, ok := index[cast.ToUint64(id)]
+	if !ok {
+		return nil
+	}
+	crumbs := make([]CategoryCrumbDTO, 0, current.Depth+1)
+	for _, ancestorID := range current.AncestorIDs() {
+		if c, ok := index[ancestorID]; ok {
+			crumbs = append(crumbs, CategoryCrumbDTO{ID: c.GetStringID(), Name: c.Name})
+		}
+	}
+	return crumbs
+}
+
+// categoryIndex 按 ID 索引的全部分类，缓存过期后重新加载
+func categoryIndex() map[uint64]category.Category {
+	categoryIndexMu.RLock()
+	index, expires := categoryIndexCache, categoryIndexExpires
+	categoryIndexMu.RUnlock()
+	if index != nil && time.Now().Before(expires) {
+		return index
+	}
+
+	categories, err := repositories.NewCategoryRepository().All()
+	if err != nil {
+		logger.LogIf(err)
+		if index != nil {
+			return index
+		}
+		return map[uint64]category.Category{}
+	}
+	index = make(map[uint64]category.Category, len(categories))
+	for _, c := range categories {
+		index[c.ID] = c
+	}
+	categoryIndexMu.Lock()
+	categoryIndexCache, categoryIndexExpires = index, time.Now().Add(categoryIndexTTL)
+	categoryIndexMu.Unlock()
+	return index
+}
+
+// invalidateCategoryIndex 分类变更后使本实例的分类索引失效，并通知其他实例
+func invalidateCategoryIndex() {
+	ForgetCategoryIndex()
+	if redis.Redis == nil {
+		return
+	}
+	logger.LogIf(redis.Redis.Client.Publish(context.Background(), CategoryIndexChannel, "invalidate").Err())
+}
+
+// ForgetCategoryIndex 使本实例缓存的分类索引失效，收到分类变更通知时调用
+func ForgetCategoryIndex() {
+	categoryIndexMu.Lock()
+	categoryIndexExpires = time.Time{}
+	categoryIndexMu.Unlock()
 }
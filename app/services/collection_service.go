@@ -0,0 +1,265 @@
+// Package services 收藏夹业务逻辑
+package services
+
+import (
+	"context"
+
+	"GoHub-Service/app/models/collection"
+	"GoHub-Service/app/repositories"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+// CollectionService 收藏夹服务：收藏夹管理、收藏列表、关注公开收藏夹
+type CollectionService struct {
+	repo        repositories.CollectionRepository
+	interaction *InteractionService
+	notifSvc    *NotificationService
+}
+
+// NewCollectionService 创建收藏夹服务实例
+func NewCollectionService() *CollectionService {
+	return &CollectionService{
+		repo:        repositories.NewCollectionRepository(),
+		interaction: NewInteractionService(),
+		notifSvc:    NewNotificationService(),
+	}
+}
+
+// CollectionSaveDTO 创建或修改收藏夹
+type CollectionSaveDTO struct {
+	Name        string
+	Description string
+	IsPublic    bool
+}
+
+// CollectionResponseDTO 收藏夹详情
+type CollectionResponseDTO struct {
+	collection.Collection
+	FollowedByMe bool `json:"followed_by_me"`
+}
+
+// Create 创建收藏夹
+func (s *CollectionService) Create(userID string, dto CollectionSaveDTO) (*collection.Collection, *apperrors.AppError) {
+	c := &collection.Collection{
+		UserID:      cast.ToUint64(userID),
+		Name:        dto.Name,
+		Description: dto.Description,
+		IsPublic:    dto.IsPublic,
+	}
+	if err := s.repo.Create(context.Background(), c); err != nil {
+		return nil, apperrors.WrapError(err, "创建收藏夹失败")
+	}
+	return c, nil
+}
+
+// Update 修改收藏夹名称、描述和公开状态，只有创建者可以修改
+func (s *CollectionService) Update(id, userID string, dto CollectionSaveDTO) (*collection.Collection, *apperrors.AppError) {
+	c, appErr := s.getOwned(id, userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	c.Name = dto.Name
+	c.Description = dto.Description
+	c.IsPublic = dto.IsPublic
+	if err := s.repo.UpdateColumns(context.Background(), c, "name", "description", "is_public"); err != nil {
+		return nil, apperrors.DatabaseUpdateError("收藏夹", err)
+	}
+	return c, nil
+}
+
+// Delete 删除收藏夹，其中的话题仍保留在收藏中
+func (s *CollectionService) Delete(id, userID string) *apperrors.AppError {
+	c, appErr := s.getOwned(id, userID)
+	if appErr != nil {
+		return appErr
+	}
+	if err := s.repo.Delete(context.Background(), c.ID); err != nil {
+		return apperrors.DatabaseDeleteError("收藏夹", err)
+	}
+	return nil
+}
+
+// Get 获取收藏夹详情，私有收藏夹只有创建者可见
+func (s *CollectionService) Get(id, viewerID string) (*CollectionResponseDTO, *apperrors.AppError) {
+	c, appErr := s.getVisible(id, viewerID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	dto := &CollectionResponseDTO{Collection: *c}
+	if viewerID != "" {
+		dto.FollowedByMe, _ = s.repo.IsFollowing(context.Background(), c.ID, cast.ToUint64(viewerID))
+	}
+	return dto, nil
+}
+
+// ListByUser 用户的收藏夹列表，查看他人时只返回公开的收藏夹
+func (s *CollectionService) ListByUser(c *gin.Context, userID, viewerID string, perPage int) ([]collection.Collection, *paginator.Paging, *apperrors.AppError) {
+	baseURL := "/api/v1/users/" + userID + "/collections"
+	publicOnly := userID != viewerID
+	if !publicOnly {
+		baseURL = "/api/v1/collections"
+	}
+	data, paging, err := s.repo.ListByUser(context.Background(), c, userID, publicOnly, baseURL, perPage)
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取收藏夹列表", err)
+	}
+	return data, paging, nil
+}
+
+// Items 收藏夹中的话题列表，不包含对当前用户不可见分类中的话题
+func (s *CollectionService) Items(c *gin.Context, id, viewerID string, perPage int) ([]collection.Item, *paginator.Paging, *apperrors.AppError) {
+	if _, appErr := s.getVisible(id, viewerID); appErr != nil {
+		return nil, nil, appErr
+	}
+	data, paging, err := s.repo.ListItems(context.Background(), c, id, perPage, HiddenCategoryIDs(viewerID))
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取收藏夹话题", err)
+	}
+	return data, paging, nil
+}
+
+// AddItem 将话题加入收藏夹，未收藏的话题会先加入收藏，已在收藏夹中时只更新备注
+func (s *CollectionService) AddItem(id, userID, topicID, note string) *apperrors.AppError {
+	c, appErr := s.getOwned(id, userID)
+	if appErr != nil {
+		return appErr
+	}
+	if appErr := s.interaction.FavoriteTopic(userID, topicID); appErr != nil {
+		return appErr
+	}
+	if _, err := s.repo.AddItem(context.Background(), c.ID, cast.ToUint64(topicID), note); err != nil {
+		return apperrors.DatabaseError("加入收藏夹", err)
+	}
+	return nil
+}
+
+// UpdateItemNote 修改收藏夹中话题的备注
+func (s *CollectionService) UpdateItemNote(id, userID, topicID, note string) *apperrors.AppError {
+	c, appErr := s.getOwned(id, userID)
+	if appErr != nil {
+		return appErr
+	}
+	updated, err := s.repo.UpdateItemNote(context.Background(), c.ID, cast.ToUint64(topicID), note)
+	if err != nil {
+		return apperrors.DatabaseUpdateError("收藏备注", err)
+	}
+	if !updated {
+		return apperrors.NotFoundError("收藏夹中的话题")
+	}
+	return nil
+}
+
+// RemoveItem 从收藏夹中移除话题，话题仍保留在收藏中
+func (s *CollectionService) RemoveItem(id, userID, topicID string) *apperrors.AppError {
+	c, appErr := s.getOwned(id, userID)
+	if appErr != nil {
+		return appErr
+	}
+	removed, err := s.repo.RemoveItem(context.Background(), c.ID, cast.ToUint64(topicID))
+	if err != nil {
+		return apperrors.DatabaseDeleteError("收藏夹中的话题", err)
+	}
+	if !removed {
+		return apperrors.NotFoundError("收藏夹中的话题")
+	}
+	return nil
+}
+
+// Reorder 按给定顺序排列收藏夹中的话题
+func (s *CollectionService) Reorder(id, userID string, topicIDs []string) *apperrors.AppError {
+	c, appErr := s.getOwned(id, userID)
+	if appErr != nil {
+		return appErr
+	}
+	ids := make([]uint64, 0, len(topicIDs))
+	seen := make(map[uint64]bool, len(topicIDs))
+	for _, topicID := range topicIDs {
+		tid := cast.ToUint64(topicID)
+		if tid == 0 || seen[tid] {
+			return apperrors.ValidationError("话题ID列表无效", map[string]interface{}{"topic_ids": topicIDs})
+		}
+		seen[tid] = true
+		ids = append(ids, tid)
+	}
+	if err := s.repo.Reorder(context.Background(), c.ID, ids); err != nil {
+		return apperrors.DatabaseUpdateError("收藏夹排序", err)
+	}
+	return nil
+}
+
+// Follow 关注公开收藏夹，并通知创建者
+func (s *CollectionService) Follow(id, userID string) *apperrors.AppError {
+	c, appErr := s.getVisible(id, userID)
+	if appErr != nil {
+		return appErr
+	}
+	ownerID := cast.ToString(c.UserID)
+	if ownerID == userID {
+		return apperrors.ValidationError("不能关注自己的收藏夹", map[string]interface{}{"collection_id": id})
+	}
+	followed, err := s.repo.Follow(context.Background(), c.ID, cast.ToUint64(userID))
+	if err != nil {
+		return apperrors.DatabaseError("关注收藏夹", err)
+	}
+	if followed && s.notifSvc != nil {
+		_ = s.notifSvc.Notify(ownerID, userID, "collection_follow", map[string]interface{}{
+			"collection_id": c.GetStringID(),
+			"name":          c.Name,
+		})
+	}
+	return nil
+}
+
+// Unfollow 取消关注收藏夹，收藏夹已转为私有时也可以取消
+func (s *CollectionService) Unfollow(id, userID string) *apperrors.AppError {
+	if _, err := s.repo.Unfollow(context.Background(), cast.ToUint64(id), cast.ToUint64(userID)); err != nil {
+		return apperrors.DatabaseError("取消关注收藏夹", err)
+	}
+	return nil
+}
+
+// Following 用户关注的公开收藏夹列表
+func (s *CollectionService) Following(c *gin.Context, userID string, perPage int) ([]collection.Follow, *paginator.Paging, *apperrors.AppError) {
+	data, paging, err := s.repo.ListFollowing(context.Background(), c, userID, perPage)
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取关注的收藏夹", err)
+	}
+	return data, paging, nil
+}
+
+// Favorites 用户收藏的话题列表，按收藏时间倒序，不包含用户已无权查看的分类中的话题
+func (s *CollectionService) Favorites(c *gin.Context, userID string, perPage int) ([]collection.Favorite, *paginator.Paging, *apperrors.AppError) {
+	data, paging, err := s.repo.ListFavorites(context.Background(), c, userID, perPage, HiddenCategoryIDs(userID))
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取收藏列表", err)
+	}
+	return data, paging, nil
+}
+
+// getVisible 获取对当前用户可见的收藏夹，私有收藏夹对他人视为不存在
+func (s *CollectionService) getVisible(id, viewerID string) (*collection.Collection, *apperrors.AppError) {
+	c, err := s.repo.GetByID(context.Background(), id)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取收藏夹", err)
+	}
+	if c == nil || !c.VisibleTo(viewerID) {
+		return nil, apperrors.NotFoundError("收藏夹").WithDetails(map[string]interface{}{"collection_id": id})
+	}
+	return c, nil
+}
+
+// getOwned 获取当前用户创建的收藏夹
+func (s *CollectionService) getOwned(id, userID string) (*collection.Collection, *apperrors.AppError) {
+	c, appErr := s.getVisible(id, userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if cast.ToString(c.UserID) != userID {
+		return nil, apperrors.AuthorizationError("只能管理自己的收藏夹")
+	}
+	return c, nil
+}
@@ -7,48 +7,78 @@ import (
 	"time"
 
 	"GoHub-Service/app/cache"
+	"GoHub-Service/app/models/category"
 	"GoHub-Service/app/models/comment"
+	"GoHub-Service/app/models/mention"
+	"GoHub-Service/app/models/spam"
+	"GoHub-Service/app/models/topic"
 	"GoHub-Service/app/repositories"
+	"GoHub-Service/pkg/config"
 	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
 	"GoHub-Service/pkg/mapper"
+	"GoHub-Service/pkg/moderator"
 	"GoHub-Service/pkg/paginator"
 	"GoHub-Service/pkg/singleflight"
 
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
 )
 
 // CommentService 评论服务
 type CommentService struct {
-	repo      repositories.CommentRepository
-	cache     *cache.CommentCache
-	notifSvc  *NotificationService
-	topicRepo repositories.TopicRepository
-	sfGroup   singleflight.Group                               // singleflight 防止缓存击穿
-	mapper    mapper.Mapper[comment.Comment, CommentResponseDTO] // 使用泛型Mapper消除DTO转换重复
+	repo         repositories.CommentRepository
+	cache        *cache.CommentCache
+	notifSvc     *NotificationService
+	topicRepo    repositories.TopicRepository
+	topicCache   *cache.TopicCache
+	categoryRepo repositories.CategoryRepository
+	mentionSvc   *MentionService
+	reactionSvc  *ReactionService
+	feedSvc      *FeedService
+	spamSvc      *SpamService
+	sfGroup      singleflight.Group                                 // singleflight 防止缓存击穿
+	mapper       mapper.Mapper[comment.Comment, CommentResponseDTO] // 使用泛型Mapper消除DTO转换重复
 }
 
 // NewCommentService 创建评论服务实例
 func NewCommentService() *CommentService {
 	// 定义DTO转换函数（只需一次）
 	converter := func(c *comment.Comment) *CommentResponseDTO {
-		return &CommentResponseDTO{
-			ID:        c.GetStringID(),
-			TopicID:   c.TopicID,
-			UserID:    c.UserID,
-			Content:   c.Content,
-			ParentID:  c.ParentID,
-			LikeCount: c.LikeCount,
-			CreatedAt: c.CreatedAt,
-			UpdatedAt: c.UpdatedAt,
+		dto := &CommentResponseDTO{
+			ID:         c.GetStringID(),
+			TopicID:    c.TopicID,
+			UserID:     c.UserID,
+			Content:    c.Content,
+			ParentID:   c.ParentID,
+			LikeCount:  c.LikeCount,
+			IsAccepted: c.IsAccepted,
+			Edited:     c.EditedAt != nil,
+			EditCount:  c.EditCount,
+			EditedAt:   c.EditedAt,
+			Hidden:     c.HiddenAt != nil,
+			CreatedAt:  c.CreatedAt,
+			UpdatedAt:  c.UpdatedAt,
 		}
+		// 被举报隐藏的评论保留在楼层中，但不再展示内容
+		if dto.Hidden {
+			dto.Content = ""
+		}
+		return dto
 	}
 
 	return &CommentService{
-		repo:      repositories.NewCommentRepository(),
-		cache:     cache.NewCommentCache(),
-		notifSvc:  NewNotificationService(),
-		topicRepo: repositories.NewTopicRepository(),
-		mapper:    mapper.NewSimpleMapper(converter),
+		repo:         repositories.NewCommentRepository(),
+		cache:        cache.NewCommentCache(),
+		notifSvc:     NewNotificationService(),
+		topicRepo:    repositories.NewTopicRepository(),
+		topicCache:   cache.NewTopicCache(),
+		categoryRepo: repositories.NewCategoryRepository(),
+		mentionSvc:   NewMentionService(),
+		reactionSvc:  NewReactionService(),
+		feedSvc:      NewFeedService(),
+		spamSvc:      NewSpamService(),
+		mapper:       mapper.NewSimpleMapper(converter),
 	}
 }
 
@@ -67,14 +97,31 @@ type CommentUpdateDTO struct {
 
 // CommentResponseDTO 评论响应DTO
 type CommentResponseDTO struct {
+	ID         string              `json:"id"`
+	TopicID    string              `json:"topic_id"`
+	UserID     string              `json:"user_id"`
+	Content    string              `json:"content"`
+	ParentID   string              `json:"parent_id"`
+	LikeCount  int64               `json:"like_count"`
+	LikedByMe  bool                `json:"liked_by_me"`
+	IsAccepted bool                `json:"is_accepted"`         // 问答分类中被采纳为答案
+	Reactions  *ReactionSummaryDTO `json:"reactions,omitempty"` // 表情回应，与 liked_by_me 一样按当前用户填充，不进缓存
+	Mentions   []MentionDTO        `json:"mentions,omitempty"`  // 内容中 @ 的用户
+	Edited     bool                `json:"edited"`              // 内容是否被修改过
+	EditCount  int                 `json:"edit_count"`
+	EditedAt   *time.Time          `json:"edited_at"`
+	Hidden     bool                `json:"hidden"` // 因举报被隐藏，内容不再展示
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+}
+
+// CommentRevisionDTO 评论历史版本DTO
+type CommentRevisionDTO struct {
 	ID        string    `json:"id"`
-	TopicID   string    `json:"topic_id"`
-	UserID    string    `json:"user_id"`
-	Content   string    `json:"content"`
-	ParentID  string    `json:"parent_id"`
-	LikeCount int64     `json:"like_count"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	CommentID string    `json:"comment_id"`
+	EditorID  string    `json:"editor_id"`
+	Content   string    `json:"content"`    // 修改前的内容
+	CreatedAt time.Time `json:"created_at"` // 修改时间
 }
 
 // CommentListResponseDTO 评论列表响应DTO
@@ -83,6 +130,12 @@ type CommentListResponseDTO struct {
 	Paging   *paginator.Paging    `json:"paging"`
 }
 
+// CommentCursorListResponseDTO 评论列表（游标分页）响应DTO
+type CommentCursorListResponseDTO struct {
+	Comments []CommentResponseDTO    `json:"comments"`
+	Paging   *paginator.CursorPaging `json:"paging"`
+}
+
 // toResponseDTO 使用Mapper将Comment模型转换为响应DTO
 // 优化：使用泛型Mapper消除重复代码
 func (s *CommentService) toResponseDTO(c *comment.Comment) *CommentResponseDTO {
@@ -92,14 +145,43 @@ func (s *CommentService) toResponseDTO(c *comment.Comment) *CommentResponseDTO {
 // toResponseDTOList 使用Mapper将Comment模型列表转换为响应DTO列表
 // 优化：使用泛型Mapper消除重复代码，自动优化内存拷贝
 func (s *CommentService) toResponseDTOList(comments []comment.Comment) []CommentResponseDTO {
-	return s.mapper.ToDTOList(comments)
+	list := s.mapper.ToDTOList(comments)
+	s.attachMentions(list)
+	return list
+}
+
+// attachMentions 批量解析评论内容中的提及
+func (s *CommentService) attachMentions(list []CommentResponseDTO) {
+	if s.mentionSvc == nil || len(list) == 0 {
+		return
+	}
+	contents := make([]string, len(list))
+	for i := range list {
+		contents[i] = list[i].Content
+	}
+	for i, mentions := range s.mentionSvc.ResolveMany(contents) {
+		list[i].Mentions = mentions
+	}
+}
+
+// syncMentions 同步评论内容中的提及并通知新增的被提及用户
+func (s *CommentService) syncMentions(c *comment.Comment) []MentionDTO {
+	if s.mentionSvc == nil {
+		return nil
+	}
+	return s.mentionSvc.Sync(MentionSource{
+		Type:    mention.SourceComment,
+		ID:      c.GetStringID(),
+		TopicID: c.TopicID,
+		ActorID: c.UserID,
+	}, c.Content, true)
 }
 
 // GetByID 根据ID获取评论（使用 singleflight 防止缓存击穿）
 func (s *CommentService) GetByID(ctx context.Context, id string) (*CommentResponseDTO, *apperrors.AppError) {
 	// 使用 singleflight 确保同一时间只有一个请求去数据库查询
 	key := fmt.Sprintf("comment:%s", id)
-	
+
 	result, err := s.sfGroup.Do(key, func() (interface{}, error) {
 		// 尝试从缓存获取
 		if s.cache != nil {
@@ -134,12 +216,16 @@ func (s *CommentService) GetByID(ctx context.Context, id string) (*CommentRespon
 	}
 
 	commentModel := result.(*comment.Comment)
-	return s.toResponseDTO(commentModel), nil
+	dto := s.toResponseDTO(commentModel)
+	if s.mentionSvc != nil {
+		dto.Mentions = s.mentionSvc.Resolve(commentModel.Content)
+	}
+	return dto, nil
 }
 
-// List 获取评论列表
-func (s *CommentService) List(ctx context.Context, c *gin.Context, perPage int) (*CommentListResponseDTO, *apperrors.AppError) {
-	comments, paging, err := s.repo.List(ctx, c, perPage)
+// List 获取评论列表，不包含用户无权查看的分类下的评论
+func (s *CommentService) List(ctx context.Context, c *gin.Context, viewerID string, perPage int) (*CommentListResponseDTO, *apperrors.AppError) {
+	comments, paging, err := s.repo.List(ctx, c, perPage, HiddenCategoryIDs(viewerID))
 	if err != nil {
 		return nil, apperrors.DatabaseError("获取评论列表", err)
 	}
@@ -172,9 +258,9 @@ func (s *CommentService) ListByTopicID(ctx context.Context, c *gin.Context, topi
 	}, nil
 }
 
-// ListByUserID 获取指定用户的评论列表
-func (s *CommentService) ListByUserID(ctx context.Context, c *gin.Context, userID string, perPage int) (*CommentListResponseDTO, *apperrors.AppError) {
-	comments, paging, err := s.repo.ListByUserID(ctx, c, userID, perPage)
+// ListByUserID 获取指定用户的评论列表，不包含当前用户无权查看的分类下的评论
+func (s *CommentService) ListByUserID(ctx context.Context, c *gin.Context, userID, viewerID string, perPage int) (*CommentListResponseDTO, *apperrors.AppError) {
+	comments, paging, err := s.repo.ListByUserID(ctx, c, userID, perPage, HiddenCategoryIDs(viewerID))
 	if err != nil {
 		return nil, apperrors.DatabaseError("获取用户评论列表", err)
 	}
@@ -185,6 +271,38 @@ func (s *CommentService) ListByUserID(ctx context.Context, c *gin.Context, userI
 	}, nil
 }
 
+// ListByTopicIDCursor 游标分页获取指定话题的评论列表
+func (s *CommentService) ListByTopicIDCursor(ctx context.Context, c *gin.Context, topicID string, perPage int) (*CommentCursorListResponseDTO, *apperrors.AppError) {
+	comments, paging, err := s.repo.ListByTopicIDCursor(ctx, c, topicID, perPage)
+	if err != nil {
+		if appErr := invalidCursorError(c, err); appErr != nil {
+			return nil, appErr
+		}
+		return nil, apperrors.DatabaseError("获取话题评论列表", err)
+	}
+
+	return &CommentCursorListResponseDTO{
+		Comments: s.toResponseDTOList(comments),
+		Paging:   paging,
+	}, nil
+}
+
+// ListByUserIDCursor 游标分页获取指定用户的评论列表，不包含当前用户无权查看的分类下的评论
+func (s *CommentService) ListByUserIDCursor(ctx context.Context, c *gin.Context, userID, viewerID string, perPage int) (*CommentCursorListResponseDTO, *apperrors.AppError) {
+	comments, paging, err := s.repo.ListByUserIDCursor(ctx, c, userID, perPage, HiddenCategoryIDs(viewerID))
+	if err != nil {
+		if appErr := invalidCursorError(c, err); appErr != nil {
+			return nil, appErr
+		}
+		return nil, apperrors.DatabaseError("获取用户评论列表", err)
+	}
+
+	return &CommentCursorListResponseDTO{
+		Comments: s.toResponseDTOList(comments),
+		Paging:   paging,
+	}, nil
+}
+
 // ListReplies 获取评论的回复列表
 func (s *CommentService) ListReplies(ctx context.Context, c *gin.Context, parentID string, perPage int) (*CommentListResponseDTO, *apperrors.AppError) {
 	comments, paging, err := s.repo.ListReplies(ctx, c, parentID, perPage)
@@ -198,8 +316,133 @@ func (s *CommentService) ListReplies(ctx context.Context, c *gin.Context, parent
 	}, nil
 }
 
+// CommentTreeDTO 评论树中的一条评论
+type CommentTreeDTO struct {
+	CommentResponseDTO
+	ReplyTo    *CommentReplyToDTO `json:"reply_to,omitempty"` // 平铺展示的深层回复实际回复的评论
+	ReplyCount int                `json:"reply_count"`        // 全部回复数，replies 只包含其中前几条
+	Replies    []CommentTreeDTO   `json:"replies"`
+}
+
+// CommentReplyToDTO 回复对象
+type CommentReplyToDTO struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+}
+
+// CommentTreeListResponseDTO 评论树响应DTO
+type CommentTreeListResponseDTO struct {
+	Comments []CommentTreeDTO  `json:"comments"`
+	Paging   *paginator.Paging `json:"paging"`
+}
+
+// Tree 获取话题的评论树，顶级评论分页，每条评论内嵌前几条回复
+// 默认分页参数下的第一页按排序方式缓存，评论增删改时失效
+func (s *CommentService) Tree(ctx context.Context, c *gin.Context, topicID, sortBy string, perPage int) (*CommentTreeListResponseDTO, *apperrors.AppError) {
+	topicModel, err := s.topicRepo.GetByID(ctx, topicID)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取话题", err)
+	}
+	if topicModel == nil || !topicModel.IsApproved() {
+		return nil, apperrors.NotFoundError("话题").WithDetails(map[string]interface{}{"topic_id": topicID})
+	}
+
+	opts := comment.TreeOptions{
+		Sort:     sortBy,
+		MaxDepth: config.GetInt("comment.tree_max_depth", 3),
+		Preview:  config.GetInt("comment.tree_reply_preview", 3),
+		MaxNodes: config.GetInt("comment.tree_max_nodes", 2000),
+	}
+	switch opts.Sort {
+	case comment.TreeSortOldest, comment.TreeSortNewest, comment.TreeSortLikes:
+	default:
+		opts.Sort = comment.TreeSortOldest
+	}
+	if opts.MaxDepth < 2 {
+		opts.MaxDepth = 2
+	}
+
+	cacheable := s.cache != nil && cast.ToInt(c.Query("page")) <= 1 && c.Query("per_page") == ""
+	if cacheable {
+		if items, paging := s.cache.GetTree(ctx, topicID, opts.Sort); items != nil {
+			return &CommentTreeListResponseDTO{Comments: s.toTreeDTOList(items), Paging: paging}, nil
+		}
+	}
+
+	items, paging, err := s.repo.Tree(ctx, c, topicID, opts, perPage)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取评论树", err)
+	}
+	if cacheable {
+		logger.LogIf(s.cache.SetTree(ctx, topicID, opts.Sort, items, paging))
+	}
+	return &CommentTreeListResponseDTO{Comments: s.toTreeDTOList(items), Paging: paging}, nil
+}
+
+// toTreeDTOList 将评论树转换为响应DTO，并批量解析所有评论中的提及
+func (s *CommentService) toTreeDTOList(items []comment.TreeItem) []CommentTreeDTO {
+	var all []*CommentTreeDTO
+	var convert func(items []comment.TreeItem) []CommentTreeDTO
+	convert = func(items []comment.TreeItem) []CommentTreeDTO {
+		list := make([]CommentTreeDTO, len(items))
+		for i := range items {
+			list[i] = CommentTreeDTO{
+				CommentResponseDTO: *s.toResponseDTO(&items[i].Comment),
+				ReplyCount:         items[i].ReplyCount,
+				Replies:            convert(items[i].Replies),
+			}
+			if replyTo := items[i].ReplyTo; replyTo != nil {
+				list[i].ReplyTo = &CommentReplyToDTO{ID: cast.ToString(replyTo.ID), UserID: replyTo.UserID}
+			}
+		}
+		return list
+	}
+	list := convert(items)
+
+	var collect func(list []CommentTreeDTO)
+	collect = func(list []CommentTreeDTO) {
+		for i := range list {
+			all = append(all, &list[i])
+			collect(list[i].Replies)
+		}
+	}
+	collect(list)
+	if s.mentionSvc != nil && len(all) > 0 {
+		contents := make([]string, len(all))
+		for i, dto := range all {
+			contents[i] = dto.Content
+		}
+		for i, mentions := range s.mentionSvc.ResolveMany(contents) {
+			all[i].Mentions = mentions
+		}
+	}
+	return list
+}
+
 // Create 创建评论
 func (s *CommentService) Create(ctx context.Context, dto *CommentCreateDTO) (*CommentResponseDTO, *apperrors.AppError) {
+	// 话题锁定、关闭或归档后不能再评论
+	topicModel, err := s.topicRepo.GetByID(ctx, dto.TopicID)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取话题", err)
+	}
+	// 未通过审核的话题对外不可见
+	if topicModel == nil || !topicModel.IsApproved() {
+		return nil, apperrors.NotFoundError("话题")
+	}
+	if appErr := CheckCategoryPost(dto.UserID, topicModel.CategoryID); appErr != nil {
+		return nil, appErr
+	}
+	if appErr := topicCommentError(topicModel); appErr != nil {
+		return nil, appErr
+	}
+
+	spamCheck := SpamCheck{Type: spam.ContentComment, UserID: dto.UserID, Text: dto.Content}
+	spamVerdict, appErr := s.spamSvc.Check(ctx, spamCheck)
+	if appErr != nil {
+		return nil, appErr
+	}
+
 	// 创建评论模型
 	commentModel := &comment.Comment{
 		TopicID:  dto.TopicID,
@@ -218,13 +461,16 @@ func (s *CommentService) Create(ctx context.Context, dto *CommentCreateDTO) (*Co
 		return nil, apperrors.DatabaseError("创建评论", err)
 	}
 
+	// 更新话题最后回复时间，用于按最新回复排序
+	if err := s.topicRepo.TouchLastReply(ctx, dto.TopicID, commentModel.CreatedAt); err != nil {
+		logger.LogIf(err)
+	}
+
 	// 发送通知：话题作者、父评论作者
 	if s.notifSvc != nil {
 		// 通知话题作者
-		if topicModel, err := s.topicRepo.GetByID(ctx, dto.TopicID); err == nil && topicModel != nil {
-			if topicModel.UserID != "" && topicModel.UserID != dto.UserID {
-				_ = s.notifSvc.Notify(topicModel.UserID, dto.UserID, "comment_created", map[string]interface{}{"topic_id": dto.TopicID, "comment_id": commentModel.GetStringID()})
-			}
+		if topicModel.UserID != "" && topicModel.UserID != dto.UserID {
+			_ = s.notifSvc.Notify(topicModel.UserID, dto.UserID, "comment_created", map[string]interface{}{"topic_id": dto.TopicID, "comment_id": commentModel.GetStringID()})
 		}
 		// 通知父评论作者
 		if dto.ParentID != "" && dto.ParentID != "0" {
@@ -240,12 +486,47 @@ func (s *CommentService) Create(ctx context.Context, dto *CommentCreateDTO) (*Co
 	if s.cache != nil {
 		s.cache.InvalidateByTopicID(ctx, dto.TopicID)
 	}
+	spamCheck.ID = commentModel.GetStringID()
+	s.spamSvc.Record(ctx, spamCheck, spamVerdict)
+	submitCommentForModeration(commentModel)
+	s.feedSvc.PublishComment(commentModel)
+
+	result := s.toResponseDTO(commentModel)
+	result.Mentions = s.syncMentions(commentModel)
+	return result, nil
+}
 
-	return s.toResponseDTO(commentModel), nil
+// CanViewTopic 用户是否可以查看话题下的评论，话题所在分类对用户不可见时返回 false
+// 与话题详情一致，未通过审核的话题下的评论只有作者、管理员和所在分类的版主可见
+// 话题不存在时返回 true，交由后续查询按原有逻辑处理
+func (s *CommentService) CanViewTopic(ctx context.Context, topicID, viewerID string) bool {
+	topicModel, err := s.topicRepo.GetByID(ctx, topicID)
+	if err != nil || topicModel == nil {
+		return true
+	}
+	if !topicModel.IsApproved() && !canViewUnapproved(topicModel.UserID, topicModel.CategoryID, viewerID) {
+		return false
+	}
+	return CanViewCategory(viewerID, topicModel.CategoryID)
+}
+
+// topicCommentError 话题不接受评论时返回对应的业务错误
+func topicCommentError(t *topic.Topic) *apperrors.AppError {
+	switch t.State() {
+	case topic.StateArchived:
+		return apperrors.BusinessError(apperrors.CodeTopicArchived, "话题已归档，不能评论")
+	case topic.StateClosed:
+		return apperrors.BusinessError(apperrors.CodeTopicClosed, "话题已关闭，不能评论")
+	case topic.StateLocked:
+		return apperrors.BusinessError(apperrors.CodeTopicLocked, "话题已锁定，不能评论")
+	}
+	return nil
 }
 
 // Update 更新评论
-func (s *CommentService) Update(ctx context.Context, id string, dto *CommentUpdateDTO) (*CommentResponseDTO, *apperrors.AppError) {
+// 只有作者可以修改自己的评论，且需在发布后的编辑时限内；管理员和可以管理话题所在分类的版主不受这两项限制
+// 修改前的内容作为历史版本保存，内容未变化时不产生编辑记录
+func (s *CommentService) Update(ctx context.Context, id string, dto *CommentUpdateDTO, operatorID string) (*CommentResponseDTO, *apperrors.AppError) {
 	// 获取评论
 	commentModel, err := s.repo.GetByID(ctx, id)
 	if err != nil {
@@ -255,13 +536,39 @@ func (s *CommentService) Update(ctx context.Context, id string, dto *CommentUpda
 		return nil, apperrors.NotFoundError("评论")
 	}
 
-	// 更新字段
-	if dto.Content != nil {
-		commentModel.Content = *dto.Content
+	if !s.canModerate(ctx, commentModel, operatorID) {
+		if commentModel.UserID != operatorID {
+			return nil, apperrors.AuthorizationError("只能修改自己的评论")
+		}
+		if window := config.GetInt("comment.edit_window", 15); window > 0 &&
+			time.Since(commentModel.CreatedAt) > time.Duration(window)*time.Minute {
+			return nil, apperrors.BusinessErrorf(apperrors.CodeCommentEditExpired, "评论发布超过 %d 分钟，不能再修改", window)
+		}
+	}
+
+	if dto.Content == nil || *dto.Content == commentModel.Content {
+		return s.toResponseDTO(commentModel), nil
+	}
+
+	spamCheck := SpamCheck{Type: spam.ContentComment, ID: commentModel.GetStringID(), UserID: commentModel.UserID, Text: *dto.Content}
+	spamVerdict, appErr := s.spamSvc.Check(ctx, spamCheck)
+	if appErr != nil {
+		return nil, appErr
 	}
 
-	// 保存更新
-	if err := s.repo.Update(ctx, commentModel); err != nil {
+	revision := &comment.Revision{
+		CommentID: commentModel.ID,
+		EditorID:  cast.ToUint64(operatorID),
+		Content:   commentModel.Content,
+	}
+	now := time.Now()
+	commentModel.Content = *dto.Content
+	commentModel.EditCount++
+	commentModel.EditedAt = &now
+	commentModel.UpdatedAt = now
+
+	// 保存更新和历史版本
+	if err := s.repo.Edit(ctx, commentModel, revision); err != nil {
 		return nil, apperrors.DatabaseError("更新评论", err)
 	}
 
@@ -270,13 +577,65 @@ func (s *CommentService) Update(ctx context.Context, id string, dto *CommentUpda
 		s.cache.Invalidate(ctx, id)
 		s.cache.InvalidateByTopicID(ctx, commentModel.TopicID)
 	}
+	s.spamSvc.Record(ctx, spamCheck, spamVerdict)
+	submitCommentForModeration(commentModel)
 
-	return s.toResponseDTO(commentModel), nil
+	result := s.toResponseDTO(commentModel)
+	result.Mentions = s.syncMentions(commentModel)
+	return result, nil
 }
 
-// Delete 删除评论
-func (s *CommentService) Delete(ctx context.Context, id string) *apperrors.AppError {
-	// 获取评论信息用于清除缓存
+// canModerate 用户能否管理评论所在话题的分类，被指派了分类的版主只能管理这些分类下的评论
+func (s *CommentService) canModerate(ctx context.Context, c *comment.Comment, userID string) bool {
+	categoryID := ""
+	if topicModel, err := s.topicRepo.GetByID(ctx, c.TopicID); err == nil && topicModel != nil {
+		categoryID = topicModel.CategoryID
+	}
+	return category.UserCanModerate(userID, categoryID)
+}
+
+// submitCommentForModeration 提交评论内容异步审核
+func submitCommentForModeration(c *comment.Comment) {
+	SubmitForModeration(moderator.Content{
+		Type:     moderator.ContentComment,
+		ID:       c.GetStringID(),
+		AuthorID: c.UserID,
+		Body:     c.Content,
+	})
+}
+
+// Revisions 获取评论的历史版本，只有作者、管理员和可以管理话题所在分类的版主可以查看
+func (s *CommentService) Revisions(ctx context.Context, id, viewerID string) ([]CommentRevisionDTO, *apperrors.AppError) {
+	commentModel, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取评论", err)
+	}
+	if commentModel == nil {
+		return nil, apperrors.NotFoundError("评论")
+	}
+	if commentModel.UserID != viewerID && !s.canModerate(ctx, commentModel, viewerID) {
+		return nil, apperrors.AuthorizationError("无权查看评论的编辑历史")
+	}
+
+	revisions, err := s.repo.Revisions(ctx, commentModel.ID)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取评论编辑历史", err)
+	}
+	list := make([]CommentRevisionDTO, 0, len(revisions))
+	for _, r := range revisions {
+		list = append(list, CommentRevisionDTO{
+			ID:        r.GetStringID(),
+			CommentID: cast.ToString(r.CommentID),
+			EditorID:  cast.ToString(r.EditorID),
+			Content:   r.Content,
+			CreatedAt: r.CreatedAt,
+		})
+	}
+	return list, nil
+}
+
+// Delete 删除评论，删除后进入回收站，回复不会因父评论被删除而丢失
+func (s *CommentService) Delete(ctx context.Context, id, operatorID string) *apperrors.AppError {
 	commentModel, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return apperrors.DatabaseError("获取评论", err)
@@ -285,46 +644,221 @@ func (s *CommentService) Delete(ctx context.Context, id string) *apperrors.AppEr
 		return apperrors.NotFoundError("评论")
 	}
 
-	// 删除评论
-	if err := s.repo.Delete(ctx, id); err != nil {
-		return apperrors.DatabaseError("删除评论", err)
+	// 软删除并清除缓存
+	if _, appErr := NewTrashService().Delete(repositories.TrashComment, []uint64{commentModel.ID}, operatorID, ""); appErr != nil {
+		return appErr
 	}
 
-	// 清除缓存
-	if s.cache != nil {
-		s.cache.Invalidate(ctx, id)
-		s.cache.InvalidateByTopicID(ctx, commentModel.TopicID)
+	return nil
+}
+
+// AcceptAnswer 将评论采纳为问答话题的答案，已有采纳答案时改为采纳这条
+// 只有话题作者和可以管理话题所在分类的版主可以采纳，且只能采纳顶级评论；回答者获得积分并收到通知
+func (s *CommentService) AcceptAnswer(ctx context.Context, id, operatorID string) (*CommentResponseDTO, *apperrors.AppError) {
+	commentModel, topicModel, appErr := s.getForAccept(ctx, id, operatorID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if commentModel.ParentID != "" && commentModel.ParentID != "0" {
+		return nil, apperrors.BusinessError(apperrors.CodeCommentNotAnswer, "只能采纳对话题的直接回答")
+	}
+
+	points := config.GetInt64("comment.accepted_answer_points", 10)
+	previous, err := s.repo.Accept(ctx, commentModel.TopicID, commentModel.ID, points)
+	if err != nil {
+		return nil, apperrors.DatabaseError("采纳回答", err)
+	}
+	s.invalidateAccepted(ctx, commentModel, previous)
+
+	if s.notifSvc != nil && !commentModel.IsAccepted && commentModel.UserID != operatorID {
+		_ = s.notifSvc.Notify(commentModel.UserID, operatorID, "answer_accepted", map[string]interface{}{
+			"topic_id":   commentModel.TopicID,
+			"comment_id": id,
+			"title":      topicModel.Title,
+		})
+	}
+
+	commentModel.IsAccepted = true
+	return s.toResponseDTO(commentModel), nil
+}
+
+// UnacceptAnswer 取消采纳，话题回到未解决状态并扣回回答者的积分
+func (s *CommentService) UnacceptAnswer(ctx context.Context, id, operatorID string) *apperrors.AppError {
+	commentModel, _, appErr := s.getForAccept(ctx, id, operatorID)
+	if appErr != nil {
+		return appErr
+	}
+	if !commentModel.IsAccepted {
+		return nil
 	}
 
+	points := config.GetInt64("comment.accepted_answer_points", 10)
+	previous, err := s.repo.Accept(ctx, commentModel.TopicID, 0, points)
+	if err != nil {
+		return apperrors.DatabaseError("取消采纳", err)
+	}
+	s.invalidateAccepted(ctx, commentModel, previous)
 	return nil
 }
 
-// LikeComment 点赞评论
-func (s *CommentService) LikeComment(ctx context.Context, id string) *apperrors.AppError {
-	if err := s.repo.IncrementLikeCount(ctx, id); err != nil {
-		return apperrors.DatabaseError("点赞评论", err)
+// getForAccept 获取要采纳的评论和所属话题，并检查话题是否在问答分类中以及操作人权限
+func (s *CommentService) getForAccept(ctx context.Context, id, operatorID string) (*comment.Comment, *topic.Topic, *apperrors.AppError) {
+	commentModel, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取评论", err)
+	}
+	if commentModel == nil {
+		return nil, nil, apperrors.NotFoundError("评论")
+	}
+	topicModel, err := s.topicRepo.GetByID(ctx, commentModel.TopicID)
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取话题", err)
+	}
+	if topicModel == nil || !topicModel.IsApproved() {
+		return nil, nil, apperrors.NotFoundError("话题")
+	}
+	if topicModel.UserID != operatorID && !category.UserCanModerate(operatorID, topicModel.CategoryID) {
+		return nil, nil, apperrors.AuthorizationError("只有话题作者可以采纳回答")
+	}
+	categoryModel, err := s.categoryRepo.GetByID(topicModel.CategoryID)
+	if err != nil || !categoryModel.IsQA {
+		return nil, nil, apperrors.BusinessError(apperrors.CodeTopicNotQuestion, "话题不在问答分类中，不能采纳回答")
 	}
+	return commentModel, topicModel, nil
+}
 
-	// 清除缓存
+// invalidateAccepted 采纳状态变化后清除评论、评论列表和话题缓存
+func (s *CommentService) invalidateAccepted(ctx context.Context, c *comment.Comment, previous *comment.Comment) {
 	if s.cache != nil {
-		s.cache.Invalidate(ctx, id)
+		s.cache.Invalidate(ctx, c.GetStringID())
+		if previous != nil {
+			s.cache.Invalidate(ctx, previous.GetStringID())
+		}
+		s.cache.InvalidateByTopicID(ctx, c.TopicID)
 	}
+	if s.topicCache != nil {
+		logger.LogIf(s.topicCache.Delete(ctx, c.TopicID))
+		logger.LogIf(s.topicCache.ClearList(ctx))
+	}
+}
 
+// LikeComment 点赞评论，重复点赞不会重复计数，首次点赞时通知评论作者
+func (s *CommentService) LikeComment(ctx context.Context, id, userID string) *apperrors.AppError {
+	commentModel, appErr := s.getForLike(ctx, id)
+	if appErr != nil {
+		return appErr
+	}
+	liked, err := s.repo.Like(ctx, userID, id)
+	if err != nil {
+		return apperrors.DatabaseError("点赞评论", err)
+	}
+	if !liked {
+		return nil
+	}
+	s.invalidateLikes(ctx, commentModel)
+	if s.notifSvc != nil && commentModel.UserID != "" && commentModel.UserID != userID {
+		_ = s.notifSvc.Notify(commentModel.UserID, userID, "comment_like", map[string]interface{}{
+			"topic_id":   commentModel.TopicID,
+			"comment_id": id,
+		})
+	}
 	return nil
 }
 
-// UnlikeComment 取消点赞
-func (s *CommentService) UnlikeComment(ctx context.Context, id string) *apperrors.AppError {
-	if err := s.repo.DecrementLikeCount(ctx, id); err != nil {
+// UnlikeComment 取消点赞，未点赞时不做任何修改
+func (s *CommentService) UnlikeComment(ctx context.Context, id, userID string) *apperrors.AppError {
+	commentModel, appErr := s.getForLike(ctx, id)
+	if appErr != nil {
+		return appErr
+	}
+	unliked, err := s.repo.Unlike(ctx, userID, id)
+	if err != nil {
 		return apperrors.DatabaseError("取消点赞评论", err)
 	}
+	if unliked {
+		s.invalidateLikes(ctx, commentModel)
+	}
+	return nil
+}
 
-	// 清除缓存
+// getForLike 获取要点赞的评论
+func (s *CommentService) getForLike(ctx context.Context, id string) (*comment.Comment, *apperrors.AppError) {
+	commentModel, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取评论", err)
+	}
+	if commentModel == nil {
+		return nil, apperrors.NotFoundError("评论")
+	}
+	return commentModel, nil
+}
+
+// invalidateLikes 点赞数变化后清除评论和评论树缓存
+func (s *CommentService) invalidateLikes(ctx context.Context, c *comment.Comment) {
 	if s.cache != nil {
-		s.cache.Invalidate(ctx, id)
+		s.cache.Invalidate(ctx, c.GetStringID())
+		s.cache.InvalidateByTopicID(ctx, c.TopicID)
 	}
+}
 
-	return nil
+// FillViewerState 填充与当前用户相关的字段：点赞状态和表情回应，未登录时只填充回应数
+func (s *CommentService) FillViewerState(ctx context.Context, viewerID string, comments ...*CommentResponseDTO) {
+	if len(comments) == 0 {
+		return
+	}
+	ids := make([]uint64, 0, len(comments))
+	for _, c := range comments {
+		ids = append(ids, cast.ToUint64(c.ID))
+	}
+	if s.reactionSvc != nil {
+		summaries := s.reactionSvc.Summaries(ReactionTargetComment, ids, viewerID)
+		for _, c := range comments {
+			c.Reactions = summaries[cast.ToUint64(c.ID)]
+		}
+	}
+	if viewerID == "" {
+		return
+	}
+	liked, err := s.repo.LikedIDs(ctx, viewerID, ids)
+	if err != nil {
+		logger.LogIf(err)
+		return
+	}
+	for _, c := range comments {
+		c.LikedByMe = liked[cast.ToUint64(c.ID)]
+	}
+}
+
+// FillListViewerState 填充评论列表中与当前用户相关的字段
+func (s *CommentService) FillListViewerState(ctx context.Context, viewerID string, list []CommentResponseDTO) {
+	comments := make([]*CommentResponseDTO, 0, len(list))
+	for i := range list {
+		comments = append(comments, &list[i])
+	}
+	s.FillViewerState(ctx, viewerID, comments...)
+}
+
+// FillTreeViewerState 填充评论树中与当前用户相关的字段
+func (s *CommentService) FillTreeViewerState(ctx context.Context, viewerID string, tree []CommentTreeDTO) {
+	var comments []*CommentResponseDTO
+	var collect func(list []CommentTreeDTO)
+	collect = func(list []CommentTreeDTO) {
+		for i := range list {
+			comments = append(comments, &list[i].CommentResponseDTO)
+			collect(list[i].Replies)
+		}
+	}
+	collect(tree)
+	s.FillViewerState(ctx, viewerID, comments...)
+}
+
+// RecountLikes 按点赞记录重新计算评论点赞数，返回修正的评论数
+func (s *CommentService) RecountLikes(ctx context.Context) (int64, *apperrors.AppError) {
+	fixed, err := s.repo.RecountLikes(ctx)
+	if err != nil {
+		return fixed, apperrors.DatabaseError("重新计算评论点赞数", err)
+	}
+	return fixed, nil
 }
 
 // CountByTopicID 统计话题的评论数
@@ -0,0 +1,288 @@
+// Package services 异步内容审核
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"GoHub-Service/app/models/moderation"
+	"GoHub-Service/app/models/report"
+	"GoHub-Service/app/models/role"
+	"GoHub-Service/app/repositories"
+	"GoHub-Service/pkg/config"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/moderator"
+	"GoHub-Service/pkg/security"
+
+	"github.com/spf13/cast"
+	"go.uber.org/zap"
+)
+
+// 审核流水线只构建一次，正则规则和分类服务配置在启动时读取
+var (
+	moderationPipeline     *moderator.Pipeline
+	moderationPipelineOnce sync.Once
+	moderationJobs         chan moderator.Content
+)
+
+// ModerationPipeline 根据配置构建审核流水线：敏感词、正则规则、链接信誉、重复内容、外部分类服务
+func ModerationPipeline() *moderator.Pipeline {
+	moderationPipelineOnce.Do(func() {
+		stages := []moderator.Moderator{
+			moderator.NewSensitiveWordModerator(security.GetFilter()),
+		}
+		if spec := config.GetString("moderation.regex_rules"); spec != "" {
+			rules, err := moderator.ParseRegexRules(spec)
+			if err != nil {
+				logger.LogIf(err)
+			} else {
+				stages = append(stages, moderator.NewRegexModerator(rules))
+			}
+		}
+		stages = append(stages,
+			moderator.NewLinkModerator(
+				splitList(config.GetString("moderation.blocked_domains")),
+				splitList(config.GetString("moderation.allowed_domains")),
+				config.GetInt("moderation.max_links", 5),
+			),
+			moderator.NewDuplicateModerator(
+				moderator.RedisDuplicateStore{},
+				time.Duration(config.GetInt("moderation.duplicate_window_minutes", 10))*time.Minute,
+				config.GetInt("moderation.duplicate_min_length", 10),
+			),
+		)
+		if url := config.GetString("moderation.webhook_url"); url != "" {
+			timeout := time.Duration(config.GetInt("moderation.webhook_timeout_ms", 3000)) * time.Millisecond
+			stages = append(stages, moderator.NewWebhookModerator(url, config.GetString("moderation.webhook_token"), timeout))
+		}
+		moderationPipeline = moderator.NewPipeline(
+			config.GetFloat64("moderation.review_score", 0.5),
+			config.GetFloat64("moderation.reject_score", 0.9),
+			stages...,
+		)
+	})
+	return moderationPipeline
+}
+
+// StartContentModeration 启动审核工作协程
+func StartContentModeration(workers, queueSize int) {
+	if workers <= 0 || moderationJobs != nil {
+		return
+	}
+	moderationJobs = make(chan moderator.Content, queueSize)
+	for i := 0; i < workers; i++ {
+		go func() {
+			svc := NewContentModerationService()
+			for content := range moderationJobs {
+				svc.Handle(context.Background(), content)
+			}
+		}()
+	}
+}
+
+// SubmitForModeration 提交内容异步审核，不阻塞发布流程
+// 工作协程未启动（如命令行任务）时同步处理；队列已满时最多等待 pipeline_enqueue_timeout_ms，仍无法入队则放弃并记录日志
+func SubmitForModeration(content moderator.Content) {
+	if !config.GetBool("moderation.pipeline_enabled") || strings.TrimSpace(content.Text()) == "" {
+		return
+	}
+	if moderationJobs == nil {
+		NewContentModerationService().Handle(context.Background(), content)
+		return
+	}
+	select {
+	case moderationJobs <- content:
+		return
+	default:
+	}
+
+	timer := time.NewTimer(time.Duration(config.GetInt("moderation.pipeline_enqueue_timeout_ms", 200)) * time.Millisecond)
+	defer timer.Stop()
+	select {
+	case moderationJobs <- content:
+	case <-timer.C:
+		logger.Logger.Warn("审核队列已满，放弃审核",
+			zap.String("type", content.Type),
+			zap.String("id", content.ID),
+		)
+	}
+}
+
+// ContentModerationService 执行审核流水线并处理审核结论
+// review：话题撤回审核队列，评论、私信和个人资料提交到举报队列等待版主处理
+// reject：话题审核拒绝，评论进入回收站，私信隐藏，个人资料清空简介和城市
+type ContentModerationService struct {
+	pipeline    *moderator.Pipeline
+	reportRepo  repositories.ReportRepository
+	messageRepo repositories.MessageRepository
+	userRepo    repositories.UserRepository
+	logRepo     repositories.ModerationLogRepository
+	notifSvc    *NotificationService
+}
+
+// NewContentModerationService 创建内容审核服务实例
+func NewContentModerationService() *ContentModerationService {
+	return &ContentModerationService{
+		pipeline:    ModerationPipeline(),
+		reportRepo:  repositories.NewReportRepository(),
+		messageRepo: repositories.NewMessageRepository(),
+		userRepo:    repositories.NewUserRepository(),
+		logRepo:     repositories.NewModerationLogRepository(),
+		notifSvc:    NewNotificationService(),
+	}
+}
+
+// Handle 审核内容并处理结论，管理员和版主发布的内容不审核
+func (s *ContentModerationService) Handle(ctx context.Context, content moderator.Content) moderator.Decision {
+	if content.AuthorID != "" && role.UserHasAnyRole(content.AuthorID, "admin", "moderator") {
+		return moderator.Decision{Verdict: moderator.VerdictAllow}
+	}
+
+	decision := s.pipeline.Run(ctx, content)
+	if decision.Verdict == moderator.VerdictAllow {
+		return decision
+	}
+	s.Apply(ctx, content, decision)
+	return decision
+}
+
+// Apply 按审核结论处理内容并写入操作日志，垃圾信息检测送审的内容也由此进入审核队列
+func (s *ContentModerationService) Apply(ctx context.Context, content moderator.Content, decision moderator.Decision) {
+	var err error
+	if decision.Verdict == moderator.VerdictReject {
+		err = s.reject(ctx, content, decision)
+	} else {
+		err = s.review(ctx, content, decision)
+	}
+	if err != nil {
+		logger.Logger.Error("处理审核结论失败",
+			zap.String("type", content.Type),
+			zap.String("id", content.ID),
+			zap.String("verdict", decision.Verdict),
+			zap.Error(err),
+		)
+		return
+	}
+	s.record(content, decision)
+}
+
+// review 需要人工审核的内容进入对应的审核队列
+func (s *ContentModerationService) review(ctx context.Context, content moderator.Content, decision moderator.Decision) error {
+	if content.Type == moderator.ContentTopic {
+		if appErr := NewModerationService().Requeue(content.ID, "0", decision.Reason()); appErr != nil {
+			return appErr
+		}
+		return nil
+	}
+	return s.fileReport(ctx, content, decision)
+}
+
+// reject 直接拒绝的内容不再对外展示，并通知作者
+func (s *ContentModerationService) reject(ctx context.Context, content moderator.Content, decision moderator.Decision) error {
+	reason := decision.Reason()
+	switch content.Type {
+	case moderator.ContentTopic:
+		// 审核拒绝时已通知作者
+		if _, appErr := NewModerationService().Reject(content.ID, "0", reason); appErr != nil {
+			return appErr
+		}
+		return nil
+	case moderator.ContentComment:
+		if _, appErr := NewTrashService().Delete(repositories.TrashComment, []uint64{cast.ToUint64(content.ID)}, "0", reason); appErr != nil {
+			return appErr
+		}
+	case moderator.ContentMessage:
+		if err := s.messageRepo.Hide(content.ID); err != nil {
+			return err
+		}
+	case moderator.ContentProfile:
+		if err := s.userRepo.ClearProfile(content.AuthorID); err != nil {
+			return err
+		}
+		// 昵称无法自动清空，交由版主处理
+		if err := s.fileReport(ctx, content, decision); err != nil {
+			return err
+		}
+	}
+	if s.notifSvc != nil {
+		_ = s.notifSvc.Notify(content.AuthorID, "0", "content_rejected", map[string]interface{}{
+			"type":   content.Type,
+			"id":     content.ID,
+			"reason": reason,
+		})
+	}
+	return nil
+}
+
+// fileReport 以系统身份提交举报，同一内容只提交一次
+func (s *ContentModerationService) fileReport(ctx context.Context, content moderator.Content, decision moderator.Decision) error {
+	targetType, targetID := reportTargetOf(content)
+	existing, err := s.reportRepo.GetByReporter(ctx, 0, targetType, targetID)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.IsPending() {
+		return nil
+	}
+	detail := decision.Reason()
+	if len([]rune(detail)) > 500 {
+		detail = string([]rune(detail)[:500])
+	}
+	if existing != nil {
+		// 举报人和目标唯一，已处理过的系统举报重新打开
+		return s.reportRepo.Reopen(ctx, existing.ID, detail)
+	}
+	return s.reportRepo.Create(ctx, &report.Report{
+		TargetType: targetType,
+		TargetID:   targetID,
+		OwnerID:    cast.ToUint64(content.AuthorID),
+		Reason:     report.ReasonAutoModeration,
+		Detail:     detail,
+		Status:     report.StatusOpen,
+	})
+}
+
+// record 写入操作日志，操作人为 0 表示审核流水线
+func (s *ContentModerationService) record(content moderator.Content, decision moderator.Decision) {
+	targetType, targetID := reportTargetOf(content)
+	action := moderation.ActionAutoReview
+	if decision.Verdict == moderator.VerdictReject {
+		action = moderation.ActionAutoReject
+	}
+	reason := decision.Reason()
+	if len([]rune(reason)) > 500 {
+		reason = string([]rune(reason)[:500])
+	}
+	log := &moderation.Log{
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Reason:     reason,
+	}
+	if raw, err := json.Marshal(map[string]interface{}{"score": decision.Score, "results": decision.Results}); err == nil {
+		log.Detail = string(raw)
+	}
+	logger.LogIf(s.logRepo.Create(context.Background(), log))
+}
+
+// reportTargetOf 审核内容对应的举报目标，个人资料对应用户
+func reportTargetOf(content moderator.Content) (string, uint64) {
+	if content.Type == moderator.ContentProfile {
+		return report.TargetUser, cast.ToUint64(content.AuthorID)
+	}
+	return content.Type, cast.ToUint64(content.ID)
+}
+
+// splitList 解析逗号分隔的配置项
+func splitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
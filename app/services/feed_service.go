@@ -0,0 +1,579 @@
+// Package services 首页个性化信息流
+package services
+
+import (
+	"context"
+	"time"
+
+	"GoHub-Service/app/models/comment"
+	"GoHub-Service/app/models/subscription"
+	"GoHub-Service/app/models/tag"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/repositories"
+	"GoHub-Service/pkg/config"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/feed"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+// feedCursorColumns 信息流游标的排序字段：发生时间（毫秒）倒序，同一时间按条目 Key 倒序
+var feedCursorColumns = []paginator.SortColumn{
+	{Name: "created_at", Desc: true},
+	{Name: "key", Desc: true},
+}
+
+// feedExcerptLength 信息流中回复摘要的最大字数
+const feedExcerptLength = 140
+
+// FeedService 首页信息流
+// 粉丝数较少的作者发布内容时推送到所有粉丝的收件箱（写扩散），
+// 粉丝数达到 feed.fanout_max_followers 的作者、关注的标签和分类在读取时查询（读扩散），两部分合并后按时间倒序分页
+type FeedService struct {
+	repo repositories.FeedRepository
+}
+
+// NewFeedService 创建信息流服务实例
+func NewFeedService() *FeedService {
+	return &FeedService{
+		repo: repositories.NewFeedRepository(),
+	}
+}
+
+// FeedActorDTO 动态的作者
+type FeedActorDTO struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Avatar string `json:"avatar"`
+}
+
+// FeedCommentDTO 动态中的回复
+type FeedCommentDTO struct {
+	ID        string    `json:"id"`
+	Excerpt   string    `json:"excerpt"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FeedItemDTO 信息流中的一条动态
+type FeedItemDTO struct {
+	Type      string            `json:"type"`      // topic 发布了话题，comment 回复了话题
+	Reason    string            `json:"reason"`    // user 关注的用户，tag 关注的标签，category 关注的分类
+	ReasonID  string            `json:"reason_id"` // 对应的用户、标签或分类 ID
+	Actor     FeedActorDTO      `json:"actor"`
+	Topic     *TopicResponseDTO `json:"topic"`
+	Comment   *FeedCommentDTO   `json:"comment,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// FeedResponseDTO 信息流分页结果
+type FeedResponseDTO struct {
+	Items  []FeedItemDTO          `json:"items"`
+	Paging paginator.CursorPaging `json:"paging"`
+}
+
+// SubscriptionDTO 关注的标签或分类
+type SubscriptionDTO struct {
+	TargetType string    `json:"target_type"`
+	TargetID   string    `json:"target_id"`
+	Name       string    `json:"name"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Home 当前用户的首页信息流，按 cursor 参数翻页
+func (s *FeedService) Home(c *gin.Context, viewerID string, perPage int) (*FeedResponseDTO, *apperrors.AppError) {
+	ctx := c.Request.Context()
+	uid := cast.ToUint64(viewerID)
+	perPage = paginator.CursorPerPage(c, perPage)
+
+	var cursor *feed.Cursor
+	var before *time.Time
+	if token := c.Query("cursor"); token != "" {
+		values, err := paginator.DecodeCursor(token, feedCursorColumns)
+		if err != nil {
+			return nil, invalidCursorError(c, err)
+		}
+		cursor = &feed.Cursor{At: cast.ToInt64(values[0]), Key: cast.ToString(values[1])}
+		at := time.UnixMilli(cursor.At)
+		before = &at
+	}
+
+	sources, truncated, err := s.collect(ctx, uid, before, perPage+1)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取信息流", err)
+	}
+	candidates := feed.Merge(cursor, 0, sources...)
+	// 读扩散的来源每个最多查询 perPage+1 条，被截断的来源最后一条之前的内容还没有查出来，
+	// 只能使用不早于该时间的条目，其余留到下一页
+	if truncated != nil {
+		for i, e := range candidates {
+			if e.At < truncated.At {
+				candidates = candidates[:i]
+				break
+			}
+		}
+	}
+
+	// 条目可能因内容被删除、分类不可见或作者被屏蔽而被过滤，分批补足一页
+	items := make([]FeedItemDTO, 0, perPage+1)
+	var used []feed.Entry
+	for start := 0; start < len(candidates) && len(items) <= perPage; start += perPage + 1 {
+		end := start + perPage + 1
+		if end > len(candidates) {
+			end = len(candidates)
+		}
+		batchItems, batchEntries := s.hydrate(ctx, viewerID, candidates[start:end])
+		items = append(items, batchItems...)
+		used = append(used, batchEntries...)
+	}
+
+	paging := paginator.CursorPaging{PerPage: perPage}
+	if len(items) > perPage {
+		items, used = items[:perPage], used[:perPage]
+		paging.HasMore = true
+	} else if truncated != nil {
+		paging.HasMore = true
+	}
+	if paging.HasMore && len(used) > 0 {
+		last := used[len(used)-1]
+		token, err := paginator.EncodeCursor(feedCursorColumns, last.At, last.Key())
+		if err != nil {
+			return nil, apperrors.InternalError("生成分页游标失败", err)
+		}
+		paging.NextCursor = token
+		paging.NextPageURL = paginator.CursorPageLink("/api/v1/feed", token, perPage)
+	} else {
+		paging.HasMore = false
+	}
+	return &FeedResponseDTO{Items: items, Paging: paging}, nil
+}
+
+// collect 读取收件箱和各个读扩散来源，limit 为每个来源最多查询的条数
+// 返回被截断的来源中最晚的末尾条目，没有来源被截断时为 nil
+func (s *FeedService) collect(ctx context.Context, uid uint64, before *time.Time, limit int) ([][]feed.Entry, *feed.Entry, error) {
+	pushed, pulled, err := s.repo.FollowedAuthors(ctx, uid, feedFanoutMaxFollowers())
+	if err != nil {
+		return nil, nil, err
+	}
+	inbox, exists, err := s.repo.Inbox(ctx, uid)
+	if err != nil {
+		logger.LogIf(err)
+	} else if !exists {
+		inbox = s.rebuild(ctx, uid, pushed)
+	}
+
+	tagIDs, err := s.repo.SubscribedIDs(ctx, uid, subscription.TargetTag)
+	if err != nil {
+		return nil, nil, err
+	}
+	categoryIDs, err := s.repo.SubscribedIDs(ctx, uid, subscription.TargetCategory)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sources := [][]feed.Entry{inbox}
+	var truncated *feed.Entry
+	queries := []func() ([]feed.Entry, error){
+		func() ([]feed.Entry, error) { return s.repo.TopicsByAuthors(ctx, pulled, before, limit) },
+		func() ([]feed.Entry, error) { return s.repo.CommentsByAuthors(ctx, pulled, before, limit) },
+		func() ([]feed.Entry, error) { return s.repo.TopicsByTags(ctx, tagIDs, before, limit) },
+		func() ([]feed.Entry, error) { return s.repo.TopicsByCategories(ctx, categoryIDs, before, limit) },
+	}
+	for _, query := range queries {
+		entries, err := query()
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(entries) >= limit {
+			last := entries[len(entries)-1]
+			if truncated == nil || last.At > truncated.At {
+				truncated = &last
+			}
+		}
+		sources = append(sources, entries)
+	}
+	return sources, truncated, nil
+}
+
+// rebuild 收件箱不存在（新用户或长时间未访问已过期）时从关注的普通作者的最近内容重建
+func (s *FeedService) rebuild(ctx context.Context, uid uint64, authorIDs []uint64) []feed.Entry {
+	size := feedInboxSize()
+	topics, err := s.repo.TopicsByAuthors(ctx, authorIDs, nil, size)
+	if err != nil {
+		logger.LogIf(err)
+		return nil
+	}
+	comments, err := s.repo.CommentsByAuthors(ctx, authorIDs, nil, size)
+	if err != nil {
+		logger.LogIf(err)
+		return nil
+	}
+	entries := feed.Merge(nil, size, topics, comments)
+	logger.LogIf(s.repo.Append(ctx, uid, size, feedInboxTTL(), entries))
+	return entries
+}
+
+// hydrate 加载条目对应的话题、回复和作者，返回可以展示的动态及其对应的条目
+// 内容已删除的条目从当前用户的收件箱中移除
+func (s *FeedService) hydrate(ctx context.Context, viewerID string, entries []feed.Entry) ([]FeedItemDTO, []feed.Entry) {
+	blockedIDs, err := s.repo.BlockedIDs(ctx, cast.ToUint64(viewerID))
+	logger.LogIf(err)
+	skip := make(map[uint64]bool, len(blockedIDs)+1)
+	for _, id := range blockedIDs {
+		skip[id] = true
+	}
+	skip[cast.ToUint64(viewerID)] = true
+
+	var topicIDs, commentIDs, actorIDs []uint64
+	for _, e := range entries {
+		topicIDs = append(topicIDs, e.TopicID)
+		actorIDs = append(actorIDs, e.ActorID)
+		if e.Kind == feed.KindComment {
+			commentIDs = append(commentIDs, e.ID)
+		}
+	}
+	topics, topicsErr := s.repo.Topics(ctx, topicIDs)
+	logger.LogIf(topicsErr)
+	comments, commentsErr := s.repo.Comments(ctx, commentIDs)
+	logger.LogIf(commentsErr)
+	users, err := s.repo.Users(ctx, actorIDs)
+	logger.LogIf(err)
+
+	hidden := make(map[string]bool)
+	for _, id := range HiddenCategoryIDs(viewerID) {
+		hidden[cast.ToString(id)] = true
+	}
+	topicByID := make(map[uint64]*topic.Topic, len(topics))
+	for i := range topics {
+		topicByID[topics[i].ID] = &topics[i]
+	}
+	commentByID := make(map[uint64]*comment.Comment, len(comments))
+	for i := range comments {
+		commentByID[comments[i].ID] = &comments[i]
+	}
+	actorByID := make(map[uint64]FeedActorDTO, len(users))
+	for _, u := range users {
+		actorByID[u.ID] = FeedActorDTO{ID: cast.ToString(u.ID), Name: u.Name, Avatar: u.Avatar}
+	}
+
+	items := make([]FeedItemDTO, 0, len(entries))
+	used := make([]feed.Entry, 0, len(entries))
+	var missing []feed.Entry
+	for _, e := range entries {
+		t, ok := topicByID[e.TopicID]
+		if !ok {
+			missing = append(missing, e)
+			continue
+		}
+		actor, ok := actorByID[e.ActorID]
+		if !ok || skip[e.ActorID] || hidden[t.CategoryID] {
+			continue
+		}
+		item := FeedItemDTO{
+			Type:      e.Kind,
+			Reason:    e.Reason,
+			ReasonID:  cast.ToString(e.SourceID),
+			Actor:     actor,
+			Topic:     topicResponseDTO(t),
+			CreatedAt: e.Time(),
+		}
+		if e.Kind == feed.KindComment {
+			cm, ok := commentByID[e.ID]
+			if !ok {
+				missing = append(missing, e)
+				continue
+			}
+			item.Comment = &FeedCommentDTO{
+				ID:        cm.GetStringID(),
+				Excerpt:   truncateRunes(cm.Content, feedExcerptLength),
+				CreatedAt: cm.CreatedAt,
+			}
+		}
+		items = append(items, item)
+		used = append(used, e)
+	}
+
+	// 查询失败时无法区分内容是否已被删除，不清理收件箱
+	if len(missing) > 0 && topicsErr == nil && commentsErr == nil {
+		go s.pruneDeleted(cast.ToUint64(viewerID), missing)
+	}
+	return items, used
+}
+
+// pruneDeleted 从收件箱中移除话题或回复已删除的条目
+// 撤回审核队列的话题和被隐藏的回复之后可能恢复展示，只是暂时跳过，不从收件箱移除
+func (s *FeedService) pruneDeleted(uid uint64, missing []feed.Entry) {
+	ctx := context.Background()
+	var topicIDs, commentIDs []uint64
+	for _, e := range missing {
+		topicIDs = append(topicIDs, e.TopicID)
+		if e.Kind == feed.KindComment {
+			commentIDs = append(commentIDs, e.ID)
+		}
+	}
+	existingTopics, err := s.repo.Existing(ctx, feed.KindTopic, topicIDs)
+	if err != nil {
+		logger.LogIf(err)
+		return
+	}
+	existingComments, err := s.repo.Existing(ctx, feed.KindComment, commentIDs)
+	if err != nil {
+		logger.LogIf(err)
+		return
+	}
+	topicExists := make(map[uint64]bool, len(existingTopics))
+	for _, id := range existingTopics {
+		topicExists[id] = true
+	}
+	commentExists := make(map[uint64]bool, len(existingComments))
+	for _, id := range existingComments {
+		commentExists[id] = true
+	}
+
+	stale := make(map[string]bool)
+	for _, e := range missing {
+		if !topicExists[e.TopicID] || (e.Kind == feed.KindComment && !commentExists[e.ID]) {
+			stale[e.Key()] = true
+		}
+	}
+	if len(stale) == 0 {
+		return
+	}
+	logger.LogIf(s.repo.RemoveFromInbox(ctx, []uint64{uid}, func(e feed.Entry) bool {
+		return stale[e.Key()]
+	}))
+}
+
+// PublishTopic 话题审核通过后推送到作者粉丝的收件箱，不阻塞发布流程
+func (s *FeedService) PublishTopic(t *topic.Topic) {
+	if t == nil || !t.IsApproved() {
+		return
+	}
+	s.publish(feed.Entry{
+		At:      t.CreatedAt.UnixMilli(),
+		Kind:    feed.KindTopic,
+		ID:      t.ID,
+		ActorID: cast.ToUint64(t.UserID),
+		TopicID: t.ID,
+	})
+}
+
+// PublishComment 一级回复推送到作者粉丝的收件箱，楼中楼回复不进入信息流
+func (s *FeedService) PublishComment(cm *comment.Comment) {
+	if cm == nil || (cm.ParentID != "" && cm.ParentID != "0") {
+		return
+	}
+	s.publish(feed.Entry{
+		At:      cm.CreatedAt.UnixMilli(),
+		Kind:    feed.KindComment,
+		ID:      cm.ID,
+		ActorID: cast.ToUint64(cm.UserID),
+		TopicID: cast.ToUint64(cm.TopicID),
+	})
+}
+
+// publish 异步写扩散，粉丝数达到阈值的作者跳过，由读取时查询
+func (s *FeedService) publish(e feed.Entry) {
+	go func() {
+		ctx := context.Background()
+		count, err := s.repo.FollowersCount(ctx, e.ActorID)
+		if err != nil || count == 0 || count >= feedFanoutMaxFollowers() {
+			logger.LogIf(err)
+			return
+		}
+		followerIDs, err := s.repo.FollowerIDs(ctx, e.ActorID)
+		if err != nil {
+			logger.LogIf(err)
+			return
+		}
+		logger.LogIf(s.repo.Push(ctx, followerIDs, feedInboxSize(), feedInboxTTL(), e))
+	}()
+}
+
+// Backfill 关注用户后把对方最近的内容补充到收件箱，不阻塞关注流程
+// 收件箱尚未建立时跳过，下次读取重建时会包含这些内容；对方粉丝数达到阈值时由读取时查询
+func (s *FeedService) Backfill(followerID, followeeID string) {
+	go func() {
+		ctx := context.Background()
+		uid, authorID := cast.ToUint64(followerID), cast.ToUint64(followeeID)
+		count, err := s.repo.FollowersCount(ctx, authorID)
+		if err != nil || count >= feedFanoutMaxFollowers() {
+			logger.LogIf(err)
+			return
+		}
+		if _, exists, err := s.repo.Inbox(ctx, uid); err != nil || !exists {
+			logger.LogIf(err)
+			return
+		}
+		size := config.GetInt("feed.backfill_size", 20)
+		topics, err := s.repo.TopicsByAuthors(ctx, []uint64{authorID}, nil, size)
+		if err != nil {
+			logger.LogIf(err)
+			return
+		}
+		comments, err := s.repo.CommentsByAuthors(ctx, []uint64{authorID}, nil, size)
+		if err != nil {
+			logger.LogIf(err)
+			return
+		}
+		logger.LogIf(s.repo.Append(ctx, uid, feedInboxSize(), feedInboxTTL(), feed.Merge(nil, size, topics, comments)))
+	}()
+}
+
+// RemoveActor 取消关注后从收件箱中移除对方的内容
+func (s *FeedService) RemoveActor(followerID, followeeID string) {
+	authorID := cast.ToUint64(followeeID)
+	go func() {
+		logger.LogIf(s.repo.RemoveFromInbox(context.Background(), []uint64{cast.ToUint64(followerID)}, func(e feed.Entry) bool {
+			return e.ActorID == authorID
+		}))
+	}()
+}
+
+// RemoveContent 话题或回复被删除后从作者粉丝的收件箱中移除，删除话题时同时移除该话题下的回复动态
+// 其他作者在该话题下的回复动态在读取时发现话题已删除后移除
+func (s *FeedService) RemoveContent(kind string, ids []uint64) {
+	if len(ids) == 0 {
+		return
+	}
+	removed := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		removed[id] = true
+	}
+	match := func(e feed.Entry) bool {
+		if kind == feed.KindTopic {
+			return removed[e.TopicID]
+		}
+		return e.Kind == feed.KindComment && removed[e.ID]
+	}
+
+	go func() {
+		ctx := context.Background()
+		authorIDs, err := s.repo.ContentAuthors(ctx, kind, ids)
+		if err != nil {
+			logger.LogIf(err)
+			return
+		}
+		for _, authorID := range authorIDs {
+			// 粉丝数达到阈值的作者不写扩散，粉丝数增长前推送的旧条目在读取时清理
+			count, err := s.repo.FollowersCount(ctx, authorID)
+			if err != nil || count >= feedFanoutMaxFollowers() {
+				logger.LogIf(err)
+				continue
+			}
+			followerIDs, err := s.repo.FollowerIDs(ctx, authorID)
+			if err != nil {
+				logger.LogIf(err)
+				continue
+			}
+			logger.LogIf(s.repo.RemoveFromInbox(ctx, followerIDs, match))
+		}
+	}()
+}
+
+// Subscribe 关注标签或分类，重复关注不报错
+func (s *FeedService) Subscribe(userID, targetType, targetID string) (*SubscriptionDTO, *apperrors.AppError) {
+	name, appErr := s.targetName(userID, targetType, cast.ToUint64(targetID))
+	if appErr != nil {
+		return nil, appErr
+	}
+	if _, err := s.repo.Subscribe(context.Background(), cast.ToUint64(userID), targetType, cast.ToUint64(targetID)); err != nil {
+		return nil, apperrors.DatabaseCreateError("关注", err)
+	}
+	return &SubscriptionDTO{
+		TargetType: targetType,
+		TargetID:   targetID,
+		Name:       name,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// Unsubscribe 取消关注标签或分类
+func (s *FeedService) Unsubscribe(userID, targetType, targetID string) *apperrors.AppError {
+	if !subscription.ValidTarget(targetType) {
+		return apperrors.ValidationError("不支持的关注类型", map[string]interface{}{"target_type": targetType})
+	}
+	ok, err := s.repo.Unsubscribe(context.Background(), cast.ToUint64(userID), targetType, cast.ToUint64(targetID))
+	if err != nil {
+		return apperrors.DatabaseDeleteError("关注", err)
+	}
+	if !ok {
+		return apperrors.NotFoundError("关注")
+	}
+	return nil
+}
+
+// Subscriptions 当前用户关注的标签和分类，targetType 为空时返回全部
+func (s *FeedService) Subscriptions(c *gin.Context, userID, targetType string, perPage int) ([]SubscriptionDTO, *paginator.Paging, *apperrors.AppError) {
+	if targetType != "" && !subscription.ValidTarget(targetType) {
+		return nil, nil, apperrors.ValidationError("不支持的关注类型", map[string]interface{}{"target_type": targetType})
+	}
+	subs, paging, err := s.repo.ListSubscriptions(c.Request.Context(), c, cast.ToUint64(userID), targetType, perPage)
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取关注的标签和分类", err)
+	}
+
+	var tagIDs []uint64
+	for _, sub := range subs {
+		if sub.TargetType == subscription.TargetTag {
+			tagIDs = append(tagIDs, sub.TargetID)
+		}
+	}
+	tagNames, err := s.repo.TagNames(c.Request.Context(), tagIDs)
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取关注的标签", err)
+	}
+	categories := categoryIndex()
+
+	result := make([]SubscriptionDTO, 0, len(subs))
+	for _, sub := range subs {
+		dto := SubscriptionDTO{
+			TargetType: sub.TargetType,
+			TargetID:   cast.ToString(sub.TargetID),
+			CreatedAt:  sub.CreatedAt,
+		}
+		if sub.TargetType == subscription.TargetTag {
+			dto.Name = tagNames[sub.TargetID]
+		} else {
+			dto.Name = categories[sub.TargetID].Name
+		}
+		result = append(result, dto)
+	}
+	return result, paging, nil
+}
+
+// targetName 校验关注对象存在且对用户可见，返回其名称
+func (s *FeedService) targetName(userID, targetType string, targetID uint64) (string, *apperrors.AppError) {
+	switch targetType {
+	case subscription.TargetTag:
+		t := tag.Get(cast.ToString(targetID))
+		if t.ID == 0 {
+			return "", apperrors.NotFoundError("标签")
+		}
+		return t.Name, nil
+	case subscription.TargetCategory:
+		c, ok := categoryIndex()[targetID]
+		if !ok || !CanViewCategory(userID, cast.ToString(targetID)) {
+			return "", apperrors.NotFoundError("分类")
+		}
+		return c.Name, nil
+	}
+	return "", apperrors.ValidationError("不支持的关注类型", map[string]interface{}{"target_type": targetType})
+}
+
+// feedFanoutMaxFollowers 写扩散的粉丝数上限
+func feedFanoutMaxFollowers() int64 {
+	return int64(config.GetInt("feed.fanout_max_followers", 1000))
+}
+
+// feedInboxSize 收件箱最多保留的条目数
+func feedInboxSize() int {
+	return config.GetInt("feed.inbox_size", 500)
+}
+
+// feedInboxTTL 收件箱过期时间
+func feedInboxTTL() time.Duration {
+	return time.Duration(config.GetInt("feed.inbox_ttl_days", 14)) * 24 * time.Hour
+}
@@ -0,0 +1,153 @@
+// Package services 用户关注关系查询
+package services
+
+import (
+	"time"
+
+	"GoHub-Service/app/models/follow"
+	"GoHub-Service/app/models/user"
+	"GoHub-Service/app/repositories"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+// FollowService 粉丝列表、关注列表和关注关系查询
+// 关注和取消关注见 InteractionService.FollowUser 和 UnfollowUser
+type FollowService struct {
+	repo     repositories.FollowRepository
+	userRepo repositories.UserRepository
+}
+
+// NewFollowService 创建关注服务实例
+func NewFollowService() *FollowService {
+	return &FollowService{
+		repo:     repositories.NewFollowRepository(),
+		userRepo: repositories.NewUserRepository(),
+	}
+}
+
+// FollowUserDTO 关注列表中的用户
+type FollowUserDTO struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Avatar         string    `json:"avatar"`
+	Introduction   string    `json:"introduction"`
+	FollowersCount int64     `json:"followers_count"`
+	FollowingCount int64     `json:"following_count"`
+	FollowedAt     time.Time `json:"followed_at"`
+	FollowedByMe   bool      `json:"followed_by_me"` // 当前用户是否关注了该用户，未登录时为 false
+	FollowsMe      bool      `json:"follows_me"`     // 该用户是否关注了当前用户
+	IsMutual       bool      `json:"is_mutual"`      // 是否与当前用户互相关注
+}
+
+// FollowRelationDTO 当前用户与目标用户的关注关系
+type FollowRelationDTO struct {
+	UserID         string `json:"user_id"`
+	Following      bool   `json:"following"`   // 当前用户关注了目标用户
+	FollowedBy     bool   `json:"followed_by"` // 目标用户关注了当前用户
+	IsMutual       bool   `json:"is_mutual"`
+	FollowersCount int64  `json:"followers_count"`
+	FollowingCount int64  `json:"following_count"`
+}
+
+// Followers 用户的粉丝列表，viewerID 为当前登录用户，用于标记 followed_by_me
+func (s *FollowService) Followers(c *gin.Context, userID, viewerID string, perPage int) ([]FollowUserDTO, *paginator.Paging, *apperrors.AppError) {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return nil, nil, apperrors.NotFoundError("用户")
+	}
+	follows, paging, err := s.repo.PaginateFollowers(c, userID, perPage)
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取粉丝列表", err)
+	}
+	ids := make([]string, len(follows))
+	for i, f := range follows {
+		ids[i] = f.UserID
+	}
+	list, err := s.toUserDTOs(follows, ids, viewerID)
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取粉丝列表", err)
+	}
+	return list, paging, nil
+}
+
+// Following 用户关注的人，viewerID 为当前登录用户，用于标记 followed_by_me
+func (s *FollowService) Following(c *gin.Context, userID, viewerID string, perPage int) ([]FollowUserDTO, *paginator.Paging, *apperrors.AppError) {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return nil, nil, apperrors.NotFoundError("用户")
+	}
+	follows, paging, err := s.repo.PaginateFollowing(c, userID, perPage)
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取关注列表", err)
+	}
+	ids := make([]string, len(follows))
+	for i, f := range follows {
+		ids[i] = f.FollowID
+	}
+	list, err := s.toUserDTOs(follows, ids, viewerID)
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取关注列表", err)
+	}
+	return list, paging, nil
+}
+
+// Relation 当前用户与目标用户的关注关系，viewerID 为空时只返回计数
+func (s *FollowService) Relation(userID, viewerID string) (*FollowRelationDTO, *apperrors.AppError) {
+	target, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, apperrors.NotFoundError("用户")
+	}
+	relation := &FollowRelationDTO{
+		UserID:         userID,
+		FollowersCount: target.FollowersCount,
+		FollowingCount: target.FollowingCount,
+	}
+	if viewerID == "" || viewerID == userID {
+		return relation, nil
+	}
+	relation.Following = follow.HasFollowed(viewerID, userID)
+	relation.FollowedBy = follow.HasFollowed(userID, viewerID)
+	relation.IsMutual = relation.Following && relation.FollowedBy
+	return relation, nil
+}
+
+// toUserDTOs 按关注记录的顺序组装用户信息，ids 为每条记录对应的用户
+func (s *FollowService) toUserDTOs(follows []follow.Follow, ids []string, viewerID string) ([]FollowUserDTO, error) {
+	result := make([]FollowUserDTO, 0, len(follows))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	users, err := s.repo.Profiles(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]user.User, len(users))
+	for _, u := range users {
+		byID[cast.ToString(u.ID)] = u
+	}
+
+	followedByMe := follow.FollowingSet(viewerID, ids)
+	followsMe := follow.FollowerSet(viewerID, ids)
+	for i, f := range follows {
+		u, ok := byID[ids[i]]
+		if !ok {
+			continue
+		}
+		result = append(result, FollowUserDTO{
+			ID:             ids[i],
+			Name:           u.Name,
+			Avatar:         u.Avatar,
+			Introduction:   u.Introduction,
+			FollowersCount: u.FollowersCount,
+			FollowingCount: u.FollowingCount,
+			FollowedAt:     f.CreatedAt,
+			FollowedByMe:   followedByMe[ids[i]],
+			FollowsMe:      followsMe[ids[i]],
+			IsMutual:       followedByMe[ids[i]] && followsMe[ids[i]],
+		})
+	}
+	return result, nil
+}
@@ -5,10 +5,14 @@ import (
 	"context"
 	"time"
 
+	"GoHub-Service/app/models/topic"
 	"GoHub-Service/app/repositories"
+	"GoHub-Service/pkg/config"
 	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
 	"GoHub-Service/pkg/resource"
 
+	"github.com/spf13/cast"
 	"go.uber.org/zap"
 )
 
@@ -18,7 +22,10 @@ type InteractionService struct {
 	repo      repositories.InteractionRepository
 	topicRepo repositories.TopicRepository
 	userRepo  repositories.UserRepository
+	viewRepo  repositories.TopicViewRepository
+	blockRepo repositories.BlockRepository
 	notifSvc  *NotificationService
+	feedSvc   *FeedService
 	logger    *zap.Logger
 }
 
@@ -28,7 +35,10 @@ func NewInteractionService() *InteractionService {
 		repo:      repositories.NewInteractionRepository(),
 		topicRepo: repositories.NewTopicRepository(),
 		userRepo:  repositories.NewUserRepository(),
+		viewRepo:  repositories.NewTopicViewRepository(),
+		blockRepo: repositories.NewBlockRepository(),
 		notifSvc:  NewNotificationService(),
+		feedSvc:   NewFeedService(),
 		logger:    zap.L(),
 	}
 }
@@ -111,6 +121,8 @@ func (s *InteractionService) FollowUser(followerID, followeeID string) *apperror
 		}
 		return apperrors.WrapError(err, "关注用户失败")
 	}
+	s.forgetFollowCounters(followerID, followeeID)
+	s.feedSvc.Backfill(followerID, followeeID)
 	if s.notifSvc != nil && followeeID != followerID {
 		_ = s.notifSvc.Notify(followeeID, followerID, "user_follow", map[string]interface{}{"user_id": followerID})
 	}
@@ -127,15 +139,128 @@ func (s *InteractionService) UnfollowUser(followerID, followeeID string) *apperr
 		}
 		return apperrors.WrapError(err, "取消关注失败")
 	}
+	s.forgetFollowCounters(followerID, followeeID)
+	s.feedSvc.RemoveActor(followerID, followeeID)
 	return nil
 }
 
-func (s *InteractionService) AddTopicView(topicID string) *apperrors.AppError {
-	if _, err := s.topicRepo.GetByID(context.Background(), topicID); err != nil {
+// forgetFollowCounters 关注关系变化后清除双方的用户缓存，避免读到旧的关注数和粉丝数
+func (s *InteractionService) forgetFollowCounters(followerID, followeeID string) {
+	_ = s.userRepo.DeleteCache(followerID)
+	_ = s.userRepo.DeleteCache(followeeID)
+}
+
+// BlockUser 屏蔽用户，被屏蔽者的 @提及不再通知屏蔽者
+func (s *InteractionService) BlockUser(userID, blockedID string) *apperrors.AppError {
+	if userID == blockedID {
+		return apperrors.ValidationError("不能屏蔽自己", map[string]interface{}{"user_id": blockedID})
+	}
+	if _, err := s.userRepo.GetByID(blockedID); err != nil {
+		return apperrors.WrapError(err, "获取用户失败")
+	}
+	if _, err := s.blockRepo.Block(context.Background(), cast.ToUint64(userID), cast.ToUint64(blockedID)); err != nil {
+		return apperrors.DatabaseError("屏蔽用户", err)
+	}
+	return nil
+}
+
+// UnblockUser 取消屏蔽用户
+func (s *InteractionService) UnblockUser(userID, blockedID string) *apperrors.AppError {
+	if _, err := s.blockRepo.Unblock(context.Background(), cast.ToUint64(userID), cast.ToUint64(blockedID)); err != nil {
+		return apperrors.DatabaseError("取消屏蔽用户", err)
+	}
+	return nil
+}
+
+// AddTopicView 记录一次话题浏览
+// viewer 为访客标识（登录用户 ID 或 IP），同一访客在去重窗口内重复浏览只计一次；
+// 计数先累加在 Redis 中，由后台任务批量写入数据库
+func (s *InteractionService) AddTopicView(topicID, viewer string) *apperrors.AppError {
+	ctx := context.Background()
+	topicModel, err := s.topicRepo.GetByID(ctx, topicID)
+	if err != nil {
 		return apperrors.WrapError(err, "获取话题失败")
 	}
-	if err := s.repo.IncrementTopicView(topicID); err != nil {
+	if topicModel == nil || !topicModel.IsApproved() {
+		return apperrors.NotFoundError("话题")
+	}
+
+	window := time.Duration(config.GetInt("topic.view_dedup_minutes", 30)) * time.Minute
+	first, err := s.viewRepo.MarkViewed(ctx, topicID, viewer, window)
+	if err != nil {
+		return apperrors.WrapError(err, "增加浏览量失败")
+	}
+	if !first {
+		return nil
+	}
+	if err := s.viewRepo.Buffer(ctx, topicID, time.Now()); err != nil {
 		return apperrors.WrapError(err, "增加浏览量失败")
 	}
 	return nil
 }
+
+// FlushTopicViews 将 Redis 中累积的浏览量批量写入数据库，返回写入的浏览次数
+// 写库失败时计数保留在 Redis 中，下一轮重试；写库成功但清除缓冲失败时，重试的批次不会再次累加
+// 多实例部署时由持有写库锁的实例执行，避免重复计数
+func (s *InteractionService) FlushTopicViews() (int64, *apperrors.AppError) {
+	ctx := context.Background()
+	ttl := time.Duration(config.GetInt("topic.view_flush_lock_seconds", 300)) * time.Second
+	token, locked, err := s.viewRepo.LockFlush(ctx, ttl)
+	if err != nil {
+		return 0, apperrors.WrapError(err, "获取浏览量写库锁失败")
+	}
+	if !locked {
+		return 0, nil
+	}
+	defer func() { logger.LogIf(s.viewRepo.UnlockFlush(ctx, token)) }()
+
+	batchID, deltas, err := s.viewRepo.TakeBuffered(ctx)
+	if err != nil {
+		return 0, apperrors.WrapError(err, "读取浏览量缓冲失败")
+	}
+	if len(deltas) == 0 {
+		return 0, nil
+	}
+	if err := s.viewRepo.Save(ctx, batchID, deltas); err != nil {
+		return 0, apperrors.DatabaseError("写入浏览量", err)
+	}
+	if err := s.viewRepo.AckBuffered(ctx); err != nil {
+		return 0, apperrors.WrapError(err, "清除浏览量缓冲失败")
+	}
+
+	var total int64
+	for _, d := range deltas {
+		total += d.Views
+	}
+	return total, nil
+}
+
+// TopicViewHistory 话题最近 days 天的每日浏览量，没有浏览的日期补 0
+func (s *InteractionService) TopicViewHistory(topicID string, days int) ([]topic.DailyView, *apperrors.AppError) {
+	maxDays := config.GetInt("topic.view_history_max_days", 90)
+	if days <= 0 || days > maxDays {
+		days = maxDays
+	}
+	to := time.Now()
+	from := to.AddDate(0, 0, 1-days)
+
+	stored, err := s.viewRepo.DailyViews(context.Background(), topicID, from, to)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取浏览量历史", err)
+	}
+	byDate := make(map[string]int64, len(stored))
+	for _, v := range stored {
+		// 不同数据库驱动返回的日期格式不同，只取日期部分
+		if len(v.Date) >= 10 {
+			byDate[v.Date[:10]] = v.Views
+		}
+	}
+
+	id := cast.ToUint64(topicID)
+	history := make([]topic.DailyView, 0, days)
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		history = append(history, topic.DailyView{TopicID: id, Date: date, Views: byDate[date]})
+	}
+	return history, nil
+}
@@ -2,16 +2,31 @@
 package services
 
 import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"GoHub-Service/app/models/link"
+	"GoHub-Service/app/repositories"
+	"GoHub-Service/pkg/config"
 	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/linkcheck"
 	"GoHub-Service/pkg/mapper"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
 )
 
-// LinkService 提供友情链接查询的只读服务，主要走缓存避免频繁 DB 访问.
+// LinkService 友情链接服务
+// 前台列表走缓存避免频繁 DB 访问，后台修改、审核和可用性检测后清除缓存
 type LinkService struct {
-	mapper mapper.Mapper[link.Link, LinkResponseDTO] // 使用泛型Mapper消除DTO转换重复
+	repo     repositories.LinkRepository
+	notifSvc *NotificationService
+	mapper   mapper.Mapper[link.Link, LinkResponseDTO] // 使用泛型Mapper消除DTO转换重复
 }
 
 // NewLinkService 创建友情链接服务实例
@@ -19,26 +34,61 @@ func NewLinkService() *LinkService {
 	// 定义DTO转换函数（只需一次）
 	converter := func(l *link.Link) *LinkResponseDTO {
 		return &LinkResponseDTO{
-			ID:        l.GetStringID(),
-			Name:      l.Name,
-			URL:       l.URL,
-			CreatedAt: l.CreatedAt,
-			UpdatedAt: l.UpdatedAt,
+			ID:          l.GetStringID(),
+			Name:        l.Name,
+			URL:         l.URL,
+			Logo:        l.Logo,
+			Description: l.Description,
+			SortOrder:   l.SortOrder,
+			CreatedAt:   l.CreatedAt,
+			UpdatedAt:   l.UpdatedAt,
 		}
 	}
 
 	return &LinkService{
-		mapper: mapper.NewSimpleMapper(converter),
+		repo:     repositories.NewLinkRepository(),
+		notifSvc: NewNotificationService(),
+		mapper:   mapper.NewSimpleMapper(converter),
 	}
 }
 
 // LinkResponseDTO 友情链接响应DTO
 type LinkResponseDTO struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	URL       string    `json:"url"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	URL         string    `json:"url"`
+	Logo        string    `json:"logo,omitempty"`
+	Description string    `json:"description,omitempty"`
+	SortOrder   int       `json:"sort_order"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// LinkSaveDTO 后台创建或修改友情链接
+type LinkSaveDTO struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Logo        string `json:"logo"`
+	Description string `json:"description"`
+	SortOrder   int    `json:"sort_order"`
+	IsEnabled   *bool  `json:"is_enabled"` // 为空时创建默认启用，修改时保持不变
+}
+
+// LinkApplyDTO 用户申请友情链接
+type LinkApplyDTO struct {
+	Name         string `json:"name"`
+	URL          string `json:"url"`
+	Logo         string `json:"logo"`
+	Description  string `json:"description"`
+	ContactEmail string `json:"contact_email"`
+}
+
+// LinkCheckSummary 一轮可用性检测的结果
+type LinkCheckSummary struct {
+	Checked   int                `json:"checked"`
+	Broken    int                `json:"broken"`    // 本轮新标记为失效的链接数
+	Recovered int                `json:"recovered"` // 本轮恢复正常的链接数
+	Failures  []linkcheck.Result `json:"failures"`
 }
 
 // LinkListResponseDTO 友情链接列表响应DTO
@@ -68,3 +118,322 @@ func (s *LinkService) GetAllCached() (*LinkListResponseDTO, *apperrors.AppError)
 		Links: s.toResponseDTOList(links),
 	}, nil
 }
+
+// List 后台链接列表
+func (s *LinkService) List(c *gin.Context, perPage int) ([]link.Link, *paginator.Paging, *apperrors.AppError) {
+	links, paging, err := s.repo.List(context.Background(), c, perPage)
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取友情链接列表", err)
+	}
+	return links, paging, nil
+}
+
+// Create 管理员添加友情链接，直接审核通过
+func (s *LinkService) Create(dto LinkSaveDTO) (*link.Link, *apperrors.AppError) {
+	model := &link.Link{Status: link.StatusApproved, IsEnabled: true}
+	if appErr := s.save(model, dto); appErr != nil {
+		return nil, appErr
+	}
+	return model, nil
+}
+
+// Update 修改友情链接，地址变化后重置检测状态
+func (s *LinkService) Update(id string, dto LinkSaveDTO) (*link.Link, *apperrors.AppError) {
+	model, appErr := s.get(id)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if appErr := s.save(model, dto); appErr != nil {
+		return nil, appErr
+	}
+	return model, nil
+}
+
+// Delete 删除友情链接
+func (s *LinkService) Delete(id string) *apperrors.AppError {
+	model, appErr := s.get(id)
+	if appErr != nil {
+		return appErr
+	}
+	if err := s.repo.Delete(context.Background(), model); err != nil {
+		return apperrors.DatabaseDeleteError("友情链接", err)
+	}
+	link.ForgetCache()
+	return nil
+}
+
+// Sort 批量修改排序值，key 为链接 ID，值越小越靠前
+func (s *LinkService) Sort(orders map[uint64]int) *apperrors.AppError {
+	if len(orders) == 0 {
+		return nil
+	}
+	if err := s.repo.UpdateSort(context.Background(), orders); err != nil {
+		return apperrors.DatabaseUpdateError("友情链接排序", err)
+	}
+	link.ForgetCache()
+	return nil
+}
+
+// Apply 用户申请友情链接，审核通过后才会展示
+func (s *LinkService) Apply(userID string, dto LinkApplyDTO) (*link.Link, *apperrors.AppError) {
+	applicantID := cast.ToUint64(userID)
+	if applicantID == 0 {
+		return nil, apperrors.UnauthorizedError("请先登录")
+	}
+
+	ctx := context.Background()
+	pending, err := s.repo.CountPending(ctx, applicantID)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取友情链接申请", err)
+	}
+	if pending >= int64(config.GetInt("link.max_pending_applications", 1)) {
+		return nil, apperrors.BusinessError(apperrors.CodeConflict, "已有待审核的申请，请等待管理员处理")
+	}
+
+	email := strings.TrimSpace(dto.ContactEmail)
+	if email != "" && !strings.Contains(email, "@") {
+		return nil, apperrors.ValidationError("联系邮箱格式不正确", nil)
+	}
+
+	model := &link.Link{
+		Status:       link.StatusPending,
+		IsEnabled:    true,
+		ApplicantID:  applicantID,
+		ContactEmail: email,
+	}
+	appErr := s.save(model, LinkSaveDTO{
+		Name:        dto.Name,
+		URL:         dto.URL,
+		Logo:        dto.Logo,
+		Description: dto.Description,
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+	return model, nil
+}
+
+// Approve 审核通过友情链接申请
+func (s *LinkService) Approve(id, operatorID string) (*link.Link, *apperrors.AppError) {
+	return s.review(id, operatorID, link.StatusApproved, "")
+}
+
+// Reject 拒绝友情链接申请
+func (s *LinkService) Reject(id, operatorID, reason string) (*link.Link, *apperrors.AppError) {
+	return s.review(id, operatorID, link.StatusRejected, strings.TrimSpace(reason))
+}
+
+// Click 记录一次点击并返回跳转地址，只有前台展示的链接可以跳转
+func (s *LinkService) Click(id string) (string, *apperrors.AppError) {
+	model, appErr := s.get(id)
+	if appErr != nil {
+		return "", appErr
+	}
+	if !model.Visible() {
+		return "", apperrors.NotFoundError("友情链接").WithDetails(map[string]interface{}{"id": id})
+	}
+	if err := s.repo.IncrementClicks(context.Background(), model.ID); err != nil {
+		return "", apperrors.DatabaseUpdateError("友情链接点击数", err)
+	}
+	return model.URL, nil
+}
+
+// Check 检测单个链接并保存结果
+func (s *LinkService) Check(ctx context.Context, id string) (*linkcheck.Result, *apperrors.AppError) {
+	model, appErr := s.get(id)
+	if appErr != nil {
+		return nil, appErr
+	}
+	result := newLinkChecker().Check(ctx, model.URL)
+	changed, err := s.applyCheckResult(ctx, model, result)
+	if err != nil {
+		return nil, apperrors.DatabaseUpdateError("友情链接检测结果", err)
+	}
+	if changed {
+		link.ForgetCache()
+	}
+	return &result, nil
+}
+
+// CheckAll 检测全部已启用的链接，连续失败达到 link.fail_threshold 次后标记为失效，
+// 失效的链接恢复访问后自动取消标记，状态有变化时清除前台缓存
+func (s *LinkService) CheckAll(ctx context.Context) (*LinkCheckSummary, error) {
+	links, err := s.repo.Checkable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	checker := newLinkChecker()
+	results := make([]linkcheck.Result, len(links))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 5)
+	for i := range links {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = checker.Check(ctx, links[i].URL)
+		}(i)
+	}
+	wg.Wait()
+
+	summary := &LinkCheckSummary{Checked: len(links), Failures: []linkcheck.Result{}}
+	changed := false
+	for i := range links {
+		wasBroken := links[i].IsBroken
+		statusChanged, err := s.applyCheckResult(ctx, &links[i], results[i])
+		if err != nil {
+			return summary, err
+		}
+		if !results[i].OK {
+			summary.Failures = append(summary.Failures, results[i])
+		}
+		if statusChanged {
+			changed = true
+			if wasBroken {
+				summary.Recovered++
+			} else {
+				summary.Broken++
+			}
+		}
+	}
+	if changed {
+		link.ForgetCache()
+	}
+	return summary, nil
+}
+
+// applyCheckResult 根据检测结果更新失败次数和失效标记，返回失效状态是否发生变化
+func (s *LinkService) applyCheckResult(ctx context.Context, model *link.Link, result linkcheck.Result) (bool, error) {
+	wasBroken := model.IsBroken
+	now := time.Now()
+	model.LastCheckedAt = &now
+	model.CheckError = ""
+	if !result.OK {
+		model.CheckError = truncateRunes(result.Error, 255)
+	}
+	if err := s.repo.SaveCheckResult(ctx, model, result.OK, config.GetInt("link.fail_threshold", 3)); err != nil {
+		return false, err
+	}
+	return wasBroken != model.IsBroken, nil
+}
+
+// review 审核申请并通知申请人
+func (s *LinkService) review(id, operatorID, status, reason string) (*link.Link, *apperrors.AppError) {
+	model, appErr := s.get(id)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if model.Status != link.StatusPending {
+		return nil, apperrors.BusinessError(apperrors.CodeConflict, "该链接不是待审核状态")
+	}
+	model.Status = status
+	model.RejectReason = reason
+	if err := s.repo.Save(context.Background(), model); err != nil {
+		return nil, apperrors.DatabaseUpdateError("友情链接", err)
+	}
+	link.ForgetCache()
+
+	if model.ApplicantID > 0 {
+		_ = s.notifSvc.Notify(cast.ToString(model.ApplicantID), operatorID, "link_"+status, map[string]interface{}{
+			"link_id": model.GetStringID(),
+			"name":    model.Name,
+			"reason":  reason,
+		})
+	}
+	return model, nil
+}
+
+// save 校验后保存，地址不能与其他链接重复
+func (s *LinkService) save(model *link.Link, dto LinkSaveDTO) *apperrors.AppError {
+	name := strings.TrimSpace(dto.Name)
+	if name == "" || utf8.RuneCountInString(name) > 50 {
+		return apperrors.ValidationError("链接名称不能为空且不能超过 50 个字", nil)
+	}
+	address, appErr := normalizeLinkURL(dto.URL, "链接地址")
+	if appErr != nil {
+		return appErr
+	}
+	logo := strings.TrimSpace(dto.Logo)
+	if logo != "" {
+		if logo, appErr = normalizeLinkURL(logo, "Logo 地址"); appErr != nil {
+			return appErr
+		}
+	}
+	description := strings.TrimSpace(dto.Description)
+	if utf8.RuneCountInString(description) > 255 {
+		return apperrors.ValidationError("站点简介不能超过 255 个字", nil)
+	}
+
+	ctx := context.Background()
+	existing, err := s.repo.GetByURL(ctx, address)
+	if err != nil {
+		return apperrors.DatabaseError("获取友情链接", err)
+	}
+	if existing != nil && existing.ID != model.ID {
+		return apperrors.BusinessError(apperrors.CodeConflict, "该链接已存在").
+			WithDetails(map[string]interface{}{"url": address})
+	}
+
+	// 地址变化后之前的检测结果不再有效
+	if model.URL != address {
+		model.IsBroken = false
+		model.FailCount = 0
+		model.CheckError = ""
+		model.LastCheckedAt = nil
+	}
+	model.Name = name
+	model.URL = address
+	model.Logo = logo
+	model.Description = description
+	model.SortOrder = dto.SortOrder
+	if dto.IsEnabled != nil {
+		model.IsEnabled = *dto.IsEnabled
+	}
+	if model.ID == 0 {
+		err = s.repo.Create(ctx, model)
+	} else {
+		err = s.repo.Save(ctx, model)
+	}
+	if err != nil {
+		return apperrors.DatabaseError("保存友情链接", err)
+	}
+	link.ForgetCache()
+	return nil
+}
+
+// get 获取友情链接
+func (s *LinkService) get(id string) (*link.Link, *apperrors.AppError) {
+	model, err := s.repo.GetByID(context.Background(), id)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取友情链接", err)
+	}
+	if model == nil {
+		return nil, apperrors.NotFoundError("友情链接").WithDetails(map[string]interface{}{"id": id})
+	}
+	return model, nil
+}
+
+// newLinkChecker 按配置创建链接检测器
+func newLinkChecker() *linkcheck.Checker {
+	return linkcheck.New(time.Duration(config.GetInt("link.check_timeout_seconds", 10)) * time.Second)
+}
+
+// normalizeLinkURL 校验地址只能是 http 或 https 的完整地址
+func normalizeLinkURL(raw, field string) (string, *apperrors.AppError) {
+	raw = strings.TrimSpace(raw)
+	parsed, err := url.Parse(raw)
+	if raw == "" || err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" || len(raw) > 255 {
+		return "", apperrors.ValidationError(field+"必须是以 http:// 或 https:// 开头的有效地址", nil)
+	}
+	return raw, nil
+}
+
+// truncateRunes 截断到指定字符数
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
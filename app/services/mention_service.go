@@ -0,0 +1,225 @@
+// Package services @提及业务逻辑
+package services
+
+import (
+	"context"
+	"strings"
+
+	"GoHub-Service/app/models/user"
+	"GoHub-Service/app/repositories"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/mention"
+
+	"github.com/spf13/cast"
+)
+
+// MentionAutocompleteMax 用户名自动补全最多返回的数量
+const MentionAutocompleteMax = 20
+
+// MentionService 提及服务：解析正文中的 @用户名，记录提及并通知被提及的用户
+type MentionService struct {
+	repo      repositories.MentionRepository
+	blockRepo repositories.BlockRepository
+	topicRepo repositories.TopicRepository
+	notifSvc  *NotificationService
+}
+
+// NewMentionService 创建提及服务实例
+func NewMentionService() *MentionService {
+	return &MentionService{
+		repo:      repositories.NewMentionRepository(),
+		blockRepo: repositories.NewBlockRepository(),
+		topicRepo: repositories.NewTopicRepository(),
+		notifSvc:  NewNotificationService(),
+	}
+}
+
+// MentionUserDTO 提及候选用户
+type MentionUserDTO struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Avatar string `json:"avatar,omitempty"`
+}
+
+// MentionDTO 正文中一个提及的解析结果，供客户端把 @用户名 渲染为链接
+// 用户名只有大小写不同的多个用户且无法确定时 Ambiguous 为 true，此时不通知任何人
+type MentionDTO struct {
+	Name       string           `json:"name"` // 正文中的写法
+	UserID     string           `json:"user_id,omitempty"`
+	Ambiguous  bool             `json:"ambiguous,omitempty"`
+	Candidates []MentionUserDTO `json:"candidates,omitempty"`
+}
+
+// MentionSource 提及所在的话题或评论
+type MentionSource struct {
+	Type    string // mention.SourceTopic 或 mention.SourceComment
+	ID      string
+	TopicID string
+	ActorID string
+}
+
+// Resolve 解析正文中的提及，不存在的用户名不会出现在结果中
+func (s *MentionService) Resolve(text string) []MentionDTO {
+	return s.ResolveMany([]string{text})[0]
+}
+
+// ResolveMany 批量解析多段正文中的提及，所有用户名只查询一次
+func (s *MentionService) ResolveMany(texts []string) [][]MentionDTO {
+	extracted := make([][]string, len(texts))
+	all := make([]string, 0)
+	for i, text := range texts {
+		extracted[i] = mention.Extract(text)
+		all = append(all, extracted[i]...)
+	}
+
+	resolved := s.resolveNames(all)
+	results := make([][]MentionDTO, len(texts))
+	for i, names := range extracted {
+		for _, name := range names {
+			if dto, ok := resolved[name]; ok {
+				results[i] = append(results[i], dto)
+			}
+		}
+	}
+	return results
+}
+
+// resolveNames 将用户名解析为用户：大小写完全一致的唯一用户优先，
+// 否则不区分大小写只匹配到一个用户时使用该用户，匹配到多个时标记为有歧义
+func (s *MentionService) resolveNames(names []string) map[string]MentionDTO {
+	resolved := make(map[string]MentionDTO, len(names))
+	if len(names) == 0 {
+		return resolved
+	}
+	users, err := s.repo.FindUsersByNames(context.Background(), names)
+	if err != nil {
+		logger.LogIf(err)
+		return resolved
+	}
+	byFold := make(map[string][]user.User, len(users))
+	for _, u := range users {
+		key := strings.ToLower(u.Name)
+		byFold[key] = append(byFold[key], u)
+	}
+
+	for _, name := range names {
+		candidates := byFold[strings.ToLower(name)]
+		if len(candidates) == 0 {
+			continue
+		}
+		exact := make([]user.User, 0, 1)
+		for _, u := range candidates {
+			if u.Name == name {
+				exact = append(exact, u)
+			}
+		}
+		dto := MentionDTO{Name: name}
+		switch {
+		case len(exact) == 1:
+			dto.UserID = exact[0].GetStringID()
+		case len(candidates) == 1:
+			dto.UserID = candidates[0].GetStringID()
+		default:
+			dto.Ambiguous = true
+			for _, u := range candidates {
+				dto.Candidates = append(dto.Candidates, toMentionUserDTO(u))
+			}
+		}
+		resolved[name] = dto
+	}
+	return resolved
+}
+
+// Sync 创建或修改话题、评论后同步提及记录并返回解析结果
+// 只有新增的提及才会通知；notify 为 false 时（如话题尚未通过审核）先记录，之后由 NotifyPending 补发
+// 提及处理失败只记录日志，不影响话题和评论的保存
+func (s *MentionService) Sync(src MentionSource, text string, notify bool) []MentionDTO {
+	mentions := s.Resolve(text)
+
+	userIDs := make([]uint64, 0, len(mentions))
+	for _, m := range mentions {
+		if m.UserID != "" && m.UserID != src.ActorID {
+			userIDs = append(userIDs, cast.ToUint64(m.UserID))
+		}
+	}
+	if _, err := s.repo.Sync(context.Background(), src.Type, cast.ToUint64(src.ID), cast.ToUint64(src.ActorID), userIDs); err != nil {
+		logger.LogIf(err)
+		return mentions
+	}
+	if notify {
+		s.NotifyPending(src)
+	}
+	return mentions
+}
+
+// NotifyPending 通知尚未通知的被提及用户，屏蔽了提及者或无权查看话题所在分类的用户不会收到通知
+func (s *MentionService) NotifyPending(src MentionSource) {
+	if s.notifSvc == nil {
+		return
+	}
+	ctx := context.Background()
+	pending, err := s.repo.Pending(ctx, src.Type, cast.ToUint64(src.ID))
+	if err != nil || len(pending) == 0 {
+		logger.LogIf(err)
+		return
+	}
+
+	ids := make([]uint64, 0, len(pending))
+	userIDs := make([]uint64, 0, len(pending))
+	for _, m := range pending {
+		ids = append(ids, m.ID)
+		userIDs = append(userIDs, m.UserID)
+	}
+	blockers, err := s.blockRepo.BlockersOf(ctx, cast.ToUint64(src.ActorID), userIDs)
+	if err != nil {
+		logger.LogIf(err)
+		return
+	}
+	blocked := make(map[uint64]bool, len(blockers))
+	for _, id := range blockers {
+		blocked[id] = true
+	}
+	categoryID := ""
+	if topicModel, err := s.topicRepo.GetByID(ctx, src.TopicID); err == nil && topicModel != nil {
+		categoryID = topicModel.CategoryID
+	}
+
+	data := map[string]interface{}{
+		"source_type": src.Type,
+		"source_id":   src.ID,
+		"topic_id":    src.TopicID,
+	}
+	for _, userID := range userIDs {
+		if !blocked[userID] && CanViewCategory(cast.ToString(userID), categoryID) {
+			_ = s.notifSvc.Notify(cast.ToString(userID), src.ActorID, "user_mentioned", data)
+		}
+	}
+	// 被屏蔽或无权查看而未发送的提及同样标记为已处理，之后不会补发
+	logger.LogIf(s.repo.MarkNotified(ctx, ids))
+}
+
+// Autocomplete 输入 @ 时按用户名前缀补全
+func (s *MentionService) Autocomplete(prefix string, limit int) ([]MentionUserDTO, *apperrors.AppError) {
+	prefix = strings.TrimSpace(strings.TrimPrefix(prefix, "@"))
+	if prefix == "" {
+		return nil, apperrors.ValidationError("请输入用户名前缀", map[string]interface{}{"q": prefix})
+	}
+	if limit <= 0 || limit > MentionAutocompleteMax {
+		limit = MentionAutocompleteMax
+	}
+	users, err := s.repo.SearchUsers(context.Background(), prefix, limit)
+	if err != nil {
+		return nil, apperrors.DatabaseError("查找用户", err)
+	}
+	result := make([]MentionUserDTO, 0, len(users))
+	for _, u := range users {
+		result = append(result, toMentionUserDTO(u))
+	}
+	return result, nil
+}
+
+// toMentionUserDTO 转换为提及候选用户
+func toMentionUserDTO(u user.User) MentionUserDTO {
+	return MentionUserDTO{ID: u.GetStringID(), Name: u.Name, Avatar: u.Avatar}
+}
@@ -6,6 +6,7 @@ import (
 	"GoHub-Service/app/models/message"
 	"GoHub-Service/app/repositories"
 	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/moderator"
 	"GoHub-Service/pkg/paginator"
 
 	"github.com/gin-gonic/gin"
@@ -13,20 +14,28 @@ import (
 
 // MessageService 私信业务
 type MessageService struct {
-	repo     repositories.MessageRepository
-	userRepo repositories.UserRepository
-	notifSvc *NotificationService
+	repo        repositories.MessageRepository
+	userRepo    repositories.UserRepository
+	notifSvc    *NotificationService
+	reactionSvc *ReactionService
 }
 
 // NewMessageService 创建实例
 func NewMessageService() *MessageService {
 	return &MessageService{
-		repo:     repositories.NewMessageRepository(),
-		userRepo: repositories.NewUserRepository(),
-		notifSvc: NewNotificationService(),
+		repo:        repositories.NewMessageRepository(),
+		userRepo:    repositories.NewUserRepository(),
+		notifSvc:    NewNotificationService(),
+		reactionSvc: NewReactionService(),
 	}
 }
 
+// MessageResponseDTO 会话中的一条私信及其表情回应
+type MessageResponseDTO struct {
+	message.Message
+	Reactions *ReactionSummaryDTO `json:"reactions,omitempty"`
+}
+
 // Send 发送私信
 func (s *MessageService) Send(senderID, receiverID, body string) (*message.Message, *apperrors.AppError) {
 	if senderID == "" {
@@ -57,36 +66,102 @@ func (s *MessageService) Send(senderID, receiverID, body string) (*message.Messa
 		_ = s.notifSvc.Notify(receiverID, senderID, "direct_message", map[string]interface{}{"message_id": msg.GetStringID(), "sender_id": senderID})
 	}
 
+	SubmitForModeration(moderator.Content{
+		Type:     moderator.ContentMessage,
+		ID:       msg.GetStringID(),
+		AuthorID: senderID,
+		Body:     msg.Body,
+	})
+
 	return msg, nil
 }
 
 // Conversation 获取双方的会话消息
-func (s *MessageService) Conversation(c *gin.Context, currentUserID, partnerID string, perPage int) ([]message.Message, *paginator.Paging, int64, *apperrors.AppError) {
-	if currentUserID == partnerID {
-		return nil, nil, 0, apperrors.ValidationError("不能查看与自己的会话", map[string]interface{}{"user_id": partnerID})
+func (s *MessageService) Conversation(c *gin.Context, currentUserID, partnerID string, perPage int) ([]MessageResponseDTO, *paginator.Paging, int64, *apperrors.AppError) {
+	convID, appErr := s.conversationID(currentUserID, partnerID)
+	if appErr != nil {
+		return nil, nil, 0, appErr
 	}
 
-	if _, err := s.userRepo.GetByID(partnerID); err != nil {
-		return nil, nil, 0, apperrors.WrapError(err, "获取会话用户失败")
+	list, paging, err := s.repo.ListConversation(c, convID, currentUserID, perPage)
+	if err != nil {
+		return nil, nil, 0, apperrors.DatabaseError("获取会话消息", err)
 	}
 
-	convID := buildConversationID(currentUserID, partnerID)
-	list, paging, err := s.repo.ListConversation(c, convID, currentUserID, perPage)
+	unread, appErr := s.readConversation(convID, currentUserID)
+	if appErr != nil {
+		return nil, nil, 0, appErr
+	}
+	return s.withReactions(list, currentUserID), paging, unread, nil
+}
+
+// ConversationByCursor 游标分页获取双方的会话消息
+func (s *MessageService) ConversationByCursor(c *gin.Context, currentUserID, partnerID string, perPage int) ([]MessageResponseDTO, *paginator.CursorPaging, int64, *apperrors.AppError) {
+	convID, appErr := s.conversationID(currentUserID, partnerID)
+	if appErr != nil {
+		return nil, nil, 0, appErr
+	}
+
+	list, paging, err := s.repo.ListConversationCursor(c, convID, currentUserID, perPage)
 	if err != nil {
+		if appErr := invalidCursorError(c, err); appErr != nil {
+			return nil, nil, 0, appErr
+		}
 		return nil, nil, 0, apperrors.DatabaseError("获取会话消息", err)
 	}
 
-	// 标记为已读
+	unread, appErr := s.readConversation(convID, currentUserID)
+	if appErr != nil {
+		return nil, nil, 0, appErr
+	}
+	return s.withReactions(list, currentUserID), paging, unread, nil
+}
+
+// withReactions 为会话消息附加表情回应
+func (s *MessageService) withReactions(list []message.Message, viewerID string) []MessageResponseDTO {
+	ids := make([]uint64, 0, len(list))
+	for _, msg := range list {
+		ids = append(ids, msg.ID)
+	}
+	var summaries map[uint64]*ReactionSummaryDTO
+	if s.reactionSvc != nil {
+		summaries = s.reactionSvc.Summaries(ReactionTargetMessage, ids, viewerID)
+	}
+	result := make([]MessageResponseDTO, 0, len(list))
+	for _, msg := range list {
+		// 被举报隐藏的私信不再展示内容
+		if msg.HiddenAt != nil {
+			msg.Body = ""
+		}
+		result = append(result, MessageResponseDTO{Message: msg, Reactions: summaries[msg.ID]})
+	}
+	return result
+}
+
+// conversationID 校验会话对象并返回会话ID
+func (s *MessageService) conversationID(currentUserID, partnerID string) (string, *apperrors.AppError) {
+	if currentUserID == partnerID {
+		return "", apperrors.ValidationError("不能查看与自己的会话", map[string]interface{}{"user_id": partnerID})
+	}
+
+	if _, err := s.userRepo.GetByID(partnerID); err != nil {
+		return "", apperrors.WrapError(err, "获取会话用户失败")
+	}
+
+	return buildConversationID(currentUserID, partnerID), nil
+}
+
+// readConversation 标记会话已读并返回剩余未读数
+func (s *MessageService) readConversation(convID, currentUserID string) (int64, *apperrors.AppError) {
 	if _, err := s.repo.MarkConversationRead(convID, currentUserID); err != nil {
-		return nil, nil, 0, apperrors.DatabaseError("标记已读", err)
+		return 0, apperrors.DatabaseError("标记已读", err)
 	}
 
 	unread, err := s.repo.CountUnread(currentUserID)
 	if err != nil {
-		return nil, nil, 0, apperrors.DatabaseError("统计未读消息", err)
+		return 0, apperrors.DatabaseError("统计未读消息", err)
 	}
-
-	return list, paging, unread, nil
+	return unread, nil
 }
 
 // MarkRead 标记会话为已读
@@ -0,0 +1,560 @@
+// Package services 话题预审与审核队列
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"GoHub-Service/app/cache"
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/comment"
+	"GoHub-Service/app/models/mention"
+	"GoHub-Service/app/models/moderation"
+	"GoHub-Service/app/models/role"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/repositories"
+	"GoHub-Service/pkg/config"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/paginator"
+	"GoHub-Service/pkg/security"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+// ModerationService 审核服务
+type ModerationService struct {
+	topicRepo    repositories.TopicRepository
+	userRepo     repositories.UserRepository
+	commentRepo  repositories.CommentRepository
+	categoryRepo repositories.CategoryRepository
+	logRepo      repositories.ModerationLogRepository
+	notifSvc     *NotificationService
+	cache        *cache.TopicCache
+	commentCache *cache.CommentCache
+	feedSvc      *FeedService
+}
+
+// NewModerationService 创建审核服务实例
+func NewModerationService() *ModerationService {
+	return &ModerationService{
+		topicRepo:    repositories.NewTopicRepository(),
+		userRepo:     repositories.NewUserRepository(),
+		commentRepo:  repositories.NewCommentRepository(),
+		categoryRepo: repositories.NewCategoryRepository(),
+		logRepo:      repositories.NewModerationLogRepository(),
+		notifSvc:     NewNotificationService(),
+		cache:        cache.NewTopicCache(),
+		commentCache: cache.NewCommentCache(),
+		feedSvc:      NewFeedService(),
+	}
+}
+
+// TopicReview 新话题预审结果
+type TopicReview struct {
+	Status  int
+	Reasons []string // 进入审核队列的原因，直接发布时为空
+}
+
+// PendingReason 拼接后的审核原因，写入 topics.pending_reason
+func (r TopicReview) PendingReason() string {
+	reason := strings.Join(r.Reasons, "；")
+	if len([]rune(reason)) > 255 {
+		reason = string([]rune(reason)[:255])
+	}
+	return reason
+}
+
+// ReviewNewTopic 根据作者信任度和内容检查结果决定新话题的初始状态
+// 管理员和版主发布的话题不需要审核
+func (s *ModerationService) ReviewNewTopic(ctx context.Context, userID, title, body string) TopicReview {
+	approved := TopicReview{Status: topic.StatusApproved}
+	if !config.GetBool("moderation.pre_moderation_enabled") {
+		return approved
+	}
+	if role.UserHasAnyRole(userID, "admin", "moderator") {
+		return approved
+	}
+
+	var reasons []string
+	if author, err := s.userRepo.GetByID(userID); err == nil && author != nil {
+		if days := config.GetInt("moderation.trusted_account_days"); days > 0 &&
+			time.Since(author.CreatedAt) < time.Duration(days)*24*time.Hour {
+			reasons = append(reasons, "新注册用户")
+		}
+		if minPoints := config.GetInt64("moderation.trusted_min_points"); minPoints > 0 && author.Points < minPoints {
+			reasons = append(reasons, "用户积分不足")
+		}
+	}
+	if minApproved := config.GetInt64("moderation.trusted_min_approved_topics"); minApproved > 0 {
+		if count, err := s.topicRepo.CountApprovedByUser(ctx, userID); err == nil && count < minApproved {
+			reasons = append(reasons, "已通过审核的话题不足")
+		}
+	}
+	if config.GetBool("moderation.review_flagged_content") {
+		if words := security.GetContentChecker().FindSensitiveWords(title + "\n" + body); len(words) > 0 {
+			reasons = append(reasons, "内容包含敏感词")
+		}
+	}
+
+	if len(reasons) == 0 {
+		return approved
+	}
+	return TopicReview{Status: topic.StatusPending, Reasons: reasons}
+}
+
+// Queue 获取审核队列，scope 为 mine 时只看自己认领的，unclaimed 时只看未认领的
+// baseURL 为分页链接使用的队列地址
+func (s *ModerationService) Queue(c *gin.Context, baseURL, scope, moderatorID string, perPage int) ([]topic.Topic, *paginator.Paging, *apperrors.AppError) {
+	list, paging, err := s.topicRepo.ListPending(context.Background(), c, baseURL, scope, moderatorID, s.claimStaleBefore(), perPage)
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取审核队列", err)
+	}
+	return list, paging, nil
+}
+
+// Claim 认领待审核话题，认领超时后其他版主可以重新认领
+func (s *ModerationService) Claim(id, moderatorID string) *apperrors.AppError {
+	if _, appErr := s.getPending(id); appErr != nil {
+		return appErr
+	}
+	ok, err := s.topicRepo.Claim(context.Background(), id, cast.ToUint64(moderatorID), s.claimStaleBefore())
+	if err != nil {
+		return apperrors.DatabaseError("认领话题", err)
+	}
+	if !ok {
+		return apperrors.BusinessError(apperrors.CodeConflict, "话题已被其他版主认领")
+	}
+	return nil
+}
+
+// Assign 将待审核话题指派给指定版主
+func (s *ModerationService) Assign(id, assigneeID string) *apperrors.AppError {
+	t, appErr := s.getPending(id)
+	if appErr != nil {
+		return appErr
+	}
+	if !role.UserHasAnyRole(assigneeID, "admin", "moderator") {
+		return apperrors.ValidationError("只能指派给版主或管理员", map[string]interface{}{"moderator_id": assigneeID})
+	}
+	if !category.UserCanModerate(assigneeID, t.CategoryID) {
+		return apperrors.ValidationError("该版主无权管理话题所在的分类", map[string]interface{}{"moderator_id": assigneeID})
+	}
+
+	now := time.Now()
+	t.ClaimedBy = cast.ToUint64(assigneeID)
+	t.ClaimedAt = &now
+	if err := s.topicRepo.UpdateColumns(context.Background(), t, "claimed_by", "claimed_at"); err != nil {
+		return apperrors.DatabaseError("指派话题", err)
+	}
+	return nil
+}
+
+// Release 放弃认领，force 为 true 时可释放他人的认领
+func (s *ModerationService) Release(id, moderatorID string, force bool) *apperrors.AppError {
+	t, appErr := s.getPending(id)
+	if appErr != nil {
+		return appErr
+	}
+	if t.ClaimedBy == 0 {
+		return nil
+	}
+	if !force && t.ClaimedBy != cast.ToUint64(moderatorID) {
+		return apperrors.AuthorizationError("只能释放自己认领的话题")
+	}
+
+	t.ClaimedBy = 0
+	t.ClaimedAt = nil
+	if err := s.topicRepo.UpdateColumns(context.Background(), t, "claimed_by", "claimed_at"); err != nil {
+		return apperrors.DatabaseError("释放认领", err)
+	}
+	return nil
+}
+
+// Approve 审核通过并通知作者
+// 只有首次通过时才推送信息流和通知系列关注者、被提及用户，撤回后重新通过不会重复推送
+func (s *ModerationService) Approve(id, moderatorID string) (*topic.Topic, *apperrors.AppError) {
+	t, firstApproval, appErr := s.review(id, moderatorID, topic.StatusApproved, "")
+	if appErr != nil {
+		return nil, appErr
+	}
+	s.record(moderatorID, moderation.ActionApprove, t.ID, "", nil)
+	s.notifyAuthor(t, moderatorID, "topic_approved")
+	if !firstApproval {
+		return t, nil
+	}
+	s.feedSvc.PublishTopic(t)
+	// 系列的关注者和正文中提及的用户在审核通过后才收到通知
+	NewSeriesService().NotifyNewPart(t.GetStringID())
+	NewMentionService().NotifyPending(MentionSource{
+		Type:    mention.SourceTopic,
+		ID:      t.GetStringID(),
+		TopicID: t.GetStringID(),
+		ActorID: t.UserID,
+	})
+	return t, nil
+}
+
+// Reject 审核拒绝并将原因通知作者
+func (s *ModerationService) Reject(id, moderatorID, reason string) (*topic.Topic, *apperrors.AppError) {
+	t, _, appErr := s.review(id, moderatorID, topic.StatusRejected, reason)
+	if appErr != nil {
+		return nil, appErr
+	}
+	s.record(moderatorID, moderation.ActionReject, t.ID, reason, nil)
+	s.notifyAuthor(t, moderatorID, "topic_rejected")
+	return t, nil
+}
+
+// review 写入审核结果，同时清除认领信息和话题缓存
+// 第二个返回值表示本次是否为话题的首次审核通过
+func (s *ModerationService) review(id, moderatorID string, status int, reason string) (*topic.Topic, bool, *apperrors.AppError) {
+	ctx := context.Background()
+	t, err := s.topicRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, false, apperrors.DatabaseError("获取话题", err)
+	}
+	if t == nil {
+		return nil, false, apperrors.NotFoundError("话题")
+	}
+	if t.Status == status {
+		if status == topic.StatusApproved {
+			return nil, false, apperrors.BusinessError(apperrors.CodeInvalidParameter, "话题已审核通过")
+		}
+		return nil, false, apperrors.BusinessError(apperrors.CodeInvalidParameter, "话题已被拒绝")
+	}
+
+	now := time.Now()
+	t.Status = status
+	t.RejectReason = reason
+	t.ReviewedBy = cast.ToUint64(moderatorID)
+	t.ReviewedAt = &now
+	t.ClaimedBy = 0
+	t.ClaimedAt = nil
+	firstApproval := status == topic.StatusApproved && t.ApprovedAt == nil
+	if firstApproval {
+		t.ApprovedAt = &now
+	}
+	if err := s.topicRepo.UpdateColumns(ctx, t, "status", "reject_reason", "reviewed_by", "reviewed_at", "approved_at", "claimed_by", "claimed_at"); err != nil {
+		return nil, false, apperrors.DatabaseError("审核话题", err)
+	}
+
+	if s.cache != nil {
+		s.cache.Delete(ctx, id)
+		s.cache.ClearList(ctx)
+	}
+	logger.LogIf(s.categoryRepo.RecountTopics(t.CategoryID))
+	syncTopicIndex([]uint64{t.ID}, status != topic.StatusApproved)
+	return t, firstApproval, nil
+}
+
+// Requeue 将已发布的话题撤回审核队列，重新审核通过前对外不可见
+// 用于处理被举报的话题，moderatorID 为 0 时表示系统自动撤回
+func (s *ModerationService) Requeue(id, moderatorID, reason string) *apperrors.AppError {
+	t, appErr := s.getTopic(id)
+	if appErr != nil {
+		return appErr
+	}
+	if t.Status == topic.StatusPending {
+		return nil
+	}
+
+	t.Status = topic.StatusPending
+	t.PendingReason = reason
+	t.ClaimedBy = 0
+	t.ClaimedAt = nil
+	if err := s.topicRepo.UpdateColumns(context.Background(), t, "status", "pending_reason", "claimed_by", "claimed_at"); err != nil {
+		return apperrors.DatabaseError("撤回话题", err)
+	}
+
+	logger.LogIf(s.categoryRepo.RecountTopics(t.CategoryID))
+	s.invalidateTopics(t.ID)
+	syncTopicIndex([]uint64{t.ID}, true)
+	s.record(moderatorID, moderation.ActionHide, t.ID, reason, nil)
+	return nil
+}
+
+// Move 将话题移动到其他分类
+func (s *ModerationService) Move(id, categoryID, moderatorID, reason string) (*topic.Topic, *apperrors.AppError) {
+	t, appErr := s.getTopic(id)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if appErr := s.checkCategory(categoryID); appErr != nil {
+		return nil, appErr
+	}
+	if appErr := s.checkScope(moderatorID, categoryID); appErr != nil {
+		return nil, appErr
+	}
+	if t.CategoryID == categoryID {
+		return nil, apperrors.BusinessError(apperrors.CodeInvalidParameter, "话题已在该分类下")
+	}
+
+	fromCategoryID := t.CategoryID
+	t.CategoryID = categoryID
+	if err := s.topicRepo.UpdateColumns(context.Background(), t, "category_id"); err != nil {
+		return nil, apperrors.DatabaseError("移动话题", err)
+	}
+
+	logger.LogIf(s.categoryRepo.RecountTopics(fromCategoryID, categoryID))
+	s.invalidateTopics(t.ID)
+	if t.IsApproved() {
+		syncTopicIndex([]uint64{t.ID}, false)
+	}
+
+	detail := map[string]interface{}{"from_category_id": fromCategoryID, "to_category_id": categoryID}
+	s.record(moderatorID, moderation.ActionMove, t.ID, reason, detail)
+	s.notify(t.UserID, moderatorID, "topic_moved", map[string]interface{}{
+		"topic_id":         t.GetStringID(),
+		"title":            t.Title,
+		"from_category_id": fromCategoryID,
+		"to_category_id":   categoryID,
+		"reason":           reason,
+	})
+	return t, nil
+}
+
+// Merge 将重复话题合并到目标话题
+// 原话题的评论、点赞和收藏转移到目标话题，原话题进入回收站，访问原话题时跳转到目标话题
+func (s *ModerationService) Merge(sourceID, targetID, moderatorID, reason string) (*topic.Topic, *apperrors.AppError) {
+	if sourceID == targetID {
+		return nil, apperrors.ValidationError("不能合并到自身", map[string]interface{}{"target_id": targetID})
+	}
+	source, appErr := s.getTopic(sourceID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	target, appErr := s.getTopic(targetID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if appErr := s.checkScope(moderatorID, target.CategoryID); appErr != nil {
+		return nil, appErr
+	}
+
+	if reason == "" {
+		reason = "合并到话题 #" + targetID
+	}
+	if err := s.topicRepo.Merge(context.Background(), source, target, cast.ToUint64(moderatorID), reason); err != nil {
+		return nil, apperrors.DatabaseError("合并话题", err)
+	}
+
+	logger.LogIf(s.categoryRepo.RecountTopics(source.CategoryID, target.CategoryID))
+	s.invalidateTopics(source.ID, target.ID)
+	syncTopicIndex([]uint64{source.ID}, true)
+	if target.IsApproved() {
+		syncTopicIndex([]uint64{target.ID}, false)
+	}
+
+	s.record(moderatorID, moderation.ActionMerge, source.ID, reason, map[string]interface{}{"target_id": target.ID})
+	s.notify(source.UserID, moderatorID, "topic_merged", map[string]interface{}{
+		"topic_id":     source.GetStringID(),
+		"title":        source.Title,
+		"target_id":    target.GetStringID(),
+		"target_title": target.Title,
+		"reason":       reason,
+	})
+
+	merged, appErr := s.getTopic(targetID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return merged, nil
+}
+
+// SplitOptions 拆分话题的参数
+type SplitOptions struct {
+	CommentIDs []uint64
+	Title      string
+	CategoryID string // 为空时与原话题相同
+	Reason     string
+}
+
+// Split 将选中的评论拆分为新话题
+// 新话题的作者和正文取最早一条评论，该评论从原话题删除，其余评论按原顺序挂到新话题下
+func (s *ModerationService) Split(sourceID, moderatorID string, opts SplitOptions) (*topic.Topic, *apperrors.AppError) {
+	ctx := context.Background()
+	source, appErr := s.getTopic(sourceID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if opts.CategoryID == "" {
+		opts.CategoryID = source.CategoryID
+	} else if appErr := s.checkCategory(opts.CategoryID); appErr != nil {
+		return nil, appErr
+	} else if appErr := s.checkScope(moderatorID, opts.CategoryID); appErr != nil {
+		return nil, appErr
+	}
+
+	all, err := s.commentRepo.GetByIDs(ctx, opts.CommentIDs)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取评论", err)
+	}
+	// 只拆分属于该话题的评论，GetByIDs 已按时间正序
+	var selected []comment.Comment
+	commentIDs := make([]uint64, 0, len(all))
+	for _, cm := range all {
+		if cm.TopicID == sourceID {
+			selected = append(selected, cm)
+			commentIDs = append(commentIDs, cm.ID)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, apperrors.ValidationError("请选择该话题下的评论", map[string]interface{}{"comment_ids": opts.CommentIDs})
+	}
+
+	first, last := selected[0], selected[len(selected)-1]
+	lastRepliedAt := last.CreatedAt
+	now := time.Now()
+	newTopic := &topic.Topic{
+		Title:         opts.Title,
+		Body:          first.Content,
+		UserID:        first.UserID,
+		CategoryID:    opts.CategoryID,
+		Status:        topic.StatusApproved,
+		ApprovedAt:    &now,
+		LastRepliedAt: &lastRepliedAt,
+	}
+	moved, err := s.topicRepo.Split(ctx, sourceID, newTopic, first.ID, commentIDs[1:])
+	if err != nil {
+		return nil, apperrors.DatabaseError("拆分话题", err)
+	}
+
+	logger.LogIf(s.categoryRepo.RecountTopics(source.CategoryID, opts.CategoryID))
+	s.invalidateTopics(source.ID, newTopic.ID)
+	syncTopicIndex([]uint64{newTopic.ID}, false)
+
+	detail := map[string]interface{}{"new_topic_id": newTopic.ID, "comment_ids": commentIDs, "moved": moved}
+	s.record(moderatorID, moderation.ActionSplit, source.ID, opts.Reason, detail)
+
+	data := map[string]interface{}{
+		"topic_id":     source.GetStringID(),
+		"title":        source.Title,
+		"new_topic_id": newTopic.GetStringID(),
+		"new_title":    newTopic.Title,
+		"reason":       opts.Reason,
+	}
+	s.notify(source.UserID, moderatorID, "topic_split", data)
+	notified := map[string]bool{source.UserID: true}
+	for _, cm := range selected {
+		if !notified[cm.UserID] {
+			notified[cm.UserID] = true
+			s.notify(cm.UserID, moderatorID, "comments_moved", data)
+		}
+	}
+	return newTopic, nil
+}
+
+// Logs 版主操作日志
+func (s *ModerationService) Logs(c *gin.Context, perPage int) ([]moderation.Log, *paginator.Paging, *apperrors.AppError) {
+	logs, paging, err := s.logRepo.List(context.Background(), c, perPage)
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取操作日志", err)
+	}
+	return logs, paging, nil
+}
+
+// record 写入版主操作日志，失败只记录错误不影响操作结果
+func (s *ModerationService) record(moderatorID, action string, topicID uint64, reason string, detail map[string]interface{}) {
+	log := &moderation.Log{
+		ModeratorID: cast.ToUint64(moderatorID),
+		Action:      action,
+		TargetType:  "topic",
+		TargetID:    topicID,
+		Reason:      reason,
+	}
+	if detail != nil {
+		if raw, err := json.Marshal(detail); err == nil {
+			log.Detail = string(raw)
+		}
+	}
+	logger.LogIf(s.logRepo.Create(context.Background(), log))
+}
+
+// invalidateTopics 清除话题详情、列表和评论缓存
+func (s *ModerationService) invalidateTopics(ids ...uint64) {
+	ctx := context.Background()
+	for _, id := range ids {
+		if s.cache != nil {
+			s.cache.Delete(ctx, cast.ToString(id))
+		}
+		if s.commentCache != nil {
+			s.commentCache.InvalidateByTopicID(ctx, cast.ToString(id))
+		}
+	}
+	if s.cache != nil {
+		s.cache.ClearList(ctx)
+	}
+}
+
+func (s *ModerationService) getTopic(id string) (*topic.Topic, *apperrors.AppError) {
+	t, err := s.topicRepo.GetByID(context.Background(), id)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取话题", err)
+	}
+	if t == nil {
+		return nil, apperrors.NotFoundError("话题")
+	}
+	return t, nil
+}
+
+func (s *ModerationService) checkCategory(categoryID string) *apperrors.AppError {
+	cat, err := s.categoryRepo.GetByID(categoryID)
+	if err != nil || cat == nil || cat.ID == 0 {
+		return apperrors.ValidationError("分类不存在", map[string]interface{}{"category_id": categoryID})
+	}
+	return nil
+}
+
+// checkScope 被指派了分类的版主只能把话题移入自己管理的分类
+func (s *ModerationService) checkScope(moderatorID, categoryID string) *apperrors.AppError {
+	if !category.UserCanModerate(moderatorID, categoryID) {
+		return apperrors.AuthorizationError("无权管理目标分类下的话题")
+	}
+	return nil
+}
+
+// notify 通知受影响的作者，操作人自己不通知
+func (s *ModerationService) notify(userID, moderatorID, typ string, data map[string]interface{}) {
+	if s.notifSvc == nil || userID == "" || userID == moderatorID {
+		return
+	}
+	_ = s.notifSvc.Notify(userID, moderatorID, typ, data)
+}
+
+func (s *ModerationService) notifyAuthor(t *topic.Topic, moderatorID, typ string) {
+	if s.notifSvc == nil || t.UserID == "" {
+		return
+	}
+	data := map[string]interface{}{
+		"topic_id": t.GetStringID(),
+		"title":    t.Title,
+	}
+	if t.Status == topic.StatusRejected {
+		data["reject_reason"] = t.RejectReason
+	}
+	_ = s.notifSvc.Notify(t.UserID, moderatorID, typ, data)
+}
+
+func (s *ModerationService) getPending(id string) (*topic.Topic, *apperrors.AppError) {
+	t, err := s.topicRepo.GetByID(context.Background(), id)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取话题", err)
+	}
+	if t == nil {
+		return nil, apperrors.NotFoundError("话题")
+	}
+	if t.Status != topic.StatusPending {
+		return nil, apperrors.BusinessError(apperrors.CodeInvalidParameter, "话题不在审核队列中")
+	}
+	return t, nil
+}
+
+// claimStaleBefore 早于该时间的认领视为过期
+func (s *ModerationService) claimStaleBefore() time.Time {
+	ttl := config.GetInt("moderation.claim_ttl_minutes", 30)
+	return time.Now().Add(-time.Duration(ttl) * time.Minute)
+}
@@ -54,6 +54,18 @@ func (s *NotificationService) List(c *gin.Context, userID string, perPage int) (
 	return list, paging, nil
 }
 
+// ListByCursor 游标分页获取通知列表
+func (s *NotificationService) ListByCursor(c *gin.Context, userID string, perPage int) ([]notification.Notification, *paginator.CursorPaging, *apperrors.AppError) {
+	list, paging, err := s.repo.ListByUserCursor(c, userID, perPage)
+	if err != nil {
+		if appErr := invalidCursorError(c, err); appErr != nil {
+			return nil, nil, appErr
+		}
+		return nil, nil, apperrors.DatabaseError("获取通知列表", err)
+	}
+	return list, paging, nil
+}
+
 // MarkRead 标记通知已读
 func (s *NotificationService) MarkRead(id, userID string) *apperrors.AppError {
 	if err := s.repo.MarkRead(id, userID); err != nil {
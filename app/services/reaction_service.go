@@ -0,0 +1,329 @@
+// Package services 表情回应业务逻辑
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"GoHub-Service/app/models/reaction"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/repositories"
+	"GoHub-Service/pkg/cache"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
+
+	"github.com/spf13/cast"
+)
+
+// 回应目标类型，供控制器使用
+const (
+	ReactionTargetTopic   = reaction.TargetTopic
+	ReactionTargetComment = reaction.TargetComment
+	ReactionTargetMessage = reaction.TargetMessage
+)
+
+// reactionTypesCacheKey 已启用表情的缓存，管理员修改表情时清除
+const reactionTypesCacheKey = "reaction:types"
+
+// ReactionService 表情回应服务：用户对话题、评论和私信回应管理员配置的表情
+type ReactionService struct {
+	repo        repositories.ReactionRepository
+	topicRepo   repositories.TopicRepository
+	commentRepo repositories.CommentRepository
+	messageRepo repositories.MessageRepository
+	notifSvc    *NotificationService
+}
+
+// NewReactionService 创建表情回应服务实例
+func NewReactionService() *ReactionService {
+	return &ReactionService{
+		repo:        repositories.NewReactionRepository(),
+		topicRepo:   repositories.NewTopicRepository(),
+		commentRepo: repositories.NewCommentRepository(),
+		messageRepo: repositories.NewMessageRepository(),
+		notifSvc:    NewNotificationService(),
+	}
+}
+
+// ReactionSummaryDTO 目标上的表情回应汇总
+type ReactionSummaryDTO struct {
+	Counts []reaction.Count `json:"counts"`
+	Mine   []string         `json:"mine"` // 当前用户回应过的表情，未登录时为空
+}
+
+// ReactionTypeSaveDTO 管理员创建或修改表情
+type ReactionTypeSaveDTO struct {
+	Emoji    string
+	Name     string
+	Position int
+	Enabled  bool
+}
+
+// reactionTarget 被回应的目标及其作者
+type reactionTarget struct {
+	OwnerID string
+	Data    map[string]interface{} // 通知附带的数据
+}
+
+// Types 用户可以使用的表情
+func (s *ReactionService) Types() ([]reaction.Type, *apperrors.AppError) {
+	var types []reaction.Type
+	cache.GetObject(context.Background(), reactionTypesCacheKey, &types)
+	if len(types) > 0 {
+		return types, nil
+	}
+	types, err := s.repo.Types(context.Background(), true)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取表情列表", err)
+	}
+	if len(types) > 0 {
+		cache.Set(context.Background(), reactionTypesCacheKey, types, 120*time.Minute)
+	}
+	return types, nil
+}
+
+// React 回应表情，只通知第一次回应该表情的情况，回应自己的内容不通知
+func (s *ReactionService) React(targetType, targetID, userID, emoji string) (*ReactionSummaryDTO, *apperrors.AppError) {
+	if appErr := s.checkEmoji(emoji); appErr != nil {
+		return nil, appErr
+	}
+	target, appErr := s.target(targetType, targetID, userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	added, err := s.repo.Add(context.Background(), targetType, cast.ToUint64(targetID), cast.ToUint64(userID), emoji)
+	if err != nil {
+		return nil, apperrors.DatabaseError("回应表情", err)
+	}
+	if added && s.notifSvc != nil && target.OwnerID != userID {
+		target.Data["reaction"] = emoji
+		_ = s.notifSvc.Notify(target.OwnerID, userID, reactionNotificationType(targetType), target.Data)
+	}
+	return s.Summary(targetType, targetID, userID), nil
+}
+
+// Unreact 取消表情回应，表情停用后仍可取消
+func (s *ReactionService) Unreact(targetType, targetID, userID, emoji string) (*ReactionSummaryDTO, *apperrors.AppError) {
+	if _, appErr := s.target(targetType, targetID, userID); appErr != nil {
+		return nil, appErr
+	}
+	if _, err := s.repo.Remove(context.Background(), targetType, cast.ToUint64(targetID), cast.ToUint64(userID), emoji); err != nil {
+		return nil, apperrors.DatabaseError("取消表情回应", err)
+	}
+	return s.Summary(targetType, targetID, userID), nil
+}
+
+// Summary 单个目标的回应汇总
+func (s *ReactionService) Summary(targetType, targetID, viewerID string) *ReactionSummaryDTO {
+	id := cast.ToUint64(targetID)
+	return s.Summaries(targetType, []uint64{id}, viewerID)[id]
+}
+
+// Summaries 批量获取目标的回应汇总，每个目标都有结果，查询失败时只记录日志
+func (s *ReactionService) Summaries(targetType string, targetIDs []uint64, viewerID string) map[uint64]*ReactionSummaryDTO {
+	summaries := make(map[uint64]*ReactionSummaryDTO, len(targetIDs))
+	for _, id := range targetIDs {
+		summaries[id] = &ReactionSummaryDTO{Counts: []reaction.Count{}, Mine: []string{}}
+	}
+	if len(targetIDs) == 0 {
+		return summaries
+	}
+	ctx := context.Background()
+	counts, err := s.repo.Counts(ctx, targetType, targetIDs)
+	if err != nil {
+		logger.LogIf(err)
+		return summaries
+	}
+	mine, err := s.repo.Mine(ctx, targetType, targetIDs, cast.ToUint64(viewerID))
+	if err != nil {
+		logger.LogIf(err)
+	}
+	for id, summary := range summaries {
+		if c, ok := counts[id]; ok {
+			summary.Counts = c
+		}
+		if m, ok := mine[id]; ok {
+			summary.Mine = m
+		}
+	}
+	return summaries
+}
+
+// AllTypes 后台表情列表，包含已停用的表情
+func (s *ReactionService) AllTypes() ([]reaction.Type, *apperrors.AppError) {
+	types, err := s.repo.Types(context.Background(), false)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取表情列表", err)
+	}
+	return types, nil
+}
+
+// CreateType 添加表情
+func (s *ReactionService) CreateType(dto ReactionTypeSaveDTO) (*reaction.Type, *apperrors.AppError) {
+	model := &reaction.Type{}
+	if appErr := s.saveType(model, dto); appErr != nil {
+		return nil, appErr
+	}
+	return model, nil
+}
+
+// UpdateType 修改表情名称、排序和启用状态，表情字符不能修改，避免已有的回应失去对应的表情
+func (s *ReactionService) UpdateType(id string, dto ReactionTypeSaveDTO) (*reaction.Type, *apperrors.AppError) {
+	model, appErr := s.getType(id)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if dto.Emoji != "" && dto.Emoji != model.Emoji {
+		return nil, apperrors.ValidationError("不能修改表情字符，请停用后添加新的表情", map[string]interface{}{"emoji": dto.Emoji})
+	}
+	dto.Emoji = model.Emoji
+	if appErr := s.saveType(model, dto); appErr != nil {
+		return nil, appErr
+	}
+	return model, nil
+}
+
+// DeleteType 删除表情及其全部回应，只想停止使用时应改为停用
+func (s *ReactionService) DeleteType(id string) *apperrors.AppError {
+	model, appErr := s.getType(id)
+	if appErr != nil {
+		return appErr
+	}
+	if err := s.repo.DeleteType(context.Background(), model); err != nil {
+		return apperrors.DatabaseDeleteError("表情", err)
+	}
+	cache.Forget(context.Background(), reactionTypesCacheKey)
+	return nil
+}
+
+// saveType 校验表情字符唯一后保存
+func (s *ReactionService) saveType(model *reaction.Type, dto ReactionTypeSaveDTO) *apperrors.AppError {
+	ctx := context.Background()
+	emoji := strings.TrimSpace(dto.Emoji)
+	existing, err := s.repo.GetTypeByEmoji(ctx, emoji)
+	if err != nil {
+		return apperrors.DatabaseError("获取表情", err)
+	}
+	if existing != nil && existing.ID != model.ID {
+		return apperrors.BusinessError(apperrors.CodeConflict, "表情已存在").
+			WithDetails(map[string]interface{}{"emoji": emoji})
+	}
+
+	model.Emoji = emoji
+	model.Name = dto.Name
+	model.Position = dto.Position
+	model.Enabled = dto.Enabled
+	if model.ID == 0 {
+		err = s.repo.CreateType(ctx, model)
+	} else {
+		err = s.repo.SaveType(ctx, model)
+	}
+	if err != nil {
+		return apperrors.DatabaseError("保存表情", err)
+	}
+	cache.Forget(ctx, reactionTypesCacheKey)
+	return nil
+}
+
+// getType 获取表情
+func (s *ReactionService) getType(id string) (*reaction.Type, *apperrors.AppError) {
+	model, err := s.repo.GetType(context.Background(), id)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取表情", err)
+	}
+	if model == nil {
+		return nil, apperrors.NotFoundError("表情").WithDetails(map[string]interface{}{"id": id})
+	}
+	return model, nil
+}
+
+// checkEmoji 表情必须是已启用的表情之一
+func (s *ReactionService) checkEmoji(emoji string) *apperrors.AppError {
+	types, appErr := s.Types()
+	if appErr != nil {
+		return appErr
+	}
+	for _, t := range types {
+		if t.Emoji == emoji {
+			return nil
+		}
+	}
+	return apperrors.ValidationError("不支持的表情", map[string]interface{}{"emoji": emoji})
+}
+
+// target 获取当前用户可以回应的目标：已审核通过或自己的话题、评论、自己收发的私信
+func (s *ReactionService) target(targetType, targetID, userID string) (*reactionTarget, *apperrors.AppError) {
+	ctx := context.Background()
+	switch targetType {
+	case reaction.TargetTopic:
+		topicModel, err := s.topicRepo.GetByID(ctx, targetID)
+		if err != nil {
+			return nil, apperrors.DatabaseError("获取话题", err)
+		}
+		if !s.canSeeTopic(topicModel, userID) {
+			return nil, apperrors.NotFoundError("话题").WithDetails(map[string]interface{}{"topic_id": targetID})
+		}
+		return &reactionTarget{
+			OwnerID: topicModel.UserID,
+			Data:    map[string]interface{}{"topic_id": targetID},
+		}, nil
+	case reaction.TargetComment:
+		commentModel, err := s.commentRepo.GetByID(ctx, targetID)
+		if err != nil {
+			return nil, apperrors.DatabaseError("获取评论", err)
+		}
+		if commentModel == nil {
+			return nil, apperrors.NotFoundError("评论").WithDetails(map[string]interface{}{"comment_id": targetID})
+		}
+		topicModel, err := s.topicRepo.GetByID(ctx, commentModel.TopicID)
+		if err != nil {
+			return nil, apperrors.DatabaseError("获取话题", err)
+		}
+		if !s.canSeeTopic(topicModel, userID) {
+			return nil, apperrors.NotFoundError("评论").WithDetails(map[string]interface{}{"comment_id": targetID})
+		}
+		return &reactionTarget{
+			OwnerID: commentModel.UserID,
+			Data:    map[string]interface{}{"topic_id": commentModel.TopicID, "comment_id": targetID},
+		}, nil
+	case reaction.TargetMessage:
+		msg, err := s.messageRepo.GetByID(targetID)
+		if err != nil {
+			return nil, apperrors.DatabaseError("获取私信", err)
+		}
+		if msg == nil || (msg.SenderID != userID && msg.ReceiverID != userID) {
+			return nil, apperrors.NotFoundError("私信").WithDetails(map[string]interface{}{"message_id": targetID})
+		}
+		// 通知会话的另一方
+		ownerID := msg.SenderID
+		if ownerID == userID {
+			ownerID = msg.ReceiverID
+		}
+		return &reactionTarget{
+			OwnerID: ownerID,
+			Data:    map[string]interface{}{"message_id": targetID, "sender_id": userID},
+		}, nil
+	}
+	return nil, apperrors.ValidationError("不支持的回应目标", map[string]interface{}{"target_type": targetType})
+}
+
+// canSeeTopic 用户能否看到话题：未审核通过的话题只有作者可见，不可见分类中的话题对用户视为不存在
+func (s *ReactionService) canSeeTopic(t *topic.Topic, userID string) bool {
+	if t == nil || (!t.IsApproved() && t.UserID != userID) {
+		return false
+	}
+	return CanViewCategory(userID, t.CategoryID)
+}
+
+// reactionNotificationType 表情回应沿用点赞和私信的通知类型，通过 data.reaction 区分
+func reactionNotificationType(targetType string) string {
+	switch targetType {
+	case reaction.TargetTopic:
+		return "topic_like"
+	case reaction.TargetComment:
+		return "comment_like"
+	default:
+		return "direct_message"
+	}
+}
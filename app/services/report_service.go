@@ -0,0 +1,420 @@
+// Package services 用户举报与举报处理
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"GoHub-Service/app/cache"
+	"GoHub-Service/app/models/moderation"
+	"GoHub-Service/app/models/report"
+	"GoHub-Service/app/repositories"
+	"GoHub-Service/pkg/config"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+// ReportService 举报服务：用户举报话题、评论、用户和私信，版主在举报队列中处置或驳回
+type ReportService struct {
+	repo         repositories.ReportRepository
+	topicRepo    repositories.TopicRepository
+	commentRepo  repositories.CommentRepository
+	messageRepo  repositories.MessageRepository
+	userRepo     repositories.UserRepository
+	logRepo      repositories.ModerationLogRepository
+	notifSvc     *NotificationService
+	commentCache *cache.CommentCache
+}
+
+// NewReportService 创建举报服务实例
+func NewReportService() *ReportService {
+	return &ReportService{
+		repo:         repositories.NewReportRepository(),
+		topicRepo:    repositories.NewTopicRepository(),
+		commentRepo:  repositories.NewCommentRepository(),
+		messageRepo:  repositories.NewMessageRepository(),
+		userRepo:     repositories.NewUserRepository(),
+		logRepo:      repositories.NewModerationLogRepository(),
+		notifSvc:     NewNotificationService(),
+		commentCache: cache.NewCommentCache(),
+	}
+}
+
+// ReportCreateDTO 提交举报
+type ReportCreateDTO struct {
+	ReporterID string
+	TargetType string
+	TargetID   string
+	Reason     string
+	Detail     string
+}
+
+// ReportActionDTO 版主处置举报
+type ReportActionDTO struct {
+	Action string // delete|hide|ban
+	Note   string
+	Days   int // 封禁天数，为 0 时使用默认天数
+}
+
+// reportTarget 被举报的目标
+type reportTarget struct {
+	OwnerID string
+	TopicID string // 评论所属话题，用于清除评论缓存
+}
+
+// Create 提交举报，同一用户对同一目标只能举报一次
+// 待处理的举报人数达到阈值时自动隐藏内容，等待版主处理
+func (s *ReportService) Create(dto ReportCreateDTO) (*report.Report, *apperrors.AppError) {
+	ctx := context.Background()
+	target, appErr := s.target(dto.TargetType, dto.TargetID, dto.ReporterID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if target.OwnerID == dto.ReporterID {
+		return nil, apperrors.ValidationError("不能举报自己", map[string]interface{}{"target_id": dto.TargetID})
+	}
+
+	reporterID, targetID := cast.ToUint64(dto.ReporterID), cast.ToUint64(dto.TargetID)
+	existing, err := s.repo.GetByReporter(ctx, reporterID, dto.TargetType, targetID)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取举报", err)
+	}
+	if existing != nil {
+		return nil, apperrors.BusinessError(apperrors.CodeConflict, "你已经举报过该内容")
+	}
+
+	rp := &report.Report{
+		ReporterID: reporterID,
+		TargetType: dto.TargetType,
+		TargetID:   targetID,
+		OwnerID:    cast.ToUint64(target.OwnerID),
+		Reason:     dto.Reason,
+		Detail:     dto.Detail,
+		Status:     report.StatusOpen,
+	}
+	if err := s.repo.Create(ctx, rp); err != nil {
+		return nil, apperrors.DatabaseError("提交举报", err)
+	}
+
+	s.autoHide(rp, target)
+	return rp, nil
+}
+
+// autoHide 待处理的举报人数达到阈值时自动隐藏内容，用户无法隐藏，只能由版主处理
+func (s *ReportService) autoHide(rp *report.Report, target *reportTarget) {
+	threshold := config.GetInt64("report.auto_hide_threshold", 5)
+	if threshold <= 0 || rp.TargetType == report.TargetUser {
+		return
+	}
+	count, err := s.repo.CountPendingReporters(context.Background(), rp.TargetType, rp.TargetID)
+	if err != nil {
+		logger.LogIf(err)
+		return
+	}
+	if count < threshold {
+		return
+	}
+	if appErr := s.hide(rp, target, "0", "被多名用户举报，已自动隐藏"); appErr != nil {
+		logger.LogIf(appErr)
+	}
+}
+
+// Mine 用户提交的举报及处理结果
+func (s *ReportService) Mine(c *gin.Context, reporterID string, perPage int) ([]report.Report, *paginator.Paging, *apperrors.AppError) {
+	reports, paging, err := s.repo.ListByReporter(context.Background(), c, cast.ToUint64(reporterID), perPage)
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取举报列表", err)
+	}
+	return reports, paging, nil
+}
+
+// Queue 举报队列，被指派了分类的版主只看到其管理的分类下的话题和评论举报
+func (s *ReportService) Queue(c *gin.Context, moderatorID string, perPage int) ([]report.Report, *paginator.Paging, *apperrors.AppError) {
+	reports, paging, err := s.repo.List(context.Background(), c, moderatorID, perPage)
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取举报队列", err)
+	}
+	return reports, paging, nil
+}
+
+// Get 举报详情
+func (s *ReportService) Get(id string) (*report.Report, *apperrors.AppError) {
+	rp, err := s.repo.GetByID(context.Background(), id)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取举报", err)
+	}
+	if rp == nil {
+		return nil, apperrors.NotFoundError("举报")
+	}
+	return rp, nil
+}
+
+// Triage 分拣举报，表示版主已查看，等待处置
+func (s *ReportService) Triage(id, moderatorID string) (*report.Report, *apperrors.AppError) {
+	rp, appErr := s.getPending(id)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if rp.Status == report.StatusTriaged {
+		return rp, nil
+	}
+	if err := s.repo.Triage(context.Background(), rp.ID, cast.ToUint64(moderatorID)); err != nil {
+		return nil, apperrors.DatabaseError("分拣举报", err)
+	}
+	rp.Status = report.StatusTriaged
+	rp.HandledBy = cast.ToUint64(moderatorID)
+	return rp, nil
+}
+
+// Act 处置举报：删除或隐藏内容、封禁作者，同时关闭目标上所有待处理的举报并通知举报人
+func (s *ReportService) Act(id, moderatorID string, dto ReportActionDTO) (*report.Report, *apperrors.AppError) {
+	rp, appErr := s.getPending(id)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !report.UserCanHandle(moderatorID, rp) {
+		return nil, apperrors.AuthorizationError("无权处理该分类下的举报")
+	}
+	target, appErr := s.target(rp.TargetType, cast.ToString(rp.TargetID), "")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	reason := dto.Note
+	if reason == "" {
+		reason = "举报处理"
+	}
+	switch dto.Action {
+	case report.ActionDelete:
+		appErr = s.delete(rp, moderatorID, reason)
+	case report.ActionHide:
+		appErr = s.hide(rp, target, moderatorID, reason)
+	case report.ActionBan:
+		appErr = s.ban(rp, target, moderatorID, reason, dto.Days)
+	default:
+		appErr = apperrors.ValidationError("不支持的处置方式", map[string]interface{}{"action": dto.Action})
+	}
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return s.close(rp, report.StatusActioned, dto.Action, moderatorID, dto.Note)
+}
+
+// Dismiss 驳回举报，关闭目标上所有待处理的举报并通知举报人
+// 评论和私信因举报被自动隐藏时一并恢复
+func (s *ReportService) Dismiss(id, moderatorID, note string) (*report.Report, *apperrors.AppError) {
+	rp, appErr := s.getPending(id)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if appErr := s.unhide(rp); appErr != nil {
+		return nil, appErr
+	}
+	s.record(moderatorID, moderation.ActionDismiss, rp, note)
+	return s.close(rp, report.StatusDismissed, "", moderatorID, note)
+}
+
+// close 关闭目标上所有待处理的举报并通知每个举报人处理结果
+func (s *ReportService) close(rp *report.Report, status, action, moderatorID, note string) (*report.Report, *apperrors.AppError) {
+	closed, err := s.repo.Close(context.Background(), rp.TargetType, rp.TargetID, status, action, cast.ToUint64(moderatorID), note)
+	if err != nil {
+		return nil, apperrors.DatabaseError("关闭举报", err)
+	}
+
+	result := rp
+	for i := range closed {
+		if closed[i].ID == rp.ID {
+			result = &closed[i]
+		}
+		// 审核流水线提交的举报没有举报人
+		if s.notifSvc != nil && closed[i].ReporterID != 0 {
+			_ = s.notifSvc.Notify(cast.ToString(closed[i].ReporterID), moderatorID, "report_resolved", map[string]interface{}{
+				"report_id":   closed[i].GetStringID(),
+				"target_type": closed[i].TargetType,
+				"target_id":   cast.ToString(closed[i].TargetID),
+				"status":      status,
+				"action":      action,
+				"note":        note,
+			})
+		}
+	}
+	return result, nil
+}
+
+// delete 删除被举报的内容，话题和评论进入回收站，私信直接删除
+func (s *ReportService) delete(rp *report.Report, moderatorID, reason string) *apperrors.AppError {
+	switch rp.TargetType {
+	case report.TargetTopic:
+		if _, appErr := NewTrashService().Delete(repositories.TrashTopic, []uint64{rp.TargetID}, moderatorID, reason); appErr != nil {
+			return appErr
+		}
+	case report.TargetComment:
+		if _, appErr := NewTrashService().Delete(repositories.TrashComment, []uint64{rp.TargetID}, moderatorID, reason); appErr != nil {
+			return appErr
+		}
+	case report.TargetMessage:
+		if err := s.messageRepo.Delete(cast.ToString(rp.TargetID)); err != nil {
+			return apperrors.DatabaseError("删除私信", err)
+		}
+	default:
+		return apperrors.ValidationError("用户不能删除，请使用封禁", map[string]interface{}{"action": report.ActionDelete})
+	}
+	s.record(moderatorID, moderation.ActionDelete, rp, reason)
+	return nil
+}
+
+// hide 隐藏被举报的内容：话题撤回审核队列，评论和私信保留但不再展示内容
+func (s *ReportService) hide(rp *report.Report, target *reportTarget, moderatorID, reason string) *apperrors.AppError {
+	ctx := context.Background()
+	switch rp.TargetType {
+	case report.TargetTopic:
+		// 撤回审核队列时已写入操作日志
+		return NewModerationService().Requeue(cast.ToString(rp.TargetID), moderatorID, reason)
+	case report.TargetComment:
+		if err := s.commentRepo.Hide(ctx, rp.TargetID); err != nil {
+			return apperrors.DatabaseError("隐藏评论", err)
+		}
+		if s.commentCache != nil {
+			s.commentCache.Invalidate(ctx, cast.ToString(rp.TargetID))
+			s.commentCache.InvalidateByTopicID(ctx, target.TopicID)
+		}
+	case report.TargetMessage:
+		if err := s.messageRepo.Hide(cast.ToString(rp.TargetID)); err != nil {
+			return apperrors.DatabaseError("隐藏私信", err)
+		}
+	default:
+		return apperrors.ValidationError("用户不能隐藏，请使用封禁", map[string]interface{}{"action": report.ActionHide})
+	}
+	s.record(moderatorID, moderation.ActionHide, rp, reason)
+	return nil
+}
+
+// unhide 恢复被自动隐藏的评论和私信，版主处置时隐藏的内容保持隐藏
+// 自动隐藏的话题已撤回审核队列，由版主在审核队列中处理
+func (s *ReportService) unhide(rp *report.Report) *apperrors.AppError {
+	if rp.TargetType != report.TargetComment && rp.TargetType != report.TargetMessage {
+		return nil
+	}
+	ctx := context.Background()
+	hiddenByModerator, err := s.repo.HasAction(ctx, rp.TargetType, rp.TargetID, report.ActionHide)
+	if err != nil {
+		return apperrors.DatabaseError("获取举报", err)
+	}
+	if hiddenByModerator {
+		return nil
+	}
+
+	if rp.TargetType == report.TargetMessage {
+		if err := s.messageRepo.Unhide(cast.ToString(rp.TargetID)); err != nil {
+			return apperrors.DatabaseError("恢复私信", err)
+		}
+		return nil
+	}
+	commentModel, err := s.commentRepo.GetByID(ctx, cast.ToString(rp.TargetID))
+	if err != nil {
+		return apperrors.DatabaseError("获取评论", err)
+	}
+	if commentModel == nil || commentModel.HiddenAt == nil {
+		return nil
+	}
+	if err := s.commentRepo.Unhide(ctx, rp.TargetID); err != nil {
+		return apperrors.DatabaseError("恢复评论", err)
+	}
+	if s.commentCache != nil {
+		s.commentCache.Invalidate(ctx, commentModel.GetStringID())
+		s.commentCache.InvalidateByTopicID(ctx, commentModel.TopicID)
+	}
+	return nil
+}
+
+// ban 封禁被举报内容的作者，被举报的是用户时封禁该用户
+func (s *ReportService) ban(rp *report.Report, target *reportTarget, moderatorID, reason string, days int) *apperrors.AppError {
+	if days <= 0 {
+		days = config.GetInt("report.default_ban_days", 7)
+	}
+	var until *time.Time
+	if days > 0 {
+		t := time.Now().Add(time.Duration(days) * 24 * time.Hour)
+		until = &t
+	}
+	if err := s.userRepo.Ban(target.OwnerID, cast.ToUint64(moderatorID), reason, until); err != nil {
+		return apperrors.DatabaseError("封禁用户", err)
+	}
+	s.record(moderatorID, moderation.ActionBan, rp, reason)
+	return nil
+}
+
+// getPending 获取仍待处理的举报
+func (s *ReportService) getPending(id string) (*report.Report, *apperrors.AppError) {
+	rp, appErr := s.Get(id)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !rp.IsPending() {
+		return nil, apperrors.BusinessError(apperrors.CodeInvalidParameter, "举报已处理")
+	}
+	return rp, nil
+}
+
+// target 获取被举报的目标，viewerID 不为空时检查举报人能否看到该目标
+func (s *ReportService) target(targetType, targetID, viewerID string) (*reportTarget, *apperrors.AppError) {
+	ctx := context.Background()
+	switch targetType {
+	case report.TargetTopic:
+		topicModel, err := s.topicRepo.GetByID(ctx, targetID)
+		if err != nil {
+			return nil, apperrors.DatabaseError("获取话题", err)
+		}
+		if topicModel == nil || (viewerID != "" && !topicModel.IsApproved()) {
+			return nil, apperrors.NotFoundError("话题").WithDetails(map[string]interface{}{"topic_id": targetID})
+		}
+		return &reportTarget{OwnerID: topicModel.UserID}, nil
+	case report.TargetComment:
+		commentModel, err := s.commentRepo.GetByID(ctx, targetID)
+		if err != nil {
+			return nil, apperrors.DatabaseError("获取评论", err)
+		}
+		if commentModel == nil {
+			return nil, apperrors.NotFoundError("评论").WithDetails(map[string]interface{}{"comment_id": targetID})
+		}
+		return &reportTarget{OwnerID: commentModel.UserID, TopicID: commentModel.TopicID}, nil
+	case report.TargetUser:
+		userModel, err := s.userRepo.GetByID(targetID)
+		if err != nil || userModel == nil {
+			return nil, apperrors.NotFoundError("用户").WithDetails(map[string]interface{}{"user_id": targetID})
+		}
+		return &reportTarget{OwnerID: userModel.GetStringID()}, nil
+	case report.TargetMessage:
+		// 只有会话双方可以举报私信
+		msg, err := s.messageRepo.GetByID(targetID)
+		if err != nil {
+			return nil, apperrors.DatabaseError("获取私信", err)
+		}
+		if msg == nil || (viewerID != "" && msg.SenderID != viewerID && msg.ReceiverID != viewerID) {
+			return nil, apperrors.NotFoundError("私信").WithDetails(map[string]interface{}{"message_id": targetID})
+		}
+		return &reportTarget{OwnerID: msg.SenderID}, nil
+	}
+	return nil, apperrors.ValidationError("不支持的举报目标", map[string]interface{}{"target_type": targetType})
+}
+
+// record 写入版主操作日志，附带举报ID，失败只记录错误不影响操作结果
+func (s *ReportService) record(moderatorID, action string, rp *report.Report, reason string) {
+	log := &moderation.Log{
+		ModeratorID: cast.ToUint64(moderatorID),
+		Action:      action,
+		TargetType:  rp.TargetType,
+		TargetID:    rp.TargetID,
+		Reason:      reason,
+	}
+	if raw, err := json.Marshal(map[string]interface{}{"report_id": rp.ID}); err == nil {
+		log.Detail = string(raw)
+	}
+	logger.LogIf(s.logRepo.Create(context.Background(), log))
+}
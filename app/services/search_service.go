@@ -9,7 +9,7 @@ import (
 
 // SearchService 搜索业务逻辑
 type SearchService interface {
-	SearchTopics(c *gin.Context, keyword string) (interface{}, error)
+	SearchTopics(c *gin.Context, keyword, viewerID string) (interface{}, error)
 	SearchUsers(c *gin.Context, keyword string) (interface{}, error)
 }
 
@@ -22,9 +22,10 @@ func NewSearchService(repo repositories.SearchRepository) SearchService {
 	return &searchService{repo: repo}
 }
 
-func (s *searchService) SearchTopics(c *gin.Context, keyword string) (interface{}, error) {
+// SearchTopics 搜索话题，不包含 viewerID 无权查看的分类下的话题
+func (s *searchService) SearchTopics(c *gin.Context, keyword, viewerID string) (interface{}, error) {
 	perPage := config.GetInt("paging.perpage")
-	topics, paging, err := s.repo.SearchTopics(c, keyword, perPage)
+	topics, paging, err := s.repo.SearchTopics(c, keyword, perPage, HiddenCategoryIDs(viewerID))
 	if err != nil {
 		return nil, err
 	}
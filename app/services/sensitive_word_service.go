@@ -0,0 +1,247 @@
+// Package services 敏感词词库管理
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"GoHub-Service/app/models/sensitive"
+	"GoHub-Service/app/repositories"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/paginator"
+	"GoHub-Service/pkg/redis"
+	"GoHub-Service/pkg/security"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SensitiveWordReloadChannel 词库变更通知频道，所有实例收到后重新加载词库
+const SensitiveWordReloadChannel = "sensitive_words:reload"
+
+// SensitiveWordService 敏感词词库服务
+// 词库保存在数据库中，修改后通过 Redis 发布通知，各实例重新构建前缀树后整体替换
+type SensitiveWordService struct {
+	repo repositories.SensitiveWordRepository
+}
+
+// NewSensitiveWordService 创建敏感词服务实例
+func NewSensitiveWordService() *SensitiveWordService {
+	return &SensitiveWordService{repo: repositories.NewSensitiveWordRepository()}
+}
+
+// SensitiveWordSaveDTO 创建、修改或导入敏感词
+type SensitiveWordSaveDTO struct {
+	Word     string `json:"word"`
+	Category string `json:"category"`
+	Severity string `json:"severity"`
+	Pinyin   string `json:"pinyin"` // 多个变体用逗号分隔
+	Note     string `json:"note"`
+}
+
+// SensitiveWordImportError 导入失败的词条
+type SensitiveWordImportError struct {
+	Line    int    `json:"line"` // 从 1 开始
+	Word    string `json:"word"`
+	Message string `json:"message"`
+}
+
+// SensitiveWordImportResult 导入结果
+type SensitiveWordImportResult struct {
+	Created int                        `json:"created"`
+	Updated int                        `json:"updated"`
+	Errors  []SensitiveWordImportError `json:"errors"`
+}
+
+// List 后台词库列表
+func (s *SensitiveWordService) List(c *gin.Context, perPage int) ([]sensitive.Word, *paginator.Paging, *apperrors.AppError) {
+	words, paging, err := s.repo.List(context.Background(), c, perPage)
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取敏感词列表", err)
+	}
+	return words, paging, nil
+}
+
+// Create 添加敏感词
+func (s *SensitiveWordService) Create(dto SensitiveWordSaveDTO) (*sensitive.Word, *apperrors.AppError) {
+	model := &sensitive.Word{}
+	if appErr := s.save(model, dto); appErr != nil {
+		return nil, appErr
+	}
+	return model, nil
+}
+
+// Update 修改敏感词
+func (s *SensitiveWordService) Update(id string, dto SensitiveWordSaveDTO) (*sensitive.Word, *apperrors.AppError) {
+	model, appErr := s.get(id)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if appErr := s.save(model, dto); appErr != nil {
+		return nil, appErr
+	}
+	return model, nil
+}
+
+// Delete 删除敏感词
+func (s *SensitiveWordService) Delete(id string) *apperrors.AppError {
+	model, appErr := s.get(id)
+	if appErr != nil {
+		return appErr
+	}
+	if err := s.repo.Delete(context.Background(), model); err != nil {
+		return apperrors.DatabaseDeleteError("敏感词", err)
+	}
+	s.changed()
+	return nil
+}
+
+// Import 批量导入，已存在的词按导入内容更新，格式错误的词条跳过并在结果中返回
+func (s *SensitiveWordService) Import(items []SensitiveWordSaveDTO) (*SensitiveWordImportResult, *apperrors.AppError) {
+	result := &SensitiveWordImportResult{Errors: []SensitiveWordImportError{}}
+	words := make([]sensitive.Word, 0, len(items))
+	seen := make(map[string]bool)
+	for i, item := range items {
+		word, appErr := normalizeSensitiveWord(item)
+		if appErr == nil && seen[word.Word] {
+			appErr = apperrors.ValidationError("导入内容中重复的敏感词", nil)
+		}
+		if appErr != nil {
+			result.Errors = append(result.Errors, SensitiveWordImportError{Line: i + 1, Word: item.Word, Message: appErr.Message})
+			continue
+		}
+		seen[word.Word] = true
+		words = append(words, word)
+	}
+	if len(words) == 0 {
+		return result, nil
+	}
+
+	created, updated, err := s.repo.Import(context.Background(), words)
+	if err != nil {
+		return nil, apperrors.DatabaseError("导入敏感词", err)
+	}
+	result.Created, result.Updated = created, updated
+	s.changed()
+	return result, nil
+}
+
+// Export 导出词库，category 不为空时只导出该分类
+func (s *SensitiveWordService) Export(category string) ([]sensitive.Word, *apperrors.AppError) {
+	words, err := s.repo.All(context.Background(), category)
+	if err != nil {
+		return nil, apperrors.DatabaseError("导出敏感词", err)
+	}
+	return words, nil
+}
+
+// Reload 从数据库加载词库并替换当前实例的过滤器
+func (s *SensitiveWordService) Reload() error {
+	words, err := s.repo.All(context.Background(), "")
+	if err != nil {
+		return err
+	}
+	entries := make([]security.WordEntry, 0, len(words))
+	for _, w := range words {
+		entries = append(entries, w.Entry())
+	}
+	security.GetFilter().Load(entries)
+	return nil
+}
+
+// changed 词库变更后立即重新加载本实例，并通知其他实例重新加载
+func (s *SensitiveWordService) changed() {
+	logger.LogIf(s.Reload())
+	if redis.Redis == nil {
+		return
+	}
+	logger.LogIf(redis.Redis.Client.Publish(context.Background(), SensitiveWordReloadChannel, "reload").Err())
+}
+
+// save 校验词条唯一后保存
+func (s *SensitiveWordService) save(model *sensitive.Word, dto SensitiveWordSaveDTO) *apperrors.AppError {
+	word, appErr := normalizeSensitiveWord(dto)
+	if appErr != nil {
+		return appErr
+	}
+
+	ctx := context.Background()
+	existing, err := s.repo.GetByWord(ctx, word.Word)
+	if err != nil {
+		return apperrors.DatabaseError("获取敏感词", err)
+	}
+	if existing != nil && existing.ID != model.ID {
+		return apperrors.BusinessError(apperrors.CodeConflict, "敏感词已存在").
+			WithDetails(map[string]interface{}{"word": word.Word})
+	}
+
+	model.Word = word.Word
+	model.Category = word.Category
+	model.Severity = word.Severity
+	model.Pinyin = word.Pinyin
+	model.Note = word.Note
+	if model.ID == 0 {
+		err = s.repo.Create(ctx, model)
+	} else {
+		err = s.repo.Save(ctx, model)
+	}
+	if err != nil {
+		return apperrors.DatabaseError("保存敏感词", err)
+	}
+	s.changed()
+	return nil
+}
+
+// get 获取敏感词
+func (s *SensitiveWordService) get(id string) (*sensitive.Word, *apperrors.AppError) {
+	model, err := s.repo.GetByID(context.Background(), id)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取敏感词", err)
+	}
+	if model == nil {
+		return nil, apperrors.NotFoundError("敏感词").WithDetails(map[string]interface{}{"id": id})
+	}
+	return model, nil
+}
+
+// normalizeSensitiveWord 校验并统一词条格式，分类默认为 other，处理方式默认为替换
+func normalizeSensitiveWord(dto SensitiveWordSaveDTO) (sensitive.Word, *apperrors.AppError) {
+	word := sensitive.Word{
+		Word:     strings.TrimSpace(dto.Word),
+		Category: strings.ToLower(strings.TrimSpace(dto.Category)),
+		Severity: strings.ToLower(strings.TrimSpace(dto.Severity)),
+		Note:     strings.TrimSpace(dto.Note),
+	}
+	if word.Category == "" {
+		word.Category = "other"
+	}
+	if word.Severity == "" {
+		word.Severity = security.SeverityReplace
+	}
+
+	var pinyin []string
+	for _, item := range strings.Split(dto.Pinyin, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			pinyin = append(pinyin, item)
+		}
+	}
+	word.Pinyin = strings.Join(pinyin, ",")
+
+	switch {
+	case security.NormalizeWord(word.Word) == "":
+		return word, apperrors.ValidationError("敏感词不能为空", map[string]interface{}{"word": dto.Word})
+	case len([]rune(word.Word)) > 100:
+		return word, apperrors.ValidationError("敏感词长度不能超过 100 个字", map[string]interface{}{"word": dto.Word})
+	case len(word.Category) > 20:
+		return word, apperrors.ValidationError("分类长度不能超过 20 个字符", map[string]interface{}{"category": dto.Category})
+	case !slices.Contains(sensitive.Severities, word.Severity):
+		return word, apperrors.ValidationError(fmt.Sprintf("处理方式只能是 %s", strings.Join(sensitive.Severities, "、")), map[string]interface{}{"severity": dto.Severity})
+	case len([]rune(word.Pinyin)) > 255:
+		return word, apperrors.ValidationError("变体长度不能超过 255 个字", map[string]interface{}{"pinyin": dto.Pinyin})
+	case len([]rune(word.Note)) > 255:
+		return word, apperrors.ValidationError("备注长度不能超过 255 个字", map[string]interface{}{"note": dto.Note})
+	}
+	return word, nil
+}
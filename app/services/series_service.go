@@ -0,0 +1,318 @@
+// Package services 话题系列业务逻辑
+package services
+
+import (
+	"context"
+
+	"GoHub-Service/app/models/series"
+	"GoHub-Service/app/repositories"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+// SeriesService 话题系列服务：作者管理系列，读者浏览和关注系列
+type SeriesService struct {
+	repo      repositories.SeriesRepository
+	topicRepo repositories.TopicRepository
+	notifSvc  *NotificationService
+}
+
+// NewSeriesService 创建话题系列服务实例
+func NewSeriesService() *SeriesService {
+	return &SeriesService{
+		repo:      repositories.NewSeriesRepository(),
+		topicRepo: repositories.NewTopicRepository(),
+		notifSvc:  NewNotificationService(),
+	}
+}
+
+// SeriesSaveDTO 创建或修改系列
+type SeriesSaveDTO struct {
+	Title       string
+	Description string
+}
+
+// SeriesDetailDTO 系列页面
+type SeriesDetailDTO struct {
+	series.Series
+	Parts        []series.Part `json:"parts"`
+	FollowedByMe bool          `json:"followed_by_me"`
+}
+
+// SeriesNavDTO 话题详情中的系列导航
+type SeriesNavDTO struct {
+	ID       string       `json:"id"`
+	Title    string       `json:"title"`
+	Position int          `json:"position"` // 当前话题是第几篇，从 1 开始
+	Total    int          `json:"total"`
+	Prev     *series.Part `json:"prev,omitempty"`
+	Next     *series.Part `json:"next,omitempty"`
+}
+
+// Create 创建系列
+func (s *SeriesService) Create(userID string, dto SeriesSaveDTO) (*series.Series, *apperrors.AppError) {
+	model := &series.Series{
+		UserID:      cast.ToUint64(userID),
+		Title:       dto.Title,
+		Description: dto.Description,
+	}
+	if err := s.repo.Create(context.Background(), model); err != nil {
+		return nil, apperrors.WrapError(err, "创建系列失败")
+	}
+	return model, nil
+}
+
+// Update 修改系列标题和描述
+func (s *SeriesService) Update(id, userID string, dto SeriesSaveDTO) (*series.Series, *apperrors.AppError) {
+	model, appErr := s.getOwned(id, userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	model.Title = dto.Title
+	model.Description = dto.Description
+	if err := s.repo.UpdateColumns(context.Background(), model, "title", "description"); err != nil {
+		return nil, apperrors.DatabaseUpdateError("系列", err)
+	}
+	return model, nil
+}
+
+// Delete 删除系列，系列中的话题不受影响
+func (s *SeriesService) Delete(id, userID string) *apperrors.AppError {
+	model, appErr := s.getOwned(id, userID)
+	if appErr != nil {
+		return appErr
+	}
+	if err := s.repo.Delete(context.Background(), model.ID); err != nil {
+		return apperrors.DatabaseDeleteError("系列", err)
+	}
+	return nil
+}
+
+// Show 系列页面，作者本人可以看到未审核通过的话题，对当前用户不可见分类中的话题不返回
+func (s *SeriesService) Show(id, viewerID string) (*SeriesDetailDTO, *apperrors.AppError) {
+	model, appErr := s.get(id)
+	if appErr != nil {
+		return nil, appErr
+	}
+	isOwner := viewerID != "" && cast.ToString(model.UserID) == viewerID
+	parts, err := s.repo.Parts(context.Background(), model.ID, !isOwner, HiddenCategoryIDs(viewerID))
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取系列话题", err)
+	}
+	dto := &SeriesDetailDTO{Series: *model, Parts: parts}
+	if viewerID != "" {
+		dto.FollowedByMe, _ = s.repo.IsFollowing(context.Background(), model.ID, cast.ToUint64(viewerID))
+	}
+	return dto, nil
+}
+
+// ListByUser 作者的系列列表
+func (s *SeriesService) ListByUser(c *gin.Context, userID string, perPage int) ([]series.Series, *paginator.Paging, *apperrors.AppError) {
+	data, paging, err := s.repo.ListByUser(context.Background(), c, userID, perPage)
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取系列列表", err)
+	}
+	return data, paging, nil
+}
+
+// AddTopic 将作者自己的话题追加到系列末尾，已审核通过的话题会通知系列的关注者
+func (s *SeriesService) AddTopic(id, userID, topicID string) *apperrors.AppError {
+	model, appErr := s.getOwned(id, userID)
+	if appErr != nil {
+		return appErr
+	}
+	ctx := context.Background()
+	topicModel, err := s.topicRepo.GetByID(ctx, topicID)
+	if err != nil {
+		return apperrors.WrapError(err, "获取话题失败")
+	}
+	if topicModel == nil {
+		return apperrors.NotFoundError("话题").WithDetails(map[string]interface{}{"topic_id": topicID})
+	}
+	if topicModel.UserID != userID {
+		return apperrors.AuthorizationError("只能将自己的话题加入系列")
+	}
+	currentID, err := s.repo.SeriesIDOfTopic(ctx, topicID)
+	if err != nil {
+		return apperrors.DatabaseError("获取话题所属系列", err)
+	}
+	if currentID != 0 {
+		return apperrors.BusinessError(apperrors.CodeConflict, "话题已属于一个系列").
+			WithDetails(map[string]interface{}{"series_id": currentID})
+	}
+
+	if err := s.repo.AddTopic(ctx, model.ID, topicModel.ID); err != nil {
+		return apperrors.DatabaseError("加入系列", err)
+	}
+	if topicModel.IsApproved() {
+		s.NotifyNewPart(topicID)
+	}
+	return nil
+}
+
+// RemoveTopic 将话题移出系列
+func (s *SeriesService) RemoveTopic(id, userID, topicID string) *apperrors.AppError {
+	model, appErr := s.getOwned(id, userID)
+	if appErr != nil {
+		return appErr
+	}
+	removed, err := s.repo.RemoveTopic(context.Background(), model.ID, cast.ToUint64(topicID))
+	if err != nil {
+		return apperrors.DatabaseDeleteError("系列中的话题", err)
+	}
+	if !removed {
+		return apperrors.NotFoundError("系列中的话题")
+	}
+	return nil
+}
+
+// Reorder 按给定顺序排列系列中的话题
+func (s *SeriesService) Reorder(id, userID string, topicIDs []string) *apperrors.AppError {
+	model, appErr := s.getOwned(id, userID)
+	if appErr != nil {
+		return appErr
+	}
+	ids := make([]uint64, 0, len(topicIDs))
+	seen := make(map[uint64]bool, len(topicIDs))
+	for _, topicID := range topicIDs {
+		tid := cast.ToUint64(topicID)
+		if tid == 0 || seen[tid] {
+			return apperrors.ValidationError("话题ID列表无效", map[string]interface{}{"topic_ids": topicIDs})
+		}
+		seen[tid] = true
+		ids = append(ids, tid)
+	}
+	if err := s.repo.Reorder(context.Background(), model.ID, ids); err != nil {
+		return apperrors.DatabaseUpdateError("系列排序", err)
+	}
+	return nil
+}
+
+// Follow 关注系列
+func (s *SeriesService) Follow(id, userID string) *apperrors.AppError {
+	model, appErr := s.get(id)
+	if appErr != nil {
+		return appErr
+	}
+	if cast.ToString(model.UserID) == userID {
+		return apperrors.ValidationError("不能关注自己的系列", map[string]interface{}{"series_id": id})
+	}
+	if _, err := s.repo.Follow(context.Background(), model.ID, cast.ToUint64(userID)); err != nil {
+		return apperrors.DatabaseError("关注系列", err)
+	}
+	return nil
+}
+
+// Unfollow 取消关注系列
+func (s *SeriesService) Unfollow(id, userID string) *apperrors.AppError {
+	if _, err := s.repo.Unfollow(context.Background(), cast.ToUint64(id), cast.ToUint64(userID)); err != nil {
+		return apperrors.DatabaseError("取消关注系列", err)
+	}
+	return nil
+}
+
+// Navigation 话题所在系列的上一篇和下一篇，只在已审核通过且对当前用户可见的话题之间导航
+// 话题不属于任何系列或查询失败时返回 nil
+func (s *SeriesService) Navigation(topicID, viewerID string) *SeriesNavDTO {
+	ctx := context.Background()
+	seriesID, err := s.repo.SeriesIDOfTopic(ctx, topicID)
+	if err != nil || seriesID == 0 {
+		logger.LogIf(err)
+		return nil
+	}
+	model, appErr := s.get(cast.ToString(seriesID))
+	if appErr != nil {
+		return nil
+	}
+	parts, err := s.repo.Parts(ctx, seriesID, true, HiddenCategoryIDs(viewerID))
+	if err != nil {
+		logger.LogIf(err)
+		return nil
+	}
+
+	nav := &SeriesNavDTO{ID: model.GetStringID(), Title: model.Title, Total: len(parts)}
+	id := cast.ToUint64(topicID)
+	for i := range parts {
+		if parts[i].TopicID != id {
+			continue
+		}
+		nav.Position = i + 1
+		if i > 0 {
+			nav.Prev = &parts[i-1]
+		}
+		if i < len(parts)-1 {
+			nav.Next = &parts[i+1]
+		}
+		break
+	}
+	return nav
+}
+
+// NotifyNewPart 通知系列的关注者有新的话题，话题加入系列或审核通过时调用
+// 无权查看话题所在分类的关注者不会收到通知
+func (s *SeriesService) NotifyNewPart(topicID string) {
+	if s.notifSvc == nil {
+		return
+	}
+	ctx := context.Background()
+	seriesID, err := s.repo.SeriesIDOfTopic(ctx, topicID)
+	if err != nil || seriesID == 0 {
+		logger.LogIf(err)
+		return
+	}
+	model, appErr := s.get(cast.ToString(seriesID))
+	if appErr != nil {
+		return
+	}
+	followerIDs, err := s.repo.FollowerIDs(ctx, seriesID)
+	if err != nil {
+		logger.LogIf(err)
+		return
+	}
+	topicModel, err := s.topicRepo.GetByID(ctx, topicID)
+	if err != nil || topicModel == nil {
+		return
+	}
+
+	data := map[string]interface{}{
+		"series_id":    model.GetStringID(),
+		"series_title": model.Title,
+		"topic_id":     topicID,
+		"title":        topicModel.Title,
+	}
+	authorID := cast.ToString(model.UserID)
+	for _, followerID := range followerIDs {
+		if !CanViewCategory(cast.ToString(followerID), topicModel.CategoryID) {
+			continue
+		}
+		_ = s.notifSvc.Notify(cast.ToString(followerID), authorID, "series_new_part", data)
+	}
+}
+
+// get 获取系列
+func (s *SeriesService) get(id string) (*series.Series, *apperrors.AppError) {
+	model, err := s.repo.GetByID(context.Background(), id)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取系列", err)
+	}
+	if model == nil {
+		return nil, apperrors.NotFoundError("系列").WithDetails(map[string]interface{}{"series_id": id})
+	}
+	return model, nil
+}
+
+// getOwned 获取当前用户创建的系列
+func (s *SeriesService) getOwned(id, userID string) (*series.Series, *apperrors.AppError) {
+	model, appErr := s.get(id)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if cast.ToString(model.UserID) != userID {
+		return nil, apperrors.AuthorizationError("只能管理自己的系列")
+	}
+	return model, nil
+}
@@ -0,0 +1,383 @@
+// Package services 垃圾信息检测
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"GoHub-Service/app/models/role"
+	"GoHub-Service/app/models/spam"
+	"GoHub-Service/app/repositories"
+	"GoHub-Service/pkg/config"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/moderator"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+// spamCandidateLimit 每次最多比较的近似内容候选数
+const spamCandidateLimit = 200
+
+// spamSettingsTTL 后台设置在各实例的缓存时间，修改后本实例立即生效
+const spamSettingsTTL = time.Minute
+
+var (
+	spamSettingsMu      sync.RWMutex
+	spamSettingsCache   *spam.Settings
+	spamSettingsExpires time.Time
+)
+
+// SpamCheck 待检查的话题或评论，ID 为空表示新发布
+type SpamCheck struct {
+	Type   string
+	ID     string
+	UserID string
+	Text   string
+}
+
+// SpamVerdict 检查结论和内容指纹，发布成功后通过 Record 保存
+type SpamVerdict struct {
+	moderator.Result
+	SimHash uint64
+	Length  int
+}
+
+// SpamSettingsDTO 修改检测阈值，未提交的字段保持不变
+type SpamSettingsDTO struct {
+	Enabled               *bool    `json:"enabled"`
+	NewAccountDays        *int     `json:"new_account_days"`
+	VelocityWindowMinutes *int     `json:"velocity_window_minutes"`
+	NewUserMaxTopics      *int     `json:"new_user_max_topics"`
+	NewUserMaxComments    *int     `json:"new_user_max_comments"`
+	MaxTopics             *int     `json:"max_topics"`
+	MaxComments           *int     `json:"max_comments"`
+	DuplicateDistance     *int     `json:"duplicate_distance"`
+	DuplicateWindowHours  *int     `json:"duplicate_window_hours"`
+	DuplicateMinLength    *int     `json:"duplicate_min_length"`
+	MaxLinks              *int     `json:"max_links"`
+	MaxLinkDensity        *float64 `json:"max_link_density"`
+	ReviewScore           *float64 `json:"review_score"`
+	RejectScore           *float64 `json:"reject_score"`
+}
+
+// SpamService 垃圾信息检测服务
+// 发布前检查发布频率和垃圾信息分数：超过频率限制或分数达到拒绝阈值时拒绝发布并记录，
+// 分数达到审核阈值时允许发布，发布后进入人工审核
+type SpamService struct {
+	repo     repositories.SpamRepository
+	userRepo repositories.UserRepository
+}
+
+// NewSpamService 创建垃圾信息检测服务实例
+func NewSpamService() *SpamService {
+	return &SpamService{
+		repo:     repositories.NewSpamRepository(),
+		userRepo: repositories.NewUserRepository(),
+	}
+}
+
+// Check 发布前检查，被拒绝时返回错误
+// 管理员和版主不做检查，但仍计算指纹，供检查其他用户的近似内容
+func (s *SpamService) Check(ctx context.Context, in SpamCheck) (*SpamVerdict, *apperrors.AppError) {
+	verdict := &SpamVerdict{
+		Result:  moderator.Allow(),
+		SimHash: moderator.SimHash(in.Text),
+		Length:  moderator.NormalizedLength(in.Text),
+	}
+	settings := s.Settings(ctx)
+	if !settings.Enabled || in.UserID == "" || role.UserHasAnyRole(in.UserID, "admin", "moderator") {
+		return verdict, nil
+	}
+
+	newAccount := false
+	if author, err := s.userRepo.GetByID(in.UserID); err == nil && author != nil && settings.NewAccountDays > 0 {
+		newAccount = time.Since(author.CreatedAt) < time.Duration(settings.NewAccountDays)*24*time.Hour
+	}
+
+	if in.ID == "" {
+		if appErr := s.checkVelocity(ctx, in, settings, newAccount); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	signals := moderator.SpamSignals{NewAccount: newAccount}
+	signals.Links, signals.LinkDensity = moderator.LinkDensity(in.Text)
+	if settings.DuplicateWindowHours > 0 && verdict.Length >= settings.DuplicateMinLength {
+		since := time.Now().Add(-time.Duration(settings.DuplicateWindowHours) * time.Hour)
+		candidates, err := s.repo.Candidates(ctx, moderator.SplitSimHash(verdict.SimHash), since, spamCandidateLimit)
+		if err != nil {
+			// 查找失败时不影响发布
+			logger.LogIf(err)
+		}
+		authors := make(map[uint64]bool)
+		userID := cast.ToUint64(in.UserID)
+		for _, c := range candidates {
+			if c.ContentType == in.Type && cast.ToString(c.ContentID) == in.ID {
+				continue
+			}
+			if moderator.HammingDistance(c.SimHash, verdict.SimHash) > settings.DuplicateDistance {
+				continue
+			}
+			if c.UserID == userID {
+				signals.OwnDuplicates++
+			} else {
+				authors[c.UserID] = true
+			}
+		}
+		signals.OtherAuthors = len(authors)
+	}
+
+	verdict.Result = moderator.ScoreSpam(signals, moderator.SpamThresholds{
+		MaxLinks:       settings.MaxLinks,
+		MaxLinkDensity: settings.MaxLinkDensity,
+		ReviewScore:    settings.ReviewScore,
+		RejectScore:    settings.RejectScore,
+	})
+	verdict.Stage = "spam"
+	if verdict.Verdict == moderator.VerdictReject {
+		s.recordAttempt(ctx, in, spam.ActionBlocked, spam.ReasonSpam, verdict.Score, verdict.Reasons)
+		return nil, apperrors.BusinessError(apperrors.CodeSpamDetected, "内容疑似垃圾信息，发布失败").
+			WithDetails(map[string]interface{}{"reasons": verdict.Reasons})
+	}
+	return verdict, nil
+}
+
+// Record 内容发布或修改成功后保存指纹，需要审核的内容提交到审核队列
+func (s *SpamService) Record(ctx context.Context, in SpamCheck, verdict *SpamVerdict) {
+	if verdict == nil {
+		return
+	}
+	bands := moderator.SplitSimHash(verdict.SimHash)
+	logger.LogIf(s.repo.SaveFingerprint(ctx, &spam.Fingerprint{
+		ContentType: in.Type,
+		ContentID:   cast.ToUint64(in.ID),
+		UserID:      cast.ToUint64(in.UserID),
+		SimHash:     verdict.SimHash,
+		Band0:       bands[0],
+		Band1:       bands[1],
+		Band2:       bands[2],
+		Band3:       bands[3],
+		Length:      verdict.Length,
+	}))
+	if verdict.Verdict != moderator.VerdictReview {
+		return
+	}
+
+	s.recordAttempt(ctx, in, spam.ActionReview, spam.ReasonSpam, verdict.Score, verdict.Reasons)
+	NewContentModerationService().Apply(ctx, moderator.Content{
+		Type:     in.Type,
+		ID:       in.ID,
+		AuthorID: in.UserID,
+		Body:     in.Text,
+	}, moderator.Decision{
+		Verdict: verdict.Verdict,
+		Score:   verdict.Score,
+		Reasons: verdict.Reasons,
+		Results: []moderator.Result{verdict.Result},
+	})
+}
+
+// checkVelocity 统计窗口内的发布数，新用户和普通用户分别限制
+func (s *SpamService) checkVelocity(ctx context.Context, in SpamCheck, settings spam.Settings, newAccount bool) *apperrors.AppError {
+	limit := settings.MaxPosts(in.Type, newAccount)
+	if limit <= 0 || settings.VelocityWindowMinutes <= 0 {
+		return nil
+	}
+	window := time.Duration(settings.VelocityWindowMinutes) * time.Minute
+	count, err := s.repo.CountRecent(ctx, cast.ToUint64(in.UserID), in.Type, time.Now().Add(-window))
+	if err != nil {
+		logger.LogIf(err)
+		return nil
+	}
+	if count < int64(limit) {
+		return nil
+	}
+
+	reason := fmt.Sprintf("%d 分钟内已发布 %d 条，上限为 %d 条", settings.VelocityWindowMinutes, count, limit)
+	if newAccount {
+		reason = "新注册用户" + reason
+	}
+	s.recordAttempt(ctx, in, spam.ActionBlocked, spam.ReasonVelocity, 0, []string{reason})
+	return apperrors.BusinessError(apperrors.CodePostingTooFrequent, "发布过于频繁，请稍后再试").
+		WithDetails(map[string]interface{}{
+			"window_minutes": settings.VelocityWindowMinutes,
+			"limit":          limit,
+		})
+}
+
+// recordAttempt 记录拒绝发布或送审的内容
+func (s *SpamService) recordAttempt(ctx context.Context, in SpamCheck, action, reason string, score float64, reasons []string) {
+	detail := strings.Join(reasons, "；")
+	if len([]rune(detail)) > 500 {
+		detail = string([]rune(detail)[:500])
+	}
+	excerpt := strings.TrimSpace(in.Text)
+	if len([]rune(excerpt)) > 200 {
+		excerpt = string([]rune(excerpt)[:200])
+	}
+	logger.LogIf(s.repo.CreateAttempt(ctx, &spam.Attempt{
+		UserID:      cast.ToUint64(in.UserID),
+		ContentType: in.Type,
+		ContentID:   cast.ToUint64(in.ID),
+		Action:      action,
+		Reason:      reason,
+		Score:       score,
+		Detail:      detail,
+		Excerpt:     excerpt,
+	}))
+}
+
+// Settings 当前生效的检测阈值，后台未设置时使用配置中的默认值
+func (s *SpamService) Settings(ctx context.Context) spam.Settings {
+	spamSettingsMu.RLock()
+	cached, expires := spamSettingsCache, spamSettingsExpires
+	spamSettingsMu.RUnlock()
+	if cached != nil && time.Now().Before(expires) {
+		return *cached
+	}
+
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		logger.LogIf(err)
+	}
+	if settings == nil {
+		settings = defaultSpamSettings()
+	}
+	cacheSpamSettings(settings)
+	return *settings
+}
+
+// UpdateSettings 修改检测阈值
+func (s *SpamService) UpdateSettings(ctx context.Context, dto SpamSettingsDTO, adminID string) (*spam.Settings, *apperrors.AppError) {
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取垃圾信息检测设置", err)
+	}
+	if settings == nil {
+		settings = defaultSpamSettings()
+	}
+
+	assign := func(dst *int, src *int) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	if dto.Enabled != nil {
+		settings.Enabled = *dto.Enabled
+	}
+	assign(&settings.NewAccountDays, dto.NewAccountDays)
+	assign(&settings.VelocityWindowMinutes, dto.VelocityWindowMinutes)
+	assign(&settings.NewUserMaxTopics, dto.NewUserMaxTopics)
+	assign(&settings.NewUserMaxComments, dto.NewUserMaxComments)
+	assign(&settings.MaxTopics, dto.MaxTopics)
+	assign(&settings.MaxComments, dto.MaxComments)
+	assign(&settings.DuplicateDistance, dto.DuplicateDistance)
+	assign(&settings.DuplicateWindowHours, dto.DuplicateWindowHours)
+	assign(&settings.DuplicateMinLength, dto.DuplicateMinLength)
+	assign(&settings.MaxLinks, dto.MaxLinks)
+	if dto.MaxLinkDensity != nil {
+		settings.MaxLinkDensity = *dto.MaxLinkDensity
+	}
+	if dto.ReviewScore != nil {
+		settings.ReviewScore = *dto.ReviewScore
+	}
+	if dto.RejectScore != nil {
+		settings.RejectScore = *dto.RejectScore
+	}
+	if appErr := validateSpamSettings(settings); appErr != nil {
+		return nil, appErr
+	}
+
+	settings.UpdatedBy = cast.ToUint64(adminID)
+	if err := s.repo.SaveSettings(ctx, settings); err != nil {
+		return nil, apperrors.DatabaseError("保存垃圾信息检测设置", err)
+	}
+	cacheSpamSettings(settings)
+	return settings, nil
+}
+
+// Attempts 后台拦截记录列表
+func (s *SpamService) Attempts(c *gin.Context, perPage int) ([]spam.Attempt, *paginator.Paging, *apperrors.AppError) {
+	attempts, paging, err := s.repo.Attempts(context.Background(), c, perPage)
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取拦截记录", err)
+	}
+	return attempts, paging, nil
+}
+
+// Stats 最近 days 天的拦截统计
+func (s *SpamService) Stats(ctx context.Context, days int) ([]spam.AttemptStat, *apperrors.AppError) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	stats, err := s.repo.AttemptStats(ctx, today.AddDate(0, 0, 1-days))
+	if err != nil {
+		return nil, apperrors.DatabaseError("获取拦截统计", err)
+	}
+	return stats, nil
+}
+
+// defaultSpamSettings 配置中的默认阈值
+func defaultSpamSettings() *spam.Settings {
+	return &spam.Settings{
+		Enabled:               config.GetBool("spam.enabled"),
+		NewAccountDays:        config.GetInt("spam.new_account_days", 7),
+		VelocityWindowMinutes: config.GetInt("spam.velocity_window_minutes", 60),
+		NewUserMaxTopics:      config.GetInt("spam.new_user_max_topics", 3),
+		NewUserMaxComments:    config.GetInt("spam.new_user_max_comments", 20),
+		MaxTopics:             config.GetInt("spam.max_topics", 10),
+		MaxComments:           config.GetInt("spam.max_comments", 60),
+		DuplicateDistance:     config.GetInt("spam.duplicate_distance", 3),
+		DuplicateWindowHours:  config.GetInt("spam.duplicate_window_hours", 72),
+		DuplicateMinLength:    config.GetInt("spam.duplicate_min_length", 20),
+		MaxLinks:              config.GetInt("spam.max_links", 5),
+		MaxLinkDensity:        config.GetFloat64("spam.max_link_density", 0.5),
+		ReviewScore:           config.GetFloat64("spam.review_score", 0.4),
+		RejectScore:           config.GetFloat64("spam.reject_score", 0.8),
+	}
+}
+
+// cacheSpamSettings 缓存检测阈值
+func cacheSpamSettings(settings *spam.Settings) {
+	copied := *settings
+	spamSettingsMu.Lock()
+	defer spamSettingsMu.Unlock()
+	spamSettingsCache = &copied
+	spamSettingsExpires = time.Now().Add(spamSettingsTTL)
+}
+
+// validateSpamSettings 校验阈值范围
+// 指纹分为 4 段，汉明距离超过 3 时按段查找会漏掉候选，因此最大为 3
+func validateSpamSettings(s *spam.Settings) *apperrors.AppError {
+	for field, value := range map[string]int{
+		"new_account_days":        s.NewAccountDays,
+		"velocity_window_minutes": s.VelocityWindowMinutes,
+		"new_user_max_topics":     s.NewUserMaxTopics,
+		"new_user_max_comments":   s.NewUserMaxComments,
+		"max_topics":              s.MaxTopics,
+		"max_comments":            s.MaxComments,
+		"duplicate_window_hours":  s.DuplicateWindowHours,
+		"duplicate_min_length":    s.DuplicateMinLength,
+		"max_links":               s.MaxLinks,
+	} {
+		if value < 0 {
+			return apperrors.ValidationError("阈值不能为负数", map[string]interface{}{field: value})
+		}
+	}
+	switch {
+	case s.DuplicateDistance < 0 || s.DuplicateDistance >= moderator.SimHashBands:
+		return apperrors.ValidationError(fmt.Sprintf("近似内容的汉明距离只能在 0 到 %d 之间", moderator.SimHashBands-1), map[string]interface{}{"duplicate_distance": s.DuplicateDistance})
+	case s.MaxLinkDensity < 0 || s.MaxLinkDensity > 1:
+		return apperrors.ValidationError("链接占比只能在 0 到 1 之间", map[string]interface{}{"max_link_density": s.MaxLinkDensity})
+	case s.ReviewScore < 0 || s.ReviewScore > 1 || s.RejectScore < 0 || s.RejectScore > 1:
+		return apperrors.ValidationError("分数只能在 0 到 1 之间", map[string]interface{}{"review_score": s.ReviewScore, "reject_score": s.RejectScore})
+	case s.ReviewScore > 0 && s.RejectScore > 0 && s.ReviewScore > s.RejectScore:
+		return apperrors.ValidationError("审核分数不能高于拒绝分数", map[string]interface{}{"review_score": s.ReviewScore, "reject_score": s.RejectScore})
+	}
+	return nil
+}
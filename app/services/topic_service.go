@@ -3,80 +3,176 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"GoHub-Service/app/cache"
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/mention"
+	"GoHub-Service/app/models/spam"
+	"GoHub-Service/app/models/tag"
 	"GoHub-Service/app/models/topic"
 	"GoHub-Service/app/repositories"
+	"GoHub-Service/pkg/elasticsearch"
 	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/logger"
 	"GoHub-Service/pkg/mapper"
+	"GoHub-Service/pkg/moderator"
 	"GoHub-Service/pkg/paginator"
 	"GoHub-Service/pkg/singleflight"
 
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
 )
 
 // TopicService Topic服务
 type TopicService struct {
-	repo    repositories.TopicRepository
-	cache   *cache.TopicCache
-	sfGroup singleflight.Group                           // singleflight 防止缓存击穿
-	mapper  mapper.Mapper[topic.Topic, TopicResponseDTO] // 使用泛型Mapper消除DTO转换重复
+	repo          repositories.TopicRepository
+	categoryRepo  repositories.CategoryRepository
+	cache         *cache.TopicCache
+	mentionSvc    *MentionService
+	feedSvc       *FeedService
+	spamSvc       *SpamService
+	moderationSvc *ModerationService
+	sfGroup       singleflight.Group                           // singleflight 防止缓存击穿
+	mapper        mapper.Mapper[topic.Topic, TopicResponseDTO] // 使用泛型Mapper消除DTO转换重复
 }
 
 // NewTopicService 创建Topic服务实例
 func NewTopicService() *TopicService {
-	// 定义DTO转换函数（只需一次）
-	converter := func(t *topic.Topic) *TopicResponseDTO {
-		return &TopicResponseDTO{
-			ID:            t.GetStringID(),
-			Title:         t.Title,
-			Body:          t.Body,
-			CategoryID:    t.CategoryID,
-			UserID:        t.UserID,
-			LikeCount:     t.LikeCount,
-			FavoriteCount: t.FavoriteCount,
-			ViewCount:     t.ViewCount,
-			CreatedAt:     t.CreatedAt,
-			UpdatedAt:     t.UpdatedAt,
-		}
+	return &TopicService{
+		repo:          repositories.NewTopicRepository(),
+		categoryRepo:  repositories.NewCategoryRepository(),
+		cache:         cache.NewTopicCache(),
+		mentionSvc:    NewMentionService(),
+		feedSvc:       NewFeedService(),
+		spamSvc:       NewSpamService(),
+		moderationSvc: NewModerationService(),
+		mapper:        mapper.NewSimpleMapper(topicResponseDTO),
 	}
+}
 
-	return &TopicService{
-		repo:   repositories.NewTopicRepository(),
-		cache:  cache.NewTopicCache(),
-		mapper: mapper.NewSimpleMapper(converter),
+// topicResponseDTO 将话题模型转换为响应DTO，TopicService 的 Mapper 和信息流共用
+func topicResponseDTO(t *topic.Topic) *TopicResponseDTO {
+	return &TopicResponseDTO{
+		ID:                t.GetStringID(),
+		Title:             t.Title,
+		Body:              t.Body,
+		CategoryID:        t.CategoryID,
+		Breadcrumbs:       CategoryBreadcrumbs(t.CategoryID),
+		UserID:            t.UserID,
+		LikeCount:         t.LikeCount,
+		FavoriteCount:     t.FavoriteCount,
+		ViewCount:         t.ViewCount,
+		IsPinned:          t.IsPinned,
+		Status:            t.Status,
+		PendingReason:     t.PendingReason,
+		RejectReason:      t.RejectReason,
+		State:             t.State(),
+		IsLocked:          t.IsLocked,
+		ClosedAt:          t.ClosedAt,
+		CloseReason:       t.CloseReason,
+		ArchivedAt:        t.ArchivedAt,
+		Solved:            t.IsSolved(),
+		AcceptedCommentID: acceptedCommentID(t),
+		SolvedAt:          t.SolvedAt,
+		Tags:              tagNames(t.Tags),
+		LastRepliedAt:     t.LastRepliedAt,
+		CreatedAt:         t.CreatedAt,
+		UpdatedAt:         t.UpdatedAt,
 	}
 }
 
 // TopicCreateDTO 创建话题DTO
 type TopicCreateDTO struct {
-	Title      string `json:"title" binding:"required,min=3,max=255"`
-	Body       string `json:"body" binding:"required"`
-	CategoryID string `json:"category_id" binding:"required"`
-	UserID     string `json:"user_id" binding:"required"`
+	Title      string   `json:"title" binding:"required,min=3,max=255"`
+	Body       string   `json:"body" binding:"required"`
+	CategoryID string   `json:"category_id" binding:"required"`
+	UserID     string   `json:"user_id" binding:"required"`
+	Tags       []string `json:"tags,omitempty"`
 }
 
 // TopicUpdateDTO 更新话题DTO
 type TopicUpdateDTO struct {
-	Title      *string `json:"title,omitempty" binding:"omitempty,min=3,max=255"`
-	Body       *string `json:"body,omitempty"`
-	CategoryID *string `json:"category_id,omitempty"`
+	Title      *string   `json:"title,omitempty" binding:"omitempty,min=3,max=255"`
+	Body       *string   `json:"body,omitempty"`
+	CategoryID *string   `json:"category_id,omitempty"`
+	Tags       *[]string `json:"tags,omitempty"`
+}
+
+// TopicListQueryDTO 话题列表筛选DTO
+type TopicListQueryDTO struct {
+	CategoryID  string
+	Subtree     bool // 同时包含子分类下的话题
+	UserID      string
+	Tag         string
+	Status      string
+	Solved      string
+	StartDate   string
+	EndDate     string
+	SortBy      string
+	PinnedFirst bool
+	ViewerID    string // 当前登录用户，作者本人可以看到自己未通过审核的话题
+}
+
+// toFilter 转换为模型层的筛选条件，标签名与存储时一致做规范化
+func (q TopicListQueryDTO) toFilter() topic.ListFilter {
+	tagName := ""
+	if names := tag.NormalizeNames([]string{q.Tag}); len(names) > 0 {
+		tagName = names[0]
+	}
+	// 只有查看自己的话题时才能看到待审核和被拒绝的话题
+	status := q.Status
+	if q.UserID == "" || q.UserID != q.ViewerID {
+		status = cast.ToString(topic.StatusApproved)
+	}
+	return topic.ListFilter{
+		CategoryID:  q.CategoryID,
+		Subtree:     q.Subtree,
+		UserID:      q.UserID,
+		Tag:         tagName,
+		Status:      status,
+		Solved:      q.Solved,
+		StartDate:   q.StartDate,
+		EndDate:     q.EndDate,
+		SortBy:      q.SortBy,
+		PinnedFirst: q.PinnedFirst,
+
+		HiddenCategoryIDs: HiddenCategoryIDs(q.ViewerID),
+	}
 }
 
 // TopicResponseDTO 话题响应DTO
 type TopicResponseDTO struct {
-	ID         string    `json:"id"`
-	Title      string    `json:"title"`
-	Body       string    `json:"body"`
-	CategoryID string    `json:"category_id"`
-	UserID     string    `json:"user_id"`
-	LikeCount     int64     `json:"like_count"`
-	FavoriteCount int64     `json:"favorite_count"`
-	ViewCount     int64     `json:"view_count"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID                string              `json:"id"`
+	Title             string              `json:"title"`
+	Body              string              `json:"body"`
+	CategoryID        string              `json:"category_id"`
+	Breadcrumbs       []CategoryCrumbDTO  `json:"breadcrumbs"` // 从顶级分类到所属分类的路径
+	UserID            string              `json:"user_id"`
+	LikeCount         int64               `json:"like_count"`
+	FavoriteCount     int64               `json:"favorite_count"`
+	ViewCount         int64               `json:"view_count"`
+	IsPinned          bool                `json:"is_pinned"`
+	Status            int                 `json:"status"`
+	PendingReason     string              `json:"pending_reason,omitempty"`
+	RejectReason      string              `json:"reject_reason,omitempty"`
+	State             string              `json:"state"` // open|locked|closed|archived，非 open 时不能评论
+	IsLocked          bool                `json:"is_locked"`
+	ClosedAt          *time.Time          `json:"closed_at,omitempty"`
+	CloseReason       string              `json:"close_reason,omitempty"`
+	ArchivedAt        *time.Time          `json:"archived_at,omitempty"`
+	Solved            bool                `json:"solved"`                        // 问答话题是否已采纳回答
+	AcceptedCommentID string              `json:"accepted_comment_id,omitempty"` // 被采纳的回答
+	SolvedAt          *time.Time          `json:"solved_at,omitempty"`
+	Tags              []string            `json:"tags"`
+	LastRepliedAt     *time.Time          `json:"last_replied_at,omitempty"`
+	Series            *SeriesNavDTO       `json:"series,omitempty"`    // 所属系列及上一篇/下一篇，仅详情返回
+	Mentions          []MentionDTO        `json:"mentions,omitempty"`  // 正文中 @ 的用户，仅详情和创建、修改返回
+	Reactions         *ReactionSummaryDTO `json:"reactions,omitempty"` // 表情回应，仅详情返回
+	CreatedAt         time.Time           `json:"created_at"`
+	UpdatedAt         time.Time           `json:"updated_at"`
 }
 
 // TopicListResponseDTO 话题列表响应DTO
@@ -85,6 +181,29 @@ type TopicListResponseDTO struct {
 	Paging *paginator.Paging  `json:"paging"`
 }
 
+// TopicCursorListResponseDTO 话题列表（游标分页）响应DTO
+type TopicCursorListResponseDTO struct {
+	Topics []TopicResponseDTO      `json:"topics"`
+	Paging *paginator.CursorPaging `json:"paging"`
+}
+
+// acceptedCommentID 被采纳回答的ID，未采纳时为空
+func acceptedCommentID(t *topic.Topic) string {
+	if !t.IsSolved() {
+		return ""
+	}
+	return cast.ToString(t.AcceptedCommentID)
+}
+
+// tagNames 提取标签名称列表
+func tagNames(tags []tag.Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
 // toResponseDTO 使用Mapper将Topic模型转换为响应DTO
 // 优化：使用泛型Mapper消除重复代码
 func (s *TopicService) toResponseDTO(t *topic.Topic) *TopicResponseDTO {
@@ -100,7 +219,7 @@ func (s *TopicService) toResponseDTOList(topics []topic.Topic) []TopicResponseDT
 // GetByID 根据ID获取话题（使用 singleflight 防止缓存击穿）
 func (s *TopicService) GetByID(id string) (*TopicResponseDTO, *apperrors.AppError) {
 	key := fmt.Sprintf("topic:%s", id)
-	
+
 	result, err := s.sfGroup.Do(key, func() (interface{}, error) {
 		// 尝试从缓存获取
 		if s.cache != nil {
@@ -118,45 +237,161 @@ func (s *TopicService) GetByID(id string) (*TopicResponseDTO, *apperrors.AppErro
 		if topicModel == nil {
 			return nil, apperrors.NotFoundError("话题").WithDetails(map[string]interface{}{"topic_id": id})
 		}
-		
+
 		// 更新缓存
 		if s.cache != nil {
 			s.cache.Set(context.Background(), topicModel)
 		}
-		
+
 		return topicModel, nil
 	})
-	
+
 	if err != nil {
 		if appErr, ok := err.(*apperrors.AppError); ok {
 			return nil, appErr
 		}
 		return nil, apperrors.WrapError(err, "获取话题失败")
 	}
-	
+
 	topicModel := result.(*topic.Topic)
 	return s.toResponseDTO(topicModel), nil
 }
 
-// List 获取话题列表
-func (s *TopicService) List(c *gin.Context, perPage int) (*TopicListResponseDTO, *apperrors.AppError) {
+// MergedInto 已被合并的话题返回目标话题 ID，未合并时返回空字符串
+func (s *TopicService) MergedInto(id string) string {
+	targetID, err := s.repo.MergedInto(context.Background(), id)
+	if err != nil || targetID == 0 {
+		return ""
+	}
+	return cast.ToString(targetID)
+}
+
+// GetVisible 获取对当前用户可见的话题，未通过审核的话题只有作者、管理员和所在分类的版主可见
+func (s *TopicService) GetVisible(id, viewerID string) (*TopicResponseDTO, *apperrors.AppError) {
+	dto, appErr := s.GetByID(id)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if dto.Status != topic.StatusApproved && !canViewUnapproved(dto.UserID, dto.CategoryID, viewerID) {
+		return nil, apperrors.NotFoundError("话题").WithDetails(map[string]interface{}{"topic_id": id})
+	}
+	// 无权查看的分类与话题不存在一样处理，不暴露受限话题的存在
+	if !CanViewCategory(viewerID, dto.CategoryID) {
+		return nil, apperrors.NotFoundError("话题").WithDetails(map[string]interface{}{"topic_id": id})
+	}
+	return dto, nil
+}
+
+// canViewUnapproved 用户能否查看待审核或被拒绝的话题及其评论：作者本人、管理员和能管理话题所在分类的版主
+func canViewUnapproved(authorID, categoryID, viewerID string) bool {
+	if viewerID == "" {
+		return false
+	}
+	return authorID == viewerID || category.UserCanModerate(viewerID, categoryID)
+}
+
+// RelatedTopicsMax 相关话题最多返回的数量，缓存中始终保存这么多条
+const RelatedTopicsMax = 20
+
+// Related 获取相关话题
+// 启用 Elasticsearch 时使用 more_like_this 按内容相似度查询，未启用或查询失败时
+// 按共同标签、共同点赞用户和同一分类计算；结果按话题缓存，话题修改时失效
+func (s *TopicService) Related(id, viewerID string, limit int) ([]TopicResponseDTO, *apperrors.AppError) {
+	if _, appErr := s.GetVisible(id, viewerID); appErr != nil {
+		return nil, appErr
+	}
+	if limit <= 0 || limit > RelatedTopicsMax {
+		limit = RelatedTopicsMax
+	}
+
+	ctx := context.Background()
+	var related []topic.Topic
+	found := false
+	if s.cache != nil {
+		related, found = s.cache.GetRelated(ctx, id)
+	}
+	if !found {
+		var err error
+		related, err = s.findRelated(ctx, id)
+		if err != nil {
+			return nil, apperrors.DatabaseError("获取相关话题", err)
+		}
+		if s.cache != nil {
+			s.cache.SetRelated(ctx, id, related)
+		}
+	}
+
+	// 缓存的相关话题与用户无关，返回前去掉不可见分类的话题
+	if hidden := HiddenCategoryIDs(viewerID); len(hidden) > 0 {
+		related = excludeCategories(related, hidden)
+	}
+	if len(related) > limit {
+		related = related[:limit]
+	}
+	return s.toResponseDTOList(related), nil
+}
+
+// excludeCategories 去掉属于指定分类的话题
+func excludeCategories(topics []topic.Topic, categoryIDs []uint64) []topic.Topic {
+	hidden := make(map[string]bool, len(categoryIDs))
+	for _, id := range categoryIDs {
+		hidden[cast.ToString(id)] = true
+	}
+	visible := make([]topic.Topic, 0, len(topics))
+	for _, t := range topics {
+		if !hidden[t.CategoryID] {
+			visible = append(visible, t)
+		}
+	}
+	return visible
+}
+
+// findRelated 优先使用搜索引擎查询相关话题，无结果时退回数据库计算
+func (s *TopicService) findRelated(ctx context.Context, id string) ([]topic.Topic, error) {
+	if client := elasticsearch.Default(); client != nil {
+		esCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		results, err := elasticsearch.NewSearchService(client).RelatedTopics(esCtx, id, RelatedTopicsMax)
+		cancel()
+		if err == nil && len(results) > 0 {
+			ids := make([]uint64, 0, len(results))
+			for _, r := range results {
+				ids = append(ids, uint64(r.ID))
+			}
+			return s.repo.GetApprovedByIDs(ctx, ids)
+		}
+		logger.LogIf(err)
+	}
+
+	topicModel, err := s.repo.GetByID(ctx, id)
+	if err != nil || topicModel == nil {
+		return nil, err
+	}
+	ids, err := s.repo.RelatedIDs(ctx, topicModel, RelatedTopicsMax)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetApprovedByIDs(ctx, ids)
+}
+
+// List 按筛选条件获取话题列表，列表缓存以完整查询条件为 key
+func (s *TopicService) List(c *gin.Context, query TopicListQueryDTO, perPage int) (*TopicListResponseDTO, *apperrors.AppError) {
+	ctx := context.Background()
+	filter := query.toFilter()
 	if s.cache != nil {
-		topics, found := s.cache.GetList(context.Background(), c)
-		if found && len(topics) > 0 {
-			data, pager, _ := s.repo.List(context.Background(), c, perPage)
+		if page, found := s.cache.GetList(ctx, c, filter); found {
 			return &TopicListResponseDTO{
-				Topics: s.toResponseDTOList(data),
-				Paging: pager,
+				Topics: s.toResponseDTOList(page.Topics),
+				Paging: &page.Paging,
 			}, nil
 		}
 	}
 
-	data, pager, err := s.repo.List(context.Background(), c, perPage)
+	data, pager, err := s.repo.List(ctx, c, filter, perPage)
 	if err != nil {
 		return nil, apperrors.WrapError(err, "获取话题列表失败")
 	}
-	if len(data) > 0 && s.cache != nil {
-		s.cache.SetList(context.Background(), c, data)
+	if s.cache != nil {
+		s.cache.SetList(ctx, c, filter, &cache.TopicListPage{Topics: data, Paging: *pager})
 	}
 	return &TopicListResponseDTO{
 		Topics: s.toResponseDTOList(data),
@@ -164,21 +399,94 @@ func (s *TopicService) List(c *gin.Context, perPage int) (*TopicListResponseDTO,
 	}, nil
 }
 
+// ListByCursor 游标分页获取话题列表，翻页时不受新发布话题影响
+func (s *TopicService) ListByCursor(c *gin.Context, query TopicListQueryDTO, perPage int) (*TopicCursorListResponseDTO, *apperrors.AppError) {
+	ctx := context.Background()
+	filter := query.toFilter()
+	if s.cache != nil {
+		if page, found := s.cache.GetList(ctx, c, filter); found && page.Cursor != nil {
+			return &TopicCursorListResponseDTO{
+				Topics: s.toResponseDTOList(page.Topics),
+				Paging: page.Cursor,
+			}, nil
+		}
+	}
+
+	data, pager, err := s.repo.ListByCursor(ctx, c, filter, perPage)
+	if err != nil {
+		if appErr := invalidCursorError(c, err); appErr != nil {
+			return nil, appErr
+		}
+		return nil, apperrors.WrapError(err, "获取话题列表失败")
+	}
+	if s.cache != nil {
+		s.cache.SetList(ctx, c, filter, &cache.TopicListPage{Topics: data, Cursor: pager})
+	}
+	return &TopicCursorListResponseDTO{
+		Topics: s.toResponseDTOList(data),
+		Paging: pager,
+	}, nil
+}
+
+// invalidCursorError 游标被篡改或已失效时返回验证错误，其他错误返回 nil 交由调用方处理
+func invalidCursorError(c *gin.Context, err error) *apperrors.AppError {
+	if errors.Is(err, paginator.ErrInvalidCursor) {
+		return apperrors.ValidationError("分页游标无效", map[string]interface{}{"cursor": c.Query("cursor")})
+	}
+	return nil
+}
+
 // Create 创建话题
 func (s *TopicService) Create(dto TopicCreateDTO) (*TopicResponseDTO, *apperrors.AppError) {
+	if appErr := CheckCategoryPost(dto.UserID, dto.CategoryID); appErr != nil {
+		return nil, appErr
+	}
+	spamCheck := SpamCheck{Type: spam.ContentTopic, UserID: dto.UserID, Text: dto.Title + "\n" + dto.Body}
+	spamVerdict, appErr := s.spamSvc.Check(context.Background(), spamCheck)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	now := time.Now()
+	review := s.moderationSvc.ReviewNewTopic(context.Background(), dto.UserID, dto.Title, dto.Body)
+	if spamVerdict.Verdict == moderator.VerdictReview {
+		review.Status = topic.StatusPending
+		review.Reasons = append(review.Reasons, "疑似垃圾信息")
+	}
 	topicModel := &topic.Topic{
-		Title:      dto.Title,
-		Body:       dto.Body,
-		CategoryID: dto.CategoryID,
-		UserID:     dto.UserID,
+		Title:         dto.Title,
+		Body:          dto.Body,
+		CategoryID:    dto.CategoryID,
+		UserID:        dto.UserID,
+		Status:        review.Status,
+		PendingReason: review.PendingReason(),
+		LastRepliedAt: &now,
+	}
+	if topicModel.IsApproved() {
+		topicModel.ApprovedAt = &now
 	}
 	if err := s.repo.Create(context.Background(), topicModel); err != nil {
 		return nil, apperrors.WrapError(err, "创建话题失败")
 	}
+	if len(dto.Tags) > 0 {
+		if err := s.repo.SyncTags(context.Background(), topicModel, dto.Tags); err != nil {
+			return nil, apperrors.WrapError(err, "保存话题标签失败")
+		}
+	}
 	if s.cache != nil {
 		s.cache.ClearList(context.Background())
 	}
-	return s.toResponseDTO(topicModel), nil
+	if topicModel.IsApproved() {
+		logger.LogIf(s.categoryRepo.RecountTopics(topicModel.CategoryID))
+	}
+	// 待审核的话题在审核通过后再进入粉丝的信息流
+	s.feedSvc.PublishTopic(topicModel)
+	spamCheck.ID = topicModel.GetStringID()
+	s.spamSvc.Record(context.Background(), spamCheck, spamVerdict)
+	submitTopicForModeration(topicModel)
+	result := s.toResponseDTO(topicModel)
+	result.Mentions = s.syncMentions(topicModel)
+	return result, nil
 }
 
 // Update 更新话题
@@ -190,36 +498,208 @@ func (s *TopicService) Update(id string, dto TopicUpdateDTO) (*TopicResponseDTO,
 	if topicModel == nil {
 		return nil, apperrors.NotFoundError("话题").WithDetails(map[string]interface{}{"topic_id": id})
 	}
+	textBefore := topicModel.Title + "\n" + topicModel.Body
 	if dto.Title != nil {
 		topicModel.Title = *dto.Title
 	}
 	if dto.Body != nil {
 		topicModel.Body = *dto.Body
 	}
-	if dto.CategoryID != nil {
+	if dto.CategoryID != nil && *dto.CategoryID != topicModel.CategoryID {
+		if appErr := CheckCategoryPost(topicModel.UserID, *dto.CategoryID); appErr != nil {
+			return nil, appErr
+		}
 		topicModel.CategoryID = *dto.CategoryID
 	}
+	spamCheck := SpamCheck{Type: spam.ContentTopic, ID: topicModel.GetStringID(), UserID: topicModel.UserID, Text: topicModel.Title + "\n" + topicModel.Body}
+	spamVerdict, appErr := s.spamSvc.Check(context.Background(), spamCheck)
+	if appErr != nil {
+		return nil, appErr
+	}
+	// 被拒绝的话题修改后重新进入审核队列
+	if topicModel.Status == topic.StatusRejected {
+		topicModel.Status = topic.StatusPending
+		topicModel.PendingReason = "修改后重新提交"
+		topicModel.ClaimedBy = 0
+		topicModel.ClaimedAt = nil
+	}
 	if err := s.repo.Update(context.Background(), topicModel); err != nil {
 		return nil, apperrors.WrapError(err, "更新话题失败")
 	}
+	if dto.Tags != nil {
+		if err := s.repo.SyncTags(context.Background(), topicModel, *dto.Tags); err != nil {
+			return nil, apperrors.WrapError(err, "保存话题标签失败")
+		}
+	}
 	if s.cache != nil {
 		s.cache.Delete(context.Background(), id)
 		s.cache.ClearList(context.Background())
 	}
-	return s.toResponseDTO(topicModel), nil
+	s.spamSvc.Record(context.Background(), spamCheck, spamVerdict)
+	// 只修改标签或分类时不重新审核
+	if topicModel.Title+"\n"+topicModel.Body != textBefore {
+		submitTopicForModeration(topicModel)
+	}
+	result := s.toResponseDTO(topicModel)
+	result.Mentions = s.syncMentions(topicModel)
+	return result, nil
+}
+
+// submitTopicForModeration 提交话题标题和正文异步审核
+func submitTopicForModeration(t *topic.Topic) {
+	SubmitForModeration(moderator.Content{
+		Type:     moderator.ContentTopic,
+		ID:       t.GetStringID(),
+		AuthorID: t.UserID,
+		Title:    t.Title,
+		Body:     t.Body,
+	})
+}
+
+// syncMentions 同步话题正文中的提及，未通过审核的话题先不通知，审核通过时再通知
+func (s *TopicService) syncMentions(t *topic.Topic) []MentionDTO {
+	if s.mentionSvc == nil {
+		return nil
+	}
+	return s.mentionSvc.Sync(MentionSource{
+		Type:    mention.SourceTopic,
+		ID:      t.GetStringID(),
+		TopicID: t.GetStringID(),
+		ActorID: t.UserID,
+	}, t.Body, t.IsApproved())
+}
+
+// Mentions 解析话题正文中的提及，用于话题详情
+func (s *TopicService) Mentions(body string) []MentionDTO {
+	if s.mentionSvc == nil {
+		return nil
+	}
+	return s.mentionSvc.Resolve(body)
+}
+
+// Delete 删除话题，删除后进入回收站，可由管理员恢复
+func (s *TopicService) Delete(id, operatorID string) *apperrors.AppError {
+	count, appErr := NewTrashService().Delete(repositories.TrashTopic, []uint64{cast.ToUint64(id)}, operatorID, "")
+	if appErr != nil {
+		return apperrors.WrapError(appErr, "删除话题失败")
+	}
+	if count == 0 {
+		return apperrors.NotFoundError("话题").WithDetails(map[string]interface{}{"topic_id": id})
+	}
+	return nil
 }
 
-// Delete 删除话题
-func (s *TopicService) Delete(id string) *apperrors.AppError {
-	err := s.repo.Delete(context.Background(), id)
+// Lock 锁定话题，锁定后不能再发表评论
+func (s *TopicService) Lock(id, operatorID string) (*TopicResponseDTO, *apperrors.AppError) {
+	return s.changeState(id, func(t *topic.Topic) ([]string, *apperrors.AppError) {
+		if t.IsLocked {
+			return nil, apperrors.BusinessError(apperrors.CodeTopicLocked, "话题已锁定")
+		}
+		now := time.Now()
+		t.IsLocked = true
+		t.LockedAt = &now
+		t.LockedBy = cast.ToUint64(operatorID)
+		return []string{"is_locked", "locked_at", "locked_by"}, nil
+	})
+}
+
+// Unlock 解除锁定，非版主只能解除自己设置的锁定
+func (s *TopicService) Unlock(id, operatorID string, isModerator bool) (*TopicResponseDTO, *apperrors.AppError) {
+	return s.changeState(id, func(t *topic.Topic) ([]string, *apperrors.AppError) {
+		if !t.IsLocked {
+			return nil, apperrors.BusinessError(apperrors.CodeInvalidParameter, "话题未锁定")
+		}
+		if !isModerator && t.LockedBy != cast.ToUint64(operatorID) {
+			return nil, apperrors.AuthorizationError("话题由管理员锁定，无法解除")
+		}
+		t.IsLocked = false
+		t.LockedAt = nil
+		t.LockedBy = 0
+		return []string{"is_locked", "locked_at", "locked_by"}, nil
+	})
+}
+
+// Close 关闭话题并记录原因
+func (s *TopicService) Close(id, operatorID, reason string) (*TopicResponseDTO, *apperrors.AppError) {
+	return s.changeState(id, func(t *topic.Topic) ([]string, *apperrors.AppError) {
+		if t.ClosedAt != nil {
+			return nil, apperrors.BusinessError(apperrors.CodeTopicClosed, "话题已关闭")
+		}
+		now := time.Now()
+		t.ClosedAt = &now
+		t.ClosedBy = cast.ToUint64(operatorID)
+		t.CloseReason = reason
+		return []string{"closed_at", "closed_by", "close_reason"}, nil
+	})
+}
+
+// Reopen 重新开放已关闭或已归档的话题，非版主不能重新开放被管理员关闭的话题
+// 归档的话题同时刷新最后回复时间，避免下一轮归档任务立即再次归档
+func (s *TopicService) Reopen(id, operatorID string, isModerator bool) (*TopicResponseDTO, *apperrors.AppError) {
+	return s.changeState(id, func(t *topic.Topic) ([]string, *apperrors.AppError) {
+		if t.ClosedAt == nil && t.ArchivedAt == nil {
+			return nil, apperrors.BusinessError(apperrors.CodeInvalidParameter, "话题未关闭或归档")
+		}
+		if !isModerator && t.ClosedAt != nil && t.ClosedBy != cast.ToUint64(operatorID) {
+			return nil, apperrors.AuthorizationError("话题由管理员关闭，无法重新开放")
+		}
+		columns := []string{"closed_at", "closed_by", "close_reason", "archived_at"}
+		if t.ArchivedAt != nil {
+			now := time.Now()
+			t.LastRepliedAt = &now
+			columns = append(columns, "last_replied_at")
+		}
+		t.ClosedAt = nil
+		t.ClosedBy = 0
+		t.CloseReason = ""
+		t.ArchivedAt = nil
+		return columns, nil
+	})
+}
+
+// changeState 修改话题讨论状态，apply 修改模型并返回需要保存的字段
+func (s *TopicService) changeState(id string, apply func(t *topic.Topic) ([]string, *apperrors.AppError)) (*TopicResponseDTO, *apperrors.AppError) {
+	ctx := context.Background()
+	topicModel, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return apperrors.WrapError(err, "删除话题失败")
+		return nil, apperrors.WrapError(err, "获取话题失败")
 	}
+	if topicModel == nil {
+		return nil, apperrors.NotFoundError("话题").WithDetails(map[string]interface{}{"topic_id": id})
+	}
+
+	columns, appErr := apply(topicModel)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if err := s.repo.UpdateColumns(ctx, topicModel, columns...); err != nil {
+		return nil, apperrors.WrapError(err, "更新话题状态失败")
+	}
+
 	if s.cache != nil {
-		s.cache.Delete(context.Background(), id)
-		s.cache.ClearList(context.Background())
+		s.cache.Delete(ctx, id)
+		s.cache.ClearList(ctx)
 	}
-	return nil
+	return s.toResponseDTO(topicModel), nil
+}
+
+// ArchiveInactive 归档超过 days 天无新回复的话题，返回归档数量
+func (s *TopicService) ArchiveInactive(days int) (int, *apperrors.AppError) {
+	if days <= 0 {
+		return 0, nil
+	}
+	ctx := context.Background()
+	ids, err := s.repo.ArchiveInactive(ctx, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return 0, apperrors.DatabaseError("归档话题", err)
+	}
+	if s.cache != nil && len(ids) > 0 {
+		for _, id := range ids {
+			s.cache.Delete(ctx, cast.ToString(id))
+		}
+		s.cache.ClearList(ctx)
+	}
+	return len(ids), nil
 }
 
 // CheckOwnership 检查用户是否拥有该话题
@@ -228,5 +708,8 @@ func (s *TopicService) CheckOwnership(topicID, userID string) (bool, *apperrors.
 	if err != nil {
 		return false, apperrors.WrapError(err, "检查话题所有权失败")
 	}
+	if topicModel == nil {
+		return false, apperrors.NotFoundError("话题").WithDetails(map[string]interface{}{"topic_id": topicID})
+	}
 	return topicModel.UserID == userID, nil
 }
@@ -0,0 +1,209 @@
+// Package services 回收站业务逻辑
+package services
+
+import (
+	"context"
+	"time"
+
+	"GoHub-Service/app/cache"
+	"GoHub-Service/app/repositories"
+	"GoHub-Service/pkg/elasticsearch"
+	apperrors "GoHub-Service/pkg/errors"
+	"GoHub-Service/pkg/feed"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+// trashPurgeBatchSize 定期清理时每批彻底删除的记录数
+const trashPurgeBatchSize = 500
+
+// TrashKinds 回收站支持的资源类型
+var TrashKinds = []string{repositories.TrashTopic, repositories.TrashComment, repositories.TrashUser}
+
+// TrashService 回收站服务：软删除、恢复、彻底删除
+type TrashService struct {
+	repo         repositories.TrashRepository
+	categoryRepo repositories.CategoryRepository
+	topicCache   *cache.TopicCache
+	commentCache *cache.CommentCache
+	feedSvc      *FeedService
+}
+
+// NewTrashService 创建回收站服务实例
+func NewTrashService() *TrashService {
+	return &TrashService{
+		repo:         repositories.NewTrashRepository(),
+		categoryRepo: repositories.NewCategoryRepository(),
+		topicCache:   cache.NewTopicCache(),
+		commentCache: cache.NewCommentCache(),
+		feedSvc:      NewFeedService(),
+	}
+}
+
+// Delete 软删除，记录操作人和原因，返回实际删除的条数
+func (s *TrashService) Delete(kind string, ids []uint64, operatorID, reason string) (int64, *apperrors.AppError) {
+	if appErr := checkTrashKind(kind, ids); appErr != nil {
+		return 0, appErr
+	}
+	count, err := s.repo.SoftDelete(context.Background(), kind, ids, cast.ToUint64(operatorID), reason)
+	if err != nil {
+		return 0, apperrors.DatabaseDeleteError(kind, err)
+	}
+	if count > 0 {
+		s.afterChange(kind, ids, false)
+	}
+	return count, nil
+}
+
+// Restore 从回收站恢复
+func (s *TrashService) Restore(kind string, ids []uint64) (int64, *apperrors.AppError) {
+	if appErr := checkTrashKind(kind, ids); appErr != nil {
+		return 0, appErr
+	}
+	count, err := s.repo.Restore(context.Background(), kind, ids)
+	if err != nil {
+		return 0, apperrors.DatabaseUpdateError(kind, err)
+	}
+	if count > 0 {
+		s.afterChange(kind, ids, true)
+	}
+	return count, nil
+}
+
+// Purge 彻底删除回收站中的记录，不可恢复
+func (s *TrashService) Purge(kind string, ids []uint64) (int64, *apperrors.AppError) {
+	if appErr := checkTrashKind(kind, ids); appErr != nil {
+		return 0, appErr
+	}
+	count, err := s.purge(context.Background(), kind, ids)
+	if err != nil {
+		return 0, apperrors.DatabaseDeleteError(kind, err)
+	}
+	return count, nil
+}
+
+// List 回收站列表
+func (s *TrashService) List(c *gin.Context, kind string, perPage int) (interface{}, *paginator.Paging, *apperrors.AppError) {
+	if appErr := checkTrashKind(kind, nil); appErr != nil {
+		return nil, nil, appErr
+	}
+	data, paging, err := s.repo.List(context.Background(), c, kind, perPage)
+	if err != nil {
+		return nil, nil, apperrors.DatabaseError("获取回收站列表", err)
+	}
+	return data, paging, nil
+}
+
+// PurgeExpired 彻底删除在回收站中超过 days 天的记录，返回各类型的删除条数
+func (s *TrashService) PurgeExpired(days int) (map[string]int64, *apperrors.AppError) {
+	ctx := context.Background()
+	before := time.Now().AddDate(0, 0, -days)
+	purged := make(map[string]int64, len(TrashKinds))
+
+	for _, kind := range TrashKinds {
+		for {
+			ids, err := s.repo.TrashedBefore(ctx, kind, before, trashPurgeBatchSize)
+			if err != nil {
+				return purged, apperrors.DatabaseError("查询过期回收站记录", err)
+			}
+			if len(ids) == 0 {
+				break
+			}
+			count, err := s.purge(ctx, kind, ids)
+			if err != nil {
+				return purged, apperrors.DatabaseDeleteError(kind, err)
+			}
+			purged[kind] += count
+			if len(ids) < trashPurgeBatchSize {
+				break
+			}
+		}
+	}
+	return purged, nil
+}
+
+// purge 彻底删除记录，评论的回复会一起删除，完成后清除所属话题的评论缓存
+func (s *TrashService) purge(ctx context.Context, kind string, ids []uint64) (int64, error) {
+	var topicIDs []string
+	if kind == repositories.TrashComment && s.commentCache != nil {
+		var err error
+		topicIDs, err = s.repo.CommentTopicIDs(ctx, ids)
+		logger.LogIf(err)
+	}
+	count, err := s.repo.Purge(ctx, kind, ids)
+	for _, topicID := range topicIDs {
+		s.commentCache.InvalidateByTopicID(ctx, topicID)
+	}
+	return count, err
+}
+
+// afterChange 删除或恢复后清理缓存并同步搜索索引
+func (s *TrashService) afterChange(kind string, ids []uint64, restored bool) {
+	ctx := context.Background()
+	switch kind {
+	case repositories.TrashTopic:
+		if s.topicCache != nil {
+			for _, id := range ids {
+				s.topicCache.Delete(ctx, cast.ToString(id))
+			}
+			s.topicCache.ClearList(ctx)
+		}
+		categoryIDs, err := s.repo.TopicCategoryIDs(ctx, ids)
+		logger.LogIf(err)
+		logger.LogIf(s.categoryRepo.RecountTopics(categoryIDs...))
+		syncTopicIndex(ids, !restored)
+		if !restored {
+			s.feedSvc.RemoveContent(feed.KindTopic, ids)
+		}
+	case repositories.TrashComment:
+		if s.commentCache != nil {
+			for _, id := range ids {
+				s.commentCache.Invalidate(ctx, cast.ToString(id))
+			}
+			topicIDs, err := s.repo.CommentTopicIDs(ctx, ids)
+			logger.LogIf(err)
+			for _, topicID := range topicIDs {
+				s.commentCache.InvalidateByTopicID(ctx, topicID)
+			}
+		}
+		if !restored {
+			s.feedSvc.RemoveContent(feed.KindComment, ids)
+		}
+	}
+}
+
+// checkTrashKind 校验资源类型和 ID 列表
+func checkTrashKind(kind string, ids []uint64) *apperrors.AppError {
+	for _, k := range TrashKinds {
+		if k == kind {
+			if ids != nil && len(ids) == 0 {
+				return apperrors.ValidationError("ID列表不能为空", map[string]interface{}{"ids": ids})
+			}
+			return nil
+		}
+	}
+	return apperrors.ValidationError("不支持的回收站类型", map[string]interface{}{"type": kind})
+}
+
+// syncTopicIndex 同步话题的搜索索引，remove 为 true 时从索引中删除
+// 未启用 Elasticsearch 时直接跳过
+func syncTopicIndex(ids []uint64, remove bool) {
+	client := elasticsearch.Default()
+	if client == nil {
+		return
+	}
+	syncer := elasticsearch.NewSyncService(client)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, id := range ids {
+		if remove {
+			logger.LogIf(syncer.RemoveSingleTopic(ctx, id))
+		} else {
+			logger.LogIf(syncer.IndexSingleTopic(ctx, id))
+		}
+	}
+}
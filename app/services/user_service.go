@@ -4,12 +4,14 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"GoHub-Service/app/models/user"
 	"GoHub-Service/app/repositories"
 	apperrors "GoHub-Service/pkg/errors"
 	"GoHub-Service/pkg/mapper"
+	"GoHub-Service/pkg/moderator"
 	"GoHub-Service/pkg/paginator"
 	"GoHub-Service/pkg/resource"
 
@@ -26,6 +28,14 @@ func (s *UserService) UpdateProfile(currentUser *user.User, name, city, introduc
 	if rowsAffected > 0 {
 		// 更新缓存
 		_ = s.repo.SetCache(currentUser)
+		// 昵称、城市和简介提交异步审核
+		SubmitForModeration(moderator.Content{
+			Type:     moderator.ContentProfile,
+			ID:       currentUser.GetStringID(),
+			AuthorID: currentUser.GetStringID(),
+			Title:    name,
+			Body:     strings.TrimSpace(city + "\n" + introduction),
+		})
 		return currentUser, nil
 	}
 	return nil, fmt.Errorf("用户信息更新失败")
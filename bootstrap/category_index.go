@@ -0,0 +1,25 @@
+package bootstrap
+
+import (
+	"context"
+
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/redis"
+)
+
+// SetupCategoryIndex 订阅分类变更通知
+// 任一实例修改分类后发布通知，所有实例使缓存的分类索引失效，下次使用时重新加载
+func SetupCategoryIndex() {
+	if redis.Redis == nil {
+		return
+	}
+
+	go func() {
+		sub := redis.Redis.Client.Subscribe(context.Background(), services.CategoryIndexChannel)
+		defer sub.Close()
+
+		for range sub.Channel() {
+			services.ForgetCategoryIndex()
+		}
+	}()
+}
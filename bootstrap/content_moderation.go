@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/config"
+)
+
+// StartContentModeration 启动异步内容审核工作协程
+// 话题、评论、个人资料和私信发布后提交到队列，由工作协程执行审核流水线
+func StartContentModeration() {
+	if !config.GetBool("moderation.pipeline_enabled") {
+		return
+	}
+	services.StartContentModeration(
+		config.GetInt("moderation.pipeline_workers", 2),
+		config.GetInt("moderation.pipeline_queue_size", 1000),
+	)
+}
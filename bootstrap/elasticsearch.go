@@ -47,5 +47,6 @@ func LoadElasticsearch() {
 		return
 	}
 
+	elasticsearch.SetDefault(client)
 	logger.Info("Elasticsearch initialized successfully")
 }
@@ -0,0 +1,57 @@
+package bootstrap
+
+import (
+	"context"
+	"time"
+
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/config"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/redis"
+
+	"go.uber.org/zap"
+)
+
+// linkCheckLockKey 友情链接检测锁
+const linkCheckLockKey = "link:check:lock"
+
+// StartLinkChecker 启动友情链接可用性检测任务
+// 每隔 link.check_interval_minutes 分钟检测一次，连续失败的链接标记为失效并从前台隐藏
+func StartLinkChecker() {
+	minutes := config.GetInt("link.check_interval_minutes", 720)
+	if minutes <= 0 {
+		return
+	}
+	interval := time.Duration(minutes) * time.Minute
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		svc := services.NewLinkService()
+		for range ticker.C {
+			// 多实例部署时每轮只由抢到锁的实例检测，锁在本轮间隔内不释放，避免其他实例随后重复检测
+			ctx := context.Background()
+			acquired, err := redis.Redis.Client.SetNX(ctx, linkCheckLockKey, 1, interval*9/10).Result()
+			if err != nil {
+				logger.LogIf(err)
+				continue
+			}
+			if !acquired {
+				continue
+			}
+			summary, err := svc.CheckAll(ctx)
+			if err != nil {
+				logger.LogIf(err)
+				continue
+			}
+			if summary.Broken > 0 || summary.Recovered > 0 {
+				logger.Logger.Info("友情链接可用性检测",
+					zap.Int("checked", summary.Checked),
+					zap.Int("broken", summary.Broken),
+					zap.Int("recovered", summary.Recovered),
+				)
+			}
+		}
+	}()
+}
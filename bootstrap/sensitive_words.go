@@ -0,0 +1,36 @@
+package bootstrap
+
+import (
+	"context"
+
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/redis"
+
+	"go.uber.org/zap"
+)
+
+// SetupSensitiveWords 从数据库加载敏感词词库，并订阅词库变更通知
+// 任一实例修改词库后发布通知，所有实例重新加载，加载失败时继续使用当前词库
+func SetupSensitiveWords() {
+	svc := services.NewSensitiveWordService()
+	if err := svc.Reload(); err != nil {
+		logger.Logger.Warn("加载敏感词词库失败，使用内置词库", zap.Error(err))
+	}
+	if redis.Redis == nil {
+		return
+	}
+
+	go func() {
+		sub := redis.Redis.Client.Subscribe(context.Background(), services.SensitiveWordReloadChannel)
+		defer sub.Close()
+
+		for range sub.Channel() {
+			if err := svc.Reload(); err != nil {
+				logger.LogIf(err)
+				continue
+			}
+			logger.Logger.Debug("敏感词词库已重新加载")
+		}
+	}()
+}
@@ -0,0 +1,41 @@
+package bootstrap
+
+import (
+	"time"
+
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/config"
+	"GoHub-Service/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// StartTopicArchiver 启动话题自动归档任务
+// 每隔 topic.archive_interval_minutes 分钟，归档超过 topic.archive_after_days 天无新回复的话题
+func StartTopicArchiver() {
+	days := config.GetInt("topic.archive_after_days")
+	if days <= 0 {
+		return
+	}
+	interval := time.Duration(config.GetInt("topic.archive_interval_minutes", 60)) * time.Minute
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		svc := services.NewTopicService()
+		for range ticker.C {
+			count, err := svc.ArchiveInactive(days)
+			if err != nil {
+				logger.LogIf(err)
+				continue
+			}
+			if count > 0 {
+				logger.Logger.Info("话题自动归档",
+					zap.Int("archived_count", count),
+					zap.Int("inactive_days", days),
+				)
+			}
+		}
+	}()
+}
@@ -0,0 +1,43 @@
+package bootstrap
+
+import (
+	"time"
+
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/config"
+	"GoHub-Service/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// StartTrashPurger 启动回收站定期清理任务
+// 每隔 trash.purge_interval_minutes 分钟，彻底删除在回收站中超过 trash.retention_days 天的记录
+func StartTrashPurger() {
+	days := config.GetInt("trash.retention_days")
+	if days <= 0 {
+		return
+	}
+	interval := time.Duration(config.GetInt("trash.purge_interval_minutes", 360)) * time.Minute
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		svc := services.NewTrashService()
+		for range ticker.C {
+			purged, err := svc.PurgeExpired(days)
+			if err != nil {
+				logger.LogIf(err)
+			}
+			for kind, count := range purged {
+				if count > 0 {
+					logger.Logger.Info("回收站自动清理",
+						zap.String("type", kind),
+						zap.Int64("purged_count", count),
+						zap.Int("retention_days", days),
+					)
+				}
+			}
+		}
+	}()
+}
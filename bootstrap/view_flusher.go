@@ -0,0 +1,37 @@
+package bootstrap
+
+import (
+	"time"
+
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/config"
+	"GoHub-Service/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// StartViewFlusher 启动话题浏览量批量写库任务
+// 每隔 topic.view_flush_interval_seconds 秒，将 Redis 中累积的浏览量写入数据库
+func StartViewFlusher() {
+	interval := time.Duration(config.GetInt("topic.view_flush_interval_seconds", 60)) * time.Second
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		svc := services.NewInteractionService()
+		for range ticker.C {
+			flushed, err := svc.FlushTopicViews()
+			if err != nil {
+				logger.LogIf(err)
+				continue
+			}
+			if flushed > 0 {
+				logger.Logger.Debug("话题浏览量写入数据库", zap.Int64("views", flushed))
+			}
+		}
+	}()
+}
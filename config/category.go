@@ -0,0 +1,13 @@
+package config
+
+import "GoHub-Service/pkg/config"
+
+func init() {
+    config.Add("category", func() map[string]interface{} {
+        return map[string]interface{}{
+
+            // 发布权限为 approved 的分类，用户至少需要多少篇已通过审核的话题才能发布
+            "approved_min_topics": config.Env("CATEGORY_APPROVED_MIN_TOPICS", 1),
+        }
+    })
+}
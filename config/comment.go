@@ -0,0 +1,25 @@
+package config
+
+import "GoHub-Service/pkg/config"
+
+func init() {
+    config.Add("comment", func() map[string]interface{} {
+        return map[string]interface{}{
+
+            // 评论树展示的最大层数（顶级评论为第 1 层，最小为 2），更深的回复平铺在上一层并标注回复对象
+            "tree_max_depth": config.Env("COMMENT_TREE_MAX_DEPTH", 3),
+
+            // 评论树中每条评论内嵌的回复数，其余回复通过 /comments/:id/replies 加载
+            "tree_reply_preview": config.Env("COMMENT_TREE_REPLY_PREVIEW", 3),
+
+            // 构建一页评论树最多加载的回复数，防止超大讨论串拖慢查询
+            "tree_max_nodes": config.Env("COMMENT_TREE_MAX_NODES", 2000),
+
+            // 发布后允许作者修改评论的时间（分钟），0 表示不限制；版主和管理员不受限制
+            "edit_window": config.Env("COMMENT_EDIT_WINDOW", 15),
+
+            // 问答分类中回答被采纳时奖励回答者的积分，取消采纳时扣回
+            "accepted_answer_points": config.Env("COMMENT_ACCEPTED_ANSWER_POINTS", 10),
+        }
+    })
+}
@@ -0,0 +1,22 @@
+package config
+
+import "GoHub-Service/pkg/config"
+
+func init() {
+    config.Add("feed", func() map[string]interface{} {
+        return map[string]interface{}{
+
+            // 粉丝数达到该值的作者不再推送到关注者的收件箱，改为读取信息流时查询
+            "fanout_max_followers": config.Env("FEED_FANOUT_MAX_FOLLOWERS", 1000),
+
+            // 每个用户收件箱最多保留的条目数
+            "inbox_size": config.Env("FEED_INBOX_SIZE", 500),
+
+            // 收件箱过期天数，过期后下次访问时从数据库重建
+            "inbox_ttl_days": config.Env("FEED_INBOX_TTL_DAYS", 14),
+
+            // 关注用户后补充到收件箱的该用户最近内容条数
+            "backfill_size": config.Env("FEED_BACKFILL_SIZE", 20),
+        }
+    })
+}
@@ -0,0 +1,22 @@
+package config
+
+import "GoHub-Service/pkg/config"
+
+func init() {
+    config.Add("link", func() map[string]interface{} {
+        return map[string]interface{}{
+
+            // 友情链接可用性检测间隔（分钟），0 表示不自动检测
+            "check_interval_minutes": config.Env("LINK_CHECK_INTERVAL_MINUTES", 720),
+
+            // 单个链接检测超时时间（秒）
+            "check_timeout_seconds": config.Env("LINK_CHECK_TIMEOUT_SECONDS", 10),
+
+            // 连续检测失败多少次后标记为失效
+            "fail_threshold": config.Env("LINK_FAIL_THRESHOLD", 3),
+
+            // 每个用户最多同时存在的待审核申请数
+            "max_pending_applications": config.Env("LINK_MAX_PENDING_APPLICATIONS", 1),
+        }
+    })
+}
@@ -0,0 +1,65 @@
+package config
+
+import "GoHub-Service/pkg/config"
+
+func init() {
+    config.Add("moderation", func() map[string]interface{} {
+        return map[string]interface{}{
+
+            // 是否开启新话题预审，关闭后所有话题直接发布
+            "pre_moderation_enabled": config.Env("MODERATION_PRE_MODERATION_ENABLED", true),
+
+            // 注册不满多少天的账号发布的话题需要审核，0 表示不限制
+            "trusted_account_days": config.Env("MODERATION_TRUSTED_ACCOUNT_DAYS", 3),
+
+            // 积分低于该值的用户发布的话题需要审核，0 表示不限制
+            "trusted_min_points": config.Env("MODERATION_TRUSTED_MIN_POINTS", 0),
+
+            // 已通过审核的话题数少于该值的用户需要审核，0 表示不限制
+            "trusted_min_approved_topics": config.Env("MODERATION_TRUSTED_MIN_APPROVED_TOPICS", 1),
+
+            // 内容检查发现敏感词时是否进入审核队列
+            "review_flagged_content": config.Env("MODERATION_REVIEW_FLAGGED_CONTENT", true),
+
+            // 版主认领后多少分钟内未处理，其他版主可以重新认领
+            "claim_ttl_minutes": config.Env("MODERATION_CLAIM_TTL_MINUTES", 30),
+
+            // 是否开启异步内容审核流水线，对话题、评论、个人资料和私信生效
+            "pipeline_enabled": config.Env("MODERATION_PIPELINE_ENABLED", true),
+
+            // 审核流水线的工作协程数和队列长度
+            "pipeline_workers":    config.Env("MODERATION_PIPELINE_WORKERS", 2),
+            "pipeline_queue_size": config.Env("MODERATION_PIPELINE_QUEUE_SIZE", 1000),
+
+            // 队列满时提交方最多等待的毫秒数，超时后放弃审核并记录日志
+            "pipeline_enqueue_timeout_ms": config.Env("MODERATION_PIPELINE_ENQUEUE_TIMEOUT_MS", 200),
+
+            // 风险分数达到该值时进入人工审核 / 直接拒绝，0 表示不按分数判断
+            "review_score": config.Env("MODERATION_REVIEW_SCORE", 0.5),
+            "reject_score": config.Env("MODERATION_REJECT_SCORE", 0.9),
+
+            // 正则规则，每行一条，格式为 "review:正则" 或 "reject:正则"
+            "regex_rules": config.Env("MODERATION_REGEX_RULES", ""),
+
+            // 链接信誉：屏蔽的域名直接拒绝，白名单域名不计入外部链接，多个域名用逗号分隔
+            "blocked_domains": config.Env("MODERATION_BLOCKED_DOMAINS", ""),
+            "allowed_domains": config.Env("MODERATION_ALLOWED_DOMAINS", ""),
+
+            // 外部链接超过该数量时进入人工审核，0 表示不限制
+            "max_links": config.Env("MODERATION_MAX_LINKS", 5),
+
+            // 同一作者在多少分钟内重复发布相同内容时进入人工审核，0 表示不检查
+            "duplicate_window_minutes": config.Env("MODERATION_DUPLICATE_WINDOW_MINUTES", 10),
+
+            // 少于该字数的内容不做重复检查
+            "duplicate_min_length": config.Env("MODERATION_DUPLICATE_MIN_LENGTH", 10),
+
+            // 外部分类服务地址，为空时不启用
+            "webhook_url":   config.Env("MODERATION_WEBHOOK_URL", ""),
+            "webhook_token": config.Env("MODERATION_WEBHOOK_TOKEN", ""),
+
+            // 调用外部分类服务的超时时间（毫秒）
+            "webhook_timeout_ms": config.Env("MODERATION_WEBHOOK_TIMEOUT_MS", 3000),
+        }
+    })
+}
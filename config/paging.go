@@ -24,6 +24,9 @@ func init() {
             // URL 中用以分辨每页条数的参数
             // 此值若修改需一并修改请求验证规则
             "url_query_per_page": "per_page",
+
+            // URL 中用以传递游标的参数，带上此参数即使用游标分页
+            "url_query_cursor": "cursor",
         }
     })
 }
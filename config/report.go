@@ -0,0 +1,16 @@
+package config
+
+import "GoHub-Service/pkg/config"
+
+func init() {
+    config.Add("report", func() map[string]interface{} {
+        return map[string]interface{}{
+
+            // 同一内容被多少个不同用户举报后自动隐藏（话题撤回审核队列），0 表示不自动隐藏
+            "auto_hide_threshold": config.Env("REPORT_AUTO_HIDE_THRESHOLD", 5),
+
+            // 通过举报封禁用户时未指定天数的默认封禁天数
+            "default_ban_days": config.Env("REPORT_DEFAULT_BAN_DAYS", 7),
+        }
+    })
+}
@@ -0,0 +1,44 @@
+package config
+
+import "GoHub-Service/pkg/config"
+
+func init() {
+    config.Add("spam", func() map[string]interface{} {
+        return map[string]interface{}{
+
+            // 以下为垃圾信息检测的默认阈值，管理员在后台修改后以后台设置为准
+
+            // 是否开启发布频率限制和垃圾信息检测
+            "enabled": config.Env("SPAM_ENABLED", true),
+
+            // 注册不满多少天的账号按新用户限制发布频率
+            "new_account_days": config.Env("SPAM_NEW_ACCOUNT_DAYS", 7),
+
+            // 发布频率统计窗口（分钟）
+            "velocity_window_minutes": config.Env("SPAM_VELOCITY_WINDOW_MINUTES", 60),
+
+            // 统计窗口内新用户和普通用户最多发布的话题、评论数，0 表示不限制
+            "new_user_max_topics":   config.Env("SPAM_NEW_USER_MAX_TOPICS", 3),
+            "new_user_max_comments": config.Env("SPAM_NEW_USER_MAX_COMMENTS", 20),
+            "max_topics":            config.Env("SPAM_MAX_TOPICS", 10),
+            "max_comments":          config.Env("SPAM_MAX_COMMENTS", 60),
+
+            // SimHash 汉明距离不超过该值视为近似内容，最大为 3
+            "duplicate_distance": config.Env("SPAM_DUPLICATE_DISTANCE", 3),
+
+            // 在最近多少小时内发布的内容中查找近似内容
+            "duplicate_window_hours": config.Env("SPAM_DUPLICATE_WINDOW_HOURS", 72),
+
+            // 少于该字数的内容不做近似判断
+            "duplicate_min_length": config.Env("SPAM_DUPLICATE_MIN_LENGTH", 20),
+
+            // 链接数和链接字符占比超过该值时计入垃圾信息分数，0 表示不检查
+            "max_links":        config.Env("SPAM_MAX_LINKS", 5),
+            "max_link_density": config.Env("SPAM_MAX_LINK_DENSITY", 0.5),
+
+            // 垃圾信息分数达到该值时进入人工审核 / 拒绝发布
+            "review_score": config.Env("SPAM_REVIEW_SCORE", 0.4),
+            "reject_score": config.Env("SPAM_REJECT_SCORE", 0.8),
+        }
+    })
+}
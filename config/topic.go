@@ -0,0 +1,28 @@
+package config
+
+import "GoHub-Service/pkg/config"
+
+func init() {
+    config.Add("topic", func() map[string]interface{} {
+        return map[string]interface{}{
+
+            // 话题无新回复超过多少天后自动归档，归档后不能再评论，0 表示不自动归档
+            "archive_after_days": config.Env("TOPIC_ARCHIVE_AFTER_DAYS", 180),
+
+            // 自动归档任务的执行间隔（分钟）
+            "archive_interval_minutes": config.Env("TOPIC_ARCHIVE_INTERVAL_MINUTES", 60),
+
+            // 同一用户或 IP 在多少分钟内重复浏览同一话题只计一次
+            "view_dedup_minutes": config.Env("TOPIC_VIEW_DEDUP_MINUTES", 30),
+
+            // 浏览量从 Redis 批量写入数据库的间隔（秒）
+            "view_flush_interval_seconds": config.Env("TOPIC_VIEW_FLUSH_INTERVAL_SECONDS", 60),
+
+            // 浏览量写库锁的过期时间（秒），多实例部署时同一时刻只有一个实例写库
+            "view_flush_lock_seconds": config.Env("TOPIC_VIEW_FLUSH_LOCK_SECONDS", 300),
+
+            // 作者可查询的每日浏览量历史最大天数
+            "view_history_max_days": config.Env("TOPIC_VIEW_HISTORY_MAX_DAYS", 90),
+        }
+    })
+}
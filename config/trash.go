@@ -0,0 +1,16 @@
+package config
+
+import "GoHub-Service/pkg/config"
+
+func init() {
+    config.Add("trash", func() map[string]interface{} {
+        return map[string]interface{}{
+
+            // 回收站保留天数，超过后彻底删除，0 表示不自动清理
+            "retention_days": config.Env("TRASH_RETENTION_DAYS", 30),
+
+            // 回收站清理任务的执行间隔（分钟）
+            "purge_interval_minutes": config.Env("TRASH_PURGE_INTERVAL_MINUTES", 360),
+        }
+    })
+}
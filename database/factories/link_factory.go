@@ -12,8 +12,9 @@ func MakeLinks(times int) []link.Link {
 
     for i := 0; i < times; i++ {
         model := link.Link{
-            Name: faker.Username(),
-            URL:  faker.URL(),
+            Name:      faker.Username(),
+            URL:       faker.URL(),
+            IsEnabled: true,
         }
         objs = append(objs, model)
     }
@@ -19,6 +19,7 @@ func MakeTopics(count int) []topic.Topic {
             Body:       faker.Paragraph(),
             CategoryID: "3",
             UserID:     "1",
+            Status:     topic.StatusApproved,
         }
         objs = append(objs, topicModel)
     }
@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"database/sql"
+	"time"
+
+	"GoHub-Service/app/models"
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Tag struct {
+		models.BaseModel
+		Name        string `gorm:"type:varchar(50);uniqueIndex;not null"`
+		TopicsCount int64  `gorm:"type:int;default:0;comment:话题数"`
+		models.CommonTimestampsField
+	}
+
+	type TopicTag struct {
+		TopicID uint64 `gorm:"primaryKey;autoIncrement:false"`
+		TagID   uint64 `gorm:"primaryKey;autoIncrement:false;index"`
+	}
+
+	type Topic struct {
+		LastRepliedAt *time.Time `gorm:"index;comment:最后回复时间"`
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&Tag{}, &TopicTag{}, &Topic{})
+
+		// 回填最后回复时间：有评论取最新评论时间，否则取发布时间
+		_, _ = DB.Exec(`UPDATE topics SET last_replied_at = COALESCE(
+			(SELECT MAX(comments.created_at) FROM comments WHERE comments.topic_id = topics.id),
+			topics.created_at
+		) WHERE last_replied_at IS NULL`)
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.DropColumn(&Topic{}, "last_replied_at")
+		_ = migrator.DropTable(&TopicTag{})
+		_ = migrator.DropTable(&Tag{})
+	}
+
+	migrate.Add("2026_01_05_010000_add_tags_and_topic_last_replied_at", up, down)
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	// 游标分页按 (排序字段, id) 做范围查询，InnoDB 二级索引自带主键，
+	// 只需保证过滤条件 + 排序字段上有联合索引
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		// 话题列表：置顶优先 + 最新回复 / 浏览最多
+		DB.Exec("CREATE INDEX idx_topics_pinned_last_replied ON topics(is_pinned, last_replied_at)")
+		DB.Exec("CREATE INDEX idx_topics_view_count ON topics(view_count)")
+
+		// 通知列表：按用户 + 时间倒序
+		DB.Exec("CREATE INDEX idx_notifications_user_created ON notifications(user_id, created_at)")
+
+		// 私信会话：按会话 + 时间
+		DB.Exec("CREATE INDEX idx_messages_conversation_created ON messages(conversation_id, created_at)")
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		DB.Exec("DROP INDEX idx_topics_pinned_last_replied ON topics")
+		DB.Exec("DROP INDEX idx_topics_view_count ON topics")
+		DB.Exec("DROP INDEX idx_notifications_user_created ON notifications")
+		DB.Exec("DROP INDEX idx_messages_conversation_created ON messages")
+	}
+
+	migrate.Add("2026_01_06_010000_add_cursor_pagination_indexes", up, down)
+}
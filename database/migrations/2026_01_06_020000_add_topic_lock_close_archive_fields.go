@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"database/sql"
+	"time"
+
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Topic struct {
+		IsLocked    bool       `gorm:"type:boolean;default:false;comment:是否锁定"`
+		LockedAt    *time.Time `gorm:"comment:锁定时间"`
+		LockedBy    uint64     `gorm:"comment:锁定操作人ID"`
+		ClosedAt    *time.Time `gorm:"index;comment:关闭时间"`
+		ClosedBy    uint64     `gorm:"comment:关闭操作人ID"`
+		CloseReason string     `gorm:"type:varchar(500);comment:关闭原因"`
+		ArchivedAt  *time.Time `gorm:"index;comment:归档时间"`
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&Topic{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		for _, column := range []string{"is_locked", "locked_at", "locked_by", "closed_at", "closed_by", "close_reason", "archived_at"} {
+			_ = migrator.DropColumn(&Topic{}, column)
+		}
+	}
+
+	migrate.Add("2026_01_06_020000_add_topic_lock_close_archive_fields", up, down)
+}
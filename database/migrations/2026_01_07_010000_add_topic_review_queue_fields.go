@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"database/sql"
+	"time"
+
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Topic struct {
+		PendingReason string     `gorm:"type:varchar(255);comment:进入审核队列的原因"`
+		ClaimedBy     uint64     `gorm:"index;default:0;comment:认领审核的版主ID"`
+		ClaimedAt     *time.Time `gorm:"comment:认领时间"`
+		ReviewedBy    uint64     `gorm:"comment:审核人ID"`
+		ReviewedAt    *time.Time `gorm:"comment:审核时间"`
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&Topic{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		for _, column := range []string{"pending_reason", "claimed_by", "claimed_at", "reviewed_by", "reviewed_at"} {
+			_ = migrator.DropColumn(&Topic{}, column)
+		}
+	}
+
+	migrate.Add("2026_01_07_010000_add_topic_review_queue_fields", up, down)
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type SoftDeletes struct {
+		DeletedAt    gorm.DeletedAt `gorm:"index"`
+		DeletedBy    uint64         `gorm:"default:0;comment:删除操作人ID"`
+		DeleteReason string         `gorm:"type:varchar(255);comment:删除原因"`
+	}
+	type Topic struct {
+		SoftDeletes
+	}
+	type Comment struct {
+		SoftDeletes
+	}
+	type User struct {
+		SoftDeletes
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&Topic{}, &Comment{}, &User{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		for _, model := range []interface{}{&Topic{}, &Comment{}, &User{}} {
+			for _, column := range []string{"deleted_at", "deleted_by", "delete_reason"} {
+				_ = migrator.DropColumn(model, column)
+			}
+		}
+	}
+
+	migrate.Add("2026_01_08_010000_add_soft_delete_fields", up, down)
+}
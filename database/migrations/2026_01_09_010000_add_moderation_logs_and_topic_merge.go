@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"GoHub-Service/app/models"
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Topic struct {
+		MergedIntoID uint64 `gorm:"index;default:0;comment:合并到的话题ID"`
+	}
+	type Category struct {
+		TopicsCount int64 `gorm:"default:0;comment:已发布话题数"`
+	}
+	type ModerationLog struct {
+		models.BaseModel
+
+		ModeratorID uint64 `gorm:"index;not null;comment:操作人ID"`
+		Action      string `gorm:"type:varchar(32);index;not null;comment:操作类型"`
+		TargetType  string `gorm:"type:varchar(32);index:idx_moderation_log_target;not null;comment:目标类型"`
+		TargetID    uint64 `gorm:"index:idx_moderation_log_target;not null;comment:目标ID"`
+		Reason      string `gorm:"type:varchar(500);comment:操作原因"`
+		Detail      string `gorm:"type:text;comment:操作详情(JSON)"`
+
+		models.CommonTimestampsField
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&Topic{}, &Category{}, &ModerationLog{})
+
+		// 初始化分类话题数
+		DB.Exec(`UPDATE categories SET topics_count = (
+			SELECT COUNT(*) FROM topics
+			WHERE topics.category_id = categories.id AND topics.status = 1 AND topics.deleted_at IS NULL
+		)`)
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.DropTable(&ModerationLog{})
+		_ = migrator.DropColumn(&Category{}, "topics_count")
+		_ = migrator.DropColumn(&Topic{}, "merged_into_id")
+	}
+
+	migrate.Add("2026_01_09_010000_add_moderation_logs_and_topic_merge", up, down)
+}
@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type TopicDailyView struct {
+		TopicID uint64 `gorm:"primaryKey;autoIncrement:false;comment:话题ID"`
+		Date    string `gorm:"primaryKey;type:date;comment:日期"`
+		Views   int64  `gorm:"not null;default:0;comment:当日浏览量"`
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&TopicDailyView{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.DropTable(&TopicDailyView{})
+	}
+
+	migrate.Add("2026_01_10_010000_create_topic_daily_views_table", up, down)
+}
@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"GoHub-Service/app/models"
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Collection struct {
+		models.BaseModel
+
+		UserID         uint64 `gorm:"index;not null;comment:创建者ID"`
+		Name           string `gorm:"type:varchar(100);not null;comment:名称"`
+		Description    string `gorm:"type:varchar(500);comment:描述"`
+		IsPublic       bool   `gorm:"index;default:false;comment:是否公开"`
+		ItemsCount     int64  `gorm:"default:0;comment:话题数"`
+		FollowersCount int64  `gorm:"default:0;comment:关注数"`
+
+		models.CommonTimestampsField
+	}
+	type CollectionItem struct {
+		models.BaseModel
+
+		CollectionID uint64 `gorm:"uniqueIndex:uidx_collection_item;not null;comment:收藏夹ID"`
+		TopicID      uint64 `gorm:"uniqueIndex:uidx_collection_item;index;not null;comment:话题ID"`
+		Note         string `gorm:"type:varchar(500);comment:备注"`
+		Position     int    `gorm:"default:0;comment:排序位置"`
+
+		models.CommonTimestampsField
+	}
+	type CollectionFollow struct {
+		models.BaseModel
+
+		CollectionID uint64 `gorm:"uniqueIndex:uidx_collection_follow;not null;comment:收藏夹ID"`
+		UserID       uint64 `gorm:"uniqueIndex:uidx_collection_follow;index;not null;comment:关注者ID"`
+
+		models.CommonTimestampsField
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&Collection{}, &CollectionItem{}, &CollectionFollow{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.DropTable(&CollectionFollow{}, &CollectionItem{}, &Collection{})
+	}
+
+	migrate.Add("2026_01_11_010000_create_collections_tables", up, down)
+}
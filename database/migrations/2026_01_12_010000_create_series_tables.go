@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"GoHub-Service/app/models"
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Series struct {
+		models.BaseModel
+
+		UserID         uint64 `gorm:"index;not null;comment:作者ID"`
+		Title          string `gorm:"type:varchar(100);not null;comment:标题"`
+		Description    string `gorm:"type:varchar(500);comment:描述"`
+		TopicsCount    int64  `gorm:"default:0;comment:话题数"`
+		FollowersCount int64  `gorm:"default:0;comment:关注数"`
+
+		models.CommonTimestampsField
+	}
+	type SeriesItem struct {
+		models.BaseModel
+
+		SeriesID uint64 `gorm:"index;not null;comment:系列ID"`
+		TopicID  uint64 `gorm:"uniqueIndex;not null;comment:话题ID"`
+		Position int    `gorm:"default:0;comment:排序位置"`
+
+		models.CommonTimestampsField
+	}
+	type SeriesFollow struct {
+		models.BaseModel
+
+		SeriesID uint64 `gorm:"uniqueIndex:uidx_series_follow;not null;comment:系列ID"`
+		UserID   uint64 `gorm:"uniqueIndex:uidx_series_follow;index;not null;comment:关注者ID"`
+
+		models.CommonTimestampsField
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&Series{}, &SeriesItem{}, &SeriesFollow{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.DropTable(&SeriesFollow{}, &SeriesItem{}, &Series{})
+	}
+
+	migrate.Add("2026_01_12_010000_create_series_tables", up, down)
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"database/sql"
+	"time"
+
+	"GoHub-Service/app/models"
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Mention struct {
+		models.BaseModel
+
+		SourceType string     `gorm:"type:varchar(20);uniqueIndex:uidx_mention_source;not null;comment:来源类型"`
+		SourceID   uint64     `gorm:"uniqueIndex:uidx_mention_source;not null;comment:来源ID"`
+		UserID     uint64     `gorm:"uniqueIndex:uidx_mention_source;index;not null;comment:被提及用户ID"`
+		ActorID    uint64     `gorm:"not null;comment:提及者ID"`
+		NotifiedAt *time.Time `gorm:"comment:通知时间，为空表示尚未通知"`
+
+		models.CommonTimestampsField
+	}
+	type UserBlock struct {
+		models.BaseModel
+
+		UserID    uint64 `gorm:"uniqueIndex:uidx_user_block;not null;comment:屏蔽者ID"`
+		BlockedID uint64 `gorm:"uniqueIndex:uidx_user_block;index;not null;comment:被屏蔽者ID"`
+
+		models.CommonTimestampsField
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&Mention{}, &UserBlock{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.DropTable(&UserBlock{}, &Mention{})
+	}
+
+	migrate.Add("2026_01_13_010000_create_mentions_and_user_blocks_tables", up, down)
+}
@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"database/sql"
+	"time"
+
+	"GoHub-Service/app/models"
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type ReactionType struct {
+		models.BaseModel
+
+		Emoji    string `gorm:"type:varchar(32);uniqueIndex;not null;comment:表情"`
+		Name     string `gorm:"type:varchar(50);not null;comment:名称"`
+		Position int    `gorm:"default:0;comment:排序位置"`
+		Enabled  bool   `gorm:"default:true;comment:是否启用"`
+
+		models.CommonTimestampsField
+	}
+	type Reaction struct {
+		models.BaseModel
+
+		TargetType string `gorm:"type:varchar(20);uniqueIndex:uidx_reaction;not null;comment:目标类型"`
+		TargetID   uint64 `gorm:"uniqueIndex:uidx_reaction;not null;comment:目标ID"`
+		UserID     uint64 `gorm:"uniqueIndex:uidx_reaction;index;not null;comment:用户ID"`
+		Emoji      string `gorm:"type:varchar(32);uniqueIndex:uidx_reaction;not null;comment:表情"`
+
+		models.CommonTimestampsField
+	}
+
+	// 默认可用的表情，管理员可以在后台调整
+	defaults := []struct{ emoji, name string }{
+		{"👍", "赞同"},
+		{"❤️", "喜欢"},
+		{"😂", "好笑"},
+		{"🎉", "庆祝"},
+		{"😮", "惊讶"},
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&ReactionType{}, &Reaction{})
+		now := time.Now()
+		for i, d := range defaults {
+			DB.Exec("INSERT INTO reaction_types (emoji, name, position, enabled, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)",
+				d.emoji, d.name, i+1, true, now, now)
+		}
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.DropTable(&Reaction{}, &ReactionType{})
+	}
+
+	migrate.Add("2026_01_14_010000_create_reactions_tables", up, down)
+}
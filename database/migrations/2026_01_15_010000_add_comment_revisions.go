@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"database/sql"
+	"time"
+
+	"GoHub-Service/app/models"
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Comment struct {
+		EditCount int        `gorm:"default:0;comment:编辑次数"`
+		EditedAt  *time.Time `gorm:"comment:最后编辑时间"`
+	}
+	type CommentRevision struct {
+		models.BaseModel
+
+		CommentID uint64 `gorm:"index;not null;comment:评论ID"`
+		EditorID  uint64 `gorm:"index;not null;comment:编辑人ID"`
+		Content   string `gorm:"type:text;not null;comment:修改前的内容"`
+
+		models.CommonTimestampsField
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&Comment{}, &CommentRevision{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.DropTable(&CommentRevision{})
+		_ = migrator.DropColumn(&Comment{}, "edited_at")
+		_ = migrator.DropColumn(&Comment{}, "edit_count")
+	}
+
+	migrate.Add("2026_01_15_010000_add_comment_revisions", up, down)
+}
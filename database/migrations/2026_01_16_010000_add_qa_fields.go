@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"database/sql"
+	"time"
+
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Category struct {
+		IsQA bool `gorm:"type:boolean;default:false;comment:是否问答分类"`
+	}
+	type Topic struct {
+		AcceptedCommentID uint64     `gorm:"index;default:0;comment:采纳的回答ID"`
+		SolvedAt          *time.Time `gorm:"comment:采纳回答的时间"`
+	}
+	type Comment struct {
+		IsAccepted bool `gorm:"type:boolean;default:false;comment:是否被采纳为答案"`
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&Category{}, &Topic{}, &Comment{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.DropColumn(&Comment{}, "is_accepted")
+		_ = migrator.DropColumn(&Topic{}, "solved_at")
+		_ = migrator.DropColumn(&Topic{}, "accepted_comment_id")
+		_ = migrator.DropColumn(&Category{}, "is_qa")
+	}
+
+	migrate.Add("2026_01_16_010000_add_qa_fields", up, down)
+}
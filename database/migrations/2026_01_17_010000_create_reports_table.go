@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"database/sql"
+	"time"
+
+	"GoHub-Service/app/models"
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Report struct {
+		models.BaseModel
+
+		ReporterID  uint64     `gorm:"uniqueIndex:uidx_report_reporter;not null;comment:举报人ID"`
+		TargetType  string     `gorm:"type:varchar(20);uniqueIndex:uidx_report_reporter;index:idx_report_target;not null;comment:目标类型"`
+		TargetID    uint64     `gorm:"uniqueIndex:uidx_report_reporter;index:idx_report_target;not null;comment:目标ID"`
+		OwnerID     uint64     `gorm:"index;default:0;comment:被举报内容的作者ID"`
+		Reason      string     `gorm:"type:varchar(20);index;not null;comment:举报原因"`
+		Detail      string     `gorm:"type:varchar(1000);comment:补充说明"`
+		Status      string     `gorm:"type:varchar(20);index;not null;default:open;comment:处理状态"`
+		Action      string     `gorm:"type:varchar(20);comment:处置方式"`
+		HandledBy   uint64     `gorm:"default:0;comment:处理人ID"`
+		HandledAt   *time.Time `gorm:"comment:处理时间"`
+		HandlerNote string     `gorm:"type:varchar(500);comment:处理说明"`
+
+		models.CommonTimestampsField
+	}
+	type Comment struct {
+		HiddenAt *time.Time `gorm:"comment:因举报被隐藏的时间"`
+	}
+	type Message struct {
+		HiddenAt *time.Time `gorm:"comment:因举报被隐藏的时间"`
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&Report{}, &Comment{}, &Message{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.DropColumn(&Message{}, "hidden_at")
+		_ = migrator.DropColumn(&Comment{}, "hidden_at")
+		_ = migrator.DropTable(&Report{})
+	}
+
+	migrate.Add("2026_01_17_010000_create_reports_table", up, down)
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"database/sql"
+	"time"
+
+	"GoHub-Service/app/models"
+	"GoHub-Service/pkg/migrate"
+	"GoHub-Service/pkg/security"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type SensitiveWord struct {
+		models.BaseModel
+
+		Word     string `gorm:"type:varchar(100);uniqueIndex;not null;comment:敏感词"`
+		Category string `gorm:"type:varchar(20);index;not null;default:other;comment:分类"`
+		Severity string `gorm:"type:varchar(20);index;not null;default:replace;comment:处理方式 block/replace/review"`
+		Pinyin   string `gorm:"type:varchar(255);comment:拼音等变体，多个用逗号分隔"`
+		Note     string `gorm:"type:varchar(255);comment:备注"`
+
+		models.CommonTimestampsField
+	}
+
+	// 写入内置的默认敏感词，启动时从数据库加载的词库会整体替换内置词库，空表会导致过滤失效
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&SensitiveWord{})
+		now := time.Now()
+		for _, entry := range security.DefaultWords() {
+			DB.Exec("INSERT INTO sensitive_words (word, category, severity, created_at, updated_at) VALUES (?, ?, ?, ?, ?)",
+				entry.Word, entry.Category, entry.Severity, now, now)
+		}
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.DropTable(&SensitiveWord{})
+	}
+
+	migrate.Add("2026_01_18_010000_create_sensitive_words_table", up, down)
+}
@@ -0,0 +1,75 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"GoHub-Service/app/models"
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type SpamFingerprint struct {
+		models.BaseModel
+
+		ContentType string `gorm:"type:varchar(20);uniqueIndex:uidx_spam_fingerprint_content;index:idx_spam_fingerprint_user;not null;comment:内容类型"`
+		ContentID   uint64 `gorm:"uniqueIndex:uidx_spam_fingerprint_content;not null;comment:内容ID"`
+		UserID      uint64 `gorm:"index:idx_spam_fingerprint_user;not null;comment:作者ID"`
+		SimHash     uint64 `gorm:"column:simhash;type:bigint unsigned;not null;comment:SimHash 指纹"`
+		Band0       uint16 `gorm:"index;not null;comment:指纹第 1 段"`
+		Band1       uint16 `gorm:"index;not null;comment:指纹第 2 段"`
+		Band2       uint16 `gorm:"index;not null;comment:指纹第 3 段"`
+		Band3       uint16 `gorm:"index;not null;comment:指纹第 4 段"`
+		Length      int    `gorm:"not null;default:0;comment:去掉空白和标点后的字数"`
+
+		models.CommonTimestampsField
+	}
+
+	type SpamAttempt struct {
+		models.BaseModel
+
+		UserID      uint64  `gorm:"index;not null;comment:作者ID"`
+		ContentType string  `gorm:"type:varchar(20);index;not null;comment:内容类型"`
+		ContentID   uint64  `gorm:"default:0;comment:内容ID，拒绝发布时为 0"`
+		Action      string  `gorm:"type:varchar(20);index;not null;comment:处理结果 blocked/review"`
+		Reason      string  `gorm:"type:varchar(20);index;not null;comment:拦截原因 velocity/spam"`
+		Score       float64 `gorm:"not null;default:0;comment:垃圾信息分数"`
+		Detail      string  `gorm:"type:varchar(500);comment:判定依据"`
+		Excerpt     string  `gorm:"type:varchar(200);comment:内容摘要"`
+
+		models.CommonTimestampsField
+	}
+
+	type SpamSetting struct {
+		models.BaseModel
+
+		Enabled               bool    `gorm:"not null;comment:是否开启"`
+		NewAccountDays        int     `gorm:"not null;comment:注册不满多少天视为新用户"`
+		VelocityWindowMinutes int     `gorm:"not null;comment:发布频率统计窗口（分钟）"`
+		NewUserMaxTopics      int     `gorm:"not null;comment:新用户窗口内最多发布话题数"`
+		NewUserMaxComments    int     `gorm:"not null;comment:新用户窗口内最多发布评论数"`
+		MaxTopics             int     `gorm:"not null;comment:普通用户窗口内最多发布话题数"`
+		MaxComments           int     `gorm:"not null;comment:普通用户窗口内最多发布评论数"`
+		DuplicateDistance     int     `gorm:"not null;comment:汉明距离不超过该值视为近似内容"`
+		DuplicateWindowHours  int     `gorm:"not null;comment:近似内容查找范围（小时）"`
+		DuplicateMinLength    int     `gorm:"not null;comment:少于该字数的内容不做近似判断"`
+		MaxLinks              int     `gorm:"not null;comment:链接数上限"`
+		MaxLinkDensity        float64 `gorm:"not null;comment:链接字符占比上限"`
+		ReviewScore           float64 `gorm:"not null;comment:进入人工审核的分数"`
+		RejectScore           float64 `gorm:"not null;comment:拒绝发布的分数"`
+		UpdatedBy             uint64  `gorm:"default:0;comment:最后修改人"`
+
+		models.CommonTimestampsField
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&SpamFingerprint{}, &SpamAttempt{}, &SpamSetting{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.DropTable(&SpamFingerprint{}, &SpamAttempt{}, &SpamSetting{})
+	}
+
+	migrate.Add("2026_01_19_010000_create_spam_tables", up, down)
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"GoHub-Service/app/models"
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Category struct {
+		ParentID uint64 `gorm:"index;default:0;comment:上级分类ID，0 表示顶级分类"`
+		Path     string `gorm:"type:varchar(255);index;comment:从顶级分类到本分类的ID路径，如 /1/4/9/"`
+		Depth    int    `gorm:"default:0;comment:层级，顶级分类为 0"`
+	}
+	type CategoryModerator struct {
+		models.BaseModel
+
+		CategoryID uint64 `gorm:"uniqueIndex:uidx_category_moderator;not null;comment:分类ID"`
+		UserID     uint64 `gorm:"uniqueIndex:uidx_category_moderator;index;not null;comment:版主用户ID"`
+		AssignedBy uint64 `gorm:"default:0;comment:指派人"`
+
+		models.CommonTimestampsField
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&Category{}, &CategoryModerator{})
+		// 已有分类均为顶级分类
+		_, _ = DB.Exec("UPDATE categories SET path = CONCAT('/', id, '/') WHERE path IS NULL OR path = ''")
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.DropTable(&CategoryModerator{})
+		_ = migrator.DropColumn(&Category{}, "depth")
+		_ = migrator.DropColumn(&Category{}, "path")
+		_ = migrator.DropColumn(&Category{}, "parent_id")
+	}
+
+	migrate.Add("2026_01_20_010000_add_category_tree_and_moderators", up, down)
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Category struct {
+		Visibility   string `gorm:"type:varchar(16);default:public;comment:可见范围 public/members/roles"`
+		VisibleRoles string `gorm:"type:varchar(255);comment:可见的角色，逗号分隔"`
+		PostPolicy   string `gorm:"type:varchar(16);default:anyone;comment:发布权限 anyone/approved/roles"`
+		PostRoles    string `gorm:"type:varchar(255);comment:可发布的角色，逗号分隔"`
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&Category{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.DropColumn(&Category{}, "post_roles")
+		_ = migrator.DropColumn(&Category{}, "post_policy")
+		_ = migrator.DropColumn(&Category{}, "visible_roles")
+		_ = migrator.DropColumn(&Category{}, "visibility")
+	}
+
+	migrate.Add("2026_01_21_010000_add_category_access_control", up, down)
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"database/sql"
+	"time"
+
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Link struct {
+		Logo        string `gorm:"type:varchar(255);comment:Logo 地址"`
+		Description string `gorm:"type:varchar(255);comment:站点简介"`
+		SortOrder   int    `gorm:"default:0;index;comment:排序值，越小越靠前"`
+		IsEnabled   bool   `gorm:"default:true;comment:是否启用"`
+
+		Status       string `gorm:"type:varchar(16);default:approved;index;comment:状态 pending/approved/rejected"`
+		ApplicantID  uint64 `gorm:"default:0;index;comment:申请人，管理员添加时为 0"`
+		ContactEmail string `gorm:"type:varchar(255);comment:申请人联系邮箱"`
+		RejectReason string `gorm:"type:varchar(255);comment:拒绝原因"`
+
+		ClickCount int64 `gorm:"default:0;comment:点击次数"`
+
+		IsBroken      bool   `gorm:"default:false;comment:是否失效"`
+		FailCount     int    `gorm:"default:0;comment:连续检测失败次数"`
+		CheckError    string `gorm:"type:varchar(255);comment:最近一次检测失败原因"`
+		LastCheckedAt *time.Time
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&Link{})
+		// 已有链接都是管理员添加的，视为审核通过并启用
+		_, _ = DB.Exec("UPDATE links SET status = 'approved', is_enabled = 1 WHERE status IS NULL OR status = ''")
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		for _, column := range []string{
+			"last_checked_at", "check_error", "fail_count", "is_broken", "click_count",
+			"reject_reason", "contact_email", "applicant_id", "status",
+			"is_enabled", "sort_order", "description", "logo",
+		} {
+			_ = migrator.DropColumn(&Link{}, column)
+		}
+	}
+
+	migrate.Add("2026_01_22_010000_add_link_management_fields", up, down)
+}
@@ -0,0 +1,77 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"GoHub-Service/app/models"
+	"GoHub-Service/pkg/console"
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type User struct {
+		FollowingCount int64 `gorm:"type:int;default:0;comment:关注数"`
+	}
+
+	// Follow 同一对用户只保留一条关注记录，关注时依赖唯一索引忽略重复插入
+	type Follow struct {
+		models.BaseModel
+		UserID   string `gorm:"type:varchar(255);not null;uniqueIndex:uidx_user_follow;comment:关注者ID"`
+		FollowID string `gorm:"type:varchar(255);not null;uniqueIndex:uidx_user_follow;comment:被关注者ID"`
+		models.CommonTimestampsField
+	}
+
+	type UserFollow struct {
+		models.BaseModel
+		FollowerID string `gorm:"type:bigint;not null;index:idx_user_follow_follower;uniqueIndex:uidx_user_follow_pair"`
+		FolloweeID string `gorm:"type:bigint;not null;index:idx_user_follow_followee;uniqueIndex:uidx_user_follow_pair"`
+		models.CommonTimestampsField
+	}
+
+	// recount 按 follows 表重新计算所有用户的粉丝数和关注数
+	recount := func(DB *sql.DB) {
+		_, _ = DB.Exec(`UPDATE users SET
+			followers_count = (SELECT COUNT(*) FROM follows WHERE follows.follow_id = users.id),
+			following_count = (SELECT COUNT(*) FROM follows WHERE follows.user_id = users.id)`)
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&User{})
+
+		// 删除重复的关注记录后用唯一索引替换原来的普通索引
+		_, err := DB.Exec(`DELETE f FROM follows f JOIN follows g
+			ON f.user_id = g.user_id AND f.follow_id = g.follow_id AND f.id > g.id`)
+		console.ExitIf(err)
+		if migrator.HasIndex(&Follow{}, "idx_user_follow") {
+			console.ExitIf(migrator.DropIndex(&Follow{}, "idx_user_follow"))
+		}
+		console.ExitIf(migrator.CreateIndex(&Follow{}, "uidx_user_follow"))
+
+		// 用户实际的关注记录在 user_follows 表，合并到 follows 表后删除 user_follows
+		// 复制失败时中止迁移并保留 user_follows，修复后可重新执行
+		if migrator.HasTable("user_follows") {
+			_, err = DB.Exec(`INSERT IGNORE INTO follows (user_id, follow_id, created_at, updated_at)
+				SELECT follower_id, followee_id, created_at, updated_at FROM user_follows
+				WHERE follower_id <> followee_id`)
+			console.ExitIf(err)
+			_ = migrator.DropTable("user_follows")
+		}
+		_, _ = DB.Exec("DELETE FROM follows WHERE user_id = follow_id")
+
+		recount(DB)
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&UserFollow{})
+		_, err := DB.Exec(`INSERT IGNORE INTO user_follows (follower_id, followee_id, created_at, updated_at)
+			SELECT user_id, follow_id, created_at, updated_at FROM follows`)
+		console.ExitIf(err)
+		_ = migrator.DropColumn(&User{}, "following_count")
+		_ = migrator.DropIndex(&Follow{}, "uidx_user_follow")
+		_, _ = DB.Exec("CREATE INDEX idx_user_follow ON follows (user_id, follow_id)")
+	}
+
+	migrate.Add("2026_01_23_010000_merge_user_follows_into_follows", up, down)
+}
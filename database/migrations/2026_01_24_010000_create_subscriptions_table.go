@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"GoHub-Service/app/models"
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Subscription struct {
+		models.BaseModel
+
+		UserID     uint64 `gorm:"uniqueIndex:uidx_subscription;not null;comment:用户ID"`
+		TargetType string `gorm:"type:varchar(16);uniqueIndex:uidx_subscription;index:idx_subscription_target;not null;comment:关注对象类型 tag/category"`
+		TargetID   uint64 `gorm:"uniqueIndex:uidx_subscription;index:idx_subscription_target;not null;comment:关注对象ID"`
+
+		models.CommonTimestampsField
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&Subscription{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.DropTable(&Subscription{})
+	}
+
+	migrate.Add("2026_01_24_010000_create_subscriptions_table", up, down)
+}
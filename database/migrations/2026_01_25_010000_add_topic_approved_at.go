@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"database/sql"
+	"time"
+
+	"GoHub-Service/pkg/console"
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Topic struct {
+		ApprovedAt *time.Time `gorm:"comment:首次审核通过时间"`
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&Topic{})
+		// 已通过的话题视为已经推送过信息流和通知
+		_, err := DB.Exec("UPDATE topics SET approved_at = COALESCE(reviewed_at, created_at) WHERE status = 1 AND approved_at IS NULL")
+		console.ExitIf(err)
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.DropColumn(&Topic{}, "approved_at")
+	}
+
+	migrate.Add("2026_01_25_010000_add_topic_approved_at", up, down)
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"GoHub-Service/app/models"
+	"GoHub-Service/pkg/console"
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	// Like 同一用户对同一对象只保留一条点赞记录，点赞时依赖唯一索引忽略重复插入
+	type Like struct {
+		models.BaseModel
+		UserID     string `gorm:"type:varchar(255);not null;uniqueIndex:uidx_like_target_user;comment:点赞用户ID"`
+		TargetType string `gorm:"type:varchar(50);not null;uniqueIndex:uidx_like_target_user;comment:目标类型(topic/comment)"`
+		TargetID   string `gorm:"type:varchar(255);not null;uniqueIndex:uidx_like_target_user;comment:目标ID"`
+		models.CommonTimestampsField
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		// 建表时添加唯一索引的语句忽略了错误，已有重复记录的库中索引并不存在
+		if migrator.HasIndex(&Like{}, "uidx_like_target_user") {
+			return
+		}
+		_, err := DB.Exec(`DELETE l FROM likes l JOIN likes k
+			ON l.user_id = k.user_id AND l.target_type = k.target_type AND l.target_id = k.target_id AND l.id > k.id`)
+		console.ExitIf(err)
+		console.ExitIf(migrator.CreateIndex(&Like{}, "uidx_like_target_user"))
+
+		// 重复点赞计入了评论的点赞数，按去重后的记录重新计算
+		_, err = DB.Exec(`UPDATE comments SET like_count =
+			(SELECT COUNT(*) FROM likes WHERE likes.target_type = 'comment' AND likes.target_id = comments.id)`)
+		console.ExitIf(err)
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		// 唯一索引由建表迁移声明，回滚本迁移时保留
+	}
+
+	migrate.Add("2026_01_26_010000_add_likes_unique_index", up, down)
+}
@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"database/sql"
+	"time"
+
+	"GoHub-Service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	type TopicViewFlush struct {
+		BatchID   string    `gorm:"primaryKey;type:varchar(32);comment:浏览量批次ID"`
+		CreatedAt time.Time `gorm:"index;comment:写库时间"`
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.AutoMigrate(&TopicViewFlush{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		_ = migrator.DropTable(&TopicViewFlush{})
+	}
+
+	migrate.Add("2026_01_27_010000_create_topic_view_flushes_table", up, down)
+}
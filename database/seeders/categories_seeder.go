@@ -28,6 +28,8 @@ func init() {
 			logger.LogIf(err)
 			return
 		}
+		// 种子分类均为顶级分类
+		logger.LogIf(db.Exec("UPDATE categories SET path = CONCAT('/', id, '/') WHERE path IS NULL OR path = ''").Error)
 
 		console.Success(fmt.Sprintf("Table [%v] %v rows seeded", result.Statement.Table, result.RowsAffected))
 	})
@@ -18,5 +18,6 @@ func Initialize() {
 		"SeedInteractions",
 		"SeedMessages",
 		"SeedLinksTable",
+		"SeedSensitiveWordsTable",
 	})
 }
@@ -0,0 +1,39 @@
+package seeders
+
+import (
+	"fmt"
+
+	"GoHub-Service/app/models/sensitive"
+	"GoHub-Service/pkg/console"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/security"
+	"GoHub-Service/pkg/seed"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func init() {
+
+	// 将内置的默认敏感词写入词库，之后由管理员在后台维护
+	// 迁移时已写入默认词，这里跳过已存在的词条
+	seed.Add("SeedSensitiveWordsTable", func(db *gorm.DB) {
+		defaults := security.DefaultWords()
+		words := make([]sensitive.Word, 0, len(defaults))
+		for _, entry := range defaults {
+			words = append(words, sensitive.Word{
+				Word:     entry.Word,
+				Category: entry.Category,
+				Severity: entry.Severity,
+			})
+		}
+
+		result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&words)
+		if err := result.Error; err != nil {
+			logger.LogIf(err)
+			return
+		}
+
+		console.Success(fmt.Sprintf("Table [%v] %v rows seeded", result.Statement.Table, result.RowsAffected))
+	})
+}
@@ -43,6 +43,7 @@ func init() {
 				Body:          body,
 				CategoryID:    cat,
 				UserID:        author,
+				Status:        topic.StatusApproved,
 				ViewCount:     int64(rand.Intn(2000)),
 				LikeCount:     int64(rand.Intn(200)),
 				FavoriteCount: int64(rand.Intn(150)),
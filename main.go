@@ -62,12 +62,36 @@ func main() {
 			// 初始化缓存
 			bootstrap.SetupCache()
 
+			// 初始化 Elasticsearch（未启用时跳过）
+			bootstrap.LoadElasticsearch()
+
 			// 启动资源泄漏定期报告（仅在 serve 命令时启动）
 			if command.Name() == "serve" {
 				// 从配置读取阈值和检查间隔
 				threshold := time.Duration(appconfig.GetResourceLeakThresholdMinutes()) * time.Minute
 				interval := time.Duration(appconfig.GetCheckIntervalMinutes()) * time.Minute
 				bootstrap.StartTrackerReporting(threshold, interval)
+
+				// 启动话题自动归档
+				bootstrap.StartTopicArchiver()
+
+				// 启动回收站定期清理
+				bootstrap.StartTrashPurger()
+
+				// 启动友情链接可用性检测
+				bootstrap.StartLinkChecker()
+
+				// 启动浏览量批量写库
+				bootstrap.StartViewFlusher()
+
+				// 加载敏感词词库并订阅变更
+				bootstrap.SetupSensitiveWords()
+
+				// 订阅分类变更，使各实例的分类索引失效
+				bootstrap.SetupCategoryIndex()
+
+				// 启动异步内容审核
+				bootstrap.StartContentModeration()
 			}
 		},
 	}
@@ -82,6 +106,7 @@ func main() {
 		cmd.CmdDBSeed,
 		cmd.CmdCache,
 		cmd.CmdSlowLog,
+		cmd.CmdLikes,
 	)
 
 	// 配置默认运行 Web 服务
@@ -30,7 +30,7 @@ var SelectFields = map[string][]string{
 	// 评论字段
 	"comment_basic": {"id", "content", "user_id", "topic_id"},
 	"comment_list":  {"id", "topic_id", "user_id", "content", "parent_id", "like_count", "created_at"},
-	"comment_full":  {"id", "topic_id", "user_id", "content", "parent_id", "like_count", "created_at", "updated_at"},
+	"comment_full":  {"id", "topic_id", "user_id", "content", "parent_id", "like_count", "is_accepted", "edit_count", "edited_at", "hidden_at", "created_at", "updated_at"},
 
 	// 分类字段
 	"category_basic": {"id", "name"},
@@ -15,6 +15,19 @@ type Client struct {
 	client *elasticsearch.Client
 }
 
+// defaultClient 全局客户端，由 bootstrap.LoadElasticsearch 初始化
+var defaultClient *Client
+
+// SetDefault 设置全局客户端
+func SetDefault(c *Client) {
+	defaultClient = c
+}
+
+// Default 获取全局客户端，未启用或初始化失败时返回 nil
+func Default() *Client {
+	return defaultClient
+}
+
 // NewClient 创建新的ES客户端
 func NewClient(addresses []string) (*Client, error) {
 	cfg := elasticsearch.Config{
@@ -22,6 +22,8 @@ type SearchRequest struct {
 	Page       int
 	PageSize   int
 	SortBy     string // "relevance", "latest", "popular"
+
+	ExcludeCategoryIDs []uint64 // 当前用户无权查看的分类
 }
 
 // SearchResult 搜索结果
@@ -92,6 +94,7 @@ func (ss *SearchService) buildQuery(req SearchRequest) map[string]interface{} {
 		})
 		query["query"].(map[string]interface{})["bool"].(map[string]interface{})["filter"] = filters
 	}
+	excludeCategories(query["query"].(map[string]interface{})["bool"].(map[string]interface{}), req.ExcludeCategoryIDs)
 
 	// 添加排序
 	sort := ss.buildSort(req.SortBy)
@@ -180,8 +183,33 @@ func (ss *SearchService) parseResults(results map[string]interface{}) ([]SearchR
 	return topics, total
 }
 
-// SuggestTopics 搜索建议/自动完成
-func (ss *SearchService) SuggestTopics(ctx context.Context, prefix string, limit int) ([]string, error) {
+// excludeCategories 在 bool 查询中排除指定分类
+func excludeCategories(boolQuery map[string]interface{}, categoryIDs []uint64) {
+	if len(categoryIDs) == 0 {
+		return
+	}
+	boolQuery["must_not"] = []map[string]interface{}{
+		{
+			"terms": map[string]interface{}{
+				"category_id": categoryIDs,
+			},
+		},
+	}
+}
+
+// SuggestTopics 搜索建议/自动完成，excludeCategoryIDs 中分类的话题标题不作为建议
+func (ss *SearchService) SuggestTopics(ctx context.Context, prefix string, limit int, excludeCategoryIDs ...uint64) ([]string, error) {
+	boolQuery := map[string]interface{}{
+		"must": []map[string]interface{}{
+			{
+				"prefix": map[string]interface{}{
+					"title": prefix,
+				},
+			},
+		},
+	}
+	excludeCategories(boolQuery, excludeCategoryIDs)
+
 	query := map[string]interface{}{
 		"size": 0,
 		"aggs": map[string]interface{}{
@@ -196,9 +224,7 @@ func (ss *SearchService) SuggestTopics(ctx context.Context, prefix string, limit
 			},
 		},
 		"query": map[string]interface{}{
-			"prefix": map[string]interface{}{
-				"title": prefix,
-			},
+			"bool": boolQuery,
 		},
 	}
 
@@ -220,13 +246,62 @@ func (ss *SearchService) SuggestTopics(ctx context.Context, prefix string, limit
 	return suggests, nil
 }
 
-// GetHotTopics 获取热门话题
-func (ss *SearchService) GetHotTopics(ctx context.Context, limit int) ([]SearchResult, error) {
+// GetHotTopics 获取热门话题，不包含 excludeCategoryIDs 中分类的话题
+func (ss *SearchService) GetHotTopics(ctx context.Context, limit int, excludeCategoryIDs ...uint64) ([]SearchResult, error) {
+	boolQuery := map[string]interface{}{
+		"must": []map[string]interface{}{
+			{
+				"term": map[string]interface{}{
+					"status": "published",
+				},
+			},
+		},
+	}
+	excludeCategories(boolQuery, excludeCategoryIDs)
+	query := map[string]interface{}{
+		"size": limit,
+		"query": map[string]interface{}{
+			"bool": boolQuery,
+		},
+		"sort": []interface{}{
+			map[string]interface{}{
+				"likes_count": map[string]string{"order": "desc"},
+			},
+			map[string]interface{}{
+				"views_count": map[string]string{"order": "desc"},
+			},
+		},
+	}
+
+	results, err := ss.client.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	topics, _ := ss.parseResults(results)
+	return topics, nil
+}
+
+// RelatedTopics 使用 more_like_this 查询与指定话题内容相似的话题，结果不包含话题本身
+func (ss *SearchService) RelatedTopics(ctx context.Context, topicID string, limit int) ([]SearchResult, error) {
 	query := map[string]interface{}{
 		"size": limit,
 		"query": map[string]interface{}{
 			"bool": map[string]interface{}{
 				"must": []map[string]interface{}{
+					{
+						"more_like_this": map[string]interface{}{
+							"fields": []string{"title", "content"},
+							"like": []map[string]interface{}{
+								{"_index": "gohub-topics", "_id": topicID},
+							},
+							"min_term_freq":   1,
+							"min_doc_freq":    1,
+							"max_query_terms": 25,
+						},
+					},
+				},
+				"filter": []map[string]interface{}{
 					{
 						"term": map[string]interface{}{
 							"status": "published",
@@ -235,14 +310,6 @@ func (ss *SearchService) GetHotTopics(ctx context.Context, limit int) ([]SearchR
 				},
 			},
 		},
-		"sort": []interface{}{
-			map[string]interface{}{
-				"likes_count": map[string]string{"order": "desc"},
-			},
-			map[string]interface{}{
-				"views_count": map[string]string{"order": "desc"},
-			},
-		},
 	}
 
 	results, err := ss.client.Search(ctx, query)
@@ -131,13 +131,18 @@ func (s *SyncService) RemoveSingleTopic(ctx context.Context, topicID uint64) err
 
 // topicToMap 将话题模型转为搜索文档
 func (s *SyncService) topicToMap(t topic.Topic) map[string]interface{} {
+	// 搜索只返回 published 的文档，未通过审核的话题不对外展示
+	status := "published"
+	if !t.IsApproved() {
+		status = "pending"
+	}
 	return map[string]interface{}{
 		"id":             t.ID,
 		"title":          t.Title,
 		"content":        t.Body,
 		"category_id":    t.CategoryID,
 		"user_id":        t.UserID,
-		"status":         "published", // 假设已发布
+		"status":         status,
 		"created_at":     t.CreatedAt.Format("2006-01-02T15:04:05Z"),
 		"updated_at":     t.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 		"likes_count":    t.LikeCount,
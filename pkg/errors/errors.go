@@ -78,20 +78,32 @@ const (
 	CodeTopicNotFound      = 4101 // 话题不存在
 	CodeTopicAlreadyExists = 4102 // 话题已存在
 	CodeTopicDisabled      = 4103 // 话题已禁用
+	CodeTopicLocked        = 4104 // 话题已锁定
+	CodeTopicClosed        = 4105 // 话题已关闭
+	CodeTopicArchived      = 4106 // 话题已归档
+	CodeTopicNotQuestion   = 4107 // 话题不在问答分类中
 
 	// 评论模块 4200-4299
 	CodeCommentNotFound      = 4201 // 评论不存在
 	CodeCommentAlreadyDeleted = 4202 // 评论已删除
+	CodeCommentEditExpired    = 4203 // 评论编辑时限已过
+	CodeCommentNotAnswer      = 4204 // 回复不能被采纳为答案
 
 	// 分类模块 4300-4399
 	CodeCategoryNotFound      = 4301 // 分类不存在
 	CodeCategoryAlreadyExists = 4302 // 分类已存在
+	CodeCategoryHasChildren   = 4303 // 分类下还有子分类
+	CodeCategoryPostDenied    = 4304 // 无权在该分类发布
 
 	// 权限模块 4400-4499
 	CodeRoleNotFound      = 4401 // 角色不存在
 	CodeRoleAlreadyExists = 4402 // 角色已存在
 	CodePermissionDenied  = 4403 // 权限不足
 	CodePermissionNotFound = 4404 // 权限不存在
+
+	// 内容安全模块 4500-4599
+	CodePostingTooFrequent = 4501 // 发布过于频繁
+	CodeSpamDetected       = 4502 // 疑似垃圾信息
 )
 
 // AppError 应用错误基础结构
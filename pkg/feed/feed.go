@@ -0,0 +1,124 @@
+// Package feed 个性化信息流的条目编码与多来源合并
+// 普通作者的内容在发布时写入关注者的收件箱（Redis 列表），粉丝很多的作者、关注的标签和分类在读取时查询，
+// 读取时把各来源的条目按时间倒序合并、去重后分页
+package feed
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 条目类型
+const (
+	KindTopic   = "topic"   // 发布了话题
+	KindComment = "comment" // 回复了话题
+)
+
+// 条目出现在信息流中的原因
+const (
+	ReasonUser     = "user"     // 关注的用户
+	ReasonTag      = "tag"      // 关注的标签
+	ReasonCategory = "category" // 关注的分类
+)
+
+// Entry 信息流中的一条动态
+type Entry struct {
+	At       int64  // 发生时间，毫秒时间戳
+	Kind     string // KindTopic 或 KindComment
+	ID       uint64 // 话题或评论 ID
+	ActorID  uint64 // 作者
+	TopicID  uint64 // 所属话题，话题本身与 ID 相同
+	Reason   string // 出现在信息流中的原因，收件箱中的条目都来自关注的用户，不参与编码
+	SourceID uint64 // 原因对应的用户、标签或分类 ID，不参与编码
+}
+
+// Key 同一内容的唯一标识，用于去重和同一时间戳内排序
+func (e Entry) Key() string {
+	return e.Kind + ":" + strconv.FormatUint(e.ID, 10)
+}
+
+// Time 发生时间
+func (e Entry) Time() time.Time {
+	return time.UnixMilli(e.At)
+}
+
+// Encode 编码为收件箱中保存的字符串：时间|类型|ID|作者|话题
+func (e Entry) Encode() string {
+	return fmt.Sprintf("%d|%s|%d|%d|%d", e.At, e.Kind, e.ID, e.ActorID, e.TopicID)
+}
+
+// Parse 解析收件箱中的字符串，格式不正确时返回 false
+func Parse(raw string) (Entry, bool) {
+	parts := strings.Split(raw, "|")
+	if len(parts) != 5 || (parts[1] != KindTopic && parts[1] != KindComment) {
+		return Entry{}, false
+	}
+	at, err1 := strconv.ParseInt(parts[0], 10, 64)
+	id, err2 := strconv.ParseUint(parts[2], 10, 64)
+	actor, err3 := strconv.ParseUint(parts[3], 10, 64)
+	topicID, err4 := strconv.ParseUint(parts[4], 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+		return Entry{}, false
+	}
+	return Entry{At: at, Kind: parts[1], ID: id, ActorID: actor, TopicID: topicID, Reason: ReasonUser, SourceID: actor}, true
+}
+
+// Cursor 翻页位置，只返回排在该位置之后（更早）的条目
+type Cursor struct {
+	At  int64
+	Key string
+}
+
+// CursorOf 条目所在的位置
+func CursorOf(e Entry) Cursor {
+	return Cursor{At: e.At, Key: e.Key()}
+}
+
+// Before 条目是否排在游标之后，即时间更早，时间相同时按 Key 倒序
+func (c *Cursor) Before(e Entry) bool {
+	if c == nil {
+		return true
+	}
+	return e.At < c.At || (e.At == c.At && e.Key() < c.Key)
+}
+
+// newer 排序规则：时间倒序，时间相同时按 Key 倒序，与 Cursor.Before 一致
+func newer(a, b Entry) bool {
+	if a.At != b.At {
+		return a.At > b.At
+	}
+	return a.Key() > b.Key()
+}
+
+// reasonRank 同一内容来自多个来源时保留的原因，关注的用户优先于标签和分类
+var reasonRank = map[string]int{ReasonUser: 0, ReasonTag: 1, ReasonCategory: 2}
+
+// Merge 合并多个来源的条目：去掉游标之前的条目和重复内容，按时间倒序排列，最多返回 limit 条
+// 同一内容出现在多个来源时保留优先级最高的原因
+func Merge(cursor *Cursor, limit int, sources ...[]Entry) []Entry {
+	byKey := make(map[string]Entry)
+	for _, source := range sources {
+		for _, e := range source {
+			if !cursor.Before(e) {
+				continue
+			}
+			if existing, ok := byKey[e.Key()]; ok && reasonRank[existing.Reason] <= reasonRank[e.Reason] {
+				continue
+			}
+			byKey[e.Key()] = e
+		}
+	}
+
+	merged := make([]Entry, 0, len(byKey))
+	for _, e := range byKey {
+		merged = append(merged, e)
+	}
+	sort.Slice(merged, func(i, j int) bool { return newer(merged[i], merged[j]) })
+	if limit > 0 && len(merged) > limit {
+		merged = merged[:limit]
+	}
+	return merged
+}
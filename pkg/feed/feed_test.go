@@ -0,0 +1,66 @@
+package feed
+
+import "testing"
+
+func TestEntry_EncodeParse(t *testing.T) {
+	e := Entry{At: 1767225600123, Kind: KindComment, ID: 42, ActorID: 7, TopicID: 3}
+	parsed, ok := Parse(e.Encode())
+	if !ok {
+		t.Fatalf("Parse(%q) failed", e.Encode())
+	}
+	if parsed.At != e.At || parsed.Kind != e.Kind || parsed.ID != e.ID || parsed.ActorID != e.ActorID || parsed.TopicID != e.TopicID {
+		t.Errorf("parsed = %+v, want %+v", parsed, e)
+	}
+	if parsed.Reason != ReasonUser || parsed.SourceID != 7 {
+		t.Errorf("收件箱条目应来自关注的用户，got reason=%s source=%d", parsed.Reason, parsed.SourceID)
+	}
+
+	for _, raw := range []string{"", "1|topic|1|1", "x|topic|1|1|1", "1|like|1|1|1", "1|topic|-1|1|1"} {
+		if _, ok := Parse(raw); ok {
+			t.Errorf("Parse(%q) 应失败", raw)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	inbox := []Entry{
+		{At: 300, Kind: KindTopic, ID: 3, Reason: ReasonUser},
+		{At: 100, Kind: KindTopic, ID: 1, Reason: ReasonUser},
+		{At: 300, Kind: KindTopic, ID: 3, Reason: ReasonUser}, // 重复推送
+	}
+	tags := []Entry{
+		{At: 300, Kind: KindTopic, ID: 3, Reason: ReasonTag},
+		{At: 200, Kind: KindTopic, ID: 2, Reason: ReasonTag},
+	}
+	categories := []Entry{
+		{At: 200, Kind: KindTopic, ID: 2, Reason: ReasonCategory},
+		{At: 200, Kind: KindComment, ID: 9, Reason: ReasonCategory},
+	}
+
+	merged := Merge(nil, 0, inbox, tags, categories)
+	want := []string{"topic:3", "topic:2", "comment:9", "topic:1"}
+	if len(merged) != len(want) {
+		t.Fatalf("len = %d, want %d: %+v", len(merged), len(want), merged)
+	}
+	for i, key := range want {
+		if merged[i].Key() != key {
+			t.Errorf("merged[%d] = %s, want %s", i, merged[i].Key(), key)
+		}
+	}
+	if merged[0].Reason != ReasonUser || merged[1].Reason != ReasonTag {
+		t.Errorf("重复内容应保留优先级最高的原因，got %s, %s", merged[0].Reason, merged[1].Reason)
+	}
+
+	t.Run("按游标翻页", func(t *testing.T) {
+		cursor := CursorOf(merged[1])
+		page := Merge(&cursor, 1, inbox, tags, categories)
+		if len(page) != 1 || page[0].Key() != "comment:9" {
+			t.Errorf("page = %+v, want comment:9", page)
+		}
+		cursor = CursorOf(page[0])
+		page = Merge(&cursor, 10, inbox, tags, categories)
+		if len(page) != 1 || page[0].Key() != "topic:1" {
+			t.Errorf("page = %+v, want topic:1", page)
+		}
+	})
+}
@@ -0,0 +1,147 @@
+// Package linkcheck 友情链接可用性检测
+// 先发送 HEAD 请求，服务端不支持 HEAD 时改用 GET，响应状态码 >= 400 或请求失败视为不可用
+// 链接由用户提交，默认只允许访问公网地址，防止借检测请求探测内网服务
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// MaxRedirects 最多跟随的重定向次数
+const MaxRedirects = 5
+
+// ErrPrivateAddress 目标为内网、回环或链路本地等非公网地址
+var ErrPrivateAddress = errors.New("linkcheck: 不允许访问非公网地址")
+
+// Result 单个链接的检测结果
+type Result struct {
+	URL        string        `json:"url"`
+	OK         bool          `json:"ok"`
+	StatusCode int           `json:"status_code,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	Duration   time.Duration `json:"duration"`
+}
+
+// Checker 链接检测器
+type Checker struct {
+	Client    *http.Client
+	Timeout   time.Duration // 单个链接的超时时间，0 表示使用 Client 自身的设置
+	UserAgent string
+}
+
+// New 创建检测器，timeout 为单个链接的超时时间
+func New(timeout time.Duration) *Checker {
+	return &Checker{
+		Client:    newClient(isPublicIP),
+		Timeout:   timeout,
+		UserAgent: "GoHub-LinkChecker/1.0",
+	}
+}
+
+// newClient 创建只能连接 allow 允许的 IP 的客户端
+// 在建立连接时校验解析后的地址，域名解析到内网同样会被拒绝；重定向的每一跳都重新校验且最多跟随 MaxRedirects 次
+func newClient(allow func(net.IP) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !allow(ip) {
+				return ErrPrivateAddress
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		// 不使用环境变量中的代理，否则连接的是代理地址，无法校验真正的目标
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+			MaxIdleConnsPerHost: 2,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= MaxRedirects {
+				return fmt.Errorf("linkcheck: 重定向超过 %d 次", MaxRedirects)
+			}
+			return checkTarget(req.URL, allow)
+		},
+	}
+}
+
+// checkTarget 校验重定向目标，只允许 http 和 https，主机为 IP 时直接校验地址
+func checkTarget(u *url.URL, allow func(net.IP) bool) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("linkcheck: 不支持的协议 %q", u.Scheme)
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil && !allow(ip) {
+		return ErrPrivateAddress
+	}
+	return nil
+}
+
+// isPublicIP 是否为公网地址
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast())
+}
+
+// Check 检测链接是否可以正常访问，跟随重定向后以最终响应为准
+func (c *Checker) Check(ctx context.Context, url string) Result {
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	status, err := c.do(ctx, http.MethodHead, url)
+	// 部分站点不支持 HEAD，改用 GET 再试一次
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = c.do(ctx, http.MethodGet, url)
+	}
+
+	result := Result{URL: url, StatusCode: status, Duration: time.Since(start)}
+	switch {
+	case err != nil:
+		result.Error = err.Error()
+	case status >= http.StatusBadRequest:
+		result.Error = fmt.Sprintf("HTTP %d", status)
+	default:
+		result.OK = true
+	}
+	return result
+}
+
+// do 发送请求并返回状态码，响应体只读取少量内容以便复用连接
+func (c *Checker) do(ctx context.Context, method, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.CopyN(io.Discard, resp.Body, 4096)
+	return resp.StatusCode, nil
+}
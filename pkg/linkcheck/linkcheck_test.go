@@ -0,0 +1,128 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newLocalChecker 测试服务监听在回环地址上，检测器需要允许访问
+func newLocalChecker(timeout time.Duration) *Checker {
+	checker := New(timeout)
+	checker.Client = newClient(func(net.IP) bool { return true })
+	return checker
+}
+
+func TestChecker_Check(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/get-only", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Write([]byte("hello"))
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusFound)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	checker := newLocalChecker(200 * time.Millisecond)
+	cases := []struct {
+		path   string
+		ok     bool
+		status int
+	}{
+		{"/ok", true, http.StatusOK},
+		{"/missing", false, http.StatusNotFound},
+		{"/get-only", true, http.StatusOK},
+		{"/moved", true, http.StatusOK},
+		{"/slow", false, 0},
+	}
+	for _, tc := range cases {
+		result := checker.Check(context.Background(), server.URL+tc.path)
+		if result.OK != tc.ok || result.StatusCode != tc.status {
+			t.Errorf("%s: ok=%v status=%d, want ok=%v status=%d (%s)", tc.path, result.OK, result.StatusCode, tc.ok, tc.status, result.Error)
+		}
+		if !result.OK && result.Error == "" {
+			t.Errorf("%s: 不可用的链接应返回错误原因", tc.path)
+		}
+	}
+}
+
+func TestChecker_CheckUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	if result := newLocalChecker(time.Second).Check(context.Background(), url); result.OK {
+		t.Errorf("关闭的服务应检测为不可用")
+	}
+}
+
+func TestChecker_RejectsPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	for _, target := range []string{server.URL, "http://10.0.0.1/", "http://169.254.169.254/latest/meta-data/", "http://[::1]:80/"} {
+		result := New(time.Second).Check(context.Background(), target)
+		if result.OK || !strings.Contains(result.Error, ErrPrivateAddress.Error()) {
+			t.Errorf("%s: 非公网地址应被拒绝，got ok=%v error=%q", target, result.OK, result.Error)
+		}
+	}
+}
+
+func TestChecker_Redirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/internal", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://127.0.0.2/admin", http.StatusFound)
+	})
+	mux.HandleFunc("/ftp", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "ftp://example.com/", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// 只允许测试服务所在的地址，重定向到其他回环地址时应被拒绝
+	allow := func(ip net.IP) bool { return ip.Equal(net.ParseIP("127.0.0.1")) }
+	checker := New(time.Second)
+	checker.Client = newClient(allow)
+
+	if result := checker.Check(context.Background(), server.URL+"/loop"); result.OK || !strings.Contains(result.Error, "重定向超过") {
+		t.Errorf("循环重定向应在 %d 次后停止，got ok=%v error=%q", MaxRedirects, result.OK, result.Error)
+	}
+	if result := checker.Check(context.Background(), server.URL+"/internal"); result.OK || !strings.Contains(result.Error, ErrPrivateAddress.Error()) {
+		t.Errorf("重定向到不允许的地址应被拒绝，got ok=%v error=%q", result.OK, result.Error)
+	}
+	if result := checker.Check(context.Background(), server.URL+"/ftp"); result.OK {
+		t.Errorf("重定向到非 http 协议应被拒绝，got error=%q", result.Error)
+	}
+	target, _ := url.Parse("http://192.168.1.1/")
+	if err := checkTarget(target, isPublicIP); !errors.Is(err, ErrPrivateAddress) {
+		t.Errorf("重定向目标为内网 IP 时应返回 ErrPrivateAddress，got %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+// Package mention 从话题和评论正文中提取 @用户名
+package mention
+
+import "regexp"
+
+const (
+	// MinNameLength 用户名最短长度，与注册时的用户名规则一致
+	MinNameLength = 3
+	// MaxNameLength 用户名最长长度
+	MaxNameLength = 20
+	// MaxPerText 单篇正文最多识别的提及数量，防止借提及群发通知
+	MaxPerText = 20
+)
+
+// pattern 匹配 @ 及其后连续的字母数字，前一个字符不能是字母数字或点，避免把邮箱识别为提及
+var pattern = regexp.MustCompile(`(^|[^A-Za-z0-9_.@])@([A-Za-z0-9]+)`)
+
+// Extract 提取正文中提及的用户名，按首次出现的顺序去重，最多返回 MaxPerText 个
+// 长度不符合用户名规则的片段会被忽略；同名不同大小写视为不同的写法，由调用方处理
+func Extract(text string) []string {
+	matches := pattern.FindAllStringSubmatch(text, -1)
+	names := make([]string, 0, len(matches))
+	seen := make(map[string]bool, len(matches))
+	for _, m := range matches {
+		name := m[2]
+		if len(name) < MinNameLength || len(name) > MaxNameLength || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+		if len(names) == MaxPerText {
+			break
+		}
+	}
+	return names
+}
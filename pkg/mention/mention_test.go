@@ -0,0 +1,42 @@
+package mention
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"无提及", "普通的正文内容", []string{}},
+		{"行首提及", "@alice 你好", []string{"alice"}},
+		{"中文后提及", "感谢@alice和@bob的帮助", []string{"alice", "bob"}},
+		{"标点分隔", "@alice,@bob。@carol", []string{"alice", "bob", "carol"}},
+		{"去重保持顺序", "@bob @alice @bob", []string{"bob", "alice"}},
+		{"大小写不同视为不同写法", "@Alice @alice", []string{"Alice", "alice"}},
+		{"忽略邮箱", "联系 admin@example.com", []string{}},
+		{"忽略过短和过长", "@ab @" + strings.Repeat("a", 21), []string{}},
+		{"忽略连续的@", "@@alice", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Extract(tt.text))
+		})
+	}
+}
+
+func TestExtract_Limit(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < MaxPerText+5; i++ {
+		fmt.Fprintf(&b, "@user%03d ", i)
+	}
+	names := Extract(b.String())
+	assert.Len(t, names, MaxPerText)
+	assert.Equal(t, "user000", names[0])
+}
@@ -0,0 +1,154 @@
+// Package moderator 内容审核流水线
+// 多个审核阶段按顺序检查内容，每个阶段给出 allow/review/reject 结论和 0~1 的风险分数，
+// 流水线汇总后取最严重的结论和最高分数，任一阶段给出 reject 时不再执行后续阶段
+package moderator
+
+import (
+	"context"
+	"strings"
+)
+
+// 审核结论
+const (
+	VerdictAllow  = "allow"  // 直接通过
+	VerdictReview = "review" // 需要人工审核
+	VerdictReject = "reject" // 直接拒绝
+)
+
+// 内容类型
+const (
+	ContentTopic   = "topic"
+	ContentComment = "comment"
+	ContentProfile = "profile"
+	ContentMessage = "message"
+)
+
+// Content 待审核的内容
+type Content struct {
+	Type     string `json:"type"`
+	ID       string `json:"id"`
+	AuthorID string `json:"author_id"`
+	Title    string `json:"title,omitempty"` // 话题标题或用户昵称
+	Body     string `json:"body"`
+}
+
+// Text 参与检查的全部文本
+func (c Content) Text() string {
+	if c.Title == "" {
+		return c.Body
+	}
+	return c.Title + "\n" + c.Body
+}
+
+// Result 单个审核阶段的结果
+type Result struct {
+	Stage   string   `json:"stage"`
+	Verdict string   `json:"verdict"`
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons,omitempty"`
+	Error   string   `json:"error,omitempty"` // 阶段执行失败时的错误，失败的阶段按通过处理
+}
+
+// Allow 通过且没有风险的结果
+func Allow() Result {
+	return Result{Verdict: VerdictAllow}
+}
+
+// Moderator 审核阶段
+type Moderator interface {
+	// Name 阶段名称，记录在审核结果中
+	Name() string
+	// Moderate 检查内容，返回的错误只记录不影响其他阶段
+	Moderate(ctx context.Context, content Content) (Result, error)
+}
+
+// Decision 流水线的最终结论
+type Decision struct {
+	Verdict string   `json:"verdict"`
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons,omitempty"`
+	Results []Result `json:"results"`
+}
+
+// Reason 拼接后的审核原因
+func (d Decision) Reason() string {
+	return strings.Join(d.Reasons, "；")
+}
+
+// Pipeline 审核流水线
+type Pipeline struct {
+	stages      []Moderator
+	reviewScore float64 // 分数达到该值时至少需要人工审核，0 表示不按分数判断
+	rejectScore float64 // 分数达到该值时直接拒绝，0 表示不按分数判断
+}
+
+// NewPipeline 创建审核流水线，阶段按传入顺序执行
+func NewPipeline(reviewScore, rejectScore float64, stages ...Moderator) *Pipeline {
+	return &Pipeline{stages: stages, reviewScore: reviewScore, rejectScore: rejectScore}
+}
+
+// Stages 流水线中的审核阶段
+func (p *Pipeline) Stages() []Moderator {
+	return p.stages
+}
+
+// Run 依次执行各审核阶段并汇总结论
+func (p *Pipeline) Run(ctx context.Context, content Content) Decision {
+	decision := Decision{Verdict: VerdictAllow}
+	for _, stage := range p.stages {
+		if ctx.Err() != nil {
+			break
+		}
+		result, err := stage.Moderate(ctx, content)
+		result.Stage = stage.Name()
+		if err != nil {
+			result = Result{Stage: stage.Name(), Verdict: VerdictAllow, Error: err.Error()}
+		}
+		if result.Verdict == "" {
+			result.Verdict = VerdictAllow
+		}
+		result.Verdict = severer(result.Verdict, p.verdictForScore(result.Score))
+
+		decision.Results = append(decision.Results, result)
+		decision.Verdict = severer(decision.Verdict, result.Verdict)
+		if result.Score > decision.Score {
+			decision.Score = result.Score
+		}
+		if result.Verdict != VerdictAllow {
+			decision.Reasons = append(decision.Reasons, result.Reasons...)
+		}
+		if decision.Verdict == VerdictReject {
+			break
+		}
+	}
+	return decision
+}
+
+// verdictForScore 按分数阈值得出的结论
+func (p *Pipeline) verdictForScore(score float64) string {
+	switch {
+	case p.rejectScore > 0 && score >= p.rejectScore:
+		return VerdictReject
+	case p.reviewScore > 0 && score >= p.reviewScore:
+		return VerdictReview
+	}
+	return VerdictAllow
+}
+
+// severer 返回两个结论中更严重的一个
+func severer(a, b string) string {
+	if rank(b) > rank(a) {
+		return b
+	}
+	return a
+}
+
+func rank(verdict string) int {
+	switch verdict {
+	case VerdictReject:
+		return 2
+	case VerdictReview:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,252 @@
+package moderator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"GoHub-Service/pkg/security"
+)
+
+type stubModerator struct {
+	name   string
+	result Result
+	err    error
+	calls  int
+}
+
+func (m *stubModerator) Name() string { return m.name }
+
+func (m *stubModerator) Moderate(ctx context.Context, content Content) (Result, error) {
+	m.calls++
+	return m.result, m.err
+}
+
+func TestPipeline_Run(t *testing.T) {
+	review := &stubModerator{name: "a", result: Result{Verdict: VerdictReview, Score: 0.6, Reasons: []string{"可疑"}}}
+	reject := &stubModerator{name: "b", result: Result{Verdict: VerdictReject, Score: 1, Reasons: []string{"违规"}}}
+	after := &stubModerator{name: "c"}
+
+	decision := NewPipeline(0, 0, review, reject, after).Run(context.Background(), Content{Body: "x"})
+	if decision.Verdict != VerdictReject || decision.Score != 1 {
+		t.Fatalf("decision = %s/%v, want reject/1", decision.Verdict, decision.Score)
+	}
+	if after.calls != 0 {
+		t.Errorf("拒绝后不应继续执行后续阶段")
+	}
+	if decision.Reason() != "可疑；违规" {
+		t.Errorf("Reason() = %q", decision.Reason())
+	}
+}
+
+func TestPipeline_ScoreThresholds(t *testing.T) {
+	scored := &stubModerator{name: "a", result: Result{Verdict: VerdictAllow, Score: 0.95}}
+	decision := NewPipeline(0.5, 0.9, scored).Run(context.Background(), Content{Body: "x"})
+	if decision.Verdict != VerdictReject {
+		t.Errorf("verdict = %s, want reject", decision.Verdict)
+	}
+
+	scored.result.Score = 0.6
+	decision = NewPipeline(0.5, 0.9, scored).Run(context.Background(), Content{Body: "x"})
+	if decision.Verdict != VerdictReview {
+		t.Errorf("verdict = %s, want review", decision.Verdict)
+	}
+}
+
+func TestPipeline_StageErrorAllows(t *testing.T) {
+	failing := &stubModerator{name: "a", err: errors.New("boom")}
+	decision := NewPipeline(0.5, 0.9, failing).Run(context.Background(), Content{Body: "x"})
+	if decision.Verdict != VerdictAllow {
+		t.Errorf("verdict = %s, want allow", decision.Verdict)
+	}
+	if len(decision.Results) != 1 || decision.Results[0].Error != "boom" {
+		t.Errorf("阶段错误应记录在结果中: %+v", decision.Results)
+	}
+}
+
+func TestSensitiveWordModerator(t *testing.T) {
+	filter := security.NewSensitiveWordFilter("*")
+	filter.Load([]security.WordEntry{
+		{Word: "赌场", Severity: security.SeverityReview},
+		{Word: "冰毒", Severity: security.SeverityBlock},
+		{Word: "黑客", Severity: security.SeverityReplace},
+	})
+	m := NewSensitiveWordModerator(filter)
+
+	tests := []struct {
+		body    string
+		verdict string
+	}{
+		{"正常内容", VerdictAllow},
+		{"线上赌场", VerdictReview},
+		{"出售冰毒", VerdictReject},
+		{"黑客技术", VerdictAllow},
+	}
+	for _, tt := range tests {
+		result, _ := m.Moderate(context.Background(), Content{Body: tt.body})
+		if result.Verdict != tt.verdict {
+			t.Errorf("%s: verdict = %s, want %s", tt.body, result.Verdict, tt.verdict)
+		}
+	}
+}
+
+func TestRegexModerator(t *testing.T) {
+	rules, err := ParseRegexRules("# 导流\nreview:1[3-9]\\d{9}\nreject:(?i)v[x信]\\s*[:：]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewRegexModerator(rules)
+
+	result, _ := m.Moderate(context.Background(), Content{Body: "联系 13800138000"})
+	if result.Verdict != VerdictReview {
+		t.Errorf("verdict = %s, want review", result.Verdict)
+	}
+	result, _ = m.Moderate(context.Background(), Content{Body: "加 vx: abc"})
+	if result.Verdict != VerdictReject {
+		t.Errorf("verdict = %s, want reject", result.Verdict)
+	}
+
+	if _, err := ParseRegexRules("block:abc"); err == nil {
+		t.Error("未知结论应返回错误")
+	}
+}
+
+func TestLinkModerator(t *testing.T) {
+	m := NewLinkModerator([]string{"bad.example"}, []string{"github.com"}, 2)
+	tests := []struct {
+		name    string
+		body    string
+		verdict string
+	}{
+		{"白名单", "见 https://github.com/a https://gist.github.com/b https://www.github.com/c", VerdictAllow},
+		{"黑名单子域名", "点击 https://x.bad.example/p", VerdictReject},
+		{"IP 链接", "http://10.0.0.1/a", VerdictReview},
+		{"链接过多", "http://a.com http://b.com http://c.com", VerdictReview},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := m.Moderate(context.Background(), Content{Body: tt.body})
+			if result.Verdict != tt.verdict {
+				t.Errorf("verdict = %s, want %s (%v)", result.Verdict, tt.verdict, result.Reasons)
+			}
+		})
+	}
+}
+
+func TestDuplicateModerator(t *testing.T) {
+	m := NewDuplicateModerator(NewMemoryDuplicateStore(), time.Minute, 5)
+	content := Content{Type: ContentComment, ID: "1", AuthorID: "1", Body: "这是一段足够长的内容"}
+
+	first, _ := m.Moderate(context.Background(), content)
+	if first.Verdict != VerdictAllow {
+		t.Errorf("首次发布 verdict = %s, want allow", first.Verdict)
+	}
+	resaved, _ := m.Moderate(context.Background(), content)
+	if resaved.Verdict != VerdictAllow {
+		t.Errorf("同一内容重新提交 verdict = %s, want allow", resaved.Verdict)
+	}
+	content.ID = "2"
+	content.Body = "这是一段，足够长的内容！"
+	second, _ := m.Moderate(context.Background(), content)
+	if second.Verdict != VerdictReview {
+		t.Errorf("重复发布 verdict = %s, want review", second.Verdict)
+	}
+	content.AuthorID = "2"
+	other, _ := m.Moderate(context.Background(), content)
+	if other.Verdict != VerdictAllow {
+		t.Errorf("其他作者 verdict = %s, want allow", other.Verdict)
+	}
+}
+
+func TestWebhookModerator(t *testing.T) {
+	// 本地替身分类服务：内容包含 spam 时拒绝
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var content Content
+		_ = json.NewDecoder(r.Body).Decode(&content)
+		result := Result{Verdict: VerdictAllow, Score: 0.1}
+		if content.Body == "spam" {
+			result = Result{Verdict: VerdictReject, Score: 0.99, Reasons: []string{"垃圾广告"}}
+		}
+		_ = json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+
+	m := NewWebhookModerator(server.URL, "secret", time.Second)
+	result, err := m.Moderate(context.Background(), Content{Type: ContentComment, Body: "spam"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Verdict != VerdictReject || result.Score != 0.99 {
+		t.Errorf("result = %+v, want reject/0.99", result)
+	}
+
+	unauthorized := NewWebhookModerator(server.URL, "", time.Second)
+	if _, err := unauthorized.Moderate(context.Background(), Content{Body: "spam"}); err == nil {
+		t.Error("分类服务返回非 2xx 时应返回错误")
+	}
+}
+
+func TestSimHash_NearDuplicates(t *testing.T) {
+	original := "限时优惠，添加客服领取免费会员，名额有限先到先得，错过再等一年"
+	edited := "限时优惠!!添加客服领取免费会员，名额有限 先到先得，错过再等一年啊"
+	other := "请问 Go 语言里 context 取消之后 goroutine 要怎么优雅退出"
+
+	if d := HammingDistance(SimHash(original), SimHash(edited)); d > 3 {
+		t.Errorf("近似内容的汉明距离 = %d，期望不超过 3", d)
+	}
+	if d := HammingDistance(SimHash(original), SimHash(other)); d <= 3 {
+		t.Errorf("不同内容的汉明距离 = %d，期望大于 3", d)
+	}
+	if SimHash("") != 0 {
+		t.Error("空文本的指纹应为 0")
+	}
+
+	a, b := SimHash(original), SimHash(edited)
+	bandsA, bandsB := SplitSimHash(a), SplitSimHash(b)
+	shared := false
+	for i := range bandsA {
+		shared = shared || bandsA[i] == bandsB[i]
+	}
+	if !shared {
+		t.Error("汉明距离小于分段数时至少应有一段相同")
+	}
+}
+
+func TestScoreSpam(t *testing.T) {
+	thresholds := SpamThresholds{MaxLinks: 3, MaxLinkDensity: 0.5, ReviewScore: 0.4, RejectScore: 0.8}
+
+	if got := ScoreSpam(SpamSignals{NewAccount: true}, thresholds); got.Verdict != VerdictAllow || got.Score != 0 {
+		t.Errorf("没有特征时应通过，得到 %+v", got)
+	}
+	if got := ScoreSpam(SpamSignals{OwnDuplicates: 1}, thresholds); got.Verdict != VerdictReview {
+		t.Errorf("重复发布一次应进入审核，得到 %+v", got)
+	}
+	if got := ScoreSpam(SpamSignals{OwnDuplicates: 1, OtherAuthors: 2}, thresholds); got.Verdict != VerdictReject {
+		t.Errorf("多个账号发布近似内容应拒绝，得到 %+v", got)
+	}
+	got := ScoreSpam(SpamSignals{Links: 5, LinkDensity: 0.7, NewAccount: true}, thresholds)
+	if got.Verdict != VerdictReview || len(got.Reasons) != 3 {
+		t.Errorf("新用户链接过多应进入审核并给出 3 条原因，得到 %+v", got)
+	}
+}
+
+func TestLinkDensity(t *testing.T) {
+	links, density := LinkDensity("看这里 https://a.example.com/x 和 https://b.example.com/y")
+	if links != 2 {
+		t.Errorf("链接数 = %d，期望 2", links)
+	}
+	if density < 0.8 || density >= 1 {
+		t.Errorf("链接占比 = %.2f，期望在 0.8 到 1 之间", density)
+	}
+	if links, density := LinkDensity(""); links != 0 || density != 0 {
+		t.Error("空文本不应有链接")
+	}
+}
@@ -0,0 +1,69 @@
+package moderator
+
+import (
+	"hash/fnv"
+	"math/bits"
+)
+
+// SimHashBands 指纹分段数，汉明距离小于分段数时至少有一段完全相同，用于数据库中按段查找候选
+const SimHashBands = 4
+
+// shingleSize 计算指纹时使用的字符片段长度
+const shingleSize = 3
+
+// SimHash 计算文本的 SimHash 指纹
+// 文本去掉空白和标点、统一小写后按连续字符片段计算，少量改字、插入标点不会明显改变指纹
+func SimHash(text string) uint64 {
+	runes := []rune(normalizeText(text))
+	if len(runes) == 0 {
+		return 0
+	}
+
+	var weights [64]int
+	add := func(shingle []rune) {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(string(shingle)))
+		sum := h.Sum64()
+		for i := 0; i < 64; i++ {
+			if sum&(1<<uint(i)) != 0 {
+				weights[i]++
+			} else {
+				weights[i]--
+			}
+		}
+	}
+	if len(runes) <= shingleSize {
+		add(runes)
+	} else {
+		for i := 0; i+shingleSize <= len(runes); i++ {
+			add(runes[i : i+shingleSize])
+		}
+	}
+
+	var fingerprint uint64
+	for i, w := range weights {
+		if w > 0 {
+			fingerprint |= 1 << uint(i)
+		}
+	}
+	return fingerprint
+}
+
+// HammingDistance 两个指纹不同的位数
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// SplitSimHash 将指纹按 16 位分成 4 段
+func SplitSimHash(fingerprint uint64) [SimHashBands]uint16 {
+	var bands [SimHashBands]uint16
+	for i := range bands {
+		bands[i] = uint16(fingerprint >> (16 * uint(i)))
+	}
+	return bands
+}
+
+// NormalizedLength 去掉空白和标点后的字数，过短的内容不做近似重复判断
+func NormalizedLength(text string) int {
+	return len([]rune(normalizeText(text)))
+}
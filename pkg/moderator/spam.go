@@ -0,0 +1,72 @@
+package moderator
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// SpamSignals 发布内容的垃圾信息特征
+type SpamSignals struct {
+	OwnDuplicates int     // 作者自己近期发布过的近似内容数
+	OtherAuthors  int     // 近期发布过近似内容的其他用户数
+	Links         int     // 链接数
+	LinkDensity   float64 // 链接字符占全文的比例
+	NewAccount    bool    // 作者是否为新注册用户
+}
+
+// SpamThresholds 垃圾信息判定阈值
+type SpamThresholds struct {
+	MaxLinks       int     // 链接超过该数量时加分，0 表示不限制
+	MaxLinkDensity float64 // 链接字符占比超过该值时加分，0 表示不限制
+	ReviewScore    float64 // 分数达到该值时进入人工审核
+	RejectScore    float64 // 分数达到该值时拒绝发布
+}
+
+// LinkDensity 统计文本中的链接数及链接字符占全文的比例
+func LinkDensity(text string) (int, float64) {
+	total := utf8.RuneCountInString(text)
+	if total == 0 {
+		return 0, 0
+	}
+	links := ExtractLinks(text)
+	length := 0
+	for _, link := range links {
+		length += utf8.RuneCountInString(link)
+	}
+	return len(links), float64(length) / float64(total)
+}
+
+// ScoreSpam 根据垃圾信息特征计算分数和结论
+// 重复发布近似内容、多个账号发布相同内容、链接过多或链接占比过高都会加分，新注册用户命中任一特征时额外加分
+func ScoreSpam(signals SpamSignals, thresholds SpamThresholds) Result {
+	result := Allow()
+	if signals.OwnDuplicates > 0 {
+		result.Score += 0.4 + 0.2*float64(signals.OwnDuplicates-1)
+		result.Reasons = append(result.Reasons, fmt.Sprintf("近期重复发布近似内容 %d 次", signals.OwnDuplicates))
+	}
+	if signals.OtherAuthors > 0 {
+		result.Score += 0.3 * float64(signals.OtherAuthors)
+		result.Reasons = append(result.Reasons, fmt.Sprintf("%d 个其他用户近期发布过近似内容", signals.OtherAuthors))
+	}
+	if thresholds.MaxLinks > 0 && signals.Links > thresholds.MaxLinks {
+		result.Score += 0.3
+		result.Reasons = append(result.Reasons, fmt.Sprintf("链接过多（%d 个）", signals.Links))
+	}
+	if thresholds.MaxLinkDensity > 0 && signals.LinkDensity > thresholds.MaxLinkDensity {
+		result.Score += 0.3
+		result.Reasons = append(result.Reasons, fmt.Sprintf("链接占比过高（%.0f%%）", signals.LinkDensity*100))
+	}
+	if signals.NewAccount && result.Score > 0 {
+		result.Score += 0.1
+		result.Reasons = append(result.Reasons, "新注册用户")
+	}
+	result.Score = capScore(result.Score)
+
+	switch {
+	case thresholds.RejectScore > 0 && result.Score >= thresholds.RejectScore:
+		result.Verdict = VerdictReject
+	case thresholds.ReviewScore > 0 && result.Score >= thresholds.ReviewScore:
+		result.Verdict = VerdictReview
+	}
+	return result
+}
@@ -0,0 +1,334 @@
+package moderator
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"GoHub-Service/pkg/redis"
+	"GoHub-Service/pkg/security"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// SensitiveWordModerator 敏感词检查
+// 命中拒绝类的词直接拒绝，命中审核类的词需要人工审核，替换类的词在发布时已被屏蔽，只记录不影响结论
+type SensitiveWordModerator struct {
+	filter *security.SensitiveWordFilter
+}
+
+// NewSensitiveWordModerator 使用指定的敏感词过滤器创建审核阶段
+func NewSensitiveWordModerator(filter *security.SensitiveWordFilter) *SensitiveWordModerator {
+	return &SensitiveWordModerator{filter: filter}
+}
+
+// Name 阶段名称
+func (m *SensitiveWordModerator) Name() string {
+	return "sensitive_word"
+}
+
+// Moderate 每命中一个审核类敏感词风险分数增加 0.1，从 0.5 开始
+func (m *SensitiveWordModerator) Moderate(ctx context.Context, content Content) (Result, error) {
+	matches := m.filter.Matches(content.Text())
+	if len(matches) == 0 {
+		return Allow(), nil
+	}
+
+	words := map[string][]string{}
+	seen := map[string]bool{}
+	for _, match := range matches {
+		if !seen[match.Word] {
+			seen[match.Word] = true
+			words[match.Severity] = append(words[match.Severity], match.Word)
+		}
+	}
+	switch {
+	case len(words[security.SeverityBlock]) > 0:
+		return Result{
+			Verdict: VerdictReject,
+			Score:   1,
+			Reasons: []string{"包含违禁词：" + strings.Join(words[security.SeverityBlock], "、")},
+		}, nil
+	case len(words[security.SeverityReview]) > 0:
+		review := words[security.SeverityReview]
+		return Result{
+			Verdict: VerdictReview,
+			Score:   capScore(0.5 + 0.1*float64(len(review)-1)),
+			Reasons: []string{"包含敏感词：" + strings.Join(review, "、")},
+		}, nil
+	}
+	return Result{
+		Verdict: VerdictAllow,
+		Score:   0.2,
+		Reasons: []string{"包含已屏蔽的敏感词：" + strings.Join(words[security.SeverityReplace], "、")},
+	}, nil
+}
+
+// RegexRule 正则规则
+type RegexRule struct {
+	Pattern *regexp.Regexp
+	Verdict string
+	Score   float64
+}
+
+// ParseRegexRules 解析正则规则配置，每行一条，格式为 "结论:正则"，结论为 review 或 reject
+// 空行和 # 开头的行会被忽略
+func ParseRegexRules(spec string) ([]RegexRule, error) {
+	var rules []RegexRule
+	for _, line := range strings.Split(spec, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		verdict, pattern, ok := strings.Cut(line, ":")
+		if !ok || (verdict != VerdictReview && verdict != VerdictReject) {
+			return nil, fmt.Errorf("正则规则格式错误：%s", line)
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("正则规则 %q 无法解析：%w", pattern, err)
+		}
+		score := 0.6
+		if verdict == VerdictReject {
+			score = 1
+		}
+		rules = append(rules, RegexRule{Pattern: re, Verdict: verdict, Score: score})
+	}
+	return rules, nil
+}
+
+// RegexModerator 正则规则检查，如手机号、微信号等导流信息
+type RegexModerator struct {
+	rules []RegexRule
+}
+
+// NewRegexModerator 创建正则审核阶段
+func NewRegexModerator(rules []RegexRule) *RegexModerator {
+	return &RegexModerator{rules: rules}
+}
+
+// Name 阶段名称
+func (m *RegexModerator) Name() string {
+	return "regex"
+}
+
+// Moderate 取命中规则中最严重的结论
+func (m *RegexModerator) Moderate(ctx context.Context, content Content) (Result, error) {
+	result := Allow()
+	text := content.Text()
+	for _, rule := range m.rules {
+		if match := rule.Pattern.FindString(text); match != "" {
+			result.Verdict = severer(result.Verdict, rule.Verdict)
+			if rule.Score > result.Score {
+				result.Score = rule.Score
+			}
+			result.Reasons = append(result.Reasons, "命中规则："+match)
+		}
+	}
+	return result, nil
+}
+
+var linkPattern = regexp.MustCompile(`(?i)\bhttps?://[^\s"'<>()\[\]]+`)
+
+// ExtractLinks 提取文本中的链接
+func ExtractLinks(text string) []string {
+	return linkPattern.FindAllString(text, -1)
+}
+
+// LinkModerator 链接信誉检查
+// 域名在黑名单中时直接拒绝；使用 IP 地址的链接或不在白名单中的链接过多时需要人工审核
+type LinkModerator struct {
+	blocked  []string
+	allowed  []string
+	maxLinks int // 不在白名单中的链接超过该数量时需要审核，0 表示不限制
+}
+
+// NewLinkModerator 创建链接审核阶段，域名匹配时包含子域名
+func NewLinkModerator(blocked, allowed []string, maxLinks int) *LinkModerator {
+	return &LinkModerator{blocked: normalizeDomains(blocked), allowed: normalizeDomains(allowed), maxLinks: maxLinks}
+}
+
+// Name 阶段名称
+func (m *LinkModerator) Name() string {
+	return "link"
+}
+
+// Moderate 检查内容中的全部链接
+func (m *LinkModerator) Moderate(ctx context.Context, content Content) (Result, error) {
+	result := Allow()
+	external := 0
+	for _, link := range ExtractLinks(content.Text()) {
+		u, err := url.Parse(link)
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+		host := strings.ToLower(u.Hostname())
+		if matchDomain(host, m.allowed) {
+			continue
+		}
+		external++
+		switch {
+		case matchDomain(host, m.blocked):
+			result.Verdict = VerdictReject
+			result.Score = 1
+			result.Reasons = append(result.Reasons, "包含被屏蔽的链接："+host)
+		case net.ParseIP(host) != nil:
+			result.Verdict = severer(result.Verdict, VerdictReview)
+			result.Score = maxScore(result.Score, 0.6)
+			result.Reasons = append(result.Reasons, "包含 IP 地址链接："+host)
+		}
+	}
+	if m.maxLinks > 0 && external > m.maxLinks {
+		result.Verdict = severer(result.Verdict, VerdictReview)
+		result.Score = maxScore(result.Score, 0.6)
+		result.Reasons = append(result.Reasons, fmt.Sprintf("外部链接过多（%d 个）", external))
+	}
+	return result, nil
+}
+
+// DuplicateStore 记录已发布内容的指纹及其所属内容
+type DuplicateStore interface {
+	// Claim 窗口期内指纹未被记录时记为 owner 所有，返回指纹当前所属的内容
+	Claim(ctx context.Context, key, owner string, window time.Duration) (string, error)
+}
+
+// RedisDuplicateStore 使用 Redis 记录内容指纹
+type RedisDuplicateStore struct{}
+
+// Claim 指纹不存在时写入 owner，已存在时返回记录的所属内容
+func (RedisDuplicateStore) Claim(ctx context.Context, key, owner string, window time.Duration) (string, error) {
+	if redis.Redis == nil {
+		return owner, nil
+	}
+	created, err := redis.Redis.Client.SetNX(ctx, key, owner, window).Result()
+	if err != nil || created {
+		return owner, err
+	}
+	holder, err := redis.Redis.Client.Get(ctx, key).Result()
+	if err == goredis.Nil {
+		// 两次调用之间刚好过期
+		return owner, nil
+	}
+	return holder, err
+}
+
+// MemoryDuplicateStore 进程内记录内容指纹，用于测试和单机部署
+type MemoryDuplicateStore struct {
+	mu   sync.Mutex
+	seen map[string]duplicateClaim
+}
+
+type duplicateClaim struct {
+	owner   string
+	expires time.Time
+}
+
+// NewMemoryDuplicateStore 创建进程内指纹存储
+func NewMemoryDuplicateStore() *MemoryDuplicateStore {
+	return &MemoryDuplicateStore{seen: make(map[string]duplicateClaim)}
+}
+
+// Claim 指纹不存在或已过期时写入 owner，否则返回记录的所属内容
+func (s *MemoryDuplicateStore) Claim(ctx context.Context, key, owner string, window time.Duration) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if claim, ok := s.seen[key]; ok && now.Before(claim.expires) {
+		return claim.owner, nil
+	}
+	s.seen[key] = duplicateClaim{owner: owner, expires: now.Add(window)}
+	return owner, nil
+}
+
+// DuplicateModerator 重复内容检查，同一作者在窗口期内以不同内容重复发布相同文本时需要人工审核
+type DuplicateModerator struct {
+	store     DuplicateStore
+	window    time.Duration
+	minLength int // 忽略过短的内容，如“谢谢”“+1”
+}
+
+// NewDuplicateModerator 创建重复内容审核阶段
+func NewDuplicateModerator(store DuplicateStore, window time.Duration, minLength int) *DuplicateModerator {
+	return &DuplicateModerator{store: store, window: window, minLength: minLength}
+}
+
+// Name 阶段名称
+func (m *DuplicateModerator) Name() string {
+	return "duplicate"
+}
+
+// Moderate 内容去掉空白和标点、统一小写后计算指纹
+func (m *DuplicateModerator) Moderate(ctx context.Context, content Content) (Result, error) {
+	normalized := normalizeText(content.Text())
+	if content.AuthorID == "" || m.window <= 0 || len([]rune(normalized)) < m.minLength {
+		return Allow(), nil
+	}
+	sum := sha1.Sum([]byte(normalized))
+	key := "moderation:duplicate:" + content.AuthorID + ":" + hex.EncodeToString(sum[:])
+	// 指纹记录所属内容，同一内容修改后重新提交（如只改了标签）不算重复
+	owner := content.Type + ":" + content.ID
+	holder, err := m.store.Claim(ctx, key, owner, m.window)
+	if err != nil {
+		return Result{}, err
+	}
+	if holder == owner {
+		return Allow(), nil
+	}
+	return Result{Verdict: VerdictReview, Score: 0.7, Reasons: []string{"重复发布相同内容"}}, nil
+}
+
+// normalizeText 去掉空白和标点并统一小写
+func normalizeText(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// normalizeDomains 统一域名格式，去掉空白、前导点和 www 前缀
+func normalizeDomains(domains []string) []string {
+	result := make([]string, 0, len(domains))
+	for _, d := range domains {
+		d = strings.TrimPrefix(strings.Trim(strings.ToLower(strings.TrimSpace(d)), "."), "www.")
+		if d != "" {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// matchDomain 域名或其子域名在列表中时返回 true
+func matchDomain(host string, domains []string) bool {
+	for _, d := range domains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+func capScore(score float64) float64 {
+	if score > 1 {
+		return 1
+	}
+	return score
+}
+
+func maxScore(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,70 @@
+package moderator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WebhookModerator 调用外部分类服务审核内容
+//
+// 请求：POST JSON，内容为 Content
+// 响应：{"verdict": "allow|review|reject", "score": 0.8, "reasons": ["..."]}
+// 分类服务不可用时该阶段按通过处理，错误记录在审核结果中
+type WebhookModerator struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewWebhookModerator 创建外部分类审核阶段，token 不为空时通过 Authorization 头传递
+func NewWebhookModerator(url, token string, timeout time.Duration) *WebhookModerator {
+	return &WebhookModerator{url: url, token: token, client: &http.Client{Timeout: timeout}}
+}
+
+// Name 阶段名称
+func (m *WebhookModerator) Name() string {
+	return "webhook"
+}
+
+// Moderate 将内容发送给分类服务
+func (m *WebhookModerator) Moderate(ctx context.Context, content Content) (Result, error) {
+	payload, err := json.Marshal(content)
+	if err != nil {
+		return Result{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.url, bytes.NewReader(payload))
+	if err != nil {
+		return Result{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if m.token != "" {
+		req.Header.Set("Authorization", "Bearer "+m.token)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return Result{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return Result{}, fmt.Errorf("分类服务返回 %d", resp.StatusCode)
+	}
+
+	var result Result
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&result); err != nil {
+		return Result{}, fmt.Errorf("分类服务响应无法解析：%w", err)
+	}
+	switch result.Verdict {
+	case "", VerdictAllow, VerdictReview, VerdictReject:
+	default:
+		return Result{}, fmt.Errorf("分类服务返回未知结论 %q", result.Verdict)
+	}
+	result.Score = capScore(result.Score)
+	result.Error = ""
+	return result, nil
+}
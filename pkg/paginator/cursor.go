@@ -0,0 +1,362 @@
+package paginator
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"GoHub-Service/pkg/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidCursor 游标无法解析、签名不匹配或与当前排序方式不一致
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// SortColumn 游标分页的排序字段
+type SortColumn struct {
+	Name string // 数据库字段名
+	Desc bool   // 是否倒序
+}
+
+// CursorOptions 游标分页参数
+type CursorOptions struct {
+	// 排序字段，最后一个必须能唯一确定一条记录（通常是 id），
+	// 字段上需要有联合索引，否则退化为全表扫描
+	Columns   []SortColumn
+	BaseURL   string // 用以拼接分页链接
+	PerPage   int    // 每页条数，优先从 url 参数里取
+	WithTotal bool   // 是否统计总条数，URL 带 with_total=1 时也会统计
+}
+
+// CursorPaging 游标分页数据
+type CursorPaging struct {
+	PerPage     int    `json:"per_page"`
+	HasMore     bool   `json:"has_more"`
+	NextCursor  string `json:"next_cursor"`
+	PrevCursor  string `json:"prev_cursor"`
+	NextPageURL string `json:"next_page_url"`
+	PrevPageURL string `json:"prev_page_url"`
+	TotalCount  *int64 `json:"total_count,omitempty"`
+}
+
+// cursorPayload 游标内容，编码后对客户端不透明
+type cursorPayload struct {
+	Sort   string        `json:"s"` // 排序字段签名，防止切换排序后继续使用旧游标
+	Values []cursorValue `json:"v"`
+	Prev   bool          `json:"p,omitempty"` // 是否向前翻页
+}
+
+// cursorValue 带类型的排序值，解码后还原为原始类型交给数据库驱动
+type cursorValue struct {
+	Kind  string `json:"k"` // t: 时间 i: 整数 u: 无符号整数 b: 布尔 s: 字符串 n: NULL
+	Value string `json:"v,omitempty"`
+}
+
+// UseCursor 请求是否使用游标分页，带上 cursor 参数（首页可以为空）即开启
+func UseCursor(c *gin.Context) bool {
+	_, ok := c.GetQuery(cursorQueryKey())
+	return ok
+}
+
+// CursorPaginate 基于排序字段的游标（keyset）分页
+// keyOf 按 opts.Columns 的顺序返回一条记录的排序值
+// 用法:
+//
+//	query := database.DB.Model(Notification{}).Where("user_id = ?", uid)
+//	list, paging, err := paginator.CursorPaginate(c, query, paginator.CursorOptions{
+//	    Columns: []paginator.SortColumn{{Name: "id", Desc: true}},
+//	    BaseURL: "/api/v1/notifications",
+//	}, func(n *Notification) []interface{} { return []interface{}{n.ID} })
+func CursorPaginate[T any](c *gin.Context, db *gorm.DB, opts CursorOptions, keyOf func(*T) []interface{}) ([]T, CursorPaging, error) {
+	if len(opts.Columns) == 0 {
+		return nil, CursorPaging{}, errors.New("paginator: cursor columns required")
+	}
+
+	paging := CursorPaging{PerPage: cursorPerPage(c, opts.PerPage)}
+
+	var cursor *cursorPayload
+	if token := c.Query(cursorQueryKey()); token != "" {
+		decoded, err := decodeCursor(token, opts.Columns)
+		if err != nil {
+			return nil, CursorPaging{}, err
+		}
+		cursor = decoded
+	}
+
+	if opts.WithTotal || c.Query("with_total") == "1" {
+		var total int64
+		if err := db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return nil, CursorPaging{}, err
+		}
+		paging.TotalCount = &total
+	}
+
+	backward := cursor != nil && cursor.Prev
+	query := db.Session(&gorm.Session{})
+	if cursor != nil {
+		values, err := cursor.decodeValues()
+		if err != nil {
+			return nil, CursorPaging{}, err
+		}
+		where, args := keysetCondition(opts.Columns, values, backward)
+		query = query.Where(where, args...)
+	}
+	for _, col := range opts.Columns {
+		// 向前翻页时反转排序，取回后再倒置结果
+		direction := "ASC"
+		if col.Desc != backward {
+			direction = "DESC"
+		}
+		query = query.Order(col.Name + " " + direction)
+	}
+
+	var items []T
+	if err := query.Limit(paging.PerPage + 1).Find(&items).Error; err != nil {
+		return nil, CursorPaging{}, err
+	}
+
+	hasMore := len(items) > paging.PerPage
+	if hasMore {
+		items = items[:paging.PerPage]
+	}
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	// 向后翻页时还有更多数据才有下一页；向前翻页时来源页总在后面
+	hasNext := (!backward && hasMore) || backward
+	hasPrev := (!backward && cursor != nil) || (backward && hasMore)
+	paging.HasMore = hasNext
+
+	if len(items) > 0 {
+		if hasNext {
+			token, err := encodeCursor(opts.Columns, keyOf(&items[len(items)-1]), false)
+			if err != nil {
+				return nil, CursorPaging{}, err
+			}
+			paging.NextCursor = token
+			paging.NextPageURL = cursorPageLink(opts.BaseURL, token, paging.PerPage)
+		}
+		if hasPrev {
+			token, err := encodeCursor(opts.Columns, keyOf(&items[0]), true)
+			if err != nil {
+				return nil, CursorPaging{}, err
+			}
+			paging.PrevCursor = token
+			paging.PrevPageURL = cursorPageLink(opts.BaseURL, token, paging.PerPage)
+		}
+	}
+
+	return items, paging, nil
+}
+
+// EncodeCursor 编码游标，用于无法直接交给 CursorPaginate 的场景（如合并多个来源的信息流），
+// values 按 columns 的顺序给出
+func EncodeCursor(columns []SortColumn, values ...interface{}) (string, error) {
+	return encodeCursor(columns, values, false)
+}
+
+// DecodeCursor 校验签名并还原 EncodeCursor 编码的排序值，游标无效时返回 ErrInvalidCursor
+func DecodeCursor(token string, columns []SortColumn) ([]interface{}, error) {
+	payload, err := decodeCursor(token, columns)
+	if err != nil {
+		return nil, err
+	}
+	return payload.decodeValues()
+}
+
+// CursorPageLink 拼接游标分页链接
+func CursorPageLink(baseURL, token string, perPage int) string {
+	return cursorPageLink(baseURL, token, perPage)
+}
+
+// CursorPerPage 游标分页的每页条数，优先从 url 参数里取
+func CursorPerPage(c *gin.Context, perPage int) int {
+	return cursorPerPage(c, perPage)
+}
+
+// keysetCondition 生成 (a, b, id) 之后（或之前）的条件，支持各字段排序方向不同：
+// a > ? OR (a = ? AND b > ?) OR (a = ? AND b = ? AND id > ?)
+func keysetCondition(columns []SortColumn, values []interface{}, backward bool) (string, []interface{}) {
+	var (
+		parts []string
+		args  []interface{}
+	)
+	for i, col := range columns {
+		var conds []string
+		for j := 0; j < i; j++ {
+			conds = append(conds, columns[j].Name+" = ?")
+			args = append(args, values[j])
+		}
+		op := ">"
+		if col.Desc != backward {
+			op = "<"
+		}
+		conds = append(conds, col.Name+" "+op+" ?")
+		args = append(args, values[i])
+		parts = append(parts, "("+strings.Join(conds, " AND ")+")")
+	}
+	return "(" + strings.Join(parts, " OR ") + ")", args
+}
+
+// encodeCursor 编码游标：base64(JSON) + "." + HMAC 签名
+func encodeCursor(columns []SortColumn, values []interface{}, prev bool) (string, error) {
+	if len(values) != len(columns) {
+		return "", fmt.Errorf("paginator: expect %d cursor values, got %d", len(columns), len(values))
+	}
+	payload := cursorPayload{Sort: columnsSignature(columns), Prev: prev}
+	for _, v := range values {
+		cv, err := newCursorValue(v)
+		if err != nil {
+			return "", err
+		}
+		payload.Values = append(payload.Values, cv)
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	body := base64.RawURLEncoding.EncodeToString(raw)
+	return body + "." + signCursor(body), nil
+}
+
+// decodeCursor 校验签名并解析游标
+func decodeCursor(token string, columns []SortColumn) (*cursorPayload, error) {
+	body, sign, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(sign), []byte(signCursor(body))) {
+		return nil, ErrInvalidCursor
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if payload.Sort != columnsSignature(columns) || len(payload.Values) != len(columns) {
+		return nil, ErrInvalidCursor
+	}
+	return &payload, nil
+}
+
+func (p *cursorPayload) decodeValues() ([]interface{}, error) {
+	values := make([]interface{}, 0, len(p.Values))
+	for _, cv := range p.Values {
+		v, err := cv.decode()
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func newCursorValue(v interface{}) (cursorValue, error) {
+	switch val := v.(type) {
+	case nil:
+		return cursorValue{Kind: "n"}, nil
+	case time.Time:
+		return cursorValue{Kind: "t", Value: strconv.FormatInt(val.UnixNano(), 10)}, nil
+	case *time.Time:
+		if val == nil {
+			return cursorValue{Kind: "n"}, nil
+		}
+		return newCursorValue(*val)
+	case bool:
+		return cursorValue{Kind: "b", Value: strconv.FormatBool(val)}, nil
+	case int, int8, int16, int32, int64:
+		return cursorValue{Kind: "i", Value: cast.ToString(val)}, nil
+	case uint, uint8, uint16, uint32, uint64:
+		return cursorValue{Kind: "u", Value: cast.ToString(val)}, nil
+	case string:
+		return cursorValue{Kind: "s", Value: val}, nil
+	default:
+		return cursorValue{}, fmt.Errorf("paginator: unsupported cursor value type %T", v)
+	}
+}
+
+func (cv cursorValue) decode() (interface{}, error) {
+	switch cv.Kind {
+	case "n":
+		return nil, nil
+	case "t":
+		nano, err := strconv.ParseInt(cv.Value, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return time.Unix(0, nano), nil
+	case "b":
+		return strconv.ParseBool(cv.Value)
+	case "i":
+		return strconv.ParseInt(cv.Value, 10, 64)
+	case "u":
+		return strconv.ParseUint(cv.Value, 10, 64)
+	case "s":
+		return cv.Value, nil
+	default:
+		return nil, fmt.Errorf("paginator: unknown cursor value kind %q", cv.Kind)
+	}
+}
+
+func columnsSignature(columns []SortColumn) string {
+	parts := make([]string, 0, len(columns))
+	for _, col := range columns {
+		if col.Desc {
+			parts = append(parts, col.Name+":d")
+		} else {
+			parts = append(parts, col.Name+":a")
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// signCursor 使用 app.key 签名，防止客户端伪造游标拼接任意条件
+func signCursor(body string) string {
+	mac := hmac.New(sha256.New, []byte(config.Get("app.key")))
+	mac.Write([]byte(body))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:16])
+}
+
+func cursorPerPage(c *gin.Context, perPage int) int {
+	if queryPerPage := c.Query(config.Get("paging.url_query_per_page")); queryPerPage != "" {
+		perPage = cast.ToInt(queryPerPage)
+	}
+	if perPage <= 0 {
+		perPage = config.GetInt("paging.perpage")
+	}
+	return perPage
+}
+
+func cursorQueryKey() string {
+	return config.Get("paging.url_query_cursor", "cursor")
+}
+
+// cursorPageLink 拼接游标分页链接，兼容 baseURL 带与不带 `?` 的情况
+func cursorPageLink(baseURL, token string, perPage int) string {
+	if baseURL == "" {
+		return ""
+	}
+	sep := "?"
+	if strings.Contains(baseURL, "?") {
+		sep = "&"
+	}
+	return fmt.Sprintf("%s%s%s=%s&%s=%d",
+		baseURL, sep,
+		cursorQueryKey(), url.QueryEscape(token),
+		config.Get("paging.url_query_per_page"), perPage,
+	)
+}
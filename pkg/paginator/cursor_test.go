@@ -0,0 +1,72 @@
+package paginator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	columns := []SortColumn{{Name: "is_pinned", Desc: true}, {Name: "created_at", Desc: true}, {Name: "id", Desc: true}}
+	created := time.Date(2026, 1, 5, 10, 30, 0, 123456789, time.Local)
+
+	t.Run("编码后可还原原始类型", func(t *testing.T) {
+		token, err := encodeCursor(columns, []interface{}{true, created, uint64(42)}, true)
+		assert.NoError(t, err)
+
+		payload, err := decodeCursor(token, columns)
+		assert.NoError(t, err)
+		assert.True(t, payload.Prev)
+
+		values, err := payload.decodeValues()
+		assert.NoError(t, err)
+		assert.Equal(t, true, values[0])
+		assert.True(t, created.Equal(values[1].(time.Time)))
+		assert.Equal(t, uint64(42), values[2])
+	})
+
+	t.Run("篡改内容签名校验失败", func(t *testing.T) {
+		token, _ := encodeCursor(columns, []interface{}{false, created, uint64(1)}, false)
+		body, sign, _ := strings.Cut(token, ".")
+		forged := strings.ToUpper(body[:1]) + body[1:] + "." + sign
+		if forged == token {
+			forged = "x" + token
+		}
+		_, err := decodeCursor(forged, columns)
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("排序方式变化后旧游标失效", func(t *testing.T) {
+		token, _ := encodeCursor(columns, []interface{}{false, created, uint64(1)}, false)
+		other := []SortColumn{{Name: "like_count", Desc: true}, {Name: "created_at", Desc: true}, {Name: "id", Desc: true}}
+		_, err := decodeCursor(token, other)
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("值数量不匹配", func(t *testing.T) {
+		_, err := encodeCursor(columns, []interface{}{uint64(1)}, false)
+		assert.Error(t, err)
+	})
+
+	t.Run("非法游标", func(t *testing.T) {
+		_, err := decodeCursor("not-a-cursor", columns)
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
+
+func TestKeysetCondition(t *testing.T) {
+	columns := []SortColumn{{Name: "like_count", Desc: true}, {Name: "id", Desc: false}}
+
+	t.Run("向后翻页", func(t *testing.T) {
+		where, args := keysetCondition(columns, []interface{}{10, 5}, false)
+		assert.Equal(t, "((like_count < ?) OR (like_count = ? AND id > ?))", where)
+		assert.Equal(t, []interface{}{10, 10, 5}, args)
+	})
+
+	t.Run("向前翻页方向反转", func(t *testing.T) {
+		where, _ := keysetCondition(columns, []interface{}{10, 5}, true)
+		assert.Equal(t, "((like_count > ?) OR (like_count = ? AND id < ?))", where)
+	})
+}
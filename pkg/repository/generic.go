@@ -62,9 +62,9 @@ func (r *GenericRepository[T]) List(ctx context.Context, c *gin.Context, perPage
 	var model T
 	
 	query := r.db.WithContext(ctx).Model(&model)
-	paging := paginator.Paginate(c, query, &models, perPage)
+	paging := paginator.Paginate(c, query, &models, "", perPage)
 	
-	return models, paging, nil
+	return models, &paging, nil
 }
 
 // ListWithCondition 获取列表（带条件和分页）
@@ -73,9 +73,25 @@ func (r *GenericRepository[T]) ListWithCondition(ctx context.Context, c *gin.Con
 	var model T
 	
 	query := r.db.WithContext(ctx).Model(&model).Where(condition, args...)
-	paging := paginator.Paginate(c, query, &models, perPage)
+	paging := paginator.Paginate(c, query, &models, "", perPage)
 	
-	return models, paging, nil
+	return models, &paging, nil
+}
+
+// ListByCursor 获取列表（游标分页），query 为空时查询全部
+// keyOf 按 opts.Columns 的顺序返回记录的排序值
+func (r *GenericRepository[T]) ListByCursor(ctx context.Context, c *gin.Context, query func(*gorm.DB) *gorm.DB, opts paginator.CursorOptions, keyOf func(*T) []interface{}) ([]T, *paginator.CursorPaging, error) {
+	var model T
+	db := r.db.WithContext(ctx).Model(&model)
+	if query != nil {
+		db = query(db)
+	}
+
+	models, paging, err := paginator.CursorPaginate(c, db, opts, keyOf)
+	if err != nil {
+		return nil, nil, err
+	}
+	return models, &paging, nil
 }
 
 // Create 创建记录
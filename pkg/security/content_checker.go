@@ -90,6 +90,7 @@ type CheckResult struct {
 	Message      string
 	FilteredText string
 	FoundWords   []string
+	NeedsReview  bool // 命中需要人工审核的敏感词
 }
 
 // CheckText 检查文本内容
@@ -127,17 +128,22 @@ func (c *ContentChecker) CheckText(text string, maxLength int) *CheckResult {
 		}
 	}
 
-	// 敏感词过滤
+	// 敏感词过滤：拒绝的词直接拦截，替换的词自动过滤，需要审核的词保留并标记
 	if c.config.SensitiveWordEnabled {
-		foundWords := c.sensitiveFilter.FindAll(result.FilteredText)
-		if len(foundWords) > 0 {
-			result.FoundWords = foundWords
+		matches := c.sensitiveFilter.Matches(result.FilteredText)
+		if len(matches) > 0 {
+			result.FoundWords = c.sensitiveFilter.FindAll(result.FilteredText)
+			for _, m := range matches {
+				switch m.Severity {
+				case SeverityBlock:
+					result.IsValid = false
+					result.Message = "内容包含违禁词"
+					return result
+				case SeverityReview:
+					result.NeedsReview = true
+				}
+			}
 			result.FilteredText = c.sensitiveFilter.Filter(result.FilteredText)
-			// 注意：这里不设置 IsValid = false，而是自动过滤
-			// 如果需要拒绝包含敏感词的内容，可以取消下面的注释
-			// result.IsValid = false
-			// result.Message = "内容包含敏感词"
-			// return result
 		}
 	}
 
@@ -7,10 +7,37 @@ import (
 	"unicode"
 )
 
+// 敏感词处理方式
+const (
+	SeverityBlock   = "block"   // 拒绝发布
+	SeverityReplace = "replace" // 替换为屏蔽字符后发布
+	SeverityReview  = "review"  // 允许发布，交由人工审核
+)
+
+// maxSeparatorGap 词中允许插入的连续分隔符数量，如“赌 .场”
+const maxSeparatorGap = 3
+
+// WordEntry 敏感词及其分类和处理方式
+type WordEntry struct {
+	Word     string   `json:"word"`
+	Category string   `json:"category"`
+	Severity string   `json:"severity"`
+	Pinyin   []string `json:"pinyin,omitempty"` // 拼音等变体，命中时视为命中原词
+}
+
+// Match 文本中命中的敏感词
+type Match struct {
+	WordEntry
+	Text  string // 原文中命中的片段，可能包含全角字符和分隔符
+	Start int    // 命中片段在原文中的起止位置（rune 下标，左闭右开）
+	End   int
+}
+
 // SensitiveWordFilter 敏感词过滤器
+// 匹配时忽略大小写、全角半角差异和词中插入的空格标点，拼音变体与原词等同
 type SensitiveWordFilter struct {
 	mu          sync.RWMutex
-	words       []string
+	entries     []WordEntry
 	trie        *TrieNode
 	replacement string
 }
@@ -19,6 +46,7 @@ type SensitiveWordFilter struct {
 type TrieNode struct {
 	children map[rune]*TrieNode
 	isEnd    bool
+	entry    *WordEntry
 }
 
 var (
@@ -29,13 +57,14 @@ var (
 // NewSensitiveWordFilter 创建敏感词过滤器
 func NewSensitiveWordFilter(replacement string) *SensitiveWordFilter {
 	return &SensitiveWordFilter{
-		words:       make([]string, 0),
-		trie:        &TrieNode{children: make(map[rune]*TrieNode)},
+		entries:     make([]WordEntry, 0),
+		trie:        newTrieNode(),
 		replacement: replacement,
 	}
 }
 
 // GetFilter 获取全局敏感词过滤器实例
+// 启动时使用内置词库，从数据库加载词库后整体替换
 func GetFilter() *SensitiveWordFilter {
 	once.Do(func() {
 		filter = NewSensitiveWordFilter("***")
@@ -45,212 +74,226 @@ func GetFilter() *SensitiveWordFilter {
 	return filter
 }
 
-// LoadDefaultWords 加载默认敏感词列表
-func (f *SensitiveWordFilter) LoadDefaultWords() {
-	// 政治敏感词
-	politicalWords := []string{
-		"法轮功", "法轮大法", "反党", "反共", "反政府",
-		"台独", "藏独", "疆独", "港独", "分裂",
+// DefaultWords 内置的默认敏感词，处理方式均为替换
+func DefaultWords() []WordEntry {
+	groups := map[string][]string{
+		// 政治敏感词
+		"political": {
+			"法轮功", "法轮大法", "反党", "反共", "反政府",
+			"台独", "藏独", "疆独", "港独", "分裂",
+		},
+		// 色情敏感词
+		"porn": {
+			"色情", "淫秽", "裸聊", "约炮", "一夜情",
+			"卖淫", "嫖娼", "性交易", "黄色网站",
+		},
+		// 暴力敏感词
+		"violence": {
+			"杀人", "自杀", "爆炸", "恐怖袭击", "制造炸弹",
+			"持枪", "枪支买卖", "暴力革命",
+		},
+		// 赌博欺诈
+		"gambling": {
+			"网络赌博", "赌场", "六合彩", "时时彩",
+			"诈骗", "传销", "非法集资", "高利贷",
+			"刷单", "洗钱", "黑客", "盗号",
+		},
+		// 毒品相关
+		"drug": {
+			"毒品", "大麻", "海洛因", "冰毒", "可卡因",
+			"吸毒", "贩毒", "制毒",
+		},
 	}
 
-	// 色情敏感词
-	pornWords := []string{
-		"色情", "淫秽", "裸聊", "约炮", "一夜情",
-		"卖淫", "嫖娼", "性交易", "黄色网站",
+	entries := make([]WordEntry, 0)
+	for _, category := range []string{"political", "porn", "violence", "gambling", "drug"} {
+		for _, word := range groups[category] {
+			entries = append(entries, WordEntry{Word: word, Category: category, Severity: SeverityReplace})
+		}
 	}
+	return entries
+}
 
-	// 暴力敏感词
-	violenceWords := []string{
-		"杀人", "自杀", "爆炸", "恐怖袭击", "制造炸弹",
-		"持枪", "枪支买卖", "暴力革命",
-	}
+// LoadDefaultWords 加载默认敏感词列表
+func (f *SensitiveWordFilter) LoadDefaultWords() {
+	f.Load(DefaultWords())
+}
 
-	// 赌博欺诈
-	gamblingWords := []string{
-		"网络赌博", "赌场", "六合彩", "时时彩",
-		"诈骗", "传销", "非法集资", "高利贷",
-		"刷单", "洗钱", "黑客", "盗号",
+// Load 使用新的词库整体替换当前词库
+// 新前缀树构建完成后再替换，替换前的匹配不受影响
+func (f *SensitiveWordFilter) Load(entries []WordEntry) {
+	trie := newTrieNode()
+	list := make([]WordEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Word == "" {
+			continue
+		}
+		if entry.Severity == "" {
+			entry.Severity = SeverityReplace
+		}
+		list = append(list, entry)
 	}
-
-	// 毒品相关
-	drugWords := []string{
-		"毒品", "大麻", "海洛因", "冰毒", "可卡因",
-		"吸毒", "贩毒", "制毒",
+	for i := range list {
+		addToTrie(trie, &list[i])
 	}
 
-	// 合并所有敏感词
-	allWords := append(politicalWords, pornWords...)
-	allWords = append(allWords, violenceWords...)
-	allWords = append(allWords, gamblingWords...)
-	allWords = append(allWords, drugWords...)
-
-	f.AddWords(allWords...)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.entries = list
+	f.trie = trie
 }
 
-// AddWords 添加敏感词
+// AddWords 添加敏感词，处理方式为替换
 func (f *SensitiveWordFilter) AddWords(words ...string) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
+	f.mu.RLock()
+	entries := make([]WordEntry, len(f.entries), len(f.entries)+len(words))
+	copy(entries, f.entries)
+	f.mu.RUnlock()
 
 	for _, word := range words {
-		if word == "" {
-			continue
-		}
-		f.words = append(f.words, word)
-		f.addToTrie(word)
+		entries = append(entries, WordEntry{Word: word, Severity: SeverityReplace})
 	}
+	f.Load(entries)
 }
 
-// addToTrie 添加词到前缀树
-func (f *SensitiveWordFilter) addToTrie(word string) {
-	node := f.trie
-	runes := []rune(strings.ToLower(word))
-
-	for _, r := range runes {
-		if node.children[r] == nil {
-			node.children[r] = &TrieNode{
-				children: make(map[rune]*TrieNode),
+// addToTrie 将原词和拼音变体加入前缀树，词中的分隔符不参与匹配
+func addToTrie(root *TrieNode, entry *WordEntry) {
+	for _, word := range append([]string{entry.Word}, entry.Pinyin...) {
+		node := root
+		for _, r := range word {
+			r = normalizeRune(r)
+			if isSeparator(r) {
+				continue
 			}
+			if node.children[r] == nil {
+				node.children[r] = newTrieNode()
+			}
+			node = node.children[r]
+		}
+		if node != root {
+			node.isEnd = true
+			node.entry = entry
 		}
-		node = node.children[r]
 	}
-	node.isEnd = true
 }
 
-// Filter 过滤文本中的敏感词
-func (f *SensitiveWordFilter) Filter(text string) string {
+// Matches 查找文本中命中的全部敏感词，重叠时取最长的词
+func (f *SensitiveWordFilter) Matches(text string) []Match {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
 	if text == "" {
-		return text
+		return nil
 	}
 
 	runes := []rune(text)
-	result := make([]rune, 0, len(runes))
-
+	var matches []Match
 	for i := 0; i < len(runes); i++ {
-		maxMatchLength := 0
-		node := f.trie
+		if isSeparator(normalizeRune(runes[i])) {
+			continue
+		}
+		end, entry := f.longestMatch(runes, i)
+		if entry == nil {
+			continue
+		}
+		matches = append(matches, Match{WordEntry: *entry, Text: string(runes[i:end]), Start: i, End: end})
+		i = end - 1
+	}
+	return matches
+}
 
-		// 尝试匹配最长的敏感词
-		for j := i; j < len(runes); j++ {
-			r := unicode.ToLower(runes[j])
-			if node.children[r] == nil {
+// longestMatch 从 start 开始匹配最长的敏感词，返回命中片段的结束位置
+func (f *SensitiveWordFilter) longestMatch(runes []rune, start int) (int, *WordEntry) {
+	node := f.trie
+	end, gap := 0, 0
+	var entry *WordEntry
+	for j := start; j < len(runes); j++ {
+		r := normalizeRune(runes[j])
+		if isSeparator(r) {
+			gap++
+			if gap > maxSeparatorGap {
 				break
 			}
-			node = node.children[r]
-			if node.isEnd {
-				maxMatchLength = j - i + 1
-			}
+			continue
 		}
-
-		if maxMatchLength > 0 {
-			// 找到敏感词，替换
-			result = append(result, []rune(f.replacement)...)
-			i += maxMatchLength - 1
-		} else {
-			result = append(result, runes[i])
+		gap = 0
+		node = node.children[r]
+		if node == nil {
+			break
+		}
+		if node.isEnd {
+			end, entry = j+1, node.entry
 		}
 	}
-
-	return string(result)
+	return end, entry
 }
 
-// Contains 检查文本是否包含敏感词
-func (f *SensitiveWordFilter) Contains(text string) bool {
-	f.mu.RLock()
-	defer f.mu.RUnlock()
-
-	if text == "" {
-		return false
+// Filter 将需要替换和拒绝的敏感词替换为屏蔽字符，需要审核的词保留
+func (f *SensitiveWordFilter) Filter(text string) string {
+	matches := f.Matches(text)
+	if len(matches) == 0 {
+		return text
 	}
 
-	runes := []rune(strings.ToLower(text))
-
-	for i := 0; i < len(runes); i++ {
-		node := f.trie
+	f.mu.RLock()
+	replacement := []rune(f.replacement)
+	f.mu.RUnlock()
 
-		for j := i; j < len(runes); j++ {
-			r := runes[j]
-			if node.children[r] == nil {
-				break
-			}
-			node = node.children[r]
-			if node.isEnd {
-				return true
-			}
+	runes := []rune(text)
+	result := make([]rune, 0, len(runes))
+	last := 0
+	for _, m := range matches {
+		if m.Severity == SeverityReview {
+			continue
 		}
+		result = append(result, runes[last:m.Start]...)
+		result = append(result, replacement...)
+		last = m.End
 	}
+	result = append(result, runes[last:]...)
+	return string(result)
+}
 
-	return false
+// Contains 检查文本是否包含敏感词
+func (f *SensitiveWordFilter) Contains(text string) bool {
+	return len(f.Matches(text)) > 0
 }
 
-// FindAll 查找文本中所有的敏感词
+// FindAll 查找文本中所有的敏感词，返回词库中的原词
 func (f *SensitiveWordFilter) FindAll(text string) []string {
-	f.mu.RLock()
-	defer f.mu.RUnlock()
-
 	found := make([]string, 0)
-	if text == "" {
-		return found
-	}
-
-	runes := []rune(strings.ToLower(text))
 	matched := make(map[string]bool)
-
-	for i := 0; i < len(runes); i++ {
-		node := f.trie
-
-		for j := i; j < len(runes); j++ {
-			r := runes[j]
-			if node.children[r] == nil {
-				break
-			}
-			node = node.children[r]
-			if node.isEnd {
-				word := string(runes[i : j+1])
-				if !matched[word] {
-					found = append(found, word)
-					matched[word] = true
-				}
-			}
+	for _, m := range f.Matches(text) {
+		if !matched[m.Word] {
+			found = append(found, m.Word)
+			matched[m.Word] = true
 		}
 	}
-
 	return found
 }
 
 // RemoveWords 删除敏感词
 func (f *SensitiveWordFilter) RemoveWords(words ...string) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-
-	// 重建前缀树
-	f.trie = &TrieNode{children: make(map[rune]*TrieNode)}
-	f.words = make([]string, 0)
-
-	// 重新添加除了要删除的词之外的所有词
 	removeMap := make(map[string]bool)
 	for _, word := range words {
 		removeMap[word] = true
 	}
 
-	for _, word := range f.words {
-		if !removeMap[word] {
-			f.words = append(f.words, word)
-			f.addToTrie(word)
+	f.mu.RLock()
+	entries := make([]WordEntry, 0, len(f.entries))
+	for _, entry := range f.entries {
+		if !removeMap[entry.Word] {
+			entries = append(entries, entry)
 		}
 	}
+	f.mu.RUnlock()
+
+	f.Load(entries)
 }
 
 // Clear 清空所有敏感词
 func (f *SensitiveWordFilter) Clear() {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-
-	f.words = make([]string, 0)
-	f.trie = &TrieNode{children: make(map[rune]*TrieNode)}
+	f.Load(nil)
 }
 
 // GetWords 获取所有敏感词
@@ -258,8 +301,10 @@ func (f *SensitiveWordFilter) GetWords() []string {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
-	words := make([]string, len(f.words))
-	copy(words, f.words)
+	words := make([]string, 0, len(f.entries))
+	for _, entry := range f.entries {
+		words = append(words, entry.Word)
+	}
 	return words
 }
 
@@ -270,3 +315,35 @@ func (f *SensitiveWordFilter) SetReplacement(replacement string) {
 
 	f.replacement = replacement
 }
+
+func newTrieNode() *TrieNode {
+	return &TrieNode{children: make(map[rune]*TrieNode)}
+}
+
+// normalizeRune 全角转半角并转为小写
+func normalizeRune(r rune) rune {
+	switch {
+	case r == 0x3000:
+		r = ' '
+	case r >= 0xFF01 && r <= 0xFF5E:
+		r -= 0xFEE0
+	}
+	return unicode.ToLower(r)
+}
+
+// isSeparator 空白、标点、符号和零宽字符，用于跳过词中插入的干扰字符
+func isSeparator(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.Is(unicode.Cf, r)
+}
+
+// NormalizeWord 统一词条格式，用于判断词条是否重复
+func NormalizeWord(word string) string {
+	var b strings.Builder
+	for _, r := range word {
+		r = normalizeRune(r)
+		if !isSeparator(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,86 @@
+package security
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newTestFilter() *SensitiveWordFilter {
+	f := NewSensitiveWordFilter("***")
+	f.Load([]WordEntry{
+		{Word: "赌博", Category: "gambling", Severity: SeverityReplace, Pinyin: []string{"dubo"}},
+		{Word: "冰毒", Category: "drug", Severity: SeverityBlock},
+		{Word: "代开发票", Category: "ad", Severity: SeverityReview},
+	})
+	return f
+}
+
+func TestSensitiveWordFilter_Variants(t *testing.T) {
+	f := newTestFilter()
+
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"原词", "网上赌博", []string{"赌博"}},
+		{"插入分隔符", "网上赌 . 博", []string{"赌博"}},
+		{"插入零宽字符", "网上赌\u200b博", []string{"赌博"}},
+		{"拼音", "一起 DuBo 吧", []string{"赌博"}},
+		{"拼音插入分隔符", "d-u b_o", []string{"赌博"}},
+		{"全角拼音", "ｄｕｂｏ", []string{"赌博"}},
+		{"分隔符过多", "赌    博", []string{}},
+		{"无敏感词", "正常内容", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := f.FindAll(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindAll(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSensitiveWordFilter_FilterBySeverity(t *testing.T) {
+	f := newTestFilter()
+
+	got := f.Filter("赌-博、冰毒和代开发票")
+	want := "***、***和代开发票"
+	if got != want {
+		t.Errorf("Filter() = %q, want %q", got, want)
+	}
+}
+
+func TestSensitiveWordFilter_LoadReplaces(t *testing.T) {
+	f := newTestFilter()
+	f.Load([]WordEntry{{Word: "广告"}})
+
+	if f.Contains("网上赌博") {
+		t.Error("重新加载后旧词库不应再生效")
+	}
+	matches := f.Matches("推广告示")
+	if len(matches) != 1 || matches[0].Severity != SeverityReplace {
+		t.Errorf("Matches() = %+v, want 1 match with default severity", matches)
+	}
+
+	f.RemoveWords("广告")
+	if len(f.GetWords()) != 0 {
+		t.Errorf("GetWords() = %v, want empty", f.GetWords())
+	}
+}
+
+func TestContentChecker_BlockWord(t *testing.T) {
+	checker := &ContentChecker{
+		sensitiveFilter: newTestFilter(),
+		xssFilter:       NewXSSFilter(nil, nil),
+		config:          ContentCheckConfig{Enabled: true, SensitiveWordEnabled: true},
+	}
+
+	if result := checker.CheckContent("出售冰毒"); result.IsValid {
+		t.Error("包含拒绝类敏感词时应拦截")
+	}
+	result := checker.CheckContent("代开发票，网上赌博")
+	if !result.IsValid || !result.NeedsReview || result.FilteredText != "代开发票，网上***" {
+		t.Errorf("CheckContent() = %+v", result)
+	}
+}
@@ -81,8 +81,27 @@ func RegisterAdminRoutes(r *gin.Engine) {
 			topics.POST("/:id/unpin", topicController.Unpin)     // 取消置顶
 			topics.POST("/:id/approve", topicController.Approve) // 审核通过
 			topics.POST("/:id/reject", topicController.Reject)   // 审核拒绝
+			topics.POST("/:id/lock", topicController.Lock)       // 锁定
+			topics.POST("/:id/unlock", topicController.Unlock)   // 解除锁定
+			topics.POST("/:id/close", topicController.Close)     // 关闭
+			topics.POST("/:id/reopen", topicController.Reopen)   // 重新开放
+			topics.POST("/:id/move", topicController.Move)       // 移动分类
+			topics.POST("/:id/merge", topicController.Merge)     // 合并到其他话题
+			topics.POST("/:id/split", topicController.Split)     // 拆分评论为新话题
 		}
 
+		// 审核队列
+		queue := adminGroup.Group("/queue")
+		{
+			queue.GET("", topicController.Queue)                // 待审核话题
+			queue.POST("/:id/claim", topicController.Claim)     // 认领
+			queue.POST("/:id/assign", topicController.Assign)   // 指派
+			queue.POST("/:id/release", topicController.Release) // 释放认领
+		}
+
+		// 版主操作日志
+		adminGroup.GET("/moderation-logs", topicController.Logs)
+
 		// 分类管理
 		categoryController := &admin.CategoryController{}
 		categories := adminGroup.Group("/categories")
@@ -93,6 +112,11 @@ func RegisterAdminRoutes(r *gin.Engine) {
 			categories.PUT("/:id", categoryController.Update)    // 更新分类
 			categories.DELETE("/:id", categoryController.Delete) // 删除分类
 			categories.POST("/sort", categoryController.Sort)    // 分类排序
+
+			// 分类版主：被指派后只能管理该分类及其子分类
+			categories.GET("/:id/moderators", categoryController.Moderators)                // 版主列表
+			categories.POST("/:id/moderators", categoryController.AddModerator)             // 指派版主
+			categories.DELETE("/:id/moderators/:user_id", categoryController.RemoveModerator) // 取消版主
 		}
 
 		// 角色管理
@@ -130,6 +154,15 @@ func RegisterAdminRoutes(r *gin.Engine) {
 			comments.GET("/stats", commentController.Stats)         // 评论统计
 		}
 
+		// 回收站：:type 为 topics、comments、users
+		trashController := &admin.TrashController{}
+		trash := adminGroup.Group("/trash")
+		{
+			trash.GET("/:type", trashController.Index)          // 回收站列表
+			trash.POST("/:type/restore", trashController.Restore) // 恢复
+			trash.POST("/:type/purge", trashController.Purge)     // 彻底删除
+		}
+
 		// 关注管理
 		followController := &admin.FollowController{}
 		follows := adminGroup.Group("/follows")
@@ -151,6 +184,53 @@ func RegisterAdminRoutes(r *gin.Engine) {
 			likes.DELETE("/:id", likeController.Delete)              // 删除点赞
 			likes.GET("/stats", likeController.Stats)                // 点赞统计
 		}
+
+		// 表情回应管理
+		reactionController := &admin.ReactionController{}
+		reactionTypes := adminGroup.Group("/reaction-types")
+		{
+			reactionTypes.GET("", reactionController.Index)         // 表情列表
+			reactionTypes.POST("", reactionController.Store)        // 添加表情
+			reactionTypes.PUT("/:id", reactionController.Update)    // 修改表情
+			reactionTypes.DELETE("/:id", reactionController.Delete) // 删除表情
+		}
+
+		// 敏感词词库管理，修改后所有实例自动重新加载
+		sensitiveWordController := &admin.SensitiveWordController{}
+		sensitiveWords := adminGroup.Group("/sensitive-words")
+		{
+			sensitiveWords.GET("", sensitiveWordController.Index)          // 敏感词列表
+			sensitiveWords.POST("", sensitiveWordController.Store)         // 添加敏感词
+			sensitiveWords.POST("/import", sensitiveWordController.Import) // 批量导入
+			sensitiveWords.GET("/export", sensitiveWordController.Export)  // 导出
+			sensitiveWords.PUT("/:id", sensitiveWordController.Update)     // 修改敏感词
+			sensitiveWords.DELETE("/:id", sensitiveWordController.Delete)  // 删除敏感词
+		}
+
+		// 友情链接管理：用户申请需审核通过后展示，失效链接由定期检测自动标记
+		linkController := &admin.LinkController{}
+		links := adminGroup.Group("/links")
+		{
+			links.GET("", linkController.Index)                // 链接列表
+			links.POST("", linkController.Store)               // 添加链接
+			links.POST("/sort", linkController.Sort)           // 链接排序
+			links.POST("/check", linkController.Check)         // 检测全部链接
+			links.PUT("/:id", linkController.Update)           // 修改链接
+			links.DELETE("/:id", linkController.Delete)        // 删除链接
+			links.POST("/:id/approve", linkController.Approve) // 通过申请
+			links.POST("/:id/reject", linkController.Reject)   // 拒绝申请
+			links.POST("/:id/check", linkController.Check)     // 检测单个链接
+		}
+
+		// 垃圾信息检测：阈值设置和拦截记录
+		spamController := &admin.SpamController{}
+		spamGroup := adminGroup.Group("/spam")
+		{
+			spamGroup.GET("/settings", spamController.Settings)       // 当前阈值
+			spamGroup.PUT("/settings", spamController.UpdateSettings) // 修改阈值
+			spamGroup.GET("/attempts", spamController.Attempts)       // 拦截记录
+			spamGroup.GET("/stats", spamController.Stats)             // 拦截统计
+		}
 	}
 
 	// 版主路由组（moderator 角色）
@@ -159,11 +239,43 @@ func RegisterAdminRoutes(r *gin.Engine) {
 	moderatorGroup.Use(middlewares.RequireRole("moderator"))
 	{
 		topicController := &admin.TopicController{}
-		
-		// 版主可以审核话题
+		// 被指派了分类的版主只能操作其管理的分类下的话题
+		scoped := middlewares.RequireTopicCategoryModerator()
+
+		// 版主可以审核话题，列表只包含其管理的分类
 		moderatorGroup.GET("/topics", topicController.Index)
-		moderatorGroup.POST("/topics/:id/approve", topicController.Approve)
-		moderatorGroup.POST("/topics/:id/reject", topicController.Reject)
-		moderatorGroup.DELETE("/topics/:id", topicController.Delete)
+		moderatorGroup.POST("/topics/:id/approve", scoped, topicController.Approve)
+		moderatorGroup.POST("/topics/:id/reject", scoped, topicController.Reject)
+		moderatorGroup.DELETE("/topics/:id", scoped, topicController.Delete)
+
+		// 版主可以置顶、锁定、关闭话题
+		moderatorGroup.POST("/topics/:id/pin", scoped, topicController.Pin)
+		moderatorGroup.POST("/topics/:id/unpin", scoped, topicController.Unpin)
+		moderatorGroup.POST("/topics/:id/lock", scoped, topicController.Lock)
+		moderatorGroup.POST("/topics/:id/unlock", scoped, topicController.Unlock)
+		moderatorGroup.POST("/topics/:id/close", scoped, topicController.Close)
+		moderatorGroup.POST("/topics/:id/reopen", scoped, topicController.Reopen)
+
+		// 版主可以移动、合并、拆分话题，操作记录在日志中，目标分类同样需要有管理权限
+		moderatorGroup.POST("/topics/:id/move", scoped, topicController.Move)
+		moderatorGroup.POST("/topics/:id/merge", scoped, topicController.Merge)
+		moderatorGroup.POST("/topics/:id/split", scoped, topicController.Split)
+		moderatorGroup.GET("/logs", topicController.Logs)
+
+		// 审核队列：认领后审核，避免多人重复处理
+		moderatorGroup.GET("/queue", topicController.Queue)
+		moderatorGroup.POST("/queue/:id/claim", scoped, topicController.Claim)
+		moderatorGroup.POST("/queue/:id/assign", scoped, topicController.Assign)
+		moderatorGroup.POST("/queue/:id/release", scoped, topicController.Release)
+
+		// 举报队列：处置或驳回会关闭同一内容上的所有待处理举报
+		// 被指派了分类的版主只能处理其管理的分类下的话题和评论举报
+		reportController := &admin.ReportController{}
+		reportScoped := middlewares.RequireReportCategoryModerator()
+		moderatorGroup.GET("/reports", reportController.Index)
+		moderatorGroup.GET("/reports/:id", reportScoped, reportController.Show)
+		moderatorGroup.POST("/reports/:id/triage", reportScoped, reportController.Triage)
+		moderatorGroup.POST("/reports/:id/action", reportScoped, reportController.Action)
+		moderatorGroup.POST("/reports/:id/dismiss", reportScoped, reportController.Dismiss)
 	}
 }
@@ -130,6 +130,21 @@ func RegisterAPIRoutes(r *gin.Engine) {
 	// 私信相关
 	RegisterMessageRoutes(v1)
 
+	// 收藏和收藏夹
+	RegisterCollectionRoutes(v1)
+
+	// 话题系列
+	RegisterSeriesRoutes(v1)
+
+	// 表情回应
+	RegisterReactionRoutes(v1)
+
+	// 举报
+	RegisterReportRoutes(v1)
+
+	// 首页信息流
+	RegisterFeedRoutes(v1)
+
 	// 管理后台路由
 	RegisterAdminRoutes(r)
 }
@@ -12,6 +12,7 @@ func RegisterCategoryRoutes(rg *gin.RouterGroup, categoriesCtrl *v1.CategoriesCo
 	categoriesGroup := rg.Group("/categories")
 	{
 		categoriesGroup.GET("", categoriesCtrl.Index)
+		categoriesGroup.GET("/tree", middlewares.AuthJWTOptional(), categoriesCtrl.Tree)
 		categoriesGroup.POST("", middlewares.AuthJWT(), categoriesCtrl.Store)
 		categoriesGroup.PUT(":id", middlewares.AuthJWT(), categoriesCtrl.Update)
 		categoriesGroup.DELETE(":id", middlewares.AuthJWT(), categoriesCtrl.Delete)
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	v1 "GoHub-Service/app/http/controllers/api/v1"
+	"GoHub-Service/app/http/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterCollectionRoutes 注册收藏和收藏夹相关路由
+func RegisterCollectionRoutes(r *gin.RouterGroup) {
+	controller := v1.NewCollectionsController()
+
+	r.GET("/favorites", middlewares.AuthJWT(), controller.Favorites)
+	r.GET("/users/:id/collections", middlewares.AuthJWTOptional(), controller.UserCollections)
+
+	group := r.Group("/collections")
+	{
+		group.GET("", middlewares.AuthJWT(), controller.Index)
+		group.POST("", middlewares.AuthJWT(), controller.Store)
+		group.GET("/following", middlewares.AuthJWT(), controller.Following)
+		group.GET("/:id", middlewares.AuthJWTOptional(), controller.Show)
+		group.PUT("/:id", middlewares.AuthJWT(), controller.Update)
+		group.DELETE("/:id", middlewares.AuthJWT(), controller.Delete)
+		group.GET("/:id/items", middlewares.AuthJWTOptional(), controller.Items)
+		group.POST("/:id/items", middlewares.AuthJWT(), controller.AddItem)
+		group.PUT("/:id/items/order", middlewares.AuthJWT(), controller.Reorder)
+		group.PUT("/:id/items/:topic_id", middlewares.AuthJWT(), controller.UpdateItem)
+		group.DELETE("/:id/items/:topic_id", middlewares.AuthJWT(), controller.RemoveItem)
+		group.POST("/:id/follow", middlewares.AuthJWT(), controller.Follow)
+		group.POST("/:id/unfollow", middlewares.AuthJWT(), controller.Unfollow)
+	}
+}
@@ -12,8 +12,8 @@ func RegisterCommentRoutes(rg *gin.RouterGroup, commentsCtrl *v1.CommentsControl
 	// 评论基础路由
 	commentsGroup := rg.Group("/comments")
 	{
-		commentsGroup.GET("", commentsCtrl.Index)
-		commentsGroup.GET("/:id", commentsCtrl.Show)
+		commentsGroup.GET("", middlewares.AuthJWTOptional(), commentsCtrl.Index)
+		commentsGroup.GET("/:id", middlewares.AuthJWTOptional(), commentsCtrl.Show)
 		// 创建和更新评论应用内容安全检查
 		commentsGroup.POST("", 
 			middlewares.AuthJWT(), 
@@ -26,24 +26,32 @@ func RegisterCommentRoutes(rg *gin.RouterGroup, commentsCtrl *v1.CommentsControl
 			commentsCtrl.Update,
 		)
 		commentsGroup.DELETE("/:id", middlewares.AuthJWT(), commentsCtrl.Delete)
+
+		// 问答分类中采纳回答（话题作者、版主和管理员）
+		commentsGroup.POST("/:id/accept", middlewares.AuthJWT(), commentsCtrl.Accept)
+		commentsGroup.DELETE("/:id/accept", middlewares.AuthJWT(), commentsCtrl.Unaccept)
+
+		// 评论编辑历史（作者、版主和管理员可见）
+		commentsGroup.GET("/:id/revisions", middlewares.AuthJWT(), commentsCtrl.Revisions)
 		
 		// 评论点赞
 		commentsGroup.POST("/:id/like", middlewares.AuthJWT(), commentsCtrl.Like)
 		commentsGroup.POST("/:id/unlike", middlewares.AuthJWT(), commentsCtrl.Unlike)
 		
 		// 获取评论的回复
-		commentsGroup.GET("/:id/replies", commentsCtrl.ListReplies)
+		commentsGroup.GET("/:id/replies", middlewares.AuthJWTOptional(), commentsCtrl.ListReplies)
 	}
 
 	// 话题的评论路由
 	topicsGroup := rg.Group("/topics")
 	{
-		topicsGroup.GET("/:id/comments", commentsCtrl.ListByTopicID)
+		topicsGroup.GET("/:id/comments", middlewares.AuthJWTOptional(), commentsCtrl.ListByTopicID)
+		topicsGroup.GET("/:id/comments/tree", middlewares.AuthJWTOptional(), commentsCtrl.Tree)
 	}
 
 	// 用户的评论路由
 	usersGroup := rg.Group("/users")
 	{
-		usersGroup.GET("/:id/comments", commentsCtrl.ListByUserID)
+		usersGroup.GET("/:id/comments", middlewares.AuthJWTOptional(), commentsCtrl.ListByUserID)
 	}
 }
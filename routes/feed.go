@@ -0,0 +1,21 @@
+package routes
+
+import (
+	v1 "GoHub-Service/app/http/controllers/api/v1"
+	"GoHub-Service/app/http/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterFeedRoutes 注册首页信息流相关路由
+func RegisterFeedRoutes(r *gin.RouterGroup) {
+	controller := v1.NewFeedController()
+
+	group := r.Group("/feed", middlewares.AuthJWT())
+	{
+		group.GET("", controller.Index)
+		group.GET("/subscriptions", controller.Subscriptions)
+		group.POST("/subscriptions", controller.Subscribe)
+		group.DELETE("/subscriptions/:type/:id", controller.Unsubscribe)
+	}
+}
@@ -3,6 +3,8 @@ package routes
 
 import (
 	"GoHub-Service/app/http/controllers/api/v1"
+	"GoHub-Service/app/http/middlewares"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -11,5 +13,9 @@ func RegisterLinkRoutes(rg *gin.RouterGroup, linksCtrl *v1.LinksController) {
 	linksGroup := rg.Group("/links")
 	{
 		linksGroup.GET("", linksCtrl.Index)
+		// 跳转并记录点击次数
+		linksGroup.GET("/:id/go", linksCtrl.Go)
+		// 申请友情链接，审核通过后展示
+		linksGroup.POST("/apply", middlewares.AuthJWT(), linksCtrl.Apply)
 	}
 }
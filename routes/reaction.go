@@ -0,0 +1,22 @@
+package routes
+
+import (
+	v1 "GoHub-Service/app/http/controllers/api/v1"
+	"GoHub-Service/app/http/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterReactionRoutes 注册表情回应相关路由
+func RegisterReactionRoutes(r *gin.RouterGroup) {
+	controller := v1.NewReactionsController()
+
+	r.GET("/reactions/types", controller.Types)
+
+	r.POST("/topics/:id/reactions", middlewares.AuthJWT(), controller.ReactTopic)
+	r.DELETE("/topics/:id/reactions", middlewares.AuthJWT(), controller.UnreactTopic)
+	r.POST("/comments/:id/reactions", middlewares.AuthJWT(), controller.ReactComment)
+	r.DELETE("/comments/:id/reactions", middlewares.AuthJWT(), controller.UnreactComment)
+	r.POST("/messages/:id/reactions", middlewares.AuthJWT(), controller.ReactMessage)
+	r.DELETE("/messages/:id/reactions", middlewares.AuthJWT(), controller.UnreactMessage)
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	v1 "GoHub-Service/app/http/controllers/api/v1"
+	"GoHub-Service/app/http/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterReportRoutes 注册举报相关路由
+func RegisterReportRoutes(r *gin.RouterGroup) {
+	controller := v1.NewReportsController()
+
+	r.POST("/reports", middlewares.AuthJWT(), controller.Store)
+	r.GET("/reports", middlewares.AuthJWT(), controller.Index)
+}
@@ -30,7 +30,8 @@ func RegisterSearchRoutes(r *gin.RouterGroup) {
 	}
 
 	// 主题搜索 - 优先使用Elasticsearch，降级到传统搜索
-	r.GET("/search/topics", func(c *gin.Context) {
+	// 登录用户可以搜索到其有权查看的受限分类下的话题
+	r.GET("/search/topics", middlewares.AuthJWTOptional(), func(c *gin.Context) {
 		if esSearchCtrl != nil {
 			esSearchCtrl.SearchTopics(c)
 		} else {
@@ -40,8 +41,8 @@ func RegisterSearchRoutes(r *gin.RouterGroup) {
 
 	// Elasticsearch 特定功能
 	if esSearchCtrl != nil {
-		r.GET("/search/suggestions", esSearchCtrl.SearchSuggestions)
-		r.GET("/search/hot-topics", esSearchCtrl.GetHotTopics)
+		r.GET("/search/suggestions", middlewares.AuthJWTOptional(), esSearchCtrl.SearchSuggestions)
+		r.GET("/search/hot-topics", middlewares.AuthJWTOptional(), esSearchCtrl.GetHotTopics)
 	}
 
 	// 用户搜索需要登录
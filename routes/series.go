@@ -0,0 +1,28 @@
+package routes
+
+import (
+	v1 "GoHub-Service/app/http/controllers/api/v1"
+	"GoHub-Service/app/http/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterSeriesRoutes 注册话题系列相关路由
+func RegisterSeriesRoutes(r *gin.RouterGroup) {
+	controller := v1.NewSeriesController()
+
+	r.GET("/users/:id/series", controller.UserSeries)
+
+	group := r.Group("/series")
+	{
+		group.POST("", middlewares.AuthJWT(), controller.Store)
+		group.GET("/:id", middlewares.AuthJWTOptional(), controller.Show)
+		group.PUT("/:id", middlewares.AuthJWT(), controller.Update)
+		group.DELETE("/:id", middlewares.AuthJWT(), controller.Delete)
+		group.POST("/:id/topics", middlewares.AuthJWT(), controller.AddTopic)
+		group.PUT("/:id/topics/order", middlewares.AuthJWT(), controller.Reorder)
+		group.DELETE("/:id/topics/:topic_id", middlewares.AuthJWT(), controller.RemoveTopic)
+		group.POST("/:id/follow", middlewares.AuthJWT(), controller.Follow)
+		group.POST("/:id/unfollow", middlewares.AuthJWT(), controller.Unfollow)
+	}
+}
@@ -11,7 +11,8 @@ import (
 func RegisterTopicRoutes(rg *gin.RouterGroup, topicsCtrl *v1.TopicsController) {
 	topicsGroup := rg.Group("/topics")
 	{
-		topicsGroup.GET("", topicsCtrl.Index)
+		// 登录可选：作者本人可以看到自己待审核的话题
+		topicsGroup.GET("", middlewares.AuthJWTOptional(), topicsCtrl.Index)
 		// 创建和上传应用内容安全检查
 		topicsGroup.POST("", 
 			middlewares.AuthJWT(), 
@@ -30,11 +31,18 @@ func RegisterTopicRoutes(rg *gin.RouterGroup, topicsCtrl *v1.TopicsController) {
 			topicsCtrl.Update,
 		)
 		topicsGroup.DELETE(":id", middlewares.AuthJWT(), topicsCtrl.Delete)
-		topicsGroup.GET(":id", topicsCtrl.Show)
+		topicsGroup.GET(":id", middlewares.AuthJWTOptional(), topicsCtrl.Show)
+		topicsGroup.GET(":id/related", middlewares.AuthJWTOptional(), topicsCtrl.Related)
 		topicsGroup.POST(":id/like", middlewares.AuthJWT(), topicsCtrl.Like)
 		topicsGroup.POST(":id/unlike", middlewares.AuthJWT(), topicsCtrl.Unlike)
 		topicsGroup.POST(":id/favorite", middlewares.AuthJWT(), topicsCtrl.Favorite)
 		topicsGroup.POST(":id/unfavorite", middlewares.AuthJWT(), topicsCtrl.Unfavorite)
-		topicsGroup.POST(":id/view", topicsCtrl.AddView)
+		topicsGroup.POST(":id/view", middlewares.AuthJWTOptional(), topicsCtrl.AddView)
+		topicsGroup.GET(":id/views/history", middlewares.AuthJWT(), topicsCtrl.ViewHistory)
+		// 作者锁定、关闭自己的话题
+		topicsGroup.POST(":id/lock", middlewares.AuthJWT(), topicsCtrl.Lock)
+		topicsGroup.POST(":id/unlock", middlewares.AuthJWT(), topicsCtrl.Unlock)
+		topicsGroup.POST(":id/close", middlewares.AuthJWT(), topicsCtrl.Close)
+		topicsGroup.POST(":id/reopen", middlewares.AuthJWT(), topicsCtrl.Reopen)
 	}
 }
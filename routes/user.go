@@ -12,6 +12,7 @@ func RegisterUserRoutes(rg *gin.RouterGroup, usersCtrl *v1.UsersController) {
 	usersGroup := rg.Group("/users")
 	{
 		usersGroup.GET("", usersCtrl.Index)
+		usersGroup.GET("/autocomplete", middlewares.AuthJWT(), usersCtrl.Autocomplete)
 		// 更新资料应用内容安全检查
 		usersGroup.PUT("", 
 			middlewares.AuthJWT(), 
@@ -40,7 +41,13 @@ func RegisterUserRoutes(rg *gin.RouterGroup, usersCtrl *v1.UsersController) {
 			middlewares.ImageUploadSecurity(),
 			usersCtrl.UpdateAvatar,
 		)
+		// 粉丝和关注列表公开，登录后标记当前用户的关注状态
+		usersGroup.GET("/:id/followers", middlewares.AuthJWTOptional(), usersCtrl.Followers)
+		usersGroup.GET("/:id/following", middlewares.AuthJWTOptional(), usersCtrl.Following)
+		usersGroup.GET("/:id/relation", middlewares.AuthJWTOptional(), usersCtrl.FollowRelation)
 		usersGroup.POST("/:id/follow", middlewares.AuthJWT(), usersCtrl.Follow)
 		usersGroup.POST("/:id/unfollow", middlewares.AuthJWT(), usersCtrl.Unfollow)
+		usersGroup.POST("/:id/block", middlewares.AuthJWT(), usersCtrl.Block)
+		usersGroup.POST("/:id/unblock", middlewares.AuthJWT(), usersCtrl.Unblock)
 	}
 }
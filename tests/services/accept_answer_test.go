@@ -0,0 +1,90 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/comment"
+	"GoHub-Service/app/models/notification"
+	"GoHub-Service/app/models/role"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/models/user"
+	"GoHub-Service/app/models/user_role"
+	"GoHub-Service/app/services"
+	apperrors "GoHub-Service/pkg/errors"
+)
+
+func TestCommentService_AcceptAnswer(t *testing.T) {
+	db := setupDB(t, &user.User{}, &category.Category{}, &category.Moderator{}, &role.Role{}, &user_role.UserRole{},
+		&topic.Topic{}, &comment.Comment{}, &notification.Notification{})
+	services.ForgetCategoryIndex()
+	t.Cleanup(services.ForgetCategoryIndex)
+	qa := &category.Category{Name: "问答", IsQA: true}
+	db.Create(qa)
+	chat := &category.Category{Name: "闲聊"}
+	db.Create(chat)
+	for i, name := range []string{"asker", "alice", "bob"} {
+		if err := db.Create(&user.User{Name: name, Email: name + "@example.com", Phone: "1380000000" + string(rune('1'+i)), Password: "secret"}).Error; err != nil {
+			t.Fatalf("创建用户失败: %v", err)
+		}
+	}
+
+	question := &topic.Topic{Title: "怎么做", Body: "内容", UserID: "1", CategoryID: qa.GetStringID(), Status: topic.StatusApproved}
+	db.Create(question)
+	first := &comment.Comment{TopicID: question.GetStringID(), UserID: "2", Content: "回答一", ParentID: "0"}
+	second := &comment.Comment{TopicID: question.GetStringID(), UserID: "3", Content: "回答二", ParentID: "0"}
+	db.Create(first)
+	db.Create(second)
+	reply := &comment.Comment{TopicID: question.GetStringID(), UserID: "3", Content: "追问", ParentID: first.GetStringID()}
+	db.Create(reply)
+
+	svc := services.NewCommentService()
+	ctx := context.Background()
+	if _, err := svc.AcceptAnswer(ctx, first.GetStringID(), "3"); err == nil || err.Type != apperrors.ErrorTypeAuthorization {
+		t.Errorf("只有话题作者可以采纳回答，got %v", err)
+	}
+	if _, err := svc.AcceptAnswer(ctx, reply.GetStringID(), "1"); err == nil || err.Code != apperrors.CodeCommentNotAnswer {
+		t.Errorf("不能采纳楼中楼回复，got %v", err)
+	}
+
+	if _, err := svc.AcceptAnswer(ctx, first.GetStringID(), "1"); err != nil {
+		t.Fatalf("采纳回答失败: %v", err)
+	}
+	if _, err := svc.AcceptAnswer(ctx, second.GetStringID(), "1"); err != nil {
+		t.Fatalf("改为采纳另一个回答失败: %v", err)
+	}
+	var reloaded topic.Topic
+	db.First(&reloaded, question.ID)
+	if reloaded.AcceptedCommentID != second.ID || reloaded.SolvedAt == nil {
+		t.Errorf("话题应记录最新采纳的回答，got accepted_comment_id=%d", reloaded.AcceptedCommentID)
+	}
+	var accepted []uint64
+	db.Model(&comment.Comment{}).Where("is_accepted = ?", true).Pluck("id", &accepted)
+	if len(accepted) != 1 || accepted[0] != second.ID {
+		t.Errorf("同一话题只能有一个被采纳的回答，got %v", accepted)
+	}
+	var alice, bob user.User
+	db.First(&alice, 2)
+	db.First(&bob, 3)
+	if alice.Points != 0 || bob.Points <= 0 {
+		t.Errorf("改为采纳其他回答时应扣回原回答者的积分，alice=%d bob=%d", alice.Points, bob.Points)
+	}
+
+	if err := svc.UnacceptAnswer(ctx, second.GetStringID(), "1"); err != nil {
+		t.Fatalf("取消采纳失败: %v", err)
+	}
+	var unsolved topic.Topic
+	db.First(&unsolved, question.ID)
+	if unsolved.AcceptedCommentID != 0 || unsolved.SolvedAt != nil {
+		t.Errorf("取消采纳后话题应回到未解决状态，got accepted_comment_id=%d solved_at=%v", unsolved.AcceptedCommentID, unsolved.SolvedAt)
+	}
+
+	chatTopic := &topic.Topic{Title: "闲聊", Body: "内容", UserID: "1", CategoryID: chat.GetStringID(), Status: topic.StatusApproved}
+	db.Create(chatTopic)
+	chatReply := &comment.Comment{TopicID: chatTopic.GetStringID(), UserID: "2", Content: "回复", ParentID: "0"}
+	db.Create(chatReply)
+	if _, err := svc.AcceptAnswer(ctx, chatReply.GetStringID(), "1"); err == nil || err.Code != apperrors.CodeTopicNotQuestion {
+		t.Errorf("非问答分类的话题不能采纳回答，got %v", err)
+	}
+}
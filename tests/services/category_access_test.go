@@ -0,0 +1,88 @@
+package services_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/collection"
+	"GoHub-Service/app/models/comment"
+	"GoHub-Service/app/models/reaction"
+	"GoHub-Service/app/models/role"
+	"GoHub-Service/app/models/series"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/models/user_role"
+	"GoHub-Service/app/services"
+	apperrors "GoHub-Service/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// setupRestrictedCategory 创建一个公开分类和一个只对 vip 角色可见的分类，各发布一篇话题
+func setupRestrictedCategory(t *testing.T, models ...interface{}) (db *gorm.DB, public, restricted *topic.Topic) {
+	t.Helper()
+	db = setupDB(t, append([]interface{}{&category.Category{}, &category.Moderator{}, &role.Role{}, &user_role.UserRole{}, &topic.Topic{}}, models...)...)
+	open := createCategory(t, "公开", "")
+	vip := createCategory(t, "会员", "")
+	db.Model(&category.Category{}).Where("id = ?", vip.ID).Updates(map[string]interface{}{"visibility": "roles", "visible_roles": "vip"})
+	services.ForgetCategoryIndex()
+	t.Cleanup(services.ForgetCategoryIndex)
+
+	public = &topic.Topic{Title: "公开话题", Body: "内容", UserID: "1", CategoryID: open.ID, Status: topic.StatusApproved}
+	restricted = &topic.Topic{Title: "会员话题", Body: "内容", UserID: "1", CategoryID: vip.ID, Status: topic.StatusApproved}
+	db.Create(public)
+	db.Create(restricted)
+	return db, public, restricted
+}
+
+func TestSeriesService_HidesRestrictedCategories(t *testing.T) {
+	db, public, restricted := setupRestrictedCategory(t, &series.Series{}, &series.Item{}, &series.Follow{})
+	sr := &series.Series{UserID: 1, Title: "系列"}
+	db.Create(sr)
+	db.Create(&series.Item{SeriesID: sr.ID, TopicID: public.ID, Position: 1})
+	db.Create(&series.Item{SeriesID: sr.ID, TopicID: restricted.ID, Position: 2})
+
+	svc := services.NewSeriesService()
+	detail, err := svc.Show(sr.GetStringID(), "5")
+	if err != nil {
+		t.Fatalf("获取系列失败: %v", err)
+	}
+	if len(detail.Parts) != 1 || detail.Parts[0].TopicID != public.ID {
+		t.Errorf("系列中不应出现无权查看的分类中的话题，got %+v", detail.Parts)
+	}
+	if nav := svc.Navigation(public.GetStringID(), "5"); nav == nil || nav.Total != 1 || nav.Next != nil {
+		t.Errorf("系列导航不应指向无权查看的话题，got %+v", nav)
+	}
+}
+
+func TestCollectionService_HidesRestrictedCategories(t *testing.T) {
+	db, public, restricted := setupRestrictedCategory(t, &collection.Collection{}, &collection.Item{})
+	coll := &collection.Collection{UserID: 1, Name: "收藏夹", IsPublic: true}
+	db.Create(coll)
+	db.Create(&collection.Item{CollectionID: coll.ID, TopicID: public.ID, Position: 1})
+	db.Create(&collection.Item{CollectionID: coll.ID, TopicID: restricted.ID, Position: 2})
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/api/v1/collections/"+coll.GetStringID()+"/items", nil)
+	items, _, err := services.NewCollectionService().Items(c, coll.GetStringID(), "5", 20)
+	if err != nil {
+		t.Fatalf("获取收藏夹话题失败: %v", err)
+	}
+	if len(items) != 1 || items[0].TopicID != public.ID {
+		t.Errorf("收藏夹中不应出现无权查看的分类中的话题，got %d 条", len(items))
+	}
+}
+
+func TestReactionService_RejectsRestrictedCategories(t *testing.T) {
+	db, _, restricted := setupRestrictedCategory(t, &comment.Comment{}, &reaction.Reaction{})
+	cm := &comment.Comment{TopicID: restricted.GetStringID(), UserID: "1", Content: "评论", ParentID: "0"}
+	db.Create(cm)
+
+	svc := services.NewReactionService()
+	for targetType, id := range map[string]string{services.ReactionTargetTopic: restricted.GetStringID(), services.ReactionTargetComment: cm.GetStringID()} {
+		if _, err := svc.Unreact(targetType, id, "5", "👍"); err == nil || err.Type != apperrors.ErrorTypeNotFound {
+			t.Errorf("无权查看的分类中的%s应视为不存在，got %v", targetType, err)
+		}
+	}
+}
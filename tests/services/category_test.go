@@ -0,0 +1,243 @@
+package services_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"GoHub-Service/app/http/middlewares"
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/comment"
+	"GoHub-Service/app/models/role"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/models/user_role"
+	"GoHub-Service/app/services"
+	apperrors "GoHub-Service/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// createCategory 通过服务创建分类，parentID 为空时为顶级分类
+func createCategory(t *testing.T, name, parentID string) *services.CategoryResponseDTO {
+	t.Helper()
+	created, err := services.NewCategoryService().Create(services.CategoryCreateDTO{Name: name, ParentID: parentID})
+	if err != nil {
+		t.Fatalf("创建分类 %s 失败: %v", name, err)
+	}
+	return created
+}
+
+// reloadCategory 从数据库读取分类的路径和层级
+func reloadCategory(t *testing.T, db *gorm.DB, id string) category.Category {
+	t.Helper()
+	var c category.Category
+	if err := db.Where("id = ?", id).First(&c).Error; err != nil {
+		t.Fatalf("获取分类 %s 失败: %v", id, err)
+	}
+	return c
+}
+
+// grantRole 为用户添加角色
+func grantRole(t *testing.T, db *gorm.DB, userID uint64, name string) {
+	t.Helper()
+	r := role.Role{Name: name, DisplayName: name}
+	db.Where("name = ?", name).FirstOrCreate(&r)
+	if err := db.Create(&user_role.UserRole{UserID: userID, RoleID: r.ID}).Error; err != nil {
+		t.Fatalf("添加角色失败: %v", err)
+	}
+}
+
+func setupCategoryDB(t *testing.T) *gorm.DB {
+	return setupDB(t, &category.Category{}, &category.Moderator{}, &role.Role{}, &user_role.UserRole{}, &topic.Topic{})
+}
+
+func TestCategoryService_CreateWritesPath(t *testing.T) {
+	db := setupCategoryDB(t)
+	root := createCategory(t, "技术", "")
+	child := createCategory(t, "后端", root.ID)
+
+	if got := reloadCategory(t, db, root.ID); got.Path != "/"+root.ID+"/" || got.Depth != 0 {
+		t.Errorf("顶级分类 path=%q depth=%d", got.Path, got.Depth)
+	}
+	if got := reloadCategory(t, db, child.ID); got.Path != "/"+root.ID+"/"+child.ID+"/" || got.Depth != 1 {
+		t.Errorf("子分类 path=%q depth=%d", got.Path, got.Depth)
+	}
+}
+
+func TestCategoryService_MoveSubtree(t *testing.T) {
+	db := setupCategoryDB(t)
+	a := createCategory(t, "分类A", "")
+	b := createCategory(t, "分类B", a.ID)
+	c := createCategory(t, "分类C", b.ID)
+	d := createCategory(t, "分类D", "")
+
+	if _, err := services.NewCategoryService().Update(b.ID, services.CategoryUpdateDTO{ParentID: &d.ID}); err != nil {
+		t.Fatalf("移动分类失败: %v", err)
+	}
+	if got := reloadCategory(t, db, b.ID); got.Path != "/"+d.ID+"/"+b.ID+"/" || got.Depth != 1 || got.GetStringID() != b.ID {
+		t.Errorf("被移动的分类 path=%q depth=%d", got.Path, got.Depth)
+	}
+	if got := reloadCategory(t, db, c.ID); got.Path != "/"+d.ID+"/"+b.ID+"/"+c.ID+"/" || got.Depth != 2 {
+		t.Errorf("子分类 path=%q depth=%d，应随上级一起移动", got.Path, got.Depth)
+	}
+
+	// 移到顶级
+	root := "0"
+	if _, err := services.NewCategoryService().Update(b.ID, services.CategoryUpdateDTO{ParentID: &root}); err != nil {
+		t.Fatalf("移动到顶级失败: %v", err)
+	}
+	if got := reloadCategory(t, db, c.ID); got.Path != "/"+b.ID+"/"+c.ID+"/" || got.Depth != 1 {
+		t.Errorf("移到顶级后子分类 path=%q depth=%d", got.Path, got.Depth)
+	}
+}
+
+func TestCategoryService_MoveUnderOwnDescendant(t *testing.T) {
+	db := setupCategoryDB(t)
+	a := createCategory(t, "分类A", "")
+	b := createCategory(t, "分类B", a.ID)
+	c := createCategory(t, "分类C", b.ID)
+
+	for _, target := range []string{b.ID, c.ID} {
+		_, err := services.NewCategoryService().Update(a.ID, services.CategoryUpdateDTO{ParentID: &target})
+		appErr, ok := err.(*apperrors.AppError)
+		if !ok || appErr.Type != apperrors.ErrorTypeValidation {
+			t.Fatalf("移动到自己的子分类下应返回校验错误，got %v", err)
+		}
+	}
+	if got := reloadCategory(t, db, a.ID); got.Path != "/"+a.ID+"/" || got.ParentID != 0 {
+		t.Errorf("移动失败后 path=%q parent_id=%d 不应改变", got.Path, got.ParentID)
+	}
+}
+
+func TestModeratorScope(t *testing.T) {
+	db := setupCategoryDB(t)
+	a := createCategory(t, "分类A", "")
+	b := createCategory(t, "分类B", a.ID)
+	other := createCategory(t, "其他", "")
+	grantRole(t, db, 1, "admin")
+	grantRole(t, db, 2, "moderator")
+	grantRole(t, db, 3, "moderator")
+	grantRole(t, db, 4, "user")
+	db.Create(&category.Moderator{CategoryID: reloadCategory(t, db, a.ID).ID, UserID: 2})
+
+	if _, scoped := category.ModeratorScope("1"); scoped {
+		t.Error("管理员不应受分类限制")
+	}
+	if _, scoped := category.ModeratorScope("3"); scoped {
+		t.Error("未指派分类的版主不应受分类限制")
+	}
+	ids, scoped := category.ModeratorScope("2")
+	if !scoped || len(ids) != 2 {
+		t.Fatalf("版主的管理范围应为被指派分类及其子分类，got %v scoped=%v", ids, scoped)
+	}
+
+	cases := []struct {
+		userID, categoryID string
+		want               bool
+	}{
+		{"1", other.ID, true},
+		{"2", a.ID, true},
+		{"2", b.ID, true},
+		{"2", other.ID, false},
+		{"3", other.ID, true},
+		{"4", a.ID, false},
+		{"", a.ID, false},
+	}
+	for _, tc := range cases {
+		if got := category.UserCanModerate(tc.userID, tc.categoryID); got != tc.want {
+			t.Errorf("UserCanModerate(%q, %q) = %v, want %v", tc.userID, tc.categoryID, got, tc.want)
+		}
+	}
+}
+
+func TestRequireTopicCategoryModerator(t *testing.T) {
+	db := setupCategoryDB(t)
+	a := createCategory(t, "分类A", "")
+	b := createCategory(t, "分类B", a.ID)
+	other := createCategory(t, "其他", "")
+	grantRole(t, db, 2, "moderator")
+	db.Create(&category.Moderator{CategoryID: reloadCategory(t, db, a.ID).ID, UserID: 2})
+
+	inScope := &topic.Topic{Title: "范围内", Body: "内容", UserID: "9", CategoryID: b.ID, Status: topic.StatusApproved}
+	outOfScope := &topic.Topic{Title: "范围外", Body: "内容", UserID: "9", CategoryID: other.ID, Status: topic.StatusApproved}
+	db.Create(inScope)
+	db.Create(outOfScope)
+
+	// 与版主路由组相同的中间件顺序，登录用户由测试直接写入
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("current_user_id", "2") })
+	r.Use(middlewares.RequireRole("moderator"))
+	r.POST("/api/v1/moderator/topics/:id/lock", middlewares.RequireTopicCategoryModerator(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	cases := []struct {
+		topicID string
+		want    int
+	}{
+		{inScope.GetStringID(), http.StatusOK},
+		{outOfScope.GetStringID(), http.StatusForbidden},
+		{"404", http.StatusOK}, // 话题不存在时交给控制器处理
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/moderator/topics/"+tc.topicID+"/lock", nil)
+		r.ServeHTTP(w, req)
+		if w.Code != tc.want {
+			t.Errorf("话题 %s 返回 %d, want %d", tc.topicID, w.Code, tc.want)
+		}
+	}
+}
+
+func TestCommentService_ScopedModeratorEdits(t *testing.T) {
+	db := setupDB(t, &category.Category{}, &category.Moderator{}, &role.Role{}, &user_role.UserRole{}, &topic.Topic{}, &comment.Comment{}, &comment.Revision{})
+	a := createCategory(t, "分类A", "")
+	other := createCategory(t, "其他", "")
+	grantRole(t, db, 2, "moderator")
+	db.Create(&category.Moderator{CategoryID: reloadCategory(t, db, a.ID).ID, UserID: 2})
+
+	inScope := &topic.Topic{Title: "范围内", Body: "内容", UserID: "9", CategoryID: a.ID, Status: topic.StatusApproved}
+	outOfScope := &topic.Topic{Title: "范围外", Body: "内容", UserID: "9", CategoryID: other.ID, Status: topic.StatusApproved}
+	db.Create(inScope)
+	db.Create(outOfScope)
+	inScopeComment := &comment.Comment{TopicID: inScope.GetStringID(), UserID: "9", Content: "评论", ParentID: "0"}
+	outOfScopeComment := &comment.Comment{TopicID: outOfScope.GetStringID(), UserID: "9", Content: "评论", ParentID: "0"}
+	db.Create(inScopeComment)
+	db.Create(outOfScopeComment)
+
+	svc := services.NewCommentService()
+	if _, err := svc.Revisions(context.Background(), inScopeComment.GetStringID(), "2"); err != nil {
+		t.Errorf("版主应可以查看其管理分类下评论的编辑历史: %v", err)
+	}
+	if _, err := svc.Revisions(context.Background(), outOfScopeComment.GetStringID(), "2"); err == nil || err.Type != apperrors.ErrorTypeAuthorization {
+		t.Errorf("版主不应查看其他分类下评论的编辑历史，got %v", err)
+	}
+	content := "修改后的内容"
+	if _, err := svc.Update(context.Background(), outOfScopeComment.GetStringID(), &services.CommentUpdateDTO{Content: &content}, "2"); err == nil || err.Type != apperrors.ErrorTypeAuthorization {
+		t.Errorf("版主不应修改其他分类下的评论，got %v", err)
+	}
+}
+
+func TestCommentService_CanViewTopicRequiresApproval(t *testing.T) {
+	db := setupCategoryDB(t)
+	a := createCategory(t, "分类A", "")
+	other := createCategory(t, "其他", "")
+	grantRole(t, db, 1, "admin")
+	grantRole(t, db, 2, "moderator")
+	grantRole(t, db, 3, "moderator")
+	db.Create(&category.Moderator{CategoryID: reloadCategory(t, db, a.ID).ID, UserID: 2})
+	db.Create(&category.Moderator{CategoryID: reloadCategory(t, db, other.ID).ID, UserID: 3})
+
+	pending := &topic.Topic{Title: "待审核", Body: "内容", UserID: "9", CategoryID: a.ID, Status: topic.StatusPending}
+	db.Create(pending)
+
+	svc := services.NewCommentService()
+	cases := map[string]bool{"": false, "8": false, "3": false, "9": true, "2": true, "1": true}
+	for viewerID, want := range cases {
+		if got := svc.CanViewTopic(context.Background(), pending.GetStringID(), viewerID); got != want {
+			t.Errorf("用户 %q 查看待审核话题的评论，got %v want %v", viewerID, got, want)
+		}
+	}
+}
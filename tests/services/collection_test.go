@@ -0,0 +1,68 @@
+package services_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/collection"
+	"GoHub-Service/app/models/notification"
+	"GoHub-Service/app/models/role"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/models/user_role"
+	"GoHub-Service/app/repositories"
+	"GoHub-Service/app/services"
+	apperrors "GoHub-Service/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCollectionService_PrivateCollectionItems(t *testing.T) {
+	db := setupDB(t, &category.Category{}, &category.Moderator{}, &role.Role{}, &user_role.UserRole{}, &topic.Topic{},
+		&collection.Collection{}, &collection.Item{}, &collection.Follow{}, &repositories.TopicFavorite{}, &notification.Notification{})
+	services.ForgetCategoryIndex()
+	t.Cleanup(services.ForgetCategoryIndex)
+	first := &topic.Topic{Title: "第一篇", Body: "内容", UserID: "1", CategoryID: "1", Status: topic.StatusApproved}
+	second := &topic.Topic{Title: "第二篇", Body: "内容", UserID: "1", CategoryID: "1", Status: topic.StatusApproved}
+	db.Create(first)
+	db.Create(second)
+
+	svc := services.NewCollectionService()
+	coll, err := svc.Create("7", services.CollectionSaveDTO{Name: "稍后阅读"})
+	if err != nil {
+		t.Fatalf("创建收藏夹失败: %v", err)
+	}
+	for _, tp := range []*topic.Topic{first, second} {
+		if err := svc.AddItem(coll.GetStringID(), "7", tp.GetStringID(), "备注"); err != nil {
+			t.Fatalf("加入收藏夹失败: %v", err)
+		}
+	}
+	var favorites int64
+	db.Model(&repositories.TopicFavorite{}).Where("user_id = ?", "7").Count(&favorites)
+	if favorites != 2 {
+		t.Errorf("加入收藏夹的话题应同时加入收藏，got %d 条收藏", favorites)
+	}
+
+	if err := svc.Reorder(coll.GetStringID(), "7", []string{second.GetStringID(), first.GetStringID()}); err != nil {
+		t.Fatalf("收藏夹排序失败: %v", err)
+	}
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/api/v1/collections/"+coll.GetStringID()+"/items", nil)
+	items, _, appErr := svc.Items(c, coll.GetStringID(), "7", 20)
+	if appErr != nil {
+		t.Fatalf("获取收藏夹话题失败: %v", appErr)
+	}
+	if len(items) != 2 || items[0].TopicID != second.ID || items[1].TopicID != first.ID {
+		t.Errorf("收藏夹话题应按排序后的顺序返回，got %+v", items)
+	}
+
+	if _, err := svc.Get(coll.GetStringID(), "8"); err == nil || err.Type != apperrors.ErrorTypeNotFound {
+		t.Errorf("私有收藏夹对他人应视为不存在，got %v", err)
+	}
+	if err := svc.Follow(coll.GetStringID(), "8"); err == nil {
+		t.Error("不能关注他人的私有收藏夹")
+	}
+	if err := svc.AddItem(coll.GetStringID(), "8", first.GetStringID(), ""); err == nil {
+		t.Error("不能向他人的收藏夹中添加话题")
+	}
+}
@@ -0,0 +1,63 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/comment"
+	"GoHub-Service/app/models/mention"
+	"GoHub-Service/app/models/role"
+	"GoHub-Service/app/models/spam"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/models/user"
+	"GoHub-Service/app/models/user_role"
+	"GoHub-Service/app/services"
+	apperrors "GoHub-Service/pkg/errors"
+)
+
+func TestCommentService_EditHistoryAndWindow(t *testing.T) {
+	db := setupDB(t, &user.User{}, &category.Category{}, &category.Moderator{}, &role.Role{}, &user_role.UserRole{},
+		&topic.Topic{}, &comment.Comment{}, &comment.Revision{}, &mention.Mention{}, &spam.Fingerprint{}, &spam.Attempt{}, &spam.Settings{})
+	tp := &topic.Topic{Title: "话题", Body: "内容", UserID: "1", CategoryID: "1", Status: topic.StatusApproved}
+	db.Create(tp)
+	fresh := &comment.Comment{TopicID: tp.GetStringID(), UserID: "2", Content: "原始内容", ParentID: "0"}
+	db.Create(fresh)
+	stale := &comment.Comment{TopicID: tp.GetStringID(), UserID: "2", Content: "很早的评论", ParentID: "0"}
+	db.Create(stale)
+	db.Model(stale).UpdateColumn("created_at", time.Now().Add(-time.Hour))
+
+	svc := services.NewCommentService()
+	ctx := context.Background()
+	edited := "修改后的内容"
+	result, err := svc.Update(ctx, fresh.GetStringID(), &services.CommentUpdateDTO{Content: &edited}, "2")
+	if err != nil {
+		t.Fatalf("作者在时限内修改评论失败: %v", err)
+	}
+	if result.Content != edited || !result.Edited || result.EditCount != 1 {
+		t.Errorf("修改后 content=%q edited=%v edit_count=%d", result.Content, result.Edited, result.EditCount)
+	}
+	// 内容未变化时不产生编辑记录
+	if _, err := svc.Update(ctx, fresh.GetStringID(), &services.CommentUpdateDTO{Content: &edited}, "2"); err != nil {
+		t.Fatalf("提交相同内容失败: %v", err)
+	}
+
+	revisions, err := svc.Revisions(ctx, fresh.GetStringID(), "2")
+	if err != nil {
+		t.Fatalf("获取编辑历史失败: %v", err)
+	}
+	if len(revisions) != 1 || revisions[0].Content != "原始内容" || revisions[0].EditorID != "2" {
+		t.Errorf("编辑历史应只保存修改前的内容，got %+v", revisions)
+	}
+	if _, err := svc.Revisions(ctx, fresh.GetStringID(), "3"); err == nil || err.Type != apperrors.ErrorTypeAuthorization {
+		t.Errorf("其他用户不能查看编辑历史，got %v", err)
+	}
+
+	if _, err := svc.Update(ctx, fresh.GetStringID(), &services.CommentUpdateDTO{Content: &edited}, "3"); err == nil || err.Type != apperrors.ErrorTypeAuthorization {
+		t.Errorf("不能修改他人的评论，got %v", err)
+	}
+	if _, err := svc.Update(ctx, stale.GetStringID(), &services.CommentUpdateDTO{Content: &edited}, "2"); err == nil || err.Code != apperrors.CodeCommentEditExpired {
+		t.Errorf("超过编辑时限应返回 CodeCommentEditExpired，got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"GoHub-Service/app/models/comment"
+	"GoHub-Service/app/models/like"
+	"GoHub-Service/app/models/notification"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/services"
+
+	"gorm.io/gorm"
+)
+
+func setupCommentLike(t *testing.T) (*gorm.DB, *comment.Comment) {
+	t.Helper()
+	db := setupDB(t, &topic.Topic{}, &comment.Comment{}, &like.Like{}, &notification.Notification{})
+	tp := &topic.Topic{Title: "话题", Body: "内容", UserID: "1", CategoryID: "1", Status: topic.StatusApproved}
+	db.Create(tp)
+	cm := &comment.Comment{TopicID: tp.GetStringID(), UserID: "2", Content: "评论", ParentID: "0"}
+	db.Create(cm)
+	return db, cm
+}
+
+func likeCountOf(t *testing.T, db *gorm.DB, id uint64) int64 {
+	t.Helper()
+	var cm comment.Comment
+	if err := db.First(&cm, id).Error; err != nil {
+		t.Fatalf("获取评论失败: %v", err)
+	}
+	return cm.LikeCount
+}
+
+func TestCommentService_LikeAndUnlike(t *testing.T) {
+	db, cm := setupCommentLike(t)
+	svc := services.NewCommentService()
+	ctx := context.Background()
+
+	if err := svc.LikeComment(ctx, cm.GetStringID(), "3"); err != nil {
+		t.Fatalf("点赞失败: %v", err)
+	}
+	if got := likeCountOf(t, db, cm.ID); got != 1 {
+		t.Errorf("点赞后 like_count=%d，want 1", got)
+	}
+	var notified int64
+	db.Model(&notification.Notification{}).Where("user_id = ? AND type = ?", "2", "comment_like").Count(&notified)
+	if notified != 1 {
+		t.Errorf("评论作者应收到一条点赞通知，got %d", notified)
+	}
+
+	if err := svc.UnlikeComment(ctx, cm.GetStringID(), "3"); err != nil {
+		t.Fatalf("取消点赞失败: %v", err)
+	}
+	if got := likeCountOf(t, db, cm.ID); got != 0 {
+		t.Errorf("取消点赞后 like_count=%d，want 0", got)
+	}
+	if err := svc.UnlikeComment(ctx, cm.GetStringID(), "3"); err != nil {
+		t.Fatalf("重复取消点赞失败: %v", err)
+	}
+	if got := likeCountOf(t, db, cm.ID); got != 0 {
+		t.Errorf("重复取消点赞不应改变 like_count，got %d", got)
+	}
+}
+
+func TestCommentService_DuplicateLikeCountsOnce(t *testing.T) {
+	db, cm := setupCommentLike(t)
+	svc := services.NewCommentService()
+
+	for i := 0; i < 3; i++ {
+		if err := svc.LikeComment(context.Background(), cm.GetStringID(), "3"); err != nil {
+			t.Fatalf("第 %d 次点赞失败: %v", i+1, err)
+		}
+	}
+	var rows int64
+	db.Model(&like.Like{}).Where("user_id = ? AND target_type = ? AND target_id = ?", "3", like.TargetComment, cm.GetStringID()).Count(&rows)
+	if rows != 1 {
+		t.Errorf("重复点赞只应保留一条记录，got %d", rows)
+	}
+	if got := likeCountOf(t, db, cm.ID); got != 1 {
+		t.Errorf("重复点赞只应计数一次，like_count=%d", got)
+	}
+
+	// 绕过服务直接插入重复记录时由唯一索引拒绝
+	if err := db.Create(&like.Like{UserID: "3", TargetType: like.TargetComment, TargetID: cm.GetStringID()}).Error; err == nil {
+		t.Error("likes 表应有 (user_id, target_type, target_id) 唯一索引")
+	}
+}
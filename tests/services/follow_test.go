@@ -0,0 +1,80 @@
+package services_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"GoHub-Service/app/models/follow"
+	"GoHub-Service/app/models/notification"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/models/user"
+	"GoHub-Service/app/services"
+	apperrors "GoHub-Service/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// followersOf 获取用户的粉丝列表，viewerID 为当前登录用户
+func followersOf(t *testing.T, userID, viewerID string) []services.FollowUserDTO {
+	t.Helper()
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/api/v1/users/"+userID+"/followers", nil)
+	list, _, err := services.NewFollowService().Followers(c, userID, viewerID, 20)
+	if err != nil {
+		t.Fatalf("获取粉丝列表失败: %v", err)
+	}
+	return list
+}
+
+func TestFollowService_FollowUnfollowAndFollowers(t *testing.T) {
+	db := setupDB(t, &user.User{}, &follow.Follow{}, &notification.Notification{}, &topic.Topic{})
+	for i, name := range []string{"alice", "bob", "carol"} {
+		if err := db.Create(&user.User{Name: name, Email: name + "@example.com", Phone: "1380000000" + string(rune('1'+i)), Password: "secret"}).Error; err != nil {
+			t.Fatalf("创建用户失败: %v", err)
+		}
+	}
+
+	interaction := services.NewInteractionService()
+	for _, pair := range [][2]string{{"1", "2"}, {"3", "2"}, {"2", "1"}} {
+		if err := interaction.FollowUser(pair[0], pair[1]); err != nil {
+			t.Fatalf("%s 关注 %s 失败: %v", pair[0], pair[1], err)
+		}
+	}
+	if err := interaction.FollowUser("1", "2"); err != nil {
+		t.Fatalf("重复关注应直接成功: %v", err)
+	}
+	if err := interaction.FollowUser("1", "1"); err == nil || err.Type != apperrors.ErrorTypeValidation {
+		t.Errorf("关注自己应返回校验错误，got %v", err)
+	}
+
+	// bob 查看自己的粉丝：与 alice 互相关注，carol 单向关注
+	followers := followersOf(t, "2", "2")
+	if len(followers) != 2 {
+		t.Fatalf("bob 应有 2 个粉丝，got %d", len(followers))
+	}
+	for _, f := range followers {
+		if f.IsMutual != (f.ID == "1") || !f.FollowsMe {
+			t.Errorf("粉丝 %s 的关注状态不正确: %+v", f.Name, f)
+		}
+	}
+
+	relation, err := services.NewFollowService().Relation("2", "1")
+	if err != nil {
+		t.Fatalf("获取关注关系失败: %v", err)
+	}
+	if !relation.IsMutual || relation.FollowersCount != 2 || relation.FollowingCount != 1 {
+		t.Errorf("alice 与 bob 应互相关注，bob 粉丝 2 关注 1，got %+v", relation)
+	}
+
+	if err := interaction.UnfollowUser("3", "2"); err != nil {
+		t.Fatalf("取消关注失败: %v", err)
+	}
+	if got := followersOf(t, "2", ""); len(got) != 1 || got[0].ID != "1" {
+		t.Errorf("取消关注后 bob 只应剩 alice 一个粉丝，got %+v", got)
+	}
+	var carol user.User
+	db.First(&carol, 3)
+	if carol.FollowingCount != 0 {
+		t.Errorf("取消关注后 carol 的关注数应为 0，got %d", carol.FollowingCount)
+	}
+}
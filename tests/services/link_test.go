@@ -0,0 +1,58 @@
+package services_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"GoHub-Service/app/models/link"
+	"GoHub-Service/app/services"
+)
+
+func TestLinkService_CreateDisabled(t *testing.T) {
+	db := setupDB(t, &link.Link{})
+	disabled := false
+	created, err := services.NewLinkService().Create(services.LinkSaveDTO{
+		Name:      "示例",
+		URL:       "https://example.com",
+		IsEnabled: &disabled,
+	})
+	if err != nil {
+		t.Fatalf("创建链接失败: %v", err)
+	}
+
+	var stored link.Link
+	db.First(&stored, created.ID)
+	if stored.IsEnabled {
+		t.Error("创建时指定停用，保存后 is_enabled 应为 false")
+	}
+}
+
+func TestLinkService_CheckMarksBrokenAtThreshold(t *testing.T) {
+	db := setupDB(t, &link.Link{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	created, err := services.NewLinkService().Create(services.LinkSaveDTO{Name: "示例", URL: server.URL})
+	if err != nil {
+		t.Fatalf("创建链接失败: %v", err)
+	}
+
+	svc := services.NewLinkService()
+	for i := 1; i <= 3; i++ {
+		if _, err := svc.Check(context.Background(), created.GetStringID()); err != nil {
+			t.Fatalf("第 %d 次检测失败: %v", i, err)
+		}
+		var stored link.Link
+		db.First(&stored, created.ID)
+		if stored.FailCount != i {
+			t.Errorf("第 %d 次检测后 fail_count = %d", i, stored.FailCount)
+		}
+		if stored.IsBroken != (i >= 3) {
+			t.Errorf("第 %d 次检测后 is_broken = %v", i, stored.IsBroken)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package services_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/mention"
+	"GoHub-Service/app/models/moderation"
+	"GoHub-Service/app/models/notification"
+	"GoHub-Service/app/models/role"
+	"GoHub-Service/app/models/series"
+	"GoHub-Service/app/models/tag"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/models/user"
+	"GoHub-Service/app/models/user_role"
+	"GoHub-Service/app/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestModerationService_QueueLinksUseRequestPath(t *testing.T) {
+	db := setupDB(t, &user.User{}, &category.Category{}, &category.Moderator{}, &tag.Tag{}, &topic.Topic{}, &role.Role{}, &user_role.UserRole{})
+	for i := 0; i < 2; i++ {
+		db.Create(&topic.Topic{Title: "待审核", Body: "内容", UserID: "7", CategoryID: "1", Status: topic.StatusPending})
+	}
+
+	for _, path := range []string{"/api/v1/admin/queue", "/api/v1/moderator/queue"} {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", path, nil)
+		topics, paging, err := services.NewModerationService().Queue(c, path, topic.QueueAll, "1", 1)
+		if err != nil {
+			t.Fatalf("获取审核队列失败: %v", err)
+		}
+		if len(topics) != 1 || !strings.HasPrefix(paging.NextPageURL, path+"?") {
+			t.Errorf("%s 的下一页链接为 %q", path, paging.NextPageURL)
+		}
+	}
+}
+
+func TestModerationService_ReapprovalDoesNotRenotify(t *testing.T) {
+	db := setupDB(t, &user.User{}, &category.Category{}, &category.Moderator{}, &tag.Tag{}, &topic.Topic{}, &role.Role{}, &user_role.UserRole{},
+		&moderation.Log{}, &notification.Notification{}, &mention.Mention{}, &series.Series{}, &series.Item{}, &series.Follow{})
+	tp := &topic.Topic{Title: "系列新篇", Body: "内容", UserID: "7", CategoryID: "1", Status: topic.StatusPending}
+	db.Create(tp)
+	sr := &series.Series{UserID: 7, Title: "系列"}
+	db.Create(sr)
+	db.Create(&series.Item{SeriesID: sr.ID, TopicID: tp.ID, Position: 1})
+	db.Create(&series.Follow{SeriesID: sr.ID, UserID: 9})
+
+	svc := services.NewModerationService()
+	if _, err := svc.Approve(tp.GetStringID(), "1"); err != nil {
+		t.Fatalf("审核通过失败: %v", err)
+	}
+	if err := svc.Requeue(tp.GetStringID(), "1", "被举报"); err != nil {
+		t.Fatalf("撤回话题失败: %v", err)
+	}
+	if _, err := svc.Approve(tp.GetStringID(), "1"); err != nil {
+		t.Fatalf("重新审核通过失败: %v", err)
+	}
+
+	var count int64
+	db.Model(&notification.Notification{}).Where("user_id = ? AND type = ?", "9", "series_new_part").Count(&count)
+	if count != 1 {
+		t.Errorf("重新审核通过不应再次通知系列关注者，got %d 条通知", count)
+	}
+}
@@ -0,0 +1,120 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/comment"
+	"GoHub-Service/app/models/moderation"
+	"GoHub-Service/app/models/notification"
+	"GoHub-Service/app/models/role"
+	"GoHub-Service/app/models/tag"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/models/user"
+	"GoHub-Service/app/models/user_role"
+	"GoHub-Service/app/repositories"
+	"GoHub-Service/app/services"
+)
+
+func TestModerationService_SplitMovesFirstCommentIntoBody(t *testing.T) {
+	db := setupDB(t, &user.User{}, &category.Category{}, &tag.Tag{}, &topic.Topic{}, &comment.Comment{}, &moderation.Log{}, &notification.Notification{})
+	db.Create(&category.Category{Name: "默认分类"})
+	source := &topic.Topic{Title: "原话题", Body: "内容", UserID: "1", CategoryID: "1", Status: topic.StatusApproved}
+	db.Create(source)
+
+	first := &comment.Comment{TopicID: source.GetStringID(), UserID: "2", Content: "拆分出的正文", ParentID: "0"}
+	db.Create(first)
+	second := &comment.Comment{TopicID: source.GetStringID(), UserID: "3", Content: "跟帖", ParentID: first.GetStringID()}
+	db.Create(second)
+	stay := &comment.Comment{TopicID: source.GetStringID(), UserID: "4", Content: "留在原话题", ParentID: first.GetStringID()}
+	db.Create(stay)
+
+	newTopic, err := services.NewModerationService().Split(source.GetStringID(), "9", services.SplitOptions{
+		Title:      "拆分话题",
+		CommentIDs: []uint64{first.ID, second.ID},
+	})
+	if err != nil {
+		t.Fatalf("拆分失败: %v", err)
+	}
+	if newTopic.Body != first.Content || newTopic.UserID != first.UserID {
+		t.Errorf("新话题 body=%q user_id=%s，应取第一条评论", newTopic.Body, newTopic.UserID)
+	}
+
+	var remaining []comment.Comment
+	db.Where("id IN ?", []uint64{first.ID, second.ID, stay.ID}).Order("id").Find(&remaining)
+	if len(remaining) != 2 {
+		t.Fatalf("作为正文的评论应被删除，剩余 %d 条", len(remaining))
+	}
+	for _, cm := range remaining {
+		if cm.ParentID != "0" {
+			t.Errorf("评论 %d 的父评论已成为正文，parent_id 应为 0，got %q", cm.ID, cm.ParentID)
+		}
+		switch cm.ID {
+		case second.ID:
+			if cm.TopicID != newTopic.GetStringID() {
+				t.Errorf("跟帖应移到新话题，topic_id=%s", cm.TopicID)
+			}
+		case stay.ID:
+			if cm.TopicID != source.GetStringID() {
+				t.Errorf("未选中的评论应留在原话题，topic_id=%s", cm.TopicID)
+			}
+		}
+	}
+}
+
+func TestModerationService_MergeHandlesAcceptedAnswer(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		targetQA  bool
+		wantCarry bool
+	}{
+		{"目标为问答话题时沿用采纳", true, true},
+		{"目标不是问答话题时取消采纳", false, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			db := setupDB(t, &user.User{}, &category.Category{}, &tag.Tag{}, &topic.Topic{}, &comment.Comment{}, &moderation.Log{}, &notification.Notification{},
+				&role.Role{}, &user_role.UserRole{}, &category.Moderator{}, &repositories.TopicLike{}, &repositories.TopicFavorite{})
+			grantRole(t, db, 9, "admin")
+			qa := &category.Category{Name: "问答", IsQA: true}
+			db.Create(qa)
+			targetCategory := qa
+			if !tc.targetQA {
+				targetCategory = &category.Category{Name: "闲聊"}
+				db.Create(targetCategory)
+			}
+
+			source := &topic.Topic{Title: "原问题", Body: "内容", UserID: "1", CategoryID: qa.GetStringID(), Status: topic.StatusApproved}
+			db.Create(source)
+			answer := &comment.Comment{TopicID: source.GetStringID(), UserID: "2", Content: "回答", ParentID: "0", IsAccepted: true}
+			db.Create(answer)
+			now := time.Now()
+			db.Model(source).Updates(map[string]interface{}{"accepted_comment_id": answer.ID, "solved_at": &now})
+			target := &topic.Topic{Title: "目标", Body: "内容", UserID: "3", CategoryID: targetCategory.GetStringID(), Status: topic.StatusApproved}
+			db.Create(target)
+
+			merged, err := services.NewModerationService().Merge(source.GetStringID(), target.GetStringID(), "9", "")
+			if err != nil {
+				t.Fatalf("合并失败: %v", err)
+			}
+
+			var moved comment.Comment
+			db.First(&moved, answer.ID)
+			if moved.IsAccepted != tc.wantCarry {
+				t.Errorf("移动后的回答 is_accepted=%v，want %v", moved.IsAccepted, tc.wantCarry)
+			}
+			wantAccepted := uint64(0)
+			if tc.wantCarry {
+				wantAccepted = answer.ID
+			}
+			if merged.AcceptedCommentID != wantAccepted {
+				t.Errorf("目标话题 accepted_comment_id=%d，want %d", merged.AcceptedCommentID, wantAccepted)
+			}
+			var old topic.Topic
+			db.Unscoped().First(&old, source.ID)
+			if old.AcceptedCommentID != 0 || old.SolvedAt != nil {
+				t.Errorf("原话题的采纳应被清除，got accepted_comment_id=%d", old.AcceptedCommentID)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package services_test
+
+import (
+	"testing"
+
+	"GoHub-Service/app/models/reaction"
+	"GoHub-Service/app/services"
+)
+
+func TestReactionService_CreateDisabledType(t *testing.T) {
+	db := setupDB(t, &reaction.Type{}, &reaction.Reaction{})
+	created, err := services.NewReactionService().CreateType(services.ReactionTypeSaveDTO{Emoji: "🎉", Name: "庆祝", Enabled: false})
+	if err != nil {
+		t.Fatalf("创建表情失败: %v", err)
+	}
+
+	var stored reaction.Type
+	db.First(&stored, created.ID)
+	if stored.Enabled {
+		t.Error("创建时指定停用，保存后 enabled 应为 false")
+	}
+}
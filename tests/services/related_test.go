@@ -0,0 +1,62 @@
+package services_test
+
+import (
+	"testing"
+
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/role"
+	"GoHub-Service/app/models/tag"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/models/user"
+	"GoHub-Service/app/models/user_role"
+	"GoHub-Service/app/repositories"
+	"GoHub-Service/app/services"
+)
+
+func TestTopicService_RelatedRanksBySharedSignals(t *testing.T) {
+	db := setupDB(t, &user.User{}, &category.Category{}, &category.Moderator{}, &role.Role{}, &user_role.UserRole{},
+		&tag.Tag{}, &topic.Topic{}, &repositories.TopicLike{})
+	services.ForgetCategoryIndex()
+	t.Cleanup(services.ForgetCategoryIndex)
+	db.Create(&category.Category{Name: "分类一"})
+	db.Create(&category.Category{Name: "分类二"})
+	x, y := &tag.Tag{Name: "go"}, &tag.Tag{Name: "redis"}
+	db.Create(x)
+	db.Create(y)
+
+	newTopic := func(title, categoryID string, status int, tags ...*tag.Tag) *topic.Topic {
+		m := &topic.Topic{Title: title, Body: "内容", UserID: "1", CategoryID: categoryID, Status: status}
+		db.Create(m)
+		for _, tg := range tags {
+			db.Exec("INSERT INTO topic_tags (topic_id, tag_id) VALUES (?, ?)", m.ID, tg.ID)
+		}
+		return m
+	}
+	source := newTopic("原话题", "1", topic.StatusApproved, x, y)
+	bothTags := newTopic("两个共同标签", "2", topic.StatusApproved, x, y)
+	tagAndCategory := newTopic("一个共同标签且同分类", "1", topic.StatusApproved, x)
+	coLiked := newTopic("共同点赞", "2", topic.StatusApproved)
+	sameCategory := newTopic("同分类", "1", topic.StatusApproved)
+	newTopic("待审核", "1", topic.StatusPending, x, y)
+	newTopic("无关", "2", topic.StatusApproved)
+	db.Create(&repositories.TopicLike{TopicID: source.GetStringID(), UserID: "5"})
+	db.Create(&repositories.TopicLike{TopicID: coLiked.GetStringID(), UserID: "5"})
+
+	related, err := services.NewTopicService().Related(source.GetStringID(), "", 10)
+	if err != nil {
+		t.Fatalf("获取相关话题失败: %v", err)
+	}
+	want := []string{bothTags.GetStringID(), tagAndCategory.GetStringID(), coLiked.GetStringID(), sameCategory.GetStringID()}
+	if len(related) != len(want) {
+		t.Fatalf("相关话题数量 = %d，want %d: %+v", len(related), len(want), related)
+	}
+	for i, id := range want {
+		if related[i].ID != id {
+			t.Errorf("第 %d 个相关话题 = %s (%s)，want %s", i+1, related[i].ID, related[i].Title, id)
+		}
+	}
+
+	if limited, _ := services.NewTopicService().Related(source.GetStringID(), "", 2); len(limited) != 2 {
+		t.Errorf("limit=2 时应只返回 2 个相关话题，got %d", len(limited))
+	}
+}
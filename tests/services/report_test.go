@@ -0,0 +1,141 @@
+package services_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/comment"
+	"GoHub-Service/app/models/moderation"
+	"GoHub-Service/app/models/notification"
+	"GoHub-Service/app/models/report"
+	"GoHub-Service/app/models/role"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/models/user"
+	"GoHub-Service/app/models/user_role"
+	"GoHub-Service/app/services"
+	"GoHub-Service/pkg/database"
+	apperrors "GoHub-Service/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// createReportedComment 创建一条评论，并由 5 个用户举报使其被自动隐藏
+func createReportedComment(t *testing.T) (*comment.Comment, *report.Report) {
+	t.Helper()
+	db := setupDB(t, &user.User{}, &topic.Topic{}, &comment.Comment{}, &report.Report{}, &moderation.Log{}, &notification.Notification{})
+	cm := &comment.Comment{TopicID: "1", UserID: "100", Content: "评论内容"}
+	if err := db.Create(cm).Error; err != nil {
+		t.Fatalf("创建评论失败: %v", err)
+	}
+
+	svc := services.NewReportService()
+	var first *report.Report
+	for _, reporterID := range []string{"1", "2", "3", "4", "5"} {
+		rp, err := svc.Create(services.ReportCreateDTO{
+			ReporterID: reporterID,
+			TargetType: report.TargetComment,
+			TargetID:   cm.GetStringID(),
+			Reason:     report.ReasonSpam,
+		})
+		if err != nil {
+			t.Fatalf("举报失败: %v", err)
+		}
+		if first == nil {
+			first = rp
+		}
+	}
+	return cm, first
+}
+
+func commentHidden(t *testing.T, id uint64) bool {
+	t.Helper()
+	var cm comment.Comment
+	if err := database.DB.First(&cm, id).Error; err != nil {
+		t.Fatalf("获取评论失败: %v", err)
+	}
+	return cm.HiddenAt != nil
+}
+
+func TestReportService_DismissUnhidesAutoHiddenComment(t *testing.T) {
+	cm, rp := createReportedComment(t)
+	if !commentHidden(t, cm.ID) {
+		t.Fatal("达到举报阈值后评论应被自动隐藏")
+	}
+
+	if _, err := services.NewReportService().Dismiss(rp.GetStringID(), "9", "误报"); err != nil {
+		t.Fatalf("驳回举报失败: %v", err)
+	}
+	if commentHidden(t, cm.ID) {
+		t.Error("驳回举报后评论应恢复显示")
+	}
+}
+
+func TestReportService_DismissKeepsModeratorHiddenComment(t *testing.T) {
+	cm, rp := createReportedComment(t)
+	svc := services.NewReportService()
+	if _, err := svc.Act(rp.GetStringID(), "9", services.ReportActionDTO{Action: report.ActionHide}); err != nil {
+		t.Fatalf("处置举报失败: %v", err)
+	}
+
+	again, err := svc.Create(services.ReportCreateDTO{
+		ReporterID: "6",
+		TargetType: report.TargetComment,
+		TargetID:   cm.GetStringID(),
+		Reason:     report.ReasonSpam,
+	})
+	if err != nil {
+		t.Fatalf("举报失败: %v", err)
+	}
+	if _, err := svc.Dismiss(again.GetStringID(), "9", "误报"); err != nil {
+		t.Fatalf("驳回举报失败: %v", err)
+	}
+	if !commentHidden(t, cm.ID) {
+		t.Error("版主隐藏的评论不应因驳回后续举报而恢复")
+	}
+}
+
+func TestReportService_ScopedModerator(t *testing.T) {
+	db := setupDB(t, &user.User{}, &category.Category{}, &category.Moderator{}, &topic.Topic{}, &comment.Comment{},
+		&report.Report{}, &role.Role{}, &user_role.UserRole{}, &moderation.Log{}, &notification.Notification{})
+	a := createCategory(t, "分类A", "")
+	other := createCategory(t, "其他", "")
+	grantRole(t, db, 2, "moderator")
+	db.Create(&category.Moderator{CategoryID: reloadCategory(t, db, a.ID).ID, UserID: 2})
+
+	inScope := &topic.Topic{Title: "范围内", Body: "内容", UserID: "9", CategoryID: a.ID, Status: topic.StatusApproved}
+	outOfScope := &topic.Topic{Title: "范围外", Body: "内容", UserID: "9", CategoryID: other.ID, Status: topic.StatusApproved}
+	db.Create(inScope)
+	db.Create(outOfScope)
+	cm := &comment.Comment{TopicID: outOfScope.GetStringID(), UserID: "9", Content: "评论内容"}
+	db.Create(cm)
+
+	svc := services.NewReportService()
+	submit := func(targetType, targetID string) *report.Report {
+		rp, err := svc.Create(services.ReportCreateDTO{ReporterID: "1", TargetType: targetType, TargetID: targetID, Reason: report.ReasonSpam})
+		if err != nil {
+			t.Fatalf("举报失败: %v", err)
+		}
+		return rp
+	}
+	inScopeReport := submit(report.TargetTopic, inScope.GetStringID())
+	outOfScopeReport := submit(report.TargetComment, cm.GetStringID())
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/api/v1/moderator/reports", nil)
+	reports, _, appErr := svc.Queue(c, "2", 20)
+	if appErr != nil {
+		t.Fatalf("获取举报队列失败: %v", appErr)
+	}
+	if len(reports) != 1 || reports[0].ID != inScopeReport.ID {
+		t.Errorf("版主只应看到其管理的分类下的举报，got %d 条", len(reports))
+	}
+
+	_, appErr = svc.Act(outOfScopeReport.GetStringID(), "2", services.ReportActionDTO{Action: report.ActionHide})
+	if appErr == nil || appErr.Type != apperrors.ErrorTypeAuthorization {
+		t.Errorf("处置其他分类的举报应返回无权限，got %v", appErr)
+	}
+	if commentHidden(t, cm.ID) {
+		t.Error("无权处置时评论不应被隐藏")
+	}
+}
@@ -0,0 +1,70 @@
+package services_test
+
+import (
+	"testing"
+
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/notification"
+	"GoHub-Service/app/models/role"
+	"GoHub-Service/app/models/series"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/models/user_role"
+	"GoHub-Service/app/services"
+	apperrors "GoHub-Service/pkg/errors"
+)
+
+func TestSeriesService_PartsNavigationAndNotifications(t *testing.T) {
+	db := setupDB(t, &category.Category{}, &category.Moderator{}, &role.Role{}, &user_role.UserRole{}, &topic.Topic{},
+		&series.Series{}, &series.Item{}, &series.Follow{}, &notification.Notification{})
+	services.ForgetCategoryIndex()
+	t.Cleanup(services.ForgetCategoryIndex)
+	newTopic := func(title, authorID string, status int) *topic.Topic {
+		m := &topic.Topic{Title: title, Body: "内容", UserID: authorID, CategoryID: "1", Status: status}
+		db.Create(m)
+		return m
+	}
+	first := newTopic("第一篇", "7", topic.StatusApproved)
+	draft := newTopic("待审核", "7", topic.StatusPending)
+	second := newTopic("第二篇", "7", topic.StatusApproved)
+	others := newTopic("他人的话题", "8", topic.StatusApproved)
+
+	svc := services.NewSeriesService()
+	sr, err := svc.Create("7", services.SeriesSaveDTO{Title: "入门教程"})
+	if err != nil {
+		t.Fatalf("创建系列失败: %v", err)
+	}
+	if err := svc.Follow(sr.GetStringID(), "9"); err != nil {
+		t.Fatalf("关注系列失败: %v", err)
+	}
+	for _, tp := range []*topic.Topic{first, draft, second} {
+		if err := svc.AddTopic(sr.GetStringID(), "7", tp.GetStringID()); err != nil {
+			t.Fatalf("加入系列失败: %v", err)
+		}
+	}
+	if err := svc.AddTopic(sr.GetStringID(), "7", others.GetStringID()); err == nil || err.Type != apperrors.ErrorTypeAuthorization {
+		t.Errorf("不能将他人的话题加入系列，got %v", err)
+	}
+	if err := svc.AddTopic(sr.GetStringID(), "7", first.GetStringID()); err == nil {
+		t.Error("话题已属于一个系列时不能再次加入")
+	}
+
+	var notified int64
+	db.Model(&notification.Notification{}).Where("user_id = ? AND type = ?", "9", "series_new_part").Count(&notified)
+	if notified != 2 {
+		t.Errorf("只有已审核通过的话题加入系列时通知关注者，got %d 条通知", notified)
+	}
+
+	owner, _ := svc.Show(sr.GetStringID(), "7")
+	reader, _ := svc.Show(sr.GetStringID(), "9")
+	if owner == nil || len(owner.Parts) != 3 {
+		t.Errorf("作者应看到系列中的全部话题，got %+v", owner)
+	}
+	if reader == nil || len(reader.Parts) != 2 || !reader.FollowedByMe {
+		t.Errorf("读者只应看到已审核通过的话题，got %+v", reader)
+	}
+
+	nav := svc.Navigation(second.GetStringID(), "9")
+	if nav == nil || nav.Position != 2 || nav.Total != 2 || nav.Prev == nil || nav.Prev.TopicID != first.ID || nav.Next != nil {
+		t.Errorf("系列导航应跳过待审核的话题，got %+v", nav)
+	}
+}
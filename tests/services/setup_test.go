@@ -0,0 +1,55 @@
+// Package services_test 服务层测试，使用 SQLite 内存数据库
+// Redis 指向不可用的地址，缓存读写失败后按各服务原有逻辑降级
+package services_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"GoHub-Service/pkg/cache"
+	"GoHub-Service/pkg/database"
+	"GoHub-Service/pkg/logger"
+	"GoHub-Service/pkg/redis"
+
+	"github.com/gin-gonic/gin"
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+var setupOnce sync.Once
+
+// setupDB 为每个测试创建独立的内存数据库并建表
+func setupDB(t *testing.T, models ...interface{}) *gorm.DB {
+	t.Helper()
+	setupOnce.Do(func() {
+		logger.Logger = zap.NewNop()
+		gin.SetMode(gin.TestMode)
+		redis.Redis = &redis.RedisClient{Client: goredis.NewClient(&goredis.Options{
+			Addr:        "127.0.0.1:1",
+			DialTimeout: 10 * time.Millisecond,
+			MaxRetries:  -1,
+		})}
+		cache.InitWithCacheStore(&cache.RedisStore{RedisClient: redis.Redis})
+	})
+
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: gormlogger.Discard})
+	if err != nil {
+		t.Fatalf("打开测试数据库失败: %v", err)
+	}
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatalf("建表失败: %v", err)
+	}
+	database.DB = db
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
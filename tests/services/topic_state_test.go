@@ -0,0 +1,92 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"GoHub-Service/app/models/category"
+	"GoHub-Service/app/models/tag"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/models/user"
+	"GoHub-Service/app/repositories"
+	"GoHub-Service/app/services"
+	apperrors "GoHub-Service/pkg/errors"
+)
+
+// createTopic 建表并创建一个已通过审核的话题
+func createTopic(t *testing.T, authorID string) *topic.Topic {
+	t.Helper()
+	db := setupDB(t, &user.User{}, &category.Category{}, &tag.Tag{}, &topic.Topic{})
+	db.Create(&category.Category{Name: "默认分类"})
+	m := &topic.Topic{Title: "测试话题", Body: "内容", UserID: authorID, CategoryID: "1", Status: topic.StatusApproved}
+	if err := db.Create(m).Error; err != nil {
+		t.Fatalf("创建话题失败: %v", err)
+	}
+	return m
+}
+
+func TestTopicService_AuthorUnlocksOwnLock(t *testing.T) {
+	tp := createTopic(t, "7")
+	svc := services.NewTopicService()
+
+	if _, err := svc.Lock(tp.GetStringID(), "7"); err != nil {
+		t.Fatalf("作者锁定失败: %v", err)
+	}
+	if got := topic.Get(tp.GetStringID()); got.LockedBy != 7 {
+		t.Fatalf("LockedBy = %d, want 7", got.LockedBy)
+	}
+	if _, err := svc.Unlock(tp.GetStringID(), "7", false); err != nil {
+		t.Fatalf("作者解除自己的锁定失败: %v", err)
+	}
+	if got := topic.Get(tp.GetStringID()); got.IsLocked || got.LockedBy != 0 {
+		t.Errorf("解除锁定后 is_locked=%v locked_by=%d", got.IsLocked, got.LockedBy)
+	}
+}
+
+func TestTopicService_AuthorCannotUnlockModeratorLock(t *testing.T) {
+	tp := createTopic(t, "7")
+	svc := services.NewTopicService()
+
+	if _, err := svc.Lock(tp.GetStringID(), "1"); err != nil {
+		t.Fatalf("版主锁定失败: %v", err)
+	}
+	_, err := svc.Unlock(tp.GetStringID(), "7", false)
+	if err == nil || err.Type != apperrors.ErrorTypeAuthorization {
+		t.Fatalf("作者解除版主的锁定应返回无权限，got %v", err)
+	}
+	if _, err := svc.Unlock(tp.GetStringID(), "1", true); err != nil {
+		t.Errorf("版主解除锁定失败: %v", err)
+	}
+}
+
+func TestTopicService_AuthorReopensOwnClose(t *testing.T) {
+	tp := createTopic(t, "7")
+	svc := services.NewTopicService()
+
+	if _, err := svc.Close(tp.GetStringID(), "7", "已解决"); err != nil {
+		t.Fatalf("作者关闭失败: %v", err)
+	}
+	if got := topic.Get(tp.GetStringID()); got.ClosedBy != 7 {
+		t.Fatalf("ClosedBy = %d, want 7", got.ClosedBy)
+	}
+	if _, err := svc.Reopen(tp.GetStringID(), "7", false); err != nil {
+		t.Fatalf("作者重新开放自己关闭的话题失败: %v", err)
+	}
+	if got := topic.Get(tp.GetStringID()); got.ClosedAt != nil || got.ClosedBy != 0 {
+		t.Errorf("重新开放后 closed_at=%v closed_by=%d", got.ClosedAt, got.ClosedBy)
+	}
+}
+
+func TestTopicRepository_CreatePending(t *testing.T) {
+	db := setupDB(t, &user.User{}, &category.Category{}, &tag.Tag{}, &topic.Topic{})
+	m := &topic.Topic{Title: "待审核话题", Body: "内容", UserID: "7", CategoryID: "1", Status: topic.StatusPending}
+	if err := repositories.NewTopicRepository().Create(context.Background(), m); err != nil {
+		t.Fatalf("创建话题失败: %v", err)
+	}
+
+	var stored topic.Topic
+	db.First(&stored, m.ID)
+	if stored.Status != topic.StatusPending {
+		t.Errorf("status = %d, want %d", stored.Status, topic.StatusPending)
+	}
+}
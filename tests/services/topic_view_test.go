@@ -0,0 +1,47 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/repositories"
+)
+
+func TestTopicViewRepository_SaveSkipsRetriedBatch(t *testing.T) {
+	db := setupDB(t, &topic.Topic{}, &topic.DailyView{}, &topic.ViewFlush{})
+	tp := &topic.Topic{Title: "话题", Body: "内容", UserID: "1", CategoryID: "1", Status: topic.StatusApproved}
+	db.Create(tp)
+
+	repo := repositories.NewTopicViewRepository()
+	ctx := context.Background()
+	deltas := []topic.ViewDelta{
+		{TopicID: tp.ID, Date: "2026-01-01", Views: 3},
+		{TopicID: tp.ID, Date: "2026-01-02", Views: 2},
+	}
+	// 写库成功但清除缓冲失败时，下一轮会以同一批次重试
+	for i := 0; i < 2; i++ {
+		if err := repo.Save(ctx, "batch-1", deltas); err != nil {
+			t.Fatalf("第 %d 次写入浏览量失败: %v", i+1, err)
+		}
+	}
+
+	var stored topic.Topic
+	db.First(&stored, tp.ID)
+	if stored.ViewCount != 5 {
+		t.Errorf("同一批次重试不应重复累加，view_count=%d", stored.ViewCount)
+	}
+	var daily topic.DailyView
+	db.Where("topic_id = ? AND date = ?", tp.ID, "2026-01-01").First(&daily)
+	if daily.Views != 3 {
+		t.Errorf("同一批次重试不应重复累加每日浏览量，views=%d", daily.Views)
+	}
+
+	if err := repo.Save(ctx, "batch-2", deltas[:1]); err != nil {
+		t.Fatalf("写入新批次失败: %v", err)
+	}
+	db.First(&stored, tp.ID)
+	if stored.ViewCount != 8 {
+		t.Errorf("新批次应正常累加，view_count=%d", stored.ViewCount)
+	}
+}
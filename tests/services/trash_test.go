@@ -0,0 +1,116 @@
+package services_test
+
+import (
+	"testing"
+
+	"GoHub-Service/app/models/collection"
+	"GoHub-Service/app/models/comment"
+	"GoHub-Service/app/models/like"
+	"GoHub-Service/app/models/mention"
+	"GoHub-Service/app/models/reaction"
+	"GoHub-Service/app/models/report"
+	"GoHub-Service/app/models/series"
+	"GoHub-Service/app/models/spam"
+	"GoHub-Service/app/models/tag"
+	"GoHub-Service/app/models/topic"
+	"GoHub-Service/app/repositories"
+	"GoHub-Service/app/services"
+)
+
+func TestTrashService_PurgeCommentSubtree(t *testing.T) {
+	db := setupDB(t, &comment.Comment{}, &comment.Revision{}, &like.Like{}, &reaction.Reaction{}, &mention.Mention{}, &spam.Fingerprint{}, &report.Report{})
+	root := &comment.Comment{TopicID: "1", UserID: "1", Content: "根评论", ParentID: "0"}
+	db.Create(root)
+	reply := &comment.Comment{TopicID: "1", UserID: "2", Content: "回复", ParentID: root.GetStringID()}
+	db.Create(reply)
+	nested := &comment.Comment{TopicID: "1", UserID: "3", Content: "楼中楼", ParentID: reply.GetStringID()}
+	db.Create(nested)
+	other := &comment.Comment{TopicID: "1", UserID: "4", Content: "其他评论", ParentID: "0"}
+	db.Create(other)
+
+	for _, cm := range []*comment.Comment{root, reply, nested, other} {
+		db.Create(&like.Like{UserID: "9", TargetType: like.TargetComment, TargetID: cm.GetStringID()})
+		db.Create(&reaction.Reaction{TargetType: reaction.TargetComment, TargetID: cm.ID, UserID: 9, Emoji: "👍"})
+		db.Create(&comment.Revision{CommentID: cm.ID, EditorID: cm.ID, Content: "旧内容"})
+	}
+
+	svc := services.NewTrashService()
+	if _, err := svc.Delete(repositories.TrashComment, []uint64{root.ID}, "1", "违规"); err != nil {
+		t.Fatalf("删除评论失败: %v", err)
+	}
+	count, err := svc.Purge(repositories.TrashComment, []uint64{root.ID})
+	if err != nil {
+		t.Fatalf("彻底删除评论失败: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("应删除根评论及其 2 条回复，实际删除 %d 条", count)
+	}
+
+	var comments, likes, reactions, revisions int64
+	db.Unscoped().Model(&comment.Comment{}).Count(&comments)
+	db.Model(&like.Like{}).Count(&likes)
+	db.Model(&reaction.Reaction{}).Count(&reactions)
+	db.Model(&comment.Revision{}).Count(&revisions)
+	if comments != 1 || likes != 1 || reactions != 1 || revisions != 1 {
+		t.Errorf("只应保留其他评论及其关联数据，comments=%d likes=%d reactions=%d revisions=%d", comments, likes, reactions, revisions)
+	}
+}
+
+func TestTrashService_PurgeTopicRelations(t *testing.T) {
+	db := setupDB(t, &tag.Tag{}, &topic.Topic{}, &topic.DailyView{}, &comment.Comment{}, &comment.Revision{},
+		&repositories.TopicLike{}, &repositories.TopicFavorite{}, &like.Like{}, &reaction.Reaction{},
+		&collection.Collection{}, &collection.Item{}, &series.Series{}, &series.Item{},
+		&mention.Mention{}, &spam.Fingerprint{}, &report.Report{})
+	tg := &tag.Tag{Name: "Go"}
+	db.Create(tg)
+	coll := &collection.Collection{UserID: 9, Name: "收藏夹"}
+	db.Create(coll)
+	sr := &series.Series{UserID: 1, Title: "系列"}
+	db.Create(sr)
+
+	var purged, kept *topic.Topic
+	for _, title := range []string{"被删除", "保留"} {
+		tp := &topic.Topic{Title: title, Body: "内容", UserID: "1", CategoryID: "1", Status: topic.StatusApproved, Tags: []tag.Tag{*tg}}
+		db.Create(tp)
+		id, sid := tp.ID, tp.GetStringID()
+		db.Create(&comment.Comment{TopicID: sid, UserID: "2", Content: "评论", ParentID: "0"})
+		db.Create(&repositories.TopicLike{TopicID: sid, UserID: "9"})
+		db.Create(&repositories.TopicFavorite{TopicID: sid, UserID: "9"})
+		db.Create(&like.Like{UserID: "9", TargetType: like.TargetTopic, TargetID: sid})
+		db.Create(&reaction.Reaction{TargetType: reaction.TargetTopic, TargetID: id, UserID: 9, Emoji: "👍"})
+		db.Create(&collection.Item{CollectionID: coll.ID, TopicID: id})
+		db.Create(&series.Item{SeriesID: sr.ID, TopicID: id})
+		db.Create(&mention.Mention{SourceType: mention.SourceTopic, SourceID: id, UserID: 9, ActorID: 1})
+		db.Create(&topic.DailyView{TopicID: id, Date: "2026-01-01", Views: 3})
+		db.Create(&spam.Fingerprint{ContentType: spam.ContentTopic, ContentID: id, UserID: 1})
+		db.Create(&report.Report{ReporterID: 9, TargetType: report.TargetTopic, TargetID: id, Reason: report.ReasonSpam, Status: report.StatusOpen})
+		if purged == nil {
+			purged = tp
+		} else {
+			kept = tp
+		}
+	}
+	db.Delete(purged)
+
+	count, err := services.NewTrashService().Purge(repositories.TrashTopic, []uint64{purged.ID})
+	if err != nil || count != 1 {
+		t.Fatalf("彻底删除话题失败: count=%d err=%v", count, err)
+	}
+
+	for _, m := range []interface{}{&comment.Comment{}, &repositories.TopicLike{}, &repositories.TopicFavorite{}, &like.Like{}, &reaction.Reaction{},
+		&collection.Item{}, &series.Item{}, &mention.Mention{}, &topic.DailyView{}, &spam.Fingerprint{}, &report.Report{}} {
+		var n int64
+		db.Unscoped().Model(m).Count(&n)
+		if n != 1 {
+			t.Errorf("%T 应只保留未删除话题的 1 条记录，实际 %d 条", m, n)
+		}
+	}
+	var tagged int64
+	db.Table("topic_tags").Where("topic_id = ?", kept.ID).Count(&tagged)
+	db.First(tg, tg.ID)
+	db.First(coll, coll.ID)
+	db.First(sr, sr.ID)
+	if tagged != 1 || tg.TopicsCount != 1 || coll.ItemsCount != 1 || sr.TopicsCount != 1 {
+		t.Errorf("计数应重新统计，tagged=%d tag=%d collection=%d series=%d", tagged, tg.TopicsCount, coll.ItemsCount, sr.TopicsCount)
+	}
+}